@@ -9,7 +9,11 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"maps"
 	"net/http"
+	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -24,14 +28,20 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/automaxprocs/maxprocs"
 
+	"github.com/obolnetwork/charon/app/builderrelay"
+	"github.com/obolnetwork/charon/app/doppelganger"
+	"github.com/obolnetwork/charon/app/dutyhistory"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/keymanagerapi"
 	"github.com/obolnetwork/charon/app/lifecycle"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/overrides"
 	"github.com/obolnetwork/charon/app/peerinfo"
 	"github.com/obolnetwork/charon/app/privkeylock"
 	"github.com/obolnetwork/charon/app/promauto"
@@ -39,6 +49,7 @@ import (
 	"github.com/obolnetwork/charon/app/stacksnipe"
 	"github.com/obolnetwork/charon/app/tracer"
 	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/web3signer"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/cluster/manifest"
@@ -61,6 +72,7 @@ import (
 	"github.com/obolnetwork/charon/core/validatorapi"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/keystore"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
@@ -76,24 +88,41 @@ type Config struct {
 	NoVerify                bool
 	PrivKeyFile             string
 	PrivKeyLocking          bool
+	DutyDBFile              string
+	AggSigDBDir             string
+	DutyHistoryDir          string
+	DutyHistoryMaxSizeMB    int
 	MonitoringAddr          string
 	DebugAddr               string
 	ValidatorAPIAddr        string
 	BeaconNodeAddrs         []string
 	BeaconNodeTimeout       time.Duration
 	BeaconNodeSubmitTimeout time.Duration
-	JaegerAddr              string
-	JaegerService           string
+	OTLPAddress             string
+	OTLPProtocol            string
+	OTLPServiceName         string
+	OTLPSampleRatio         float64
 	SimnetBMock             bool
 	SimnetVMock             bool
 	SimnetValidatorKeysDir  string
+	SimnetRemoteSignerAddr  string
 	SimnetSlotDuration      time.Duration
 	SyntheticBlockProposals bool
 	BuilderAPI              bool
+	BuilderRelays           []string
+	BuilderMinBid           string
+	BuilderRelayAPIAddr     string
+	ProposerOverridesFile   string
+	KeymanagerAPIAddr       string
+	KeymanagerAPITokenFile  string
+	Web3SignerAddr          string
+	Web3SignerKeysDir       string
+	Web3SignerTokenFile     string
 	SimnetBMockFuzz         bool
 	TestnetConfig           eth2util.Network
 	ProcDirectory           string
 	ConsensusProtocol       string
+	DoppelgangerEpochs      uint64
 
 	TestConfig TestConfig
 }
@@ -159,10 +188,6 @@ func Run(ctx context.Context, conf Config) (err error) {
 		eth2util.AddTestNetwork(conf.TestnetConfig)
 	}
 
-	if err := wireTracing(life, conf); err != nil {
-		return err
-	}
-
 	cluster, err := loadClusterManifest(ctx, conf)
 	if err != nil {
 		return err
@@ -210,7 +235,12 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return errors.Wrap(err, "private key not matching cluster manifest file")
 	}
 
-	enrRec, err := enr.New(p2pKey)
+	enrOpts, err := conf.P2P.ENROptions()
+	if err != nil {
+		return err
+	}
+
+	enrRec, err := enr.New(p2pKey, enrOpts...)
 	if err != nil {
 		return errors.Wrap(err, "creating enr record from privkey")
 	}
@@ -233,6 +263,11 @@ func Run(ctx context.Context, conf Config) (err error) {
 		"charon_version":  version.Version.String(),
 	}
 	log.SetLokiLabels(labels)
+
+	if err := wireTracing(life, conf, labels); err != nil {
+		return err
+	}
+
 	promRegistry, err := promauto.NewRegistry(labels)
 	if err != nil {
 		return err
@@ -257,7 +292,9 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	sender := new(p2p.Sender)
 
-	wirePeerInfo(life, tcpNode, peerIDs, cluster.GetInitialMutationHash(), sender, conf.BuilderAPI)
+	peerInfo := wirePeerInfo(life, tcpNode, peerIDs, cluster.GetInitialMutationHash(), sender, conf.BuilderAPI)
+
+	status := newStatusAPI(cluster, peers, tcpNode, peerInfo, conf.DutyHistoryDir)
 
 	// seenPubkeys channel to send seen public keys from validatorapi to monitoringapi.
 	seenPubkeys := make(chan core.PubKey)
@@ -284,10 +321,10 @@ func Run(ctx context.Context, conf Config) (err error) {
 	consensusDebugger := consensus.NewDebugger()
 
 	wireMonitoringAPI(ctx, life, conf.MonitoringAddr, conf.DebugAddr, tcpNode, eth2Cl, peerIDs,
-		promRegistry, consensusDebugger, pubkeys, seenPubkeys, vapiCalls, len(cluster.GetValidators()))
+		promRegistry, consensusDebugger, pubkeys, seenPubkeys, vapiCalls, len(cluster.GetValidators()), status)
 
 	err = wireCoreWorkflow(ctx, life, conf, cluster, nodeIdx, tcpNode, p2pKey, eth2Cl, subEth2Cl,
-		peerIDs, sender, consensusDebugger, seenPubkeysFunc, vapiCallsFunc)
+		peerIDs, sender, consensusDebugger, seenPubkeysFunc, vapiCallsFunc, peerInfo, status)
 	if err != nil {
 		return err
 	}
@@ -297,10 +334,12 @@ func Run(ctx context.Context, conf Config) (err error) {
 }
 
 // wirePeerInfo wires the peerinfo protocol.
-func wirePeerInfo(life *lifecycle.Manager, tcpNode host.Host, peers []peer.ID, lockHash []byte, sender *p2p.Sender, builderEnabled bool) {
+func wirePeerInfo(life *lifecycle.Manager, tcpNode host.Host, peers []peer.ID, lockHash []byte, sender *p2p.Sender, builderEnabled bool) *peerinfo.PeerInfo {
 	gitHash, _ := version.GitCommit()
 	peerInfo := peerinfo.New(tcpNode, peers, version.Version, lockHash, gitHash, sender.SendReceive, builderEnabled)
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartPeerInfo, lifecycle.HookFuncCtx(peerInfo.Run))
+
+	return peerInfo
 }
 
 // wireP2P constructs the p2p tcp (libp2p) and udp (discv5) nodes and registers it with the life cycle manager.
@@ -325,6 +364,7 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 	// Start libp2p TCP node.
 	opts := []libp2p.Option{
 		p2p.WithBandwidthReporter(peerIDs),
+		p2p.WithHolePunching(ctx, peerIDs),
 		libp2p.ResourceManager(new(network.NullResourceManager)),
 	}
 	opts = append(opts, conf.TestConfig.LibP2POpts...)
@@ -360,7 +400,7 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	cluster *manifestpb.Cluster, nodeIdx cluster.NodeIdx, tcpNode host.Host, p2pKey *k1.PrivateKey,
 	eth2Cl, submissionEth2Cl eth2wrap.Client, peerIDs []peer.ID, sender *p2p.Sender,
 	consensusDebugger consensus.Debugger, seenPubkeys func(core.PubKey),
-	vapiCalls func(),
+	vapiCalls func(), peerInfo *peerinfo.PeerInfo, status *statusAPI,
 ) error {
 	// Convert and prep public keys and public shares
 	var (
@@ -427,8 +467,28 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return err
 	}
 
+	regTimestamp, err := defaultRegistrationTimestamp(ctx, eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	// Proposer overrides are reloaded when the overrides file changes.
+	proposerOverrides, err := overrides.New(conf.ProposerOverridesFile, corePubkeys, regTimestamp)
+	if err != nil {
+		return errors.Wrap(err, "load proposer overrides")
+	}
+	sched.SubscribeSlots(proposerOverrides.SlotTicked)
+
+	// Fee recipients may be updated at runtime by reloaded cluster manifest mutations or proposer overrides.
+	var feeRecipientMu sync.RWMutex
 	feeRecipientFunc := func(pubkey core.PubKey) string {
-		return feeRecipientAddrByCorePubkey[pubkey]
+		feeRecipientMu.RLock()
+		defer feeRecipientMu.RUnlock()
+
+		return proposerOverrides.FeeRecipient(pubkey, feeRecipientAddrByCorePubkey[pubkey])
+	}
+	builderEnabledFunc := func(pubkey core.PubKey) bool {
+		return conf.BuilderAPI && proposerOverrides.BuilderEnabled(pubkey, true)
 	}
 	sched.SubscribeSlots(setFeeRecipient(eth2Cl, feeRecipientFunc))
 
@@ -474,27 +534,56 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return nil
 	})
 
-	gaterFunc, err := core.NewDutyGater(ctx, eth2Cl)
+	dutyGater, err := core.NewDutyGater(ctx, eth2Cl)
 	if err != nil {
 		return err
 	}
 
+	// Duties received from peers are dropped if this node is a duplicate instance of its peer ID.
+	gaterFunc := func(duty core.Duty) bool {
+		return peerInfo.CheckSelf() == nil && dutyGater(duty)
+	}
+
 	fetch, err := fetcher.New(eth2Cl, feeRecipientFunc, conf.BuilderAPI)
 	if err != nil {
 		return err
 	}
+	fetch.RegisterBuilderEnabled(builderEnabledFunc)
+	fetch.RegisterGraffiti(func(pubkey core.PubKey) string {
+		return proposerOverrides.Graffiti(pubkey, fetcher.DefaultGraffiti())
+	})
 
-	dutyDB := dutydb.NewMemDB(deadlinerFunc("dutydb"))
+	dutyDB, stopDutyDB, err := newDutyDB(conf.DutyDBFile, deadlinerFunc("dutydb"))
+	if err != nil {
+		return err
+	}
 
 	vapi, err := validatorapi.NewComponent(eth2Cl, allPubSharesByKey, nodeIdx.ShareIdx, feeRecipientFunc, conf.BuilderAPI, seenPubkeys)
 	if err != nil {
 		return err
 	}
+	gasLimitFunc := func(pubkey core.PubKey) uint64 {
+		return proposerOverrides.GasLimit(pubkey, validatorapi.DefaultGasLimit)
+	}
+	vapi.RegisterProposerOverrides(gasLimitFunc, builderEnabledFunc, proposerOverrides.RegistrationTimestamp)
 
 	if err := wireVAPIRouter(ctx, life, conf.ValidatorAPIAddr, eth2Cl, vapi, vapiCalls, conf.BuilderAPI); err != nil {
 		return err
 	}
 
+	if conf.KeymanagerAPIAddr != "" {
+		if err := wireKeymanagerAPI(ctx, life, conf, corePubkeys, proposerOverrides, feeRecipientFunc); err != nil {
+			return errors.Wrap(err, "wire keymanager api")
+		}
+	}
+
+	if conf.Web3SignerAddr != "" {
+		if err := wireWeb3Signer(ctx, life, conf, eth2Cl, corePubkeys, pubshares, dutyDB, sched.GetDutyDefinition,
+			feeRecipientFunc, gasLimitFunc); err != nil {
+			return errors.Wrap(err, "wire web3signer")
+		}
+	}
+
 	parSigDB := parsigdb.NewMemDB(int(cluster.GetThreshold()), deadlinerFunc("parsigdb"))
 
 	var parSigEx core.ParSigEx
@@ -514,11 +603,9 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return err
 	}
 
-	var aggSigDB core.AggSigDB
-	if featureset.Enabled(featureset.AggSigDBV2) {
-		aggSigDB = aggsigdb.NewMemDBV2(deadlinerFunc("aggsigdb"))
-	} else {
-		aggSigDB = aggsigdb.NewMemDB(deadlinerFunc("aggsigdb"))
+	aggSigDB, err := newAggSigDB(conf.AggSigDBDir, deadlinerFunc)
+	if err != nil {
+		return err
 	}
 
 	broadcaster, err := bcast.New(ctx, submissionEth2Cl)
@@ -531,7 +618,7 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	// Consensus
 	consensusController, err := consensus.NewConsensusController(
 		ctx, tcpNode, sender, peers, p2pKey,
-		deadlineFunc, gaterFunc, consensusDebugger)
+		deadlineFunc, gaterFunc, consensusDebugger, feeRecipientFunc)
 	if err != nil {
 		return err
 	}
@@ -540,24 +627,66 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	startConsensusCtrl := lifecycle.HookFuncCtx(consensusController.Start)
 
 	coreConsensus := consensusController.CurrentConsensus() // initially points to DefaultConsensus()
+	status.consensus = consensusController
 
 	// Priority protocol always uses QBFTv2.
-	err = wirePrioritise(ctx, conf, life, tcpNode, peerIDs, int(cluster.GetThreshold()),
+	status.infoSync, err = wirePrioritise(ctx, conf, life, tcpNode, peerIDs, int(cluster.GetThreshold()),
 		sender.SendReceive, defaultConsensus, sched, p2pKey, deadlineFunc,
-		consensusController, cluster.GetConsensusProtocol())
+		consensusController, cluster.GetConsensusProtocol(), proposerOverrides.Hash)
 	if err != nil {
 		return err
 	}
 
-	if err = wireRecaster(ctx, eth2Cl, sched, sigAgg, broadcaster, cluster.GetValidators(),
-		conf.BuilderAPI, conf.TestConfig.BroadcastCallback); err != nil {
+	recaster, err := wireRecaster(ctx, eth2Cl, sched, sigAgg, broadcaster, cluster.GetValidators(),
+		conf.BuilderAPI, proposerOverrides, conf.TestConfig.BroadcastCallback)
+	if err != nil {
 		return errors.Wrap(err, "wire recaster")
 	}
 
+	if conf.BuilderAPI && len(conf.BuilderRelays) > 0 {
+		if err := wireBuilderRelays(ctx, life, conf, eth2Cl, recaster); err != nil {
+			return errors.Wrap(err, "wire builder relays")
+		}
+	}
+
+	sched.SubscribeSlots(newManifestReloader(conf, cluster, func(ctx context.Context, val *manifestpb.Validator) error {
+		corePubkey, err := core.PubKeyFromBytes(val.GetPublicKey())
+		if err != nil {
+			return err
+		}
+
+		feeRecipientMu.Lock()
+		feeRecipientAddrByCorePubkey[corePubkey] = val.GetFeeRecipientAddress()
+		feeRecipientMu.Unlock()
+
+		// Stop recasting the registration of the previous fee recipient. It is replaced by the updated
+		// pre-generated registration below if present, or by the validator client's next registration.
+		if recaster.DeleteFeeRecipientMismatch(corePubkey, val.GetFeeRecipientAddress()) {
+			log.Info(ctx, "Dropped recast builder registration with outdated fee recipient", z.Any("pubkey", corePubkey))
+		}
+
+		if !conf.BuilderAPI {
+			return nil
+		}
+
+		// Recast the updated pre-generated registration, validator clients submit new registrations
+		// for the updated fee recipient returned by the proposer config otherwise.
+		return storePregenRegistrations(ctx, eth2Cl, recaster, proposerOverrides, []*manifestpb.Validator{val})
+	}))
+
 	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl)
 	if err != nil {
 		return err
 	}
+	track.Subscribe(status.dutyAnalysed)
+
+	if conf.DutyHistoryDir != "" {
+		history, err := dutyhistory.New(conf.DutyHistoryDir, int64(conf.DutyHistoryMaxSizeMB)<<20, peers)
+		if err != nil {
+			return errors.Wrap(err, "load duty history")
+		}
+		track.Subscribe(history.Add)
+	}
 
 	inclusion, err := tracker.NewInclusion(ctx, eth2Cl, track.InclusionChecked)
 	if err != nil {
@@ -569,6 +698,13 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		core.WithTracing(),
 		core.WithTracking(track, inclusion),
 		core.WithAsyncRetry(retryer),
+		core.WithParticipationCheck(peerInfo.CheckSelf),
+	}
+	if conf.DoppelgangerEpochs > 0 {
+		doppel := doppelganger.New(eth2Cl, corePubkeys, conf.DoppelgangerEpochs, peerInfo.DuplicatePeers)
+		sched.SubscribeSlots(doppel.SlotTicked)
+		sigAgg.Subscribe(doppel.Aggregated)
+		opts = append(opts, core.WithDoppelganger(doppel.Check))
 	}
 	core.Wire(sched, fetch, coreConsensus, dutyDB, vapi, parSigDB, parSigEx, sigAgg, aggSigDB, broadcaster, opts...)
 
@@ -587,22 +723,61 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartParSigDB, lifecycle.HookFuncCtx(parSigDB.Trim))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartTracker, lifecycle.HookFuncCtx(inclusion.Run))
 	life.RegisterStop(lifecycle.StopScheduler, lifecycle.HookFuncMin(sched.Stop))
-	life.RegisterStop(lifecycle.StopDutyDB, lifecycle.HookFuncMin(dutyDB.Shutdown))
+	life.RegisterStop(lifecycle.StopDutyDB, lifecycle.HookFuncMin(stopDutyDB))
 	life.RegisterStop(lifecycle.StopRetryer, lifecycle.HookFuncCtx(retryer.Shutdown))
 
 	return nil
 }
 
+// newDutyDB returns a new dutyDB and its shutdown function. The dutyDB persists slashing
+// protection records to the file if provided, otherwise it is in-memory only.
+func newDutyDB(filename string, deadliner core.Deadliner) (core.DutyDB, func(), error) {
+	if filename == "" {
+		db := dutydb.NewMemDB(deadliner)
+		return db, db.Shutdown, nil
+	}
+
+	db, err := dutydb.NewDiskDB(filename, deadliner)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "load dutydb")
+	}
+
+	return db, db.Shutdown, nil
+}
+
+// newAggSigDB returns a new aggSigDB. The aggSigDB persists aggregated signed data
+// required after a restart to the directory if provided, otherwise it is in-memory only.
+func newAggSigDB(dir string, deadlinerFunc func(string) core.Deadliner) (core.AggSigDB, error) {
+	var db core.AggSigDB
+	if featureset.Enabled(featureset.AggSigDBV2) {
+		db = aggsigdb.NewMemDBV2(deadlinerFunc("aggsigdb"))
+	} else {
+		db = aggsigdb.NewMemDB(deadlinerFunc("aggsigdb"))
+	}
+
+	if dir == "" {
+		return db, nil
+	}
+
+	diskDB, err := aggsigdb.NewDiskDB(dir, db, deadlinerFunc("aggsigdb_disk"))
+	if err != nil {
+		return nil, errors.Wrap(err, "load aggsigdb")
+	}
+
+	return diskDB, nil
+}
+
 // wirePrioritise wires the priority protocol which determines cluster wide priorities for the next epoch.
+// It returns the info sync component or nil if the priority protocol isn't supported.
 func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, tcpNode host.Host,
 	peers []peer.ID, threshold int, sendFunc p2p.SendReceiveFunc, coreCons core.Consensus,
 	sched core.Scheduler, p2pKey *k1.PrivateKey, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	consensusController core.ConsensusController, clusterPreferredProtocol string,
-) error {
+	consensusController core.ConsensusController, clusterPreferredProtocol string, overridesHash func() string,
+) (*infosync.Component, error) {
 	cons, ok := coreCons.(*qbft.Consensus)
 	if !ok {
 		// Priority protocol not supported for leader cast.
-		return nil
+		return nil, nil
 	}
 
 	// exchangeTimeout of 6 seconds (half a slot) is a good thumb suck.
@@ -612,7 +787,7 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 	prio, err := priority.NewComponent(ctx, tcpNode, peers, threshold,
 		sendFunc, p2p.RegisterHandler, cons, exchangeTimeout, p2pKey, deadlineFunc)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// The initial protocols order as defined by implementation is altered by:
@@ -632,6 +807,7 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 		version.Supported(),
 		allProtocols,
 		ProposalTypes(conf.BuilderAPI, conf.SyntheticBlockProposals),
+		overridesHash,
 	)
 
 	// Trigger info syncs in last slot of the epoch (for the next epoch).
@@ -669,15 +845,15 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartPeerInfo, lifecycle.HookFuncCtx(prio.Start))
 
-	return nil
+	return isync, nil
 }
 
-// wireRecaster wires the rebroadcaster component to scheduler, sigAgg and broadcaster.
+// wireRecaster wires the rebroadcaster component to scheduler, sigAgg and broadcaster and returns it.
 // This is not done in core.Wire since recaster isn't really part of the official core workflow (yet).
 func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Scheduler, sigAgg core.SigAgg,
 	broadcaster core.Broadcaster, validators []*manifestpb.Validator, builderAPI bool,
-	callback func(context.Context, core.Duty, core.SignedDataSet) error,
-) error {
+	proposerOverrides *overrides.Overrides, callback func(context.Context, core.Duty, core.SignedDataSet) error,
+) (*bcast.Recaster, error) {
 	recaster, err := bcast.NewRecaster(func(ctx context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
 		valList, err := eth2Cl.ActiveValidators(ctx)
 		if err != nil {
@@ -693,7 +869,7 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 		return ret, nil
 	})
 	if err != nil {
-		return errors.Wrap(err, "recaster init")
+		return nil, errors.Wrap(err, "recaster init")
 	}
 
 	sched.SubscribeSlots(recaster.SlotTicked)
@@ -705,9 +881,139 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 	}
 
 	if !builderAPI {
-		return nil
+		return recaster, nil
+	}
+
+	if err := storePregenRegistrations(ctx, eth2Cl, recaster, proposerOverrides, validators); err != nil {
+		return nil, err
+	}
+
+	return recaster, nil
+}
+
+// wireBuilderRelays wires the builder relay client to the recaster, submitting aggregated registrations to the relays,
+// and registers the builder API server for the beacon node with the life cycle manager.
+func wireBuilderRelays(ctx context.Context, life *lifecycle.Manager, conf Config, eth2Cl eth2wrap.Client, recaster *bcast.Recaster) error {
+	minBid, err := builderrelay.ETHToWei(conf.BuilderMinBid)
+	if err != nil {
+		return errors.Wrap(err, "parse builder min bid")
+	}
+
+	relays, err := builderrelay.New(ctx, eth2Cl, conf.BuilderRelays, minBid)
+	if err != nil {
+		return err
+	}
+
+	recaster.Subscribe(relays.SubmitRegistrations)
+
+	server := &http.Server{
+		Addr:              conf.BuilderRelayAPIAddr,
+		Handler:           builderrelay.NewRouter(ctx, relays),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	log.Info(ctx, "Builder API for beacon node enabled, submitting to builder relays directly",
+		z.Str("address", conf.BuilderRelayAPIAddr), z.Int("relays", len(conf.BuilderRelays)))
+
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartBuilderRelayAPI, httpServeHook(server.ListenAndServe))
+	life.RegisterStop(lifecycle.StopBuilderRelayAPI, lifecycle.HookFunc(server.Shutdown))
+
+	return nil
+}
+
+// wireKeymanagerAPI constructs the keymanager API server and registers it with the life cycle manager.
+func wireKeymanagerAPI(ctx context.Context, life *lifecycle.Manager, conf Config, pubkeys []core.PubKey,
+	proposerOverrides *overrides.Overrides, feeRecipientFunc func(core.PubKey) string,
+) error {
+	token, err := readTokenFile(conf.KeymanagerAPITokenFile)
+	if err != nil {
+		return errors.Wrap(err, "keymanager api token")
+	}
+
+	server := &http.Server{
+		Addr:              conf.KeymanagerAPIAddr,
+		Handler:           keymanagerapi.NewRouter(ctx, token, pubkeys, proposerOverrides, feeRecipientFunc),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	if conf.ProposerOverridesFile == "" {
+		log.Warn(ctx, "Keymanager API updates require proposer-overrides-file", nil)
+	}
+
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartKeymanagerAPI, httpServeHook(server.ListenAndServe))
+	life.RegisterStop(lifecycle.StopKeymanagerAPI, lifecycle.HookFunc(server.Shutdown))
+
+	return nil
+}
+
+// readTokenFile returns the bearer token contained in the file.
+func readTokenFile(filename string) (string, error) {
+	token, err := os.ReadFile(filename)
+	if err != nil {
+		return "", errors.Wrap(err, "read token file")
+	} else if len(bytes.TrimSpace(token)) == 0 {
+		return "", errors.New("empty token file")
+	}
+
+	return string(bytes.TrimSpace(token)), nil
+}
+
+// wireWeb3Signer constructs the Web3Signer API server signing with the node's key shares and
+// registers it with the life cycle manager. The pubshares are this node's public shares of the pubkeys by index.
+func wireWeb3Signer(ctx context.Context, life *lifecycle.Manager, conf Config, eth2Cl eth2wrap.Client,
+	pubkeys []core.PubKey, pubshares []eth2p0.BLSPubKey, dutyDB core.DutyDB,
+	dutyDefFunc func(context.Context, core.Duty) (core.DutyDefinitionSet, error),
+	feeRecipientFunc func(core.PubKey) string, gasLimitFunc func(core.PubKey) uint64,
+) error {
+	token, err := readTokenFile(conf.Web3SignerTokenFile)
+	if err != nil {
+		return errors.Wrap(err, "web3signer token")
+	}
+
+	keyFiles, err := keystore.LoadFilesUnordered(conf.Web3SignerKeysDir)
+	if err != nil {
+		return errors.Wrap(err, "load key shares")
+	}
+
+	shares := make(map[eth2p0.BLSPubKey]web3signer.Share)
+	for _, keyFile := range keyFiles {
+		pubshare, err := tbls.SecretToPublicKey(keyFile.PrivateKey)
+		if err != nil {
+			return err
+		}
+
+		idx := slices.Index(pubshares, eth2p0.BLSPubKey(pubshare))
+		if idx < 0 {
+			return errors.New("key share not a validator public share of this node", z.Str("filename", keyFile.Filename))
+		}
+
+		shares[eth2p0.BLSPubKey(pubshare)] = web3signer.Share{
+			PubKey: pubkeys[idx],
+			Secret: keyFile.PrivateKey,
+		}
+	}
+
+	server := &http.Server{
+		Addr: conf.Web3SignerAddr,
+		Handler: web3signer.NewRouter(ctx, token, eth2Cl, shares, dutyDB, dutyDefFunc,
+			feeRecipientFunc, gasLimitFunc),
+		ReadHeaderTimeout: time.Second,
 	}
 
+	log.Info(ctx, "Web3Signer API enabled, signing with key shares", z.Str("address", conf.Web3SignerAddr), z.Int("shares", len(shares)))
+
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartWeb3SignerAPI, httpServeHook(server.ListenAndServe))
+	life.RegisterStop(lifecycle.StopWeb3SignerAPI, lifecycle.HookFunc(server.Shutdown))
+
+	return nil
+}
+
+// storePregenRegistrations stores the validators' pre-generated builder registrations in the recaster.
+// Pre-generated registrations of validators with overridden fee recipient or gas limit are skipped if they are
+// older than or mismatch the overrides, since validator clients submit overridden registrations returned by the proposer config.
+func storePregenRegistrations(ctx context.Context, eth2Cl eth2wrap.Client, recaster *bcast.Recaster,
+	proposerOverrides *overrides.Overrides, validators []*manifestpb.Validator,
+) error {
 	for _, val := range validators {
 		// Check if the current cluster manifest supports pre-generate validator registrations.
 		if len(val.GetBuilderRegistrationJson()) == 0 {
@@ -724,6 +1030,21 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 			return errors.Wrap(err, "core pubkey from bytes")
 		}
 
+		if timestamp, ok := proposerOverrides.RegistrationTimestamp(pubkey); ok {
+			if timestamp.After(reg.V1.Message.Timestamp) {
+				continue
+			}
+
+			feeRecipient := fmt.Sprintf("%#x", reg.V1.Message.FeeRecipient)
+			if !strings.EqualFold(proposerOverrides.FeeRecipient(pubkey, feeRecipient), feeRecipient) ||
+				proposerOverrides.GasLimit(pubkey, reg.V1.Message.GasLimit) != reg.V1.Message.GasLimit {
+				log.Warn(ctx, "Skipping pre-generated registration of validator since it is newer than but mismatches "+
+					"its proposer overrides", nil, z.Any("pubkey", pubkey))
+
+				continue
+			}
+		}
+
 		signedData, err := core.NewVersionedSignedValidatorRegistration(reg)
 		if err != nil {
 			return errors.Wrap(err, "new versioned signed validator registration")
@@ -745,7 +1066,7 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 // newTracker creates and starts a new tracker instance.
 func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(duty core.Duty) (time.Time, bool),
 	peers []p2p.Peer, eth2Cl eth2wrap.Client,
-) (core.Tracker, error) {
+) (*tracker.Tracker, error) {
 	eth2Resp, err := eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
 	if err != nil {
 		return nil, err
@@ -779,6 +1100,26 @@ func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(
 	return track, nil
 }
 
+// defaultRegistrationTimestamp returns the timestamp of default builder registrations, which is the start of slot 1.
+func defaultRegistrationTimestamp(ctx context.Context, cl eth2wrap.Client) (time.Time, error) {
+	genesisTime, err := cl.GenesisTime(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	eth2Resp, err := cl.Spec(ctx, &eth2api.SpecOpts{})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	slotDuration, ok := eth2Resp.Data["SECONDS_PER_SLOT"].(time.Duration)
+	if !ok {
+		return time.Time{}, errors.New("fetch slot duration")
+	}
+
+	return genesisTime.Add(slotDuration), nil
+}
+
 // calculateTrackerDelay returns the slot to start tracking from. This mitigates noisy failed duties on
 // startup due to downstream VC startup delays.
 func calculateTrackerDelay(ctx context.Context, cl eth2wrap.Client, now time.Time) (uint64, error) {
@@ -1012,16 +1353,24 @@ func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, vapiAddr strin
 }
 
 // wireTracing constructs the global tracer and registers it with the life cycle manager.
-func wireTracing(life *lifecycle.Manager, conf Config) error {
-	stopjaeger, err := tracer.Init(
-		tracer.WithJaegerOrNoop(conf.JaegerAddr),
-		tracer.WithJaegerService(conf.JaegerService),
+// The labels identifying the node are added as trace resource attributes.
+func wireTracing(life *lifecycle.Manager, conf Config, labels map[string]string) error {
+	var attrs []attribute.KeyValue
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		attrs = append(attrs, attribute.String(k, labels[k]))
+	}
+
+	stopTracing, err := tracer.Init(
+		tracer.WithOTLPOrNoop(conf.OTLPProtocol, conf.OTLPAddress),
+		tracer.WithServiceName(conf.OTLPServiceName),
+		tracer.WithSampleRatio(conf.OTLPSampleRatio),
+		tracer.WithAttributes(attrs...),
 	)
 	if err != nil {
-		return errors.Wrap(err, "init jaeger tracing")
+		return errors.Wrap(err, "init otlp tracing")
 	}
 
-	life.RegisterStop(lifecycle.StopTracing, lifecycle.HookFunc(stopjaeger))
+	life.RegisterStop(lifecycle.StopTracing, lifecycle.HookFunc(stopTracing))
 
 	return nil
 }
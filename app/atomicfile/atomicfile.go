@@ -0,0 +1,67 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package atomicfile provides a helper function for atomically writing files.
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// Write atomically writes the data to the named file by writing to a temporary file
+// in the same directory that is synced and then renamed. A reader therefore either sees
+// the previous or the new contents of the file, never a partial write. The directory is
+// synced after the rename, so the new contents survive a crash once Write returns.
+func Write(filename string, data []byte, perm os.FileMode) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+"-tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "create temp file")
+	}
+	defer os.Remove(tmpFile.Name()) //nolint:errcheck // Best effort removal, fails if already renamed.
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrap(err, "write temp file")
+	}
+
+	if err := tmpFile.Chmod(perm); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrap(err, "chmod temp file")
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrap(err, "sync temp file")
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err, "close temp file")
+	}
+
+	if err := os.Rename(tmpFile.Name(), filename); err != nil {
+		return errors.Wrap(err, "rename temp file")
+	}
+
+	return syncDir(filepath.Dir(filename))
+}
+
+// syncDir syncs the directory, persisting renames of its entries.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "open directory")
+	}
+
+	if err := d.Sync(); err != nil {
+		_ = d.Close()
+		return errors.Wrap(err, "sync directory")
+	}
+
+	if err := d.Close(); err != nil {
+		return errors.Wrap(err, "close directory")
+	}
+
+	return nil
+}
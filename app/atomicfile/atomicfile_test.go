@@ -0,0 +1,37 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package atomicfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/atomicfile"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "file.json")
+
+	require.NoError(t, atomicfile.Write(filename, []byte("first"), 0o600))
+	require.NoError(t, atomicfile.Write(filename, []byte("second"), 0o644))
+
+	b, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, "second", string(b))
+
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// Writing to a missing directory fails.
+	require.Error(t, atomicfile.Write(filepath.Join(dir, "missing", "file.json"), nil, 0o644))
+}
@@ -0,0 +1,31 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package builderrelay
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+const (
+	endpointRegistrations = "validators"
+	endpointHeader        = "header"
+	endpointBlindedBlocks = "blinded_blocks"
+)
+
+var (
+	relayErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "builder_relay",
+		Name:      "errors_total",
+		Help:      "The total count of failed builder relay requests by relay and endpoint",
+	}, []string{"relay", "endpoint"})
+
+	bidValue = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "builder_relay",
+		Name:      "bid_value_gwei",
+		Help:      "The value in gwei of the latest builder relay bid served to the beacon node",
+	})
+)
@@ -0,0 +1,419 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package builderrelay provides a client of builder relays (MEV-Boost relays) and a builder API
+// server for the beacon node, replacing a separate MEV-Boost sidecar.
+package builderrelay
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	builderclient "github.com/attestantio/go-builder-client"
+	builderapi "github.com/attestantio/go-builder-client/api"
+	builderapiv1 "github.com/attestantio/go-builder-client/api/v1"
+	builderhttp "github.com/attestantio/go-builder-client/http"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	// zeroLogWarn is zerolog's warn level, this avoids importing zerolog directly.
+	zeroLogWarn = 2
+
+	// relayTimeout is the default timeout of builder relay requests.
+	relayTimeout = 10 * time.Second
+
+	// bidTimeout is the timeout of builder bid requests, bids are only useful early in the slot.
+	bidTimeout = time.Second
+
+	// bidRetention is the number of slots the relays of served bids are retained for unblinding.
+	bidRetention = 64
+)
+
+// relay is a builder relay client.
+type relay interface {
+	builderclient.BuilderBidProvider
+	builderclient.ValidatorRegistrationsSubmitter
+	builderclient.UnblindedProposalProvider
+}
+
+// bidRelay is the relay of a served bid.
+type bidRelay struct {
+	slot  eth2p0.Slot
+	relay relay
+}
+
+// New returns a new builder relay client of the relay addresses. Relay addresses must include the relay's
+// public key as URL user (e.g. https://0xpubkey@relay.example.com) which relay bid signatures are verified against.
+// Bids below minBid wei are ignored.
+func New(ctx context.Context, eth2Cl eth2wrap.Client, addresses []string, minBid *big.Int) (*Client, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("no builder relay addresses")
+	}
+
+	var relays []relay
+	for _, address := range addresses {
+		svc, err := builderhttp.New(ctx,
+			builderhttp.WithAddress(address),
+			builderhttp.WithTimeout(relayTimeout),
+			builderhttp.WithLogLevel(zeroLogWarn),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "new builder relay client")
+		}
+
+		r, ok := svc.(relay)
+		if !ok {
+			return nil, errors.New("invalid builder relay client")
+		} else if r.Pubkey() == nil {
+			return nil, errors.New("builder relay address missing public key, expected format https://0xpubkey@relay.example.com", z.Str("relay", r.Address()))
+		}
+
+		relays = append(relays, r)
+	}
+
+	return newClient(eth2Cl, relays, minBid), nil
+}
+
+// newClient returns a new builder relay client of the relays.
+func newClient(eth2Cl eth2wrap.Client, relays []relay, minBid *big.Int) *Client {
+	if minBid == nil {
+		minBid = big.NewInt(0)
+	}
+
+	return &Client{
+		eth2Cl:    eth2Cl,
+		relays:    relays,
+		minBid:    minBid,
+		bidRelays: make(map[eth2p0.Hash32]bidRelay),
+	}
+}
+
+// Client submits validator registrations to builder relays and obtains the best bid of the relays.
+type Client struct {
+	eth2Cl eth2wrap.Client
+	relays []relay
+	minBid *big.Int
+
+	mu        sync.Mutex
+	bidRelays map[eth2p0.Hash32]bidRelay // Relays of served bids by execution block hash.
+}
+
+// SubmitRegistrations submits the aggregated builder registrations to all relays.
+// It only returns an error if submitting to all relays failed.
+// It is a bcast.Recaster subscriber.
+func (c *Client) SubmitRegistrations(ctx context.Context, duty core.Duty, set core.SignedDataSet) error {
+	if duty.Type != core.DutyBuilderRegistration {
+		return nil
+	}
+
+	var registrations []*builderapi.VersionedSignedValidatorRegistration
+	for pubkey, data := range set {
+		reg, ok := data.(core.VersionedSignedValidatorRegistration)
+		if !ok || reg.V1 == nil || reg.V1.Message == nil {
+			return errors.New("invalid builder registration", z.Any("pubkey", pubkey))
+		}
+
+		registrations = append(registrations, &builderapi.VersionedSignedValidatorRegistration{
+			Version: builderspec.BuilderVersionV1,
+			V1: &builderapiv1.SignedValidatorRegistration{
+				Message: &builderapiv1.ValidatorRegistration{
+					FeeRecipient: reg.V1.Message.FeeRecipient,
+					GasLimit:     reg.V1.Message.GasLimit,
+					Timestamp:    reg.V1.Message.Timestamp,
+					Pubkey:       reg.V1.Message.Pubkey,
+				},
+				Signature: reg.V1.Signature,
+			},
+		})
+	}
+
+	var success bool
+	for _, r := range c.relays {
+		err := r.SubmitValidatorRegistrations(ctx, &builderapi.SubmitValidatorRegistrationsOpts{
+			Registrations: registrations,
+		})
+		if err != nil {
+			relayErrors.WithLabelValues(r.Address(), endpointRegistrations).Inc()
+			log.Warn(ctx, "Failed submitting builder registrations to relay", err, z.Str("relay", r.Address()))
+
+			continue
+		}
+
+		success = true
+	}
+
+	if !success {
+		return errors.New("submit builder registrations to all relays failed")
+	}
+
+	return nil
+}
+
+// BestBid returns the highest valid bid of all relays for the slot, parent execution block hash and proposer
+// or nil if no relay provided a bid of at least the minimum bid, in which case a local block should be proposed.
+func (c *Client) BestBid(ctx context.Context, slot eth2p0.Slot, parentHash eth2p0.Hash32, pubkey eth2p0.BLSPubKey,
+) (*builderspec.VersionedSignedBuilderBid, error) {
+	type result struct {
+		relay relay
+		bid   *builderspec.VersionedSignedBuilderBid
+		value *big.Int
+		err   error
+	}
+
+	results := make(chan result, len(c.relays))
+	for _, r := range c.relays {
+		go func(r relay) {
+			bid, value, err := c.relayBid(ctx, r, slot, parentHash, pubkey)
+			results <- result{relay: r, bid: bid, value: value, err: err}
+		}(r)
+	}
+
+	var best result
+	for range c.relays {
+		res := <-results
+		if res.err != nil {
+			relayErrors.WithLabelValues(res.relay.Address(), endpointHeader).Inc()
+			log.Warn(ctx, "Failed obtaining builder relay bid", res.err, z.Str("relay", res.relay.Address()))
+
+			continue
+		} else if res.bid == nil {
+			continue // No bid
+		}
+
+		if best.bid == nil || res.value.Cmp(best.value) > 0 {
+			best = res
+		}
+	}
+
+	if best.bid == nil {
+		log.Debug(ctx, "No builder relay bids, proposing local block", z.U64("slot", uint64(slot)))
+		return nil, nil
+	} else if best.value.Cmp(c.minBid) < 0 {
+		log.Info(ctx, "Builder relay bids below minimum bid, proposing local block",
+			z.U64("slot", uint64(slot)), z.Str("bid", best.value.String()), z.Str("min_bid", c.minBid.String()))
+
+		return nil, nil
+	}
+
+	blockHash, err := best.bid.BlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "bid block hash")
+	}
+
+	c.storeBidRelay(slot, blockHash, best.relay)
+	bidValue.Set(weiToGwei(best.value))
+
+	log.Info(ctx, "Obtained builder relay bid",
+		z.U64("slot", uint64(slot)), z.Str("relay", best.relay.Address()), z.Str("value", best.value.String()))
+
+	return best.bid, nil
+}
+
+// relayBid returns the relay's verified bid and its value in wei or nil if the relay didn't provide a bid.
+func (c *Client) relayBid(ctx context.Context, r relay, slot eth2p0.Slot, parentHash eth2p0.Hash32, pubkey eth2p0.BLSPubKey,
+) (*builderspec.VersionedSignedBuilderBid, *big.Int, error) {
+	resp, err := r.BuilderBid(ctx, &builderapi.BuilderBidOpts{
+		Common:     builderapi.CommonOpts{Timeout: bidTimeout},
+		Slot:       slot,
+		ParentHash: parentHash,
+		PubKey:     pubkey,
+	})
+	if err != nil {
+		return nil, nil, err
+	} else if resp == nil || resp.Data == nil || resp.Data.IsEmpty() {
+		return nil, nil, nil
+	}
+
+	bid := resp.Data
+
+	relayPubkey := r.Pubkey()
+	if relayPubkey == nil {
+		return nil, nil, errors.New("unknown relay public key")
+	}
+
+	if err := verifyBid(ctx, c.eth2Cl, bid, *relayPubkey, slot, parentHash); err != nil {
+		return nil, nil, err
+	}
+
+	value, err := bid.Value()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "bid value")
+	}
+
+	return bid, value.ToBig(), nil
+}
+
+// verifyBid returns an error if the bid isn't signed by the relay, isn't for the requested slot and parent
+// execution block, or has a zero value or an empty block hash.
+func verifyBid(ctx context.Context, eth2Cl eth2wrap.Client, bid *builderspec.VersionedSignedBuilderBid,
+	relayPubkey eth2p0.BLSPubKey, slot eth2p0.Slot, parentHash eth2p0.Hash32,
+) error {
+	builder, err := bid.Builder()
+	if err != nil {
+		return errors.Wrap(err, "bid builder")
+	} else if builder != relayPubkey {
+		return errors.New("bid builder pubkey mismatches relay pubkey")
+	}
+
+	bidParentHash, err := bid.ParentHash()
+	if err != nil {
+		return errors.Wrap(err, "bid parent hash")
+	} else if bidParentHash != parentHash {
+		return errors.New("bid parent hash mismatches requested parent hash",
+			z.Hex("expected", parentHash[:]), z.Hex("actual", bidParentHash[:]))
+	}
+
+	timestamp, err := bid.Timestamp()
+	if err != nil {
+		return errors.Wrap(err, "bid timestamp")
+	}
+
+	genesis, err := eth2Cl.GenesisTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	slotDuration, err := eth2Cl.SlotDuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The execution payload timestamp is the start time of the slot in seconds.
+	if expected := uint64(genesis.Add(time.Duration(slot) * slotDuration).Unix()); timestamp != expected {
+		return errors.New("bid timestamp mismatches requested slot",
+			z.U64("slot", uint64(slot)), z.U64("expected", expected), z.U64("actual", timestamp))
+	}
+
+	value, err := bid.Value()
+	if err != nil {
+		return errors.Wrap(err, "bid value")
+	} else if value.IsZero() {
+		return errors.New("zero bid value")
+	}
+
+	blockHash, err := bid.BlockHash()
+	if err != nil {
+		return errors.Wrap(err, "bid block hash")
+	} else if blockHash == (eth2p0.Hash32{}) {
+		return errors.New("empty bid block hash")
+	}
+
+	sigRoot, err := bid.MessageHashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "bid message root")
+	}
+
+	sig, err := bid.Signature()
+	if err != nil {
+		return errors.Wrap(err, "bid signature")
+	}
+
+	// Always use epoch 0 for DomainApplicationBuilder.
+	if err := signing.Verify(ctx, eth2Cl, signing.DomainApplicationBuilder, 0, sigRoot, sig, tbls.PublicKey(relayPubkey)); err != nil {
+		return errors.Wrap(err, "verify bid signature")
+	}
+
+	return nil
+}
+
+// Unblind submits the signed blinded proposal to the relay that provided its bid, or else to all relays,
+// and returns the unblinded proposal. Note that relays also publish the unblinded proposal.
+func (c *Client) Unblind(ctx context.Context, proposal *eth2api.VersionedSignedBlindedProposal) (*eth2api.VersionedSignedProposal, error) {
+	blockHash, err := proposal.ExecutionBlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "blinded proposal block hash")
+	}
+
+	relays := c.relays
+	if r, ok := c.getBidRelay(blockHash); ok {
+		relays = []relay{r}
+	}
+
+	for _, r := range relays {
+		resp, err := r.UnblindProposal(ctx, &builderapi.UnblindProposalOpts{Proposal: proposal})
+		if err != nil {
+			relayErrors.WithLabelValues(r.Address(), endpointBlindedBlocks).Inc()
+			log.Warn(ctx, "Failed unblinding proposal via builder relay", err, z.Str("relay", r.Address()))
+
+			continue
+		} else if resp == nil || resp.Data == nil {
+			continue
+		}
+
+		return resp.Data, nil
+	}
+
+	return nil, errors.New("unblind proposal via builder relays failed")
+}
+
+// storeBidRelay stores the relay of a served bid and deletes relays of old bids.
+func (c *Client) storeBidRelay(slot eth2p0.Slot, blockHash eth2p0.Hash32, r relay) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for hash, br := range c.bidRelays {
+		if br.slot+bidRetention < slot {
+			delete(c.bidRelays, hash)
+		}
+	}
+
+	c.bidRelays[blockHash] = bidRelay{slot: slot, relay: r}
+}
+
+// getBidRelay returns the relay of a served bid by execution block hash.
+func (c *Client) getBidRelay(blockHash eth2p0.Hash32) (relay, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	br, ok := c.bidRelays[blockHash]
+
+	return br.relay, ok
+}
+
+// ETHToWei returns the amount of wei of the decimal ether amount, e.g. "0.05".
+// The amount is parsed exactly, it must not be negative and have at most 18 decimals.
+func ETHToWei(eth string) (*big.Int, error) {
+	const decimals = 18
+
+	whole, frac, _ := strings.Cut(strings.TrimSpace(eth), ".")
+	if whole == "" && frac == "" || len(frac) > decimals || !isDigits(whole) || !isDigits(frac) {
+		return nil, errors.New("invalid ether amount", z.Str("amount", eth))
+	}
+
+	wei, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	if !ok {
+		return nil, errors.New("invalid ether amount", z.Str("amount", eth))
+	}
+
+	return wei, nil
+}
+
+// isDigits returns true if the string only contains decimal digits.
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// weiToGwei returns the amount of wei in gwei as a float.
+func weiToGwei(wei *big.Int) float64 {
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e9)).Float64()
+	return gwei
+}
@@ -0,0 +1,399 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package builderrelay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	builderapi "github.com/attestantio/go-builder-client/api"
+	builderdeneb "github.com/attestantio/go-builder-client/api/deneb"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	pubkey := testutil.RandomEth2PubKey(t)
+
+	cl, err := New(ctx, nil, []string{fmt.Sprintf("https://%#x@relay.example.com", pubkey[:])}, nil)
+	require.NoError(t, err)
+	require.Equal(t, pubkey, *cl.relays[0].Pubkey())
+
+	// Relay public keys are required to verify bids.
+	_, err = New(ctx, nil, []string{"https://relay.example.com"}, nil)
+	require.ErrorContains(t, err, "builder relay address missing public key")
+}
+
+func TestBestBid(t *testing.T) {
+	ctx := context.Background()
+	eth2Cl, err := beaconmock.New()
+	require.NoError(t, err)
+
+	low := newTestRelay(t, eth2Cl, "low", 100)
+	high := newTestRelay(t, eth2Cl, "high", 200)
+	none := &testRelay{address: "none"}
+	failing := &testRelay{address: "failing", err: errors.New("failing")}
+
+	// Bids not signed by the relay are invalid.
+	invalid := newTestRelay(t, eth2Cl, "invalid", 300)
+	invalid.bid.Deneb.Signature = testutil.RandomEth2Signature()
+
+	var parentHash eth2p0.Hash32
+	pubkey := testutil.RandomEth2PubKey(t)
+
+	cl := newClient(eth2Cl, []relay{low, high, none, failing, invalid}, big.NewInt(150))
+	bid, err := cl.BestBid(ctx, 1, parentHash, pubkey)
+	require.NoError(t, err)
+	require.Equal(t, high.bid, bid)
+
+	// The relay of served bids is used to unblind.
+	blockHash, err := bid.BlockHash()
+	require.NoError(t, err)
+	r, ok := cl.getBidRelay(blockHash)
+	require.True(t, ok)
+	require.Equal(t, high, r)
+
+	// Bids below the minimum are not served.
+	cl = newClient(eth2Cl, []relay{low, high}, big.NewInt(300))
+	bid, err = cl.BestBid(ctx, 1, parentHash, pubkey)
+	require.NoError(t, err)
+	require.Nil(t, bid)
+
+	// No bids are served if relays don't provide any.
+	cl = newClient(nil, []relay{none, failing}, nil)
+	bid, err = cl.BestBid(ctx, 1, parentHash, pubkey)
+	require.NoError(t, err)
+	require.Nil(t, bid)
+}
+
+func TestVerifyBidBuilder(t *testing.T) {
+	eth2Cl, err := beaconmock.New()
+	require.NoError(t, err)
+
+	bid := newTestRelay(t, eth2Cl, "relay", 100).bid
+
+	err = verifyBid(context.Background(), eth2Cl, bid, testutil.RandomEth2PubKey(t), testSlot, eth2p0.Hash32{})
+	require.ErrorContains(t, err, "bid builder pubkey mismatches relay pubkey")
+}
+
+func TestVerifyBid(t *testing.T) {
+	ctx := context.Background()
+	eth2Cl, err := beaconmock.New()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		mutate func(header *deneb.ExecutionPayloadHeader)
+		slot   eth2p0.Slot
+		value  uint64
+		errStr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*deneb.ExecutionPayloadHeader) {},
+			slot:   testSlot,
+			value:  100,
+		},
+		{
+			name: "parent hash",
+			mutate: func(header *deneb.ExecutionPayloadHeader) {
+				header.ParentHash = eth2p0.Hash32(testutil.RandomRoot())
+			},
+			slot:   testSlot,
+			value:  100,
+			errStr: "bid parent hash mismatches requested parent hash",
+		},
+		{
+			name:   "slot",
+			mutate: func(*deneb.ExecutionPayloadHeader) {},
+			slot:   testSlot + 1,
+			value:  100,
+			errStr: "bid timestamp mismatches requested slot",
+		},
+		{
+			name: "timestamp",
+			mutate: func(header *deneb.ExecutionPayloadHeader) {
+				header.Timestamp++
+			},
+			slot:   testSlot,
+			value:  100,
+			errStr: "bid timestamp mismatches requested slot",
+		},
+		{
+			name:   "zero value",
+			mutate: func(*deneb.ExecutionPayloadHeader) {},
+			slot:   testSlot,
+			value:  0,
+			errStr: "zero bid value",
+		},
+		{
+			name: "empty block hash",
+			mutate: func(header *deneb.ExecutionPayloadHeader) {
+				header.BlockHash = eth2p0.Hash32{}
+			},
+			slot:   testSlot,
+			value:  100,
+			errStr: "empty bid block hash",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newTestRelay(t, eth2Cl, "relay", test.value)
+			test.mutate(r.bid.Deneb.Message.Header)
+			r.signBid(t, eth2Cl, test.value)
+
+			err := verifyBid(ctx, eth2Cl, r.bid, r.pubkey, test.slot, eth2p0.Hash32{})
+			if test.errStr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.errStr)
+			}
+
+			// Invalid bids are never served, even if more valuable.
+			bid, err := newClient(eth2Cl, []relay{r}, nil).BestBid(ctx, test.slot, eth2p0.Hash32{}, testutil.RandomEth2PubKey(t))
+			require.NoError(t, err)
+			if test.errStr == "" {
+				require.Equal(t, r.bid, bid)
+			} else {
+				require.Nil(t, bid)
+			}
+		})
+	}
+}
+
+func TestSubmitRegistrations(t *testing.T) {
+	ctx := context.Background()
+
+	ok := &testRelay{address: "ok"}
+	failing := &testRelay{address: "failing", err: errors.New("failing")}
+
+	reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	set := core.SignedDataSet{testutil.RandomCorePubKey(t): reg}
+
+	cl := newClient(nil, []relay{ok, failing}, nil)
+	require.NoError(t, cl.SubmitRegistrations(ctx, core.NewBuilderRegistrationDuty(1), set))
+	require.Len(t, ok.registrations, 1)
+	require.Equal(t, reg.V1.Message.FeeRecipient, ok.registrations[0].V1.Message.FeeRecipient)
+	require.Equal(t, reg.V1.Signature, ok.registrations[0].V1.Signature)
+
+	// Other duties are ignored.
+	require.NoError(t, cl.SubmitRegistrations(ctx, core.NewRandaoDuty(1), core.SignedDataSet{}))
+
+	cl = newClient(nil, []relay{failing}, nil)
+	err := cl.SubmitRegistrations(ctx, core.NewBuilderRegistrationDuty(1), set)
+	require.ErrorContains(t, err, "submit builder registrations to all relays failed")
+}
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+
+	eth2Cl, err := beaconmock.New()
+	require.NoError(t, err)
+
+	mock := newTestRelay(t, eth2Cl, "relay", 200)
+	mock.unblinded = testutil.RandomDenebVersionedSignedProposal()
+
+	srv := httptest.NewServer(NewRouter(ctx, newClient(eth2Cl, []relay{mock}, big.NewInt(100))))
+	defer srv.Close()
+
+	pubkey := testutil.RandomEth2PubKey(t)
+	headerURL := fmt.Sprintf("%s/eth/v1/builder/header/1/%#x/%#x", srv.URL, make([]byte, 32), pubkey[:])
+
+	resp, err := http.Get(headerURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "deneb", resp.Header.Get(versionHeader))
+
+	bid := new(builderspec.VersionedSignedBuilderBid)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(bid))
+	require.Equal(t, mock.bid, bid)
+
+	// Bids below the minimum result in no content.
+	mock.signBid(t, eth2Cl, 50)
+	resp, err = http.Get(headerURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "/eth/v1/builder/header/1/0x1234/0x1234")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Unblind a signed blinded proposal.
+	blinded := testutil.RandomDenebVersionedSignedBlindedProposal()
+	body, err := json.Marshal(blinded.DenebBlinded)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/eth/v1/builder/blinded_blocks", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set(versionHeader, "deneb")
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	payload := new(builderapi.VersionedSubmitBlindedBlockResponse)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(payload))
+	require.Equal(t, eth2spec.DataVersionDeneb, payload.Version)
+	require.Equal(t, mock.unblinded.Deneb.SignedBlock.Message.Body.ExecutionPayload, payload.Deneb.ExecutionPayload)
+	require.Equal(t, blinded.DenebBlinded, mock.blinded.Deneb)
+}
+
+func TestETHToWei(t *testing.T) {
+	for eth, wei := range map[string]string{
+		"0":                    "0",
+		"1.5":                  "1500000000000000000",
+		"0.05":                 "50000000000000000",
+		".1":                   "100000000000000000",
+		"0.000000000000000001": "1",
+		"123456789.123456789":  "123456789123456789000000000",
+	} {
+		resp, err := ETHToWei(eth)
+		require.NoError(t, err)
+		require.Equal(t, wei, resp.String(), eth)
+	}
+
+	for _, eth := range []string{"", ".", "-1", "1e18", "0.0000000000000000001", "1.2.3", "abc"} {
+		_, err := ETHToWei(eth)
+		require.ErrorContains(t, err, "invalid ether amount", eth)
+	}
+
+	wei, err := ETHToWei("1.5")
+	require.NoError(t, err)
+	require.InDelta(t, 1.5e9, weiToGwei(wei), 1)
+}
+
+// testSlot is the slot of test relay bids.
+const testSlot = 1
+
+// newTestRelay returns a test relay providing a deneb bid for testSlot and the zero parent hash,
+// with the value signed by the relay.
+func newTestRelay(t *testing.T, eth2Cl eth2wrap.Client, address string, value uint64) *testRelay {
+	t.Helper()
+
+	genesis, err := eth2Cl.GenesisTime(context.Background())
+	require.NoError(t, err)
+	slotDuration, err := eth2Cl.SlotDuration(context.Background())
+	require.NoError(t, err)
+
+	header := testutil.RandomDenebExecutionPayloadHeader()
+	header.ParentHash = eth2p0.Hash32{}
+	header.Timestamp = uint64(genesis.Add(testSlot * slotDuration).Unix())
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	r := &testRelay{
+		address: address,
+		secret:  secret,
+		pubkey:  eth2p0.BLSPubKey(pubkey),
+		bid: &builderspec.VersionedSignedBuilderBid{
+			Version: eth2spec.DataVersionDeneb,
+			Deneb: &builderdeneb.SignedBuilderBid{
+				Message: &builderdeneb.BuilderBid{
+					Header:             header,
+					BlobKZGCommitments: []deneb.KZGCommitment{},
+					Pubkey:             eth2p0.BLSPubKey(pubkey),
+				},
+			},
+		},
+	}
+	r.signBid(t, eth2Cl, value)
+
+	return r
+}
+
+// signBid sets the bid value and signs the bid with the relay's secret.
+func (r *testRelay) signBid(t *testing.T, eth2Cl eth2wrap.Client, value uint64) {
+	t.Helper()
+
+	r.bid.Deneb.Message.Value = uint256.NewInt(value)
+
+	msgRoot, err := r.bid.Deneb.Message.HashTreeRoot()
+	require.NoError(t, err)
+
+	sigData, err := signing.GetDataRoot(context.Background(), eth2Cl, signing.DomainApplicationBuilder, 0, msgRoot)
+	require.NoError(t, err)
+
+	sig, err := tbls.Sign(r.secret, sigData[:])
+	require.NoError(t, err)
+
+	r.bid.Deneb.Signature = eth2p0.BLSSignature(sig)
+}
+
+// testRelay is a test in-memory relay.
+type testRelay struct {
+	address       string
+	secret        tbls.PrivateKey
+	pubkey        eth2p0.BLSPubKey
+	bid           *builderspec.VersionedSignedBuilderBid
+	unblinded     *eth2api.VersionedSignedProposal
+	err           error
+	registrations []*builderapi.VersionedSignedValidatorRegistration
+	blinded       *eth2api.VersionedSignedBlindedProposal
+}
+
+func (*testRelay) Name() string {
+	return "test"
+}
+
+func (r *testRelay) Address() string {
+	return r.address
+}
+
+func (r *testRelay) Pubkey() *eth2p0.BLSPubKey {
+	return &r.pubkey
+}
+
+func (r *testRelay) BuilderBid(context.Context, *builderapi.BuilderBidOpts) (*builderapi.Response[*builderspec.VersionedSignedBuilderBid], error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &builderapi.Response[*builderspec.VersionedSignedBuilderBid]{Data: r.bid}, nil
+}
+
+func (r *testRelay) SubmitValidatorRegistrations(_ context.Context, opts *builderapi.SubmitValidatorRegistrationsOpts) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	r.registrations = append(r.registrations, opts.Registrations...)
+
+	return nil
+}
+
+func (r *testRelay) UnblindProposal(_ context.Context, opts *builderapi.UnblindProposalOpts) (*builderapi.Response[*eth2api.VersionedSignedProposal], error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	r.blinded = opts.Proposal
+
+	return &builderapi.Response[*eth2api.VersionedSignedProposal]{Data: r.unblinded}, nil
+}
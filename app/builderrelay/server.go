@@ -0,0 +1,225 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package builderrelay
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	builderapi "github.com/attestantio/go-builder-client/api"
+	builderdeneb "github.com/attestantio/go-builder-client/api/deneb"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// versionHeader is the HTTP header containing the consensus version of request and response bodies.
+const versionHeader = "Eth-Consensus-Version"
+
+// NewRouter returns a builder API router to be configured as the beacon node's builder (MEV-Boost) endpoint.
+// It serves the best bid of the relays, or no bid if below the minimum bid so the beacon node falls back to a local block,
+// and unblinds signed blinded proposals via the relays.
+func NewRouter(ctx context.Context, cl *Client) http.Handler {
+	ctx = log.WithTopic(ctx, "builder")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/builder/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("POST /eth/v1/builder/validators", func(w http.ResponseWriter, _ *http.Request) {
+		// Aggregated registrations are submitted to relays directly, so beacon node registrations are ignored.
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /eth/v1/builder/header/{slot}/{parent_hash}/{pubkey}", func(w http.ResponseWriter, r *http.Request) {
+		handleHeader(ctx, cl, w, r)
+	})
+	mux.HandleFunc("POST /eth/v1/builder/blinded_blocks", func(w http.ResponseWriter, r *http.Request) {
+		handleBlindedBlocks(ctx, cl, w, r)
+	})
+
+	return mux
+}
+
+// handleHeader serves the best relay bid for the requested slot, parent hash and proposer.
+func handleHeader(ctx context.Context, cl *Client, w http.ResponseWriter, r *http.Request) {
+	slot, err := strconv.ParseUint(r.PathValue("slot"), 10, 64)
+	if err != nil {
+		writeError(ctx, w, http.StatusBadRequest, errors.Wrap(err, "invalid slot"))
+		return
+	}
+
+	var parentHash eth2p0.Hash32
+	if err := decodeHex(r.PathValue("parent_hash"), parentHash[:]); err != nil {
+		writeError(ctx, w, http.StatusBadRequest, errors.Wrap(err, "invalid parent hash"))
+		return
+	}
+
+	var pubkey eth2p0.BLSPubKey
+	if err := decodeHex(r.PathValue("pubkey"), pubkey[:]); err != nil {
+		writeError(ctx, w, http.StatusBadRequest, errors.Wrap(err, "invalid pubkey"))
+		return
+	}
+
+	bid, err := cl.BestBid(r.Context(), eth2p0.Slot(slot), parentHash, pubkey)
+	if err != nil {
+		writeError(ctx, w, http.StatusInternalServerError, err)
+		return
+	} else if bid == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(ctx, w, bid.Version, bid)
+}
+
+// handleBlindedBlocks unblinds the signed blinded proposal via the relays and returns its execution payload.
+func handleBlindedBlocks(ctx context.Context, cl *Client, w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(ctx, w, http.StatusBadRequest, errors.Wrap(err, "read body"))
+		return
+	}
+
+	proposal, err := decodeBlindedProposal(r.Header.Get(versionHeader), body)
+	if err != nil {
+		writeError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	unblinded, err := cl.Unblind(r.Context(), proposal)
+	if err != nil {
+		writeError(ctx, w, http.StatusBadGateway, err)
+		return
+	}
+
+	resp, err := payloadResponse(unblinded)
+	if err != nil {
+		writeError(ctx, w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(ctx, w, resp.Version, resp)
+}
+
+// decodeBlindedProposal returns the signed blinded proposal of the JSON body and consensus version.
+func decodeBlindedProposal(version string, body []byte) (*eth2api.VersionedSignedBlindedProposal, error) {
+	resp := new(eth2api.VersionedSignedBlindedProposal)
+
+	var err error
+	switch strings.ToLower(version) {
+	case eth2spec.DataVersionBellatrix.String():
+		resp.Version = eth2spec.DataVersionBellatrix
+		resp.Bellatrix = new(eth2bellatrix.SignedBlindedBeaconBlock)
+		err = json.Unmarshal(body, resp.Bellatrix)
+	case eth2spec.DataVersionCapella.String():
+		resp.Version = eth2spec.DataVersionCapella
+		resp.Capella = new(eth2capella.SignedBlindedBeaconBlock)
+		err = json.Unmarshal(body, resp.Capella)
+	case eth2spec.DataVersionDeneb.String():
+		resp.Version = eth2spec.DataVersionDeneb
+		resp.Deneb = new(eth2deneb.SignedBlindedBeaconBlock)
+		err = json.Unmarshal(body, resp.Deneb)
+	default:
+		return nil, errors.New("unsupported consensus version", z.Str("version", version))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal signed blinded proposal")
+	}
+
+	return resp, nil
+}
+
+// payloadResponse returns the builder API execution payload response of the unblinded proposal.
+func payloadResponse(proposal *eth2api.VersionedSignedProposal) (*builderapi.VersionedSubmitBlindedBlockResponse, error) {
+	resp := &builderapi.VersionedSubmitBlindedBlockResponse{Version: proposal.Version}
+
+	switch proposal.Version {
+	case eth2spec.DataVersionBellatrix:
+		if proposal.Bellatrix == nil || proposal.Bellatrix.Message == nil || proposal.Bellatrix.Message.Body == nil {
+			return nil, errors.New("no bellatrix proposal")
+		}
+		resp.Bellatrix = proposal.Bellatrix.Message.Body.ExecutionPayload
+	case eth2spec.DataVersionCapella:
+		if proposal.Capella == nil || proposal.Capella.Message == nil || proposal.Capella.Message.Body == nil {
+			return nil, errors.New("no capella proposal")
+		}
+		resp.Capella = proposal.Capella.Message.Body.ExecutionPayload
+	case eth2spec.DataVersionDeneb:
+		if proposal.Deneb == nil || proposal.Deneb.SignedBlock == nil ||
+			proposal.Deneb.SignedBlock.Message == nil || proposal.Deneb.SignedBlock.Message.Body == nil {
+			return nil, errors.New("no deneb proposal")
+		}
+		body := proposal.Deneb.SignedBlock.Message.Body
+		resp.Deneb = &builderdeneb.ExecutionPayloadAndBlobsBundle{
+			ExecutionPayload: body.ExecutionPayload,
+			BlobsBundle: &builderdeneb.BlobsBundle{
+				Commitments: body.BlobKZGCommitments,
+				Proofs:      proposal.Deneb.KZGProofs,
+				Blobs:       proposal.Deneb.Blobs,
+			},
+		}
+	default:
+		return nil, errors.New("unsupported proposal version", z.Str("version", proposal.Version.String()))
+	}
+
+	return resp, nil
+}
+
+// decodeHex decodes the 0x-prefixed hex string into the fixed length byte slice.
+func decodeHex(s string, into []byte) error {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "decode hex")
+	} else if len(b) != len(into) {
+		return errors.New("invalid length", z.Int("expected", len(into)), z.Int("actual", len(b)))
+	}
+
+	copy(into, b)
+
+	return nil
+}
+
+// writeJSON writes the JSON response with the consensus version header.
+func writeJSON(ctx context.Context, w http.ResponseWriter, version eth2spec.DataVersion, resp any) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		writeError(ctx, w, http.StatusInternalServerError, errors.Wrap(err, "marshal response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(versionHeader, version.String())
+
+	if _, err := w.Write(b); err != nil {
+		log.Error(ctx, "Failed writing builder api response", err)
+	}
+}
+
+// writeError writes a builder API error response.
+func writeError(ctx context.Context, w http.ResponseWriter, code int, err error) {
+	log.Warn(ctx, "Builder api request failed", err, z.Int("code", code))
+
+	b, _ := json.Marshal(struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{
+		Code:    code,
+		Message: err.Error(),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(b)
+}
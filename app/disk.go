@@ -3,13 +3,18 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"os"
+	"sync"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/cluster/manifest"
 	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
 )
 
 // loadClusterManifest returns the cluster manifest from the given file path.
@@ -41,3 +46,71 @@ func loadClusterManifest(ctx context.Context, conf Config) (*manifestpb.Cluster,
 
 	return cluster, nil
 }
+
+// newManifestReloader returns a slot subscriber that reloads the cluster manifest file at the start of each epoch.
+// The updateFunc is called for each validator whose fee recipient or pre-generated builder registration changed
+// compared to the currently running cluster. Other cluster changes require a restart.
+func newManifestReloader(conf Config, current *manifestpb.Cluster,
+	updateFunc func(context.Context, *manifestpb.Validator) error,
+) func(context.Context, core.Slot) error {
+	var (
+		mu         sync.Mutex
+		latest     = current.GetLatestMutationHash()
+		validators = make(map[string]*manifestpb.Validator)
+	)
+	for _, val := range current.GetValidators() {
+		validators[string(val.GetPublicKey())] = val
+	}
+
+	return func(ctx context.Context, slot core.Slot) error {
+		if !slot.FirstInEpoch() || conf.TestConfig.Lock != nil {
+			return nil
+		}
+
+		// Only cluster manifest files support mutations.
+		if _, err := os.Stat(conf.ManifestFile); err != nil {
+			return nil //nolint:nilerr // No manifest file to reload.
+		}
+
+		cluster, err := manifest.LoadCluster(conf.ManifestFile, "", nil)
+		if err != nil {
+			return errors.Wrap(err, "reload cluster manifest")
+		} else if !bytes.Equal(cluster.GetInitialMutationHash(), current.GetInitialMutationHash()) {
+			return errors.New("reloaded cluster manifest hash mismatch")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if bytes.Equal(cluster.GetLatestMutationHash(), latest) {
+			return nil // No new mutations.
+		}
+
+		if cluster.GetThreshold() != current.GetThreshold() || len(cluster.GetOperators()) != len(current.GetOperators()) ||
+			len(cluster.GetValidators()) != len(current.GetValidators()) {
+			log.Warn(ctx, "Reloaded cluster manifest contains operator, threshold or validator changes, restart required", nil)
+		}
+
+		for _, val := range cluster.GetValidators() {
+			prev, ok := validators[string(val.GetPublicKey())]
+			if !ok || (prev.GetFeeRecipientAddress() == val.GetFeeRecipientAddress() &&
+				bytes.Equal(prev.GetBuilderRegistrationJson(), val.GetBuilderRegistrationJson())) {
+				continue
+			}
+
+			if err := updateFunc(ctx, val); err != nil {
+				return errors.Wrap(err, "update validator")
+			}
+
+			log.Info(ctx, "Applied updated validator fee recipient from cluster manifest",
+				z.Str("pubkey", manifest.ValidatorPublicKeyHex(val)),
+				z.Str("fee_recipient", val.GetFeeRecipientAddress()))
+
+			validators[string(val.GetPublicKey())] = val
+		}
+
+		latest = cluster.GetLatestMutationHash()
+
+		return nil
+	}
+}
@@ -4,14 +4,28 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
 	"testing"
 	"time"
 
+	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/overrides"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/bcast"
+	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
 )
 
@@ -94,3 +108,149 @@ func TestSetFeeRecipient(t *testing.T) {
 		require.Equal(t, active, len(clone)-(i+1))
 	}
 }
+
+func TestManifestReloader(t *testing.T) {
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, _ := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	dag, err := manifest.NewDAGFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	manifestFile := path.Join(t.TempDir(), "cluster-manifest.pb")
+	writeDAG := func() {
+		b, err := proto.Marshal(dag)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(manifestFile, b, 0o644))
+	}
+	writeDAG()
+
+	current, err := manifest.LoadCluster(manifestFile, "", nil)
+	require.NoError(t, err)
+
+	var updated []*manifestpb.Validator
+	reload := newManifestReloader(Config{ManifestFile: manifestFile}, current, func(_ context.Context, val *manifestpb.Validator) error {
+		updated = append(updated, val)
+		return nil
+	})
+
+	ctx := context.Background()
+	epoch := core.Slot{Slot: 32, SlotsPerEpoch: 32}
+
+	// No new mutations.
+	require.NoError(t, reload(ctx, epoch))
+	require.Empty(t, updated)
+
+	// Set fee recipient of the second validator.
+	const feeRecipient = "0x000000000000000000000000000000000000dEaD"
+	parent, err := manifest.Hash(dag.GetMutations()[0])
+	require.NoError(t, err)
+	proposal, err := manifest.NewSetFeeRecipient(parent, lock.Validators[1].PubKey, feeRecipient, nil)
+	require.NoError(t, err)
+	hash, err := manifest.Hash(proposal)
+	require.NoError(t, err)
+
+	var approvals []*manifestpb.SignedMutation
+	for _, secret := range secrets[:3] {
+		approval, err := manifest.SignNodeApproval(hash, secret)
+		require.NoError(t, err)
+		approvals = append(approvals, approval)
+	}
+	approved, err := manifest.NewApprovedMutation(proposal, approvals)
+	require.NoError(t, err)
+
+	dag.Mutations = append(dag.Mutations, approved)
+	writeDAG()
+
+	// Only reloaded at the start of an epoch.
+	require.NoError(t, reload(ctx, core.Slot{Slot: 33, SlotsPerEpoch: 32}))
+	require.Empty(t, updated)
+
+	require.NoError(t, reload(ctx, epoch))
+	require.Len(t, updated, 1)
+	require.Equal(t, lock.Validators[1].PubKey, updated[0].GetPublicKey())
+	require.Equal(t, feeRecipient, updated[0].GetFeeRecipientAddress())
+
+	// Not updated again.
+	require.NoError(t, reload(ctx, epoch))
+	require.Len(t, updated, 1)
+
+	// Other clusters are rejected.
+	other, _, _ := cluster.NewForT(t, 1, 3, 4, seed+1, random)
+	dag, err = manifest.NewDAGFromLockForT(t, other)
+	require.NoError(t, err)
+	writeDAG()
+
+	require.ErrorContains(t, reload(ctx, epoch), "reloaded cluster manifest hash mismatch")
+}
+
+func TestStorePregenRegistrations(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	recaster, err := bcast.NewRecaster(func(context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	overridesTime := time.Unix(1700000000, 0)
+
+	var (
+		pubkeys    []core.PubKey
+		validators []*manifestpb.Validator
+		regs       []*eth2api.VersionedSignedValidatorRegistration
+	)
+	for range 4 {
+		reg := testutil.RandomVersionedSignedValidatorRegistration(t)
+		reg.V1.Message.Timestamp = overridesTime.Add(time.Hour)
+
+		b, err := json.Marshal(reg)
+		require.NoError(t, err)
+
+		pubkey := core.PubKeyFrom48Bytes(reg.V1.Message.Pubkey)
+		pubkeys = append(pubkeys, pubkey)
+		regs = append(regs, reg)
+		validators = append(validators, &manifestpb.Validator{
+			PublicKey:               reg.V1.Message.Pubkey[:],
+			BuilderRegistrationJson: b,
+		})
+	}
+
+	// The first registration is older than its overrides.
+	regs[0].V1.Message.Timestamp = overridesTime.Add(-time.Hour)
+	validators[0].BuilderRegistrationJson, err = json.Marshal(regs[0])
+	require.NoError(t, err)
+
+	feeRecipient := func(i int) *string {
+		resp := fmt.Sprintf("%#x", regs[i].V1.Message.FeeRecipient)
+		return &resp
+	}
+	otherFeeRecipient := "0x000000000000000000000000000000000000dEaD"
+
+	// The last validator isn't overridden.
+	file := overrides.File{
+		Timestamp: overridesTime.Unix(),
+		Validators: map[core.PubKey]overrides.Override{
+			pubkeys[0]: {FeeRecipient: feeRecipient(0)},
+			pubkeys[1]: {FeeRecipient: feeRecipient(1)},    // Newer matching registration
+			pubkeys[2]: {FeeRecipient: &otherFeeRecipient}, // Newer mismatching registration
+		},
+	}
+	b, err := json.Marshal(file)
+	require.NoError(t, err)
+
+	filename := path.Join(t.TempDir(), "overrides.json")
+	require.NoError(t, os.WriteFile(filename, b, 0o644))
+
+	proposerOverrides, err := overrides.New(filename, pubkeys, time.Time{})
+	require.NoError(t, err)
+
+	require.NoError(t, storePregenRegistrations(ctx, bmock, recaster, proposerOverrides, validators))
+
+	// Stored registrations are deleted if their fee recipient mismatches.
+	for i, stored := range []bool{false, true, false, true} {
+		require.Equal(t, stored, recaster.DeleteFeeRecipientMismatch(pubkeys[i], otherFeeRecipient), i)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package doppelganger provides doppelganger protection which delays partial signature submissions
+// after startup until no other instance of the cluster's validators or of the cluster's peers is detected.
+//
+// Since all peers of a distributed validator are live by design, validator liveness alone doesn't indicate
+// a doppelganger. Instead, validators are considered duplicated if the beacon node reports them live
+// in an epoch in which this cluster didn't broadcast any aggregated signatures for them.
+package doppelganger
+
+import (
+	"context"
+	"sync"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// New returns a new doppelganger checker that checks the validators for the provided number of epochs
+// after startup, during which it also checks for duplicate cluster peers returned by duplicatePeers.
+func New(eth2Cl eth2wrap.Client, pubkeys []core.PubKey, epochs uint64, duplicatePeers func() []peer.ID) *Doppelganger {
+	checkPassedGauge.Set(0)
+	detectedGauge.Set(0)
+
+	pubkeySet := make(map[core.PubKey]bool)
+	for _, pubkey := range pubkeys {
+		pubkeySet[pubkey] = true
+	}
+
+	return &Doppelganger{
+		eth2Cl:         eth2Cl,
+		pubkeys:        pubkeySet,
+		epochs:         epochs,
+		duplicatePeers: duplicatePeers,
+		broadcasted:    make(map[uint64]map[core.PubKey]bool),
+	}
+}
+
+// Doppelganger checks for duplicate instances of the cluster's validators and peers.
+type Doppelganger struct {
+	eth2Cl         eth2wrap.Client
+	pubkeys        map[core.PubKey]bool
+	epochs         uint64
+	duplicatePeers func() []peer.ID
+
+	mu            sync.Mutex
+	slotsPerEpoch uint64
+	started       bool
+	startEpoch    uint64
+	nextEpoch     uint64 // Next epoch to check.
+	passed        bool
+	detected      error
+	broadcasted   map[uint64]map[core.PubKey]bool // Validators with aggregated signatures by epoch.
+}
+
+// Check returns nil if the doppelganger check passed. It returns an error while the check is still in progress
+// or if a doppelganger was detected.
+func (d *Doppelganger) Check() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.detected != nil {
+		return d.detected
+	} else if !d.passed {
+		return errors.New("doppelganger check in progress, refusing partial signatures",
+			z.U64("epochs", d.epochs))
+	}
+
+	return nil
+}
+
+// Aggregated records the validators that this cluster aggregated signatures for. It should be subscribed to sigagg.
+func (d *Doppelganger) Aggregated(_ context.Context, duty core.Duty, set core.SignedDataSet) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.slotsPerEpoch == 0 {
+		return nil // Not started yet.
+	}
+
+	epoch := duty.Slot / d.slotsPerEpoch
+	if d.broadcasted[epoch] == nil {
+		d.broadcasted[epoch] = make(map[core.PubKey]bool)
+	}
+
+	for pubkey := range set {
+		d.broadcasted[epoch][pubkey] = true
+	}
+
+	return nil
+}
+
+// SlotTicked checks for duplicate peers every slot and checks validator liveness of each completed epoch
+// until the configured number of epochs passed. Duplicate instances of this node are refused
+// separately via peerinfo. It should be subscribed to the scheduler.
+func (d *Doppelganger) SlotTicked(ctx context.Context, slot core.Slot) error {
+	ctx = log.WithTopic(ctx, "doppelganger")
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.detected != nil || d.passed {
+		return nil
+	}
+
+	if peers := d.duplicatePeers(); len(peers) > 0 {
+		var names []string
+		for _, p := range peers {
+			names = append(names, p2p.PeerName(p))
+		}
+		d.detect(ctx, errors.New("doppelganger detected, duplicate cluster peers", z.Any("peers", names)))
+
+		return nil
+	}
+
+	if !d.started {
+		// The start epoch isn't checked since this node may have missed duties in it.
+		d.started = true
+		d.slotsPerEpoch = slot.SlotsPerEpoch
+		d.startEpoch = slot.Epoch()
+		d.nextEpoch = slot.Epoch() + 1
+		log.Info(ctx, "Doppelganger protection enabled, refusing partial signatures until check passes",
+			z.U64("epochs", d.epochs))
+
+		return nil
+	}
+
+	// Only check completed epochs, retrying failed checks on subsequent slots.
+	if slot.Epoch() <= d.nextEpoch {
+		return nil
+	}
+
+	if err := d.checkEpoch(ctx, d.nextEpoch); err != nil {
+		log.Warn(ctx, "Doppelganger liveness check failed, retrying", err, z.U64("epoch", d.nextEpoch))
+		return nil
+	} else if d.detected != nil {
+		return nil
+	}
+
+	delete(d.broadcasted, d.nextEpoch)
+	d.nextEpoch++
+
+	if d.nextEpoch-d.startEpoch > d.epochs {
+		d.passed = true
+		d.broadcasted = nil
+		checkPassedGauge.Set(1)
+		log.Info(ctx, "Doppelganger check passed, submitting partial signatures", z.U64("epochs", d.epochs))
+	}
+
+	return nil
+}
+
+// checkEpoch checks the liveness of the cluster's validators in the provided epoch.
+func (d *Doppelganger) checkEpoch(ctx context.Context, epoch uint64) error {
+	vals, err := d.eth2Cl.ActiveValidators(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get active validators")
+	} else if len(vals) == 0 {
+		return nil
+	}
+
+	pubkeysByIdx := make(map[eth2p0.ValidatorIndex]core.PubKey)
+	var indices []eth2p0.ValidatorIndex
+	for idx, pubkey := range vals {
+		corePubkey := core.PubKeyFrom48Bytes(pubkey)
+		if !d.pubkeys[corePubkey] {
+			continue
+		}
+
+		pubkeysByIdx[idx] = corePubkey
+		indices = append(indices, idx)
+	}
+
+	liveness, err := d.eth2Cl.ValidatorLiveness(ctx, eth2p0.Epoch(epoch), indices)
+	if err != nil {
+		return errors.Wrap(err, "get validator liveness")
+	}
+
+	var duplicates []string
+	for idx, live := range liveness {
+		pubkey, ok := pubkeysByIdx[idx]
+		if !live || !ok || d.broadcasted[epoch][pubkey] {
+			continue
+		}
+
+		duplicates = append(duplicates, string(pubkey))
+	}
+
+	if len(duplicates) > 0 {
+		d.detect(ctx, errors.New("doppelganger detected, validators live without cluster signatures",
+			z.U64("epoch", epoch), z.Any("pubkeys", duplicates)))
+	}
+
+	return nil
+}
+
+// detect marks the doppelganger as detected, partial signatures are refused until restart.
+func (d *Doppelganger) detect(ctx context.Context, err error) {
+	d.detected = err
+	d.passed = false
+	checkPassedGauge.Set(0)
+	detectedGauge.Set(1)
+	log.Error(ctx, "Refusing partial signatures until restarted, ensure no other instance of this node or its validators is running", err)
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package doppelganger_test
+
+import (
+	"context"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/doppelganger"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+const slotsPerEpoch = 16
+
+func TestDoppelganger(t *testing.T) {
+	ctx := context.Background()
+	set := beaconmock.ValidatorSetA
+	pubkeys, err := set.CorePubKeys()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		liveEpoch      eth2p0.Epoch
+		aggregated     bool
+		duplicatePeers []peer.ID
+		detected       bool
+	}{
+		{
+			name:       "live with cluster signatures",
+			liveEpoch:  2,
+			aggregated: true,
+		},
+		{
+			name:      "live without cluster signatures",
+			liveEpoch: 2,
+			detected:  true,
+		},
+		{
+			name:           "duplicate peers",
+			duplicatePeers: []peer.ID{testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()},
+			detected:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bmock, err := beaconmock.New(beaconmock.WithValidatorSet(set))
+			require.NoError(t, err)
+
+			bmock.ValidatorLivenessFunc = func(_ context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]bool, error) {
+				require.Len(t, indices, len(set))
+
+				resp := make(map[eth2p0.ValidatorIndex]bool)
+				for _, idx := range indices {
+					resp[idx] = epoch == test.liveEpoch && idx == 1
+				}
+
+				return resp, nil
+			}
+
+			d := doppelganger.New(bmock, pubkeys, 2, func() []peer.ID { return test.duplicatePeers })
+
+			tick := func(slot uint64) {
+				require.NoError(t, d.SlotTicked(ctx, core.Slot{Slot: slot, SlotsPerEpoch: slotsPerEpoch}))
+			}
+
+			// Start epoch isn't checked.
+			tick(1)
+			if test.detected && len(test.duplicatePeers) > 0 {
+				require.ErrorContains(t, d.Check(), "duplicate cluster peers")
+				return
+			}
+			require.ErrorContains(t, d.Check(), "doppelganger check in progress")
+
+			if test.aggregated {
+				duty := core.NewAttesterDuty(uint64(test.liveEpoch) * slotsPerEpoch)
+				pubkey := core.PubKeyFrom48Bytes(set[1].Validator.PublicKey)
+				require.NoError(t, d.Aggregated(ctx, duty, core.SignedDataSet{pubkey: nil}))
+			}
+
+			tick(2 * slotsPerEpoch) // Checks epoch 1.
+			require.ErrorContains(t, d.Check(), "doppelganger check in progress")
+
+			tick(3 * slotsPerEpoch) // Checks epoch 2.
+			if test.detected {
+				require.ErrorContains(t, d.Check(), "validators live without cluster signatures")
+				return
+			}
+			require.NoError(t, d.Check())
+		})
+	}
+}
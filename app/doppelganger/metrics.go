@@ -0,0 +1,25 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package doppelganger
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	checkPassedGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "doppelganger",
+		Name:      "check_passed",
+		Help:      "Set to 1 if the doppelganger check passed and partial signatures are submitted, else 0.",
+	})
+
+	detectedGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "doppelganger",
+		Name:      "detected",
+		Help:      "Set to 1 if a doppelganger of a validator or cluster peer was detected, else 0.",
+	})
+)
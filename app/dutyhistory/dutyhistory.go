@@ -0,0 +1,262 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package dutyhistory provides a bounded on-disk history of the duties analysed by the tracker,
+// explaining after the fact which step duties reached, why they failed and whether they were included on-chain.
+package dutyhistory
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/tracker"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	// currentFile is the segment records are appended to.
+	currentFile = "duties.jsonl"
+	// previousFile is the segment of the oldest records, replaced when the current segment is full.
+	previousFile = "duties.prev.jsonl"
+)
+
+// Inclusion results of a duty's validator.
+const (
+	InclusionIncluded    = "included"
+	InclusionNotIncluded = "not_included"
+)
+
+// Record is a duty analysed by the tracker.
+type Record struct {
+	Slot            uint64         `json:"slot"`
+	DutyType        string         `json:"duty_type"`
+	AnalysedAt      time.Time      `json:"analysed_at"`
+	Failed          bool           `json:"failed"`
+	Step            string         `json:"step"`
+	ReasonCode      string         `json:"reason_code,omitempty"`
+	Reason          string         `json:"reason,omitempty"`
+	Error           string         `json:"error,omitempty"`
+	Participation   map[string]int `json:"participation"`
+	ExpectedPerPeer int            `json:"expected_per_peer"`
+	Validators      []Validator    `json:"validators"`
+}
+
+// Validator is a validator of a duty and its on-chain inclusion result.
+type Validator struct {
+	PubKey string `json:"pubkey"`
+	// Inclusion is InclusionIncluded, InclusionNotIncluded or empty if inclusion wasn't checked.
+	Inclusion      string `json:"inclusion,omitempty"`
+	InclusionError string `json:"inclusion_error,omitempty"`
+}
+
+// New returns a new store persisting the history of analysed duties to the directory,
+// which is created if it doesn't exist. The history is bounded to approximately maxSize bytes
+// by discarding the oldest half of the records when full.
+func New(dir string, maxSize int64, peers []p2p.Peer) (*Store, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("invalid duty history max size", z.I64("max_size", maxSize))
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create duty history dir")
+	}
+
+	var size int64
+	info, err := os.Stat(filepath.Join(dir, currentFile))
+	if err == nil {
+		size = info.Size()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Wrap(err, "stat duty history file")
+	}
+
+	return &Store{
+		dir:     dir,
+		maxSize: maxSize,
+		peers:   peers,
+		size:    size,
+		nowFunc: time.Now,
+	}, nil
+}
+
+// Store persists analysed duties to a ring of two append-only segment files.
+type Store struct {
+	dir     string
+	maxSize int64
+	peers   []p2p.Peer
+	nowFunc func() time.Time
+
+	mu   sync.Mutex
+	size int64
+}
+
+// Add is a tracker subscriber persisting the analysed duty outcome.
+func (s *Store) Add(ctx context.Context, outcome tracker.Outcome) {
+	if err := s.add(NewRecord(outcome, s.peers, s.nowFunc())); err != nil {
+		log.Warn(ctx, "Failed persisting duty history", err, z.Any("duty", outcome.Duty))
+	}
+}
+
+// add appends the record to the current segment, which replaces the previous segment when full.
+func (s *Store) add(record Record) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "marshal duty history record")
+	}
+	b = append(b, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size > 0 && s.size+int64(len(b)) > s.maxSize/2 {
+		err := os.Rename(filepath.Join(s.dir, currentFile), filepath.Join(s.dir, previousFile))
+		if err != nil {
+			return errors.Wrap(err, "rotate duty history file")
+		}
+		s.size = 0
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, currentFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "open duty history file")
+	}
+	defer f.Close()
+
+	n, err := f.Write(b)
+	s.size += int64(n)
+	if err != nil {
+		return errors.Wrap(err, "write duty history file")
+	}
+
+	return nil
+}
+
+// NewRecord returns the record of the outcome with participation by peer name.
+func NewRecord(outcome tracker.Outcome, peers []p2p.Peer, now time.Time) Record {
+	record := Record{
+		Slot:            outcome.Duty.Slot,
+		DutyType:        outcome.Duty.Type.String(),
+		AnalysedAt:      now,
+		Failed:          outcome.Failed,
+		Step:            outcome.Step,
+		ReasonCode:      outcome.ReasonCode,
+		Reason:          outcome.Reason,
+		Error:           outcome.Error,
+		Participation:   make(map[string]int),
+		ExpectedPerPeer: outcome.ExpectedPerPeer,
+	}
+
+	for _, p := range peers {
+		record.Participation[p.Name] = outcome.Participation[p.ShareIdx()]
+	}
+
+	for _, pubkey := range outcome.PubKeys {
+		val := Validator{PubKey: string(pubkey)}
+		if err, ok := outcome.Inclusions[pubkey]; ok && err == nil {
+			val.Inclusion = InclusionIncluded
+		} else if ok {
+			val.Inclusion = InclusionNotIncluded
+			val.InclusionError = err.Error()
+		}
+
+		record.Validators = append(record.Validators, val)
+	}
+
+	return record
+}
+
+// Filter selects the queried records.
+type Filter struct {
+	// Slots restricts records to the slots, if not empty.
+	Slots []uint64
+	// PubKeys restricts records to duties of the validators, if not empty.
+	// Only the matching validators of the records are returned.
+	PubKeys []core.PubKey
+}
+
+// match returns the record with only the filtered validators and true if the record matches the filter.
+func (f Filter) match(record Record) (Record, bool) {
+	if len(f.Slots) > 0 && !slices.Contains(f.Slots, record.Slot) {
+		return Record{}, false
+	}
+
+	if len(f.PubKeys) == 0 {
+		return record, true
+	}
+
+	var vals []Validator
+	for _, val := range record.Validators {
+		for _, pubkey := range f.PubKeys {
+			if strings.EqualFold(val.PubKey, string(pubkey)) {
+				vals = append(vals, val)
+				break
+			}
+		}
+	}
+	record.Validators = vals
+
+	return record, len(vals) > 0
+}
+
+// Query returns the records persisted in the directory matching the filter, ordered from oldest to newest.
+// It may be called while a store is writing to the directory.
+func Query(dir string, filter Filter) ([]Record, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.Wrap(err, "duty history dir not found", z.Str("dir", dir))
+	}
+
+	var resp []Record
+	for _, file := range []string{previousFile, currentFile} {
+		records, err := readFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			if record, ok := filter.match(record); ok {
+				resp = append(resp, record)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// readFile returns the records of the segment file or nil if it doesn't exist.
+// Lines that cannot be decoded, like partially written records, are skipped.
+func readFile(filename string) ([]Record, error) {
+	f, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "open duty history file")
+	}
+	defer f.Close()
+
+	var resp []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64<<20) // Records of duties with many validators exceed the default max token size.
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+
+		resp = append(resp, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read duty history file")
+	}
+
+	return resp, nil
+}
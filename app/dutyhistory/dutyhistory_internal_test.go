@@ -0,0 +1,129 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutyhistory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/tracker"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	peers := []p2p.Peer{{Name: "alice", Index: 0}, {Name: "bob", Index: 1}}
+	pk1, pk2 := testutil.RandomCorePubKey(t), testutil.RandomCorePubKey(t)
+
+	store, err := New(dir, 1<<20, peers)
+	require.NoError(t, err)
+	store.nowFunc = func() time.Time { return time.Unix(1700000000, 0).UTC() }
+
+	store.Add(ctx, tracker.Outcome{
+		Duty:            core.NewAttesterDuty(1),
+		Step:            "chain_inclusion",
+		Participation:   map[int]int{1: 1, 2: 1},
+		ExpectedPerPeer: 1,
+		PubKeys:         []core.PubKey{pk1, pk2},
+		Inclusions:      map[core.PubKey]error{pk1: nil, pk2: errors.New("duty not included on-chain")},
+	})
+	store.Add(ctx, tracker.Outcome{
+		Duty:          core.NewProposerDuty(2),
+		Failed:        true,
+		Step:          "consensus",
+		ReasonCode:    "no_consensus",
+		Reason:        "consensus algorithm didn't complete",
+		Error:         "consensus timeout",
+		Participation: map[int]int{},
+		PubKeys:       []core.PubKey{pk2},
+	})
+
+	// Partially written records are skipped.
+	f, err := os.OpenFile(filepath.Join(dir, currentFile), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"slot":3,"duty_`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	records, err := Query(dir, Filter{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, Record{
+		Slot:            1,
+		DutyType:        "attester",
+		AnalysedAt:      time.Unix(1700000000, 0).UTC(),
+		Step:            "chain_inclusion",
+		Participation:   map[string]int{"alice": 1, "bob": 1},
+		ExpectedPerPeer: 1,
+		Validators: []Validator{
+			{PubKey: string(pk1), Inclusion: InclusionIncluded},
+			{PubKey: string(pk2), Inclusion: InclusionNotIncluded, InclusionError: "duty not included on-chain"},
+		},
+	}, records[0])
+	require.True(t, records[1].Failed)
+	require.Equal(t, "no_consensus", records[1].ReasonCode)
+	require.Equal(t, map[string]int{"alice": 0, "bob": 0}, records[1].Participation)
+
+	records, err = Query(dir, Filter{Slots: []uint64{2}})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.EqualValues(t, 2, records[0].Slot)
+
+	records, err = Query(dir, Filter{PubKeys: []core.PubKey{pk1}})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, []Validator{{PubKey: string(pk1), Inclusion: InclusionIncluded}}, records[0].Validators)
+
+	records, err = Query(dir, Filter{Slots: []uint64{2}, PubKeys: []core.PubKey{pk1}})
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	_, err = Query(filepath.Join(dir, "missing"), Filter{})
+	require.ErrorContains(t, err, "duty history dir not found")
+}
+
+func TestStoreBounded(t *testing.T) {
+	dir := t.TempDir()
+
+	const maxSize = 2000
+
+	store, err := New(dir, maxSize, nil)
+	require.NoError(t, err)
+
+	for slot := range uint64(100) {
+		store.Add(context.Background(), tracker.Outcome{Duty: core.NewAttesterDuty(slot)})
+	}
+
+	for _, file := range []string{currentFile, previousFile} {
+		info, err := os.Stat(filepath.Join(dir, file))
+		require.NoError(t, err)
+		require.LessOrEqual(t, info.Size(), int64(maxSize/2))
+	}
+
+	records, err := Query(dir, Filter{})
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	require.Less(t, len(records), 100)
+	require.EqualValues(t, 99, records[len(records)-1].Slot)
+
+	for i := 1; i < len(records); i++ {
+		require.Equal(t, records[i-1].Slot+1, records[i].Slot)
+	}
+
+	// Reopening the store continues the current segment.
+	store, err = New(dir, maxSize, nil)
+	require.NoError(t, err)
+	info, err := os.Stat(filepath.Join(dir, currentFile))
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), store.size)
+}
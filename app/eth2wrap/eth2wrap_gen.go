@@ -25,6 +25,7 @@ type Client interface {
 	eth2exp.ProposerConfigProvider
 	BlockAttestationsProvider
 	NodePeerCountProvider
+	ValidatorLivenessProvider
 
 	CachedValidatorsProvider
 	SetValidatorCache(func(context.Context) (ActiveValidators, CompleteValidators, error))
@@ -38,6 +39,7 @@ type Client interface {
 	eth2client.AttesterDutiesProvider
 	eth2client.BeaconBlockRootProvider
 	eth2client.BeaconCommitteeSubscriptionsSubmitter
+	eth2client.BeaconCommitteesProvider
 	eth2client.BlindedProposalSubmitter
 	eth2client.DepositContractProvider
 	eth2client.DomainProvider
@@ -129,13 +131,33 @@ func (m multi) SignedBeaconBlock(ctx context.Context, opts *api.SignedBeaconBloc
 	return res0, err
 }
 
+// BeaconCommittees fetches all beacon committees for the given options.
+func (m multi) BeaconCommittees(ctx context.Context, opts *api.BeaconCommitteesOpts) (*api.Response[[]*apiv1.BeaconCommittee], error) {
+	const label = "beacon_committees"
+	defer latency(label)()
+
+	res0, err := provide(ctx, m.clients,
+		func(ctx context.Context, cl Client) (*api.Response[[]*apiv1.BeaconCommittee], error) {
+			return cl.BeaconCommittees(ctx, opts)
+		},
+		nil, m.selector,
+	)
+
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res0, err
+}
+
 // AggregateAttestation fetches the aggregate attestation for the given options.
-func (m multi) AggregateAttestation(ctx context.Context, opts *api.AggregateAttestationOpts) (*api.Response[*phase0.Attestation], error) {
+func (m multi) AggregateAttestation(ctx context.Context, opts *api.AggregateAttestationOpts) (*api.Response[*spec.VersionedAttestation], error) {
 	const label = "aggregate_attestation"
 	defer latency(label)()
 
 	res0, err := provide(ctx, m.clients,
-		func(ctx context.Context, cl Client) (*api.Response[*phase0.Attestation], error) {
+		func(ctx context.Context, cl Client) (*api.Response[*spec.VersionedAttestation], error) {
 			return cl.AggregateAttestation(ctx, opts)
 		},
 		isAggregateAttestationOk, m.selector,
@@ -150,13 +172,13 @@ func (m multi) AggregateAttestation(ctx context.Context, opts *api.AggregateAtte
 }
 
 // SubmitAggregateAttestations submits aggregate attestations.
-func (m multi) SubmitAggregateAttestations(ctx context.Context, aggregateAndProofs []*phase0.SignedAggregateAndProof) error {
+func (m multi) SubmitAggregateAttestations(ctx context.Context, opts *api.SubmitAggregateAttestationsOpts) error {
 	const label = "submit_aggregate_attestations"
 	defer latency(label)()
 
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
-			return cl.SubmitAggregateAttestations(ctx, aggregateAndProofs)
+			return cl.SubmitAggregateAttestations(ctx, opts)
 		},
 		m.selector,
 	)
@@ -190,13 +212,13 @@ func (m multi) AttestationData(ctx context.Context, opts *api.AttestationDataOpt
 }
 
 // SubmitAttestations submits attestations.
-func (m multi) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+func (m multi) SubmitAttestations(ctx context.Context, opts *api.SubmitAttestationsOpts) error {
 	const label = "submit_attestations"
 	defer latency(label)()
 
 	err := submit(ctx, m.clients,
 		func(ctx context.Context, cl Client) error {
-			return cl.SubmitAttestations(ctx, attestations)
+			return cl.SubmitAttestations(ctx, opts)
 		},
 		m.selector,
 	)
@@ -250,7 +272,7 @@ func (m multi) DepositContract(ctx context.Context, opts *api.DepositContractOpt
 }
 
 // SyncCommitteeDuties obtains sync committee duties.
-// If validatorIndicess is nil it will return all duties for the given epoch.
+// If validatorIndices is nil it will return all duties for the given epoch.
 func (m multi) SyncCommitteeDuties(ctx context.Context, opts *api.SyncCommitteeDutiesOpts) (*api.Response[[]*apiv1.SyncCommitteeDuty], error) {
 	const label = "sync_committee_duties"
 	defer latency(label)()
@@ -768,8 +790,18 @@ func (l *lazy) SignedBeaconBlock(ctx context.Context, opts *api.SignedBeaconBloc
 	return cl.SignedBeaconBlock(ctx, opts)
 }
 
+// BeaconCommittees fetches all beacon committees for the given options.
+func (l *lazy) BeaconCommittees(ctx context.Context, opts *api.BeaconCommitteesOpts) (res0 *api.Response[[]*apiv1.BeaconCommittee], err error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return res0, err
+	}
+
+	return cl.BeaconCommittees(ctx, opts)
+}
+
 // AggregateAttestation fetches the aggregate attestation for the given options.
-func (l *lazy) AggregateAttestation(ctx context.Context, opts *api.AggregateAttestationOpts) (res0 *api.Response[*phase0.Attestation], err error) {
+func (l *lazy) AggregateAttestation(ctx context.Context, opts *api.AggregateAttestationOpts) (res0 *api.Response[*spec.VersionedAttestation], err error) {
 	cl, err := l.getOrCreateClient(ctx)
 	if err != nil {
 		return res0, err
@@ -779,13 +811,13 @@ func (l *lazy) AggregateAttestation(ctx context.Context, opts *api.AggregateAtte
 }
 
 // SubmitAggregateAttestations submits aggregate attestations.
-func (l *lazy) SubmitAggregateAttestations(ctx context.Context, aggregateAndProofs []*phase0.SignedAggregateAndProof) (err error) {
+func (l *lazy) SubmitAggregateAttestations(ctx context.Context, opts *api.SubmitAggregateAttestationsOpts) (err error) {
 	cl, err := l.getOrCreateClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	return cl.SubmitAggregateAttestations(ctx, aggregateAndProofs)
+	return cl.SubmitAggregateAttestations(ctx, opts)
 }
 
 // AttestationData fetches the attestation data for the given options.
@@ -799,13 +831,13 @@ func (l *lazy) AttestationData(ctx context.Context, opts *api.AttestationDataOpt
 }
 
 // SubmitAttestations submits attestations.
-func (l *lazy) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) (err error) {
+func (l *lazy) SubmitAttestations(ctx context.Context, opts *api.SubmitAttestationsOpts) (err error) {
 	cl, err := l.getOrCreateClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	return cl.SubmitAttestations(ctx, attestations)
+	return cl.SubmitAttestations(ctx, opts)
 }
 
 // AttesterDuties obtains attester duties.
@@ -829,7 +861,7 @@ func (l *lazy) DepositContract(ctx context.Context, opts *api.DepositContractOpt
 }
 
 // SyncCommitteeDuties obtains sync committee duties.
-// If validatorIndicess is nil it will return all duties for the given epoch.
+// If validatorIndices is nil it will return all duties for the given epoch.
 func (l *lazy) SyncCommitteeDuties(ctx context.Context, opts *api.SyncCommitteeDutiesOpts) (res0 *api.Response[[]*apiv1.SyncCommitteeDuty], err error) {
 	cl, err := l.getOrCreateClient(ctx)
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -262,20 +263,24 @@ func TestCtxCancel(t *testing.T) {
 }
 
 func TestBlockAttestations(t *testing.T) {
-	atts := []*eth2p0.Attestation{
+	denebAtts := []*eth2p0.Attestation{
 		testutil.RandomAttestation(),
 		testutil.RandomAttestation(),
 	}
+	electraAtts := []*electra.Attestation{
+		testutil.RandomElectraAttestation(),
+		testutil.RandomElectraAttestation(),
+	}
 
+	var resp struct {
+		Version string `json:"version"`
+		Data    any    `json:"data"`
+	}
 	statusCode := http.StatusOK
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		require.Equal(t, http.MethodGet, r.Method)
-		require.Equal(t, "/eth/v1/beacon/blocks/head/attestations", r.URL.Path)
-		b, err := json.Marshal(struct {
-			Data []*eth2p0.Attestation
-		}{
-			Data: atts,
-		})
+		require.Equal(t, "/eth/v2/beacon/blocks/head/attestations", r.URL.Path)
+		b, err := json.Marshal(resp)
 		require.NoError(t, err)
 
 		w.WriteHeader(statusCode)
@@ -283,14 +288,48 @@ func TestBlockAttestations(t *testing.T) {
 	}))
 
 	cl := eth2wrap.NewHTTPAdapterForT(t, srv.URL, time.Hour)
-	resp, err := cl.BlockAttestations(context.Background(), "head")
+
+	resp.Version, resp.Data = eth2spec.DataVersionDeneb.String(), denebAtts
+	atts, err := cl.BlockAttestations(context.Background(), "head")
 	require.NoError(t, err)
-	require.Equal(t, atts, resp)
+	require.Len(t, atts, len(denebAtts))
+	for i, att := range atts {
+		require.Equal(t, eth2spec.DataVersionDeneb, att.Version)
+		require.Equal(t, denebAtts[i], att.Deneb)
+	}
+
+	resp.Version, resp.Data = eth2spec.DataVersionElectra.String(), electraAtts
+	atts, err = cl.BlockAttestations(context.Background(), "head")
+	require.NoError(t, err)
+	require.Len(t, atts, len(electraAtts))
+	for i, att := range atts {
+		require.Equal(t, eth2spec.DataVersionElectra, att.Version)
+		require.Equal(t, electraAtts[i], att.Electra)
+	}
 
 	statusCode = http.StatusNotFound
-	resp, err = cl.BlockAttestations(context.Background(), "head")
+	atts, err = cl.BlockAttestations(context.Background(), "head")
+	require.NoError(t, err)
+	require.Empty(t, atts)
+}
+
+func TestValidatorLiveness(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/eth/v1/validator/liveness/3", r.URL.Path)
+
+		var indices []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&indices))
+		require.Equal(t, []string{"1", "2"}, indices)
+
+		_, _ = w.Write([]byte(`{"data":[{"index":"1","is_live":true},{"index":"2","is_live":false}]}`))
+	}))
+	defer srv.Close()
+
+	cl := eth2wrap.NewHTTPAdapterForT(t, srv.URL, time.Hour)
+	resp, err := cl.ValidatorLiveness(context.Background(), 3, []eth2p0.ValidatorIndex{1, 2})
 	require.NoError(t, err)
-	require.Empty(t, resp)
+	require.Equal(t, map[eth2p0.ValidatorIndex]bool{1: true, 2: false}, resp)
 }
 
 // TestOneError tests the case where one of the servers returns errors.
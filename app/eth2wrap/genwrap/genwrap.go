@@ -47,6 +47,7 @@ type Client interface {
     eth2exp.ProposerConfigProvider
     BlockAttestationsProvider
     NodePeerCountProvider
+    ValidatorLivenessProvider
 
     CachedValidatorsProvider
     SetValidatorCache(func(context.Context) (ActiveValidators, CompleteValidators, error))
@@ -104,6 +105,7 @@ type Client interface {
 		"AttesterDutiesProvider":                true,
 		"ProposalProvider":                      true,
 		"BeaconBlockRootProvider":               false,
+		"BeaconCommitteesProvider":              true,
 		"ProposalSubmitter":                     true,
 		"BeaconCommitteeSubscriptionsSubmitter": true,
 		"BlindedProposalProvider":               true,
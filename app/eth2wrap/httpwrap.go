@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -18,6 +19,8 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2http "github.com/attestantio/go-eth2-client/http"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -28,9 +31,9 @@ import (
 
 // BlockAttestationsProvider is the interface for providing attestations included in blocks.
 // It is a standard beacon API endpoint not implemented by eth2client.
-// See https://ethereum.github.io/beacon-APIs/#/Beacon/getBlockAttestations.
+// See https://ethereum.github.io/beacon-APIs/#/Beacon/getBlockAttestationsV2.
 type BlockAttestationsProvider interface {
-	BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0.Attestation, error)
+	BlockAttestations(ctx context.Context, stateID string) ([]*eth2spec.VersionedAttestation, error)
 }
 
 // NodePeerCountProvider is the interface for providing node peer count.
@@ -41,6 +44,14 @@ type NodePeerCountProvider interface {
 	NodePeerCount(ctx context.Context) (int, error)
 }
 
+// ValidatorLivenessProvider is the interface for providing validator liveness.
+// It is a standard beacon API endpoint not implemented by eth2client.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/getLiveness.
+type ValidatorLivenessProvider interface {
+	// ValidatorLiveness returns whether each validator was observed to be live by the beacon node in the epoch.
+	ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]bool, error)
+}
+
 // NewHTTPAdapterForT returns a http adapter for testing non-eth2service methods as it is nil.
 func NewHTTPAdapterForT(_ *testing.T, address string, timeout time.Duration) Client {
 	return newHTTPAdapter(nil, address, timeout)
@@ -175,10 +186,10 @@ func (h *httpAdapter) AggregateSyncCommitteeSelections(ctx context.Context, sele
 	return resp.Data, nil
 }
 
-// BlockAttestations returns the attestations included in the requested block.
-// See https://ethereum.github.io/beacon-APIs/#/Beacon/getBlockAttestations.
-func (h *httpAdapter) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0.Attestation, error) {
-	path := fmt.Sprintf("/eth/v1/beacon/blocks/%s/attestations", stateID)
+// BlockAttestations returns the versioned attestations included in the requested block.
+// See https://ethereum.github.io/beacon-APIs/#/Beacon/getBlockAttestationsV2.
+func (h *httpAdapter) BlockAttestations(ctx context.Context, stateID string) ([]*eth2spec.VersionedAttestation, error) {
+	path := fmt.Sprintf("/eth/v2/beacon/blocks/%s/attestations", stateID)
 	respBody, statusCode, err := httpGet(ctx, h.address, path, h.timeout)
 	if err != nil {
 		return nil, errors.Wrap(err, "request block attestations")
@@ -193,7 +204,30 @@ func (h *httpAdapter) BlockAttestations(ctx context.Context, stateID string) ([]
 		return nil, errors.Wrap(err, "failed to parse block attestations response")
 	}
 
-	return resp.Data, nil
+	atts := make([]*eth2spec.VersionedAttestation, 0, len(resp.Data))
+	for _, data := range resp.Data {
+		var att *eth2spec.VersionedAttestation
+		if resp.Version == eth2spec.DataVersionElectra {
+			electraAtt := new(electra.Attestation)
+			if err := json.Unmarshal(data, electraAtt); err != nil {
+				return nil, errors.Wrap(err, "failed to parse electra block attestation")
+			}
+			att = &eth2spec.VersionedAttestation{Version: resp.Version, Electra: electraAtt}
+		} else {
+			phase0Att := new(eth2p0.Attestation)
+			if err := json.Unmarshal(data, phase0Att); err != nil {
+				return nil, errors.Wrap(err, "failed to parse block attestation")
+			}
+			att, err = eth2util.NewPhase0VersionedAttestation(resp.Version, phase0Att)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		atts = append(atts, att)
+	}
+
+	return atts, nil
 }
 
 // ProposerConfig implements eth2exp.ProposerConfigProvider.
@@ -232,6 +266,38 @@ func (h *httpAdapter) NodePeerCount(ctx context.Context) (int, error) {
 	return resp.Data.Connected, nil
 }
 
+// ValidatorLiveness returns whether each validator was observed to be live by the beacon node in the epoch.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/getLiveness.
+func (h *httpAdapter) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]bool, error) {
+	reqIndices := make([]string, 0, len(indices))
+	for _, index := range indices {
+		reqIndices = append(reqIndices, strconv.FormatUint(uint64(index), 10))
+	}
+
+	reqBody, err := json.Marshal(reqIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal validator liveness request")
+	}
+
+	path := fmt.Sprintf("/eth/v1/validator/liveness/%d", epoch)
+	respBody, err := httpPost(ctx, h.address, path, bytes.NewReader(reqBody), h.timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "request validator liveness")
+	}
+
+	var resp validatorLivenessJSON
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse validator liveness response")
+	}
+
+	liveness := make(map[eth2p0.ValidatorIndex]bool)
+	for _, data := range resp.Data {
+		liveness[data.Index] = data.IsLive
+	}
+
+	return liveness, nil
+}
+
 // Domain returns the signing domain for a given domain type.
 // After EIP-7044, the VOLUNTARY_EXIT domain must always return a domain relative to the Capella hardfork.
 // This method returns just that for that domain type, otherwise follows the standard go-eth2-client flow.
@@ -257,7 +323,8 @@ type submitSyncCommitteeSelectionsJSON struct {
 }
 
 type attestationsJSON struct {
-	Data []*eth2p0.Attestation `json:"data"`
+	Version eth2spec.DataVersion `json:"version"`
+	Data    []json.RawMessage    `json:"data"`
 }
 
 type peerCountJSON struct {
@@ -266,6 +333,13 @@ type peerCountJSON struct {
 	} `json:"data"`
 }
 
+type validatorLivenessJSON struct {
+	Data []struct {
+		Index  eth2p0.ValidatorIndex `json:"index,string"`
+		IsLive bool                  `json:"is_live"`
+	} `json:"data"`
+}
+
 func httpPost(ctx context.Context, base string, endpoint string, body io.Reader, timeout time.Duration) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
@@ -192,7 +193,7 @@ func (l *lazy) AggregateSyncCommitteeSelections(ctx context.Context, partialSele
 	return cl.AggregateSyncCommitteeSelections(ctx, partialSelections)
 }
 
-func (l *lazy) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0.Attestation, error) {
+func (l *lazy) BlockAttestations(ctx context.Context, stateID string) ([]*eth2spec.VersionedAttestation, error) {
 	cl, err := l.getOrCreateClient(ctx)
 	if err != nil {
 		return nil, err
@@ -201,6 +202,15 @@ func (l *lazy) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0
 	return cl.BlockAttestations(ctx, stateID)
 }
 
+func (l *lazy) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]bool, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.ValidatorLiveness(ctx, epoch, indices)
+}
+
 func (l *lazy) NodePeerCount(ctx context.Context) (int, error) {
 	cl, err := l.getOrCreateClient(ctx)
 	if err != nil {
@@ -6,7 +6,7 @@ import (
 	"context"
 	"testing"
 
-	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -64,7 +64,7 @@ func TestLazy_NodePeerCount(t *testing.T) {
 
 func TestLazy_BlockAttestations(t *testing.T) {
 	ctx := context.Background()
-	atts := make([]*eth2p0.Attestation, 3)
+	atts := make([]*eth2spec.VersionedAttestation, 3)
 
 	client := mocks.NewClient(t)
 	client.On("BlockAttestations", ctx, "state").Return(atts, nil).Once()
@@ -5,6 +5,7 @@ package eth2wrap
 import (
 	"context"
 
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
@@ -169,12 +170,12 @@ func (m multi) AggregateSyncCommitteeSelections(ctx context.Context, selections
 	return res, err
 }
 
-func (m multi) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0.Attestation, error) {
+func (m multi) BlockAttestations(ctx context.Context, stateID string) ([]*eth2spec.VersionedAttestation, error) {
 	const label = "block_attestations"
 	defer latency(label)()
 
 	res, err := provide(ctx, m.clients,
-		func(ctx context.Context, cl Client) ([]*eth2p0.Attestation, error) {
+		func(ctx context.Context, cl Client) ([]*eth2spec.VersionedAttestation, error) {
 			return cl.BlockAttestations(ctx, stateID)
 		},
 		nil, m.selector,
@@ -187,6 +188,24 @@ func (m multi) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0
 	return res, err
 }
 
+func (m multi) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]bool, error) {
+	const label = "validator_liveness"
+	defer latency(label)()
+
+	res, err := provide(ctx, m.clients,
+		func(ctx context.Context, cl Client) (map[eth2p0.ValidatorIndex]bool, error) {
+			return cl.ValidatorLiveness(ctx, epoch, indices)
+		},
+		nil, m.selector,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
 func (m multi) NodePeerCount(ctx context.Context) (int, error) {
 	const label = "node_peer_count"
 	defer latency(label)()
@@ -7,7 +7,7 @@ import (
 	"errors"
 	"testing"
 
-	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -74,7 +74,7 @@ func TestMulti_NodePeerCount(t *testing.T) {
 
 func TestMulti_BlockAttestations(t *testing.T) {
 	ctx := context.Background()
-	atts := make([]*eth2p0.Attestation, 3)
+	atts := make([]*eth2spec.VersionedAttestation, 3)
 
 	client := mocks.NewClient(t)
 	client.On("Address").Return("test").Once()
@@ -45,6 +45,10 @@ const (
 	// The feature gets automatically enabled when the current network is gnosis|chiado,
 	// unless the user disabled this feature explicitly.
 	GnosisBlockHotfix Feature = "gnosis_block_hotfix"
+
+	// ProposalCandidates enables sharing block proposal candidates between peers before proposing,
+	// so the cluster converges on the most valuable block.
+	ProposalCandidates Feature = "proposal_candidates"
 )
 
 var (
@@ -56,6 +60,7 @@ var (
 		AggSigDBV2:           statusAlpha,
 		JSONRequests:         statusAlpha,
 		GnosisBlockHotfix:    statusAlpha,
+		ProposalCandidates:   statusAlpha,
 		// Add all features and there status here.
 	}
 
@@ -5,6 +5,7 @@ package health
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -48,6 +49,26 @@ type Checker struct {
 	maxScrapes    int
 	logFilter     z.Field
 	numValidators int
+
+	mu      sync.Mutex
+	results []CheckResult
+}
+
+// CheckResult is the latest result of a health check.
+type CheckResult struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Failing     bool   `json:"failing"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Results returns the latest results of all health checks, or nil if no checks have been run yet.
+func (c *Checker) Results() []CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]CheckResult(nil), c.results...)
 }
 
 // Run runs the health checker until the context is canceled.
@@ -71,14 +92,24 @@ func (c *Checker) Run(ctx context.Context) {
 	}
 }
 
-// instrument runs all health checks and updates the check gauge.
+// instrument runs all health checks and updates the check gauge and results.
 func (c *Checker) instrument(ctx context.Context) {
+	results := make([]CheckResult, 0, len(c.checks))
 	for _, check := range c.checks {
+		result := CheckResult{
+			Name:        check.Name,
+			Description: check.Description,
+			Severity:    string(check.Severity),
+		}
+
 		failing, err := check.Func(newQueryFunc(c.metrics), c.metadata)
 		if err != nil {
 			log.Warn(ctx, "Health check failed", err, z.Str("check", check.Name), c.logFilter)
+			result.Error = err.Error()
 			// Clear checks that fail
 		}
+		result.Failing = failing
+		results = append(results, result)
 
 		var val float64
 		if failing {
@@ -87,6 +118,10 @@ func (c *Checker) instrument(ctx context.Context) {
 
 		checkGauge.WithLabelValues(string(check.Severity), check.Name).Set(val)
 	}
+
+	c.mu.Lock()
+	c.results = results
+	c.mu.Unlock()
 }
 
 // scrape scrapes metrics from the gatherer.
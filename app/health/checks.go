@@ -93,6 +93,19 @@ var checks = []check{
 			return maxVal < required, nil
 		},
 	},
+	{
+		Name:        "duplicate_peer_id",
+		Description: "Multiple instances running with the same peer ID detected. Ensure only one instance of each node is running.",
+		Severity:    severityCritical,
+		Func: func(q query, _ Metadata) (bool, error) {
+			maxVal, err := q("app_peerinfo_duplicate_peer_id", countNonZeroLabels, gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return maxVal > 0, nil
+		},
+	},
 	{
 		Name:        "pending_validators",
 		Description: "Pending validators detected. Activate them to start validating.",
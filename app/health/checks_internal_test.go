@@ -187,6 +187,37 @@ func TestInsufficientPeerCheck(t *testing.T) {
 	})
 }
 
+func TestDuplicatePeerIDCheck(t *testing.T) {
+	m := Metadata{}
+	checkName := "duplicate_peer_id"
+	metricName := "app_peerinfo_duplicate_peer_id"
+
+	peer1 := genLabels("peer", "1")
+	peer2 := genLabels("peer", "2")
+
+	t.Run("no data", func(t *testing.T) {
+		testCheck(t, m, checkName, false, nil)
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		testCheck(t, m, checkName, false,
+			genFam(metricName,
+				genGauge(peer1, 0, 0, 0),
+				genGauge(peer2, 0, 0, 0),
+			),
+		)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		testCheck(t, m, checkName, true,
+			genFam(metricName,
+				genGauge(peer1, 0, 0, 0),
+				genGauge(peer2, 0, 1, 1),
+			),
+		)
+	})
+}
+
 func TestBNSyncingCheck(t *testing.T) {
 	m := Metadata{}
 	checkName := "beacon_node_syncing"
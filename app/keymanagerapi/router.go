@@ -0,0 +1,354 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package keymanagerapi provides a Keymanager API (https://ethereum.github.io/keymanager-APIs/) server
+// acting as a facade over the cluster's distributed validators.
+package keymanagerapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/overrides"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/fetcher"
+	"github.com/obolnetwork/charon/core/validatorapi"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+// errReadonly is returned for keystore and remote key mutations, since distributed validator keys shares
+// are managed by cluster DKG ceremonies.
+const errReadonly = "distributed validator keys are managed by charon cluster ceremonies"
+
+// NewRouter returns a Keymanager API router authenticated by the bearer token.
+// Keystores lists the cluster's distributed validators which cannot be imported or deleted.
+// Fee recipient, gas limit and graffiti updates are persisted as proposer overrides.
+func NewRouter(ctx context.Context, token string, pubkeys []core.PubKey, proposerOverrides *overrides.Overrides,
+	feeRecipientFunc func(core.PubKey) string,
+) http.Handler {
+	ctx = log.WithTopic(ctx, "keymanager")
+
+	s := server{
+		pubkeys:          pubkeys,
+		overrides:        proposerOverrides,
+		feeRecipientFunc: feeRecipientFunc,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/keystores", s.listKeystores)
+	mux.HandleFunc("POST /eth/v1/keystores", s.refuseMutation("keystores"))
+	mux.HandleFunc("DELETE /eth/v1/keystores", s.refuseMutation("pubkeys"))
+	mux.HandleFunc("GET /eth/v1/remotekeys", s.listRemoteKeys)
+	mux.HandleFunc("POST /eth/v1/remotekeys", s.refuseMutation("remote_keys"))
+	mux.HandleFunc("DELETE /eth/v1/remotekeys", s.refuseMutation("pubkeys"))
+	mux.HandleFunc("GET /eth/v1/validator/{pubkey}/feerecipient", s.getFeeRecipient)
+	mux.HandleFunc("POST /eth/v1/validator/{pubkey}/feerecipient", s.setFeeRecipient)
+	mux.HandleFunc("DELETE /eth/v1/validator/{pubkey}/feerecipient", s.deleteOverride(func(o *overrides.Override) {
+		o.FeeRecipient = nil
+	}))
+	mux.HandleFunc("GET /eth/v1/validator/{pubkey}/gas_limit", s.getGasLimit)
+	mux.HandleFunc("POST /eth/v1/validator/{pubkey}/gas_limit", s.setGasLimit)
+	mux.HandleFunc("DELETE /eth/v1/validator/{pubkey}/gas_limit", s.deleteOverride(func(o *overrides.Override) {
+		o.GasLimit = nil
+	}))
+	mux.HandleFunc("GET /eth/v1/validator/{pubkey}/graffiti", s.getGraffiti)
+	mux.HandleFunc("POST /eth/v1/validator/{pubkey}/graffiti", s.setGraffiti)
+	mux.HandleFunc("DELETE /eth/v1/validator/{pubkey}/graffiti", s.deleteOverride(func(o *overrides.Override) {
+		o.Graffiti = nil
+	}))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorised(r, token) {
+			writeError(ctx, w, http.StatusUnauthorized, errors.New("unauthorised"))
+			return
+		}
+
+		mux.ServeHTTP(w, r.WithContext(log.WithCtx(ctx, z.Str("path", r.URL.Path))))
+	})
+}
+
+// server implements the Keymanager API handlers.
+type server struct {
+	pubkeys          []core.PubKey
+	overrides        *overrides.Overrides
+	feeRecipientFunc func(core.PubKey) string
+}
+
+func (s server) listKeystores(w http.ResponseWriter, r *http.Request) {
+	type keystore struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+		DerivationPath   string `json:"derivation_path"`
+		Readonly         bool   `json:"readonly"`
+	}
+
+	resp := make([]keystore, 0, len(s.pubkeys))
+	for _, pubkey := range s.pubkeys {
+		resp = append(resp, keystore{ValidatingPubkey: string(pubkey), Readonly: true})
+	}
+
+	writeJSON(r.Context(), w, http.StatusOK, dataResponse{Data: resp})
+}
+
+func (server) listRemoteKeys(w http.ResponseWriter, r *http.Request) {
+	// Distributed validators are not remote keys of a web3signer.
+	writeJSON(r.Context(), w, http.StatusOK, dataResponse{Data: []struct{}{}})
+}
+
+// refuseMutation returns a handler responding with an error status for each item of the request's list field.
+func (server) refuseMutation(field string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, "decode request"))
+			return
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(req[field], &items); err != nil {
+			writeError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, "decode request field", z.Str("field", field)))
+			return
+		}
+
+		type status struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		}
+
+		resp := make([]status, 0, len(items))
+		for range items {
+			resp = append(resp, status{Status: "error", Message: errReadonly})
+		}
+
+		writeJSON(r.Context(), w, http.StatusOK, dataResponse{Data: resp})
+	}
+}
+
+func (s server) getFeeRecipient(w http.ResponseWriter, r *http.Request) {
+	pubkey, ok := s.pubkey(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(r.Context(), w, http.StatusOK, dataResponse{Data: struct {
+		Pubkey     string `json:"pubkey"`
+		EthAddress string `json:"ethaddress"`
+	}{
+		Pubkey:     string(pubkey),
+		EthAddress: s.feeRecipientFunc(pubkey),
+	}})
+}
+
+func (s server) setFeeRecipient(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		EthAddress string `json:"ethaddress"`
+	}
+	s.update(w, r, &req, func(o *overrides.Override) error {
+		address, err := eth2util.ChecksumAddress(req.EthAddress)
+		if err != nil {
+			return err
+		}
+
+		o.FeeRecipient = &address
+
+		return nil
+	})
+}
+
+func (s server) getGasLimit(w http.ResponseWriter, r *http.Request) {
+	pubkey, ok := s.pubkey(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(r.Context(), w, http.StatusOK, dataResponse{Data: struct {
+		Pubkey   string `json:"pubkey"`
+		GasLimit string `json:"gas_limit"`
+	}{
+		Pubkey:   string(pubkey),
+		GasLimit: strconv.FormatUint(s.overrides.GasLimit(pubkey, validatorapi.DefaultGasLimit), 10),
+	}})
+}
+
+func (s server) setGasLimit(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		GasLimit string `json:"gas_limit"`
+	}
+	s.update(w, r, &req, func(o *overrides.Override) error {
+		gasLimit, err := strconv.ParseUint(req.GasLimit, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid gas limit")
+		}
+
+		o.GasLimit = &gasLimit
+
+		return nil
+	})
+}
+
+func (s server) getGraffiti(w http.ResponseWriter, r *http.Request) {
+	pubkey, ok := s.pubkey(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(r.Context(), w, http.StatusOK, dataResponse{Data: struct {
+		Pubkey   string `json:"pubkey"`
+		Graffiti string `json:"graffiti"`
+	}{
+		Pubkey:   string(pubkey),
+		Graffiti: s.overrides.Graffiti(pubkey, fetcher.DefaultGraffiti()),
+	}})
+}
+
+func (s server) setGraffiti(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Graffiti string `json:"graffiti"`
+	}
+	s.update(w, r, &req, func(o *overrides.Override) error {
+		o.Graffiti = &req.Graffiti
+		return nil
+	})
+}
+
+// update decodes the request into req and updates the path validator's overrides.
+func (s server) update(w http.ResponseWriter, r *http.Request, req any, fn func(*overrides.Override) error) {
+	pubkey, ok := s.pubkey(w, r)
+	if !ok {
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, "decode request"))
+		return
+	}
+
+	var updated overrides.Override
+	if err := fn(&updated); err != nil {
+		writeError(r.Context(), w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := s.overrides.Update(pubkey, func(o *overrides.Override) {
+		if updated.FeeRecipient != nil {
+			o.FeeRecipient = updated.FeeRecipient
+		}
+		if updated.GasLimit != nil {
+			o.GasLimit = updated.GasLimit
+		}
+		if updated.Graffiti != nil {
+			o.Graffiti = updated.Graffiti
+		}
+	})
+	if err != nil {
+		writeError(r.Context(), w, updateStatus(err), err)
+		return
+	}
+
+	log.Info(r.Context(), "Updated validator proposer overrides via keymanager API, ensure all peers are updated",
+		z.Any("pubkey", pubkey))
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// deleteOverride returns a handler that removes the path validator's override.
+func (s server) deleteOverride(fn func(*overrides.Override)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pubkey, ok := s.pubkey(w, r)
+		if !ok {
+			return
+		}
+
+		if err := s.overrides.Update(pubkey, fn); err != nil {
+			writeError(r.Context(), w, updateStatus(err), err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// updateStatus returns the error response status code of the overrides update error.
+func updateStatus(err error) int {
+	if errors.Is(err, overrides.ErrInvalid) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// pubkey returns the distributed validator public key of the request path
+// or writes an error response and returns false.
+func (s server) pubkey(w http.ResponseWriter, r *http.Request) (core.PubKey, bool) {
+	b, err := hex.DecodeString(strings.TrimPrefix(r.PathValue("pubkey"), "0x"))
+	if err != nil {
+		writeError(r.Context(), w, http.StatusBadRequest, errors.Wrap(err, "invalid pubkey"))
+		return "", false
+	}
+
+	pubkey, err := core.PubKeyFromBytes(b)
+	if err != nil {
+		writeError(r.Context(), w, http.StatusBadRequest, err)
+		return "", false
+	}
+
+	if !slices.Contains(s.pubkeys, pubkey) {
+		writeError(r.Context(), w, http.StatusNotFound, errors.New("validator not found"))
+		return "", false
+	}
+
+	return pubkey, true
+}
+
+// authorised returns true if the request contains the bearer token.
+func authorised(r *http.Request, token string) bool {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+}
+
+// dataResponse is a Keymanager API response containing data.
+type dataResponse struct {
+	Data any `json:"data"`
+}
+
+// writeJSON writes the JSON response with the status code.
+func writeJSON(ctx context.Context, w http.ResponseWriter, code int, resp any) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		writeError(ctx, w, http.StatusInternalServerError, errors.Wrap(err, "marshal response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if _, err := w.Write(b); err != nil {
+		log.Error(ctx, "Failed writing keymanager api response", err)
+	}
+}
+
+// writeError writes a Keymanager API error response.
+func writeError(ctx context.Context, w http.ResponseWriter, code int, err error) {
+	log.Warn(ctx, "Keymanager api request failed", err, z.Int("code", code))
+
+	b, _ := json.Marshal(struct {
+		Message string `json:"message"`
+	}{
+		Message: err.Error(),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(b)
+}
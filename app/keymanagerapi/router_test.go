@@ -0,0 +1,132 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package keymanagerapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/keymanagerapi"
+	"github.com/obolnetwork/charon/app/overrides"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+const (
+	token        = "secret"
+	feeRecipient = "0x000000000000000000000000000000000000dEaD"
+	lockFeeAddr  = "0x0000000000000000000000000000000000000001"
+)
+
+func TestRouter(t *testing.T) {
+	pubkey := testutil.RandomCorePubKey(t)
+	pubkeys := []core.PubKey{pubkey}
+
+	o, err := overrides.New(filepath.Join(t.TempDir(), "overrides.yaml"), pubkeys, time.Time{})
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(keymanagerapi.NewRouter(context.Background(), token, pubkeys, o,
+		func(pubkey core.PubKey) string {
+			return o.FeeRecipient(pubkey, lockFeeAddr)
+		}))
+	defer srv.Close()
+
+	do := func(method, path, body string) (int, map[string]any) {
+		t.Helper()
+
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		var data map[string]any
+		if len(b) > 0 {
+			require.NoError(t, json.Unmarshal(b, &data))
+		}
+
+		return resp.StatusCode, data
+	}
+
+	// Requests require the bearer token.
+	resp, err := http.Get(srv.URL + "/eth/v1/keystores")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	code, data := do(http.MethodGet, "/eth/v1/keystores", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []any{map[string]any{
+		"validating_pubkey": string(pubkey),
+		"derivation_path":   "",
+		"readonly":          true,
+	}}, data["data"])
+
+	code, data = do(http.MethodGet, "/eth/v1/remotekeys", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, data["data"])
+
+	// Keystores cannot be imported.
+	code, data = do(http.MethodPost, "/eth/v1/keystores", `{"keystores":["{}"],"passwords":["p"]}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, data["data"], 1)
+	require.Equal(t, "error", data["data"].([]any)[0].(map[string]any)["status"])
+
+	feeRecipientPath := "/eth/v1/validator/" + string(pubkey) + "/feerecipient"
+	code, data = do(http.MethodGet, feeRecipientPath, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, lockFeeAddr, data["data"].(map[string]any)["ethaddress"])
+
+	code, _ = do(http.MethodPost, feeRecipientPath, `{"ethaddress":"`+strings.ToLower(feeRecipient)+`"}`)
+	require.Equal(t, http.StatusAccepted, code)
+	require.Equal(t, feeRecipient, o.FeeRecipient(pubkey, lockFeeAddr))
+
+	code, _ = do(http.MethodPost, feeRecipientPath, `{"ethaddress":"0x1234"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do(http.MethodPost, "/eth/v1/validator/"+string(pubkey)+"/gas_limit", `{"gas_limit":"36000000"}`)
+	require.Equal(t, http.StatusAccepted, code)
+	code, data = do(http.MethodGet, "/eth/v1/validator/"+string(pubkey)+"/gas_limit", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "36000000", data["data"].(map[string]any)["gas_limit"])
+
+	// Invalid overrides are bad requests.
+	code, _ = do(http.MethodPost, "/eth/v1/validator/"+string(pubkey)+"/gas_limit", `{"gas_limit":"0"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	graffitiPath := "/eth/v1/validator/" + string(pubkey) + "/graffiti"
+	code, _ = do(http.MethodPost, graffitiPath, `{"graffiti":"`+strings.Repeat("a", 33)+`"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do(http.MethodPost, graffitiPath, `{"graffiti":"obol"}`)
+	require.Equal(t, http.StatusAccepted, code)
+	code, data = do(http.MethodGet, graffitiPath, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "obol", data["data"].(map[string]any)["graffiti"])
+
+	code, _ = do(http.MethodDelete, graffitiPath, "")
+	require.Equal(t, http.StatusNoContent, code)
+	require.Equal(t, "default", o.Graffiti(pubkey, "default"))
+
+	code, _ = do(http.MethodDelete, feeRecipientPath, "")
+	require.Equal(t, http.StatusNoContent, code)
+	require.Equal(t, lockFeeAddr, o.FeeRecipient(pubkey, lockFeeAddr))
+
+	// Unknown validators are not found.
+	code, _ = do(http.MethodGet, "/eth/v1/validator/"+string(testutil.RandomCorePubKey(t))+"/feerecipient", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
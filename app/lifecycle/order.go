@@ -20,6 +20,9 @@ const (
 	StartMonitoringAPI
 	StartDebugAPI
 	StartValidatorAPI
+	StartBuilderRelayAPI
+	StartKeymanagerAPI
+	StartWeb3SignerAPI
 	StartP2PPing
 	StartP2PRouters
 	StartForceDirectConns
@@ -40,6 +43,9 @@ const (
 	StopDutyDB
 	StopBeaconMock // Close this before validator API, since it can hold long-lived connections.
 	StopValidatorAPI
+	StopBuilderRelayAPI
+	StopKeymanagerAPI
+	StopWeb3SignerAPI
 	StopTracing // Low level services...
 	StopP2PPeerDB
 	StopP2PTCPNode
@@ -17,21 +17,24 @@ func _() {
 	_ = x[StartMonitoringAPI-4]
 	_ = x[StartDebugAPI-5]
 	_ = x[StartValidatorAPI-6]
-	_ = x[StartP2PPing-7]
-	_ = x[StartP2PRouters-8]
-	_ = x[StartForceDirectConns-9]
-	_ = x[StartP2PConsensus-10]
-	_ = x[StartSimulator-11]
-	_ = x[StartScheduler-12]
-	_ = x[StartP2PEventCollector-13]
-	_ = x[StartPeerInfo-14]
-	_ = x[StartParSigDB-15]
-	_ = x[StartStackSnipe-16]
+	_ = x[StartBuilderRelayAPI-7]
+	_ = x[StartKeymanagerAPI-8]
+	_ = x[StartWeb3SignerAPI-9]
+	_ = x[StartP2PPing-10]
+	_ = x[StartP2PRouters-11]
+	_ = x[StartForceDirectConns-12]
+	_ = x[StartP2PConsensus-13]
+	_ = x[StartSimulator-14]
+	_ = x[StartScheduler-15]
+	_ = x[StartP2PEventCollector-16]
+	_ = x[StartPeerInfo-17]
+	_ = x[StartParSigDB-18]
+	_ = x[StartStackSnipe-19]
 }
 
-const _OrderStart_name = "TrackerPrivkeyLockAggSigDBRelayMonitoringAPIDebugAPIValidatorAPIP2PPingP2PRoutersForceDirectConnsP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDBStackSnipe"
+const _OrderStart_name = "TrackerPrivkeyLockAggSigDBRelayMonitoringAPIDebugAPIValidatorAPIBuilderRelayAPIKeymanagerAPIWeb3SignerAPIP2PPingP2PRoutersForceDirectConnsP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDBStackSnipe"
 
-var _OrderStart_index = [...]uint8{0, 7, 18, 26, 31, 44, 52, 64, 71, 81, 97, 109, 118, 127, 144, 152, 160, 170}
+var _OrderStart_index = [...]uint8{0, 7, 18, 26, 31, 44, 52, 64, 79, 92, 105, 112, 122, 138, 150, 159, 168, 185, 193, 201, 211}
 
 func (i OrderStart) String() string {
 	if i < 0 || i >= OrderStart(len(_OrderStart_index)-1) {
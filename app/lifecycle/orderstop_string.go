@@ -16,17 +16,20 @@ func _() {
 	_ = x[StopDutyDB-3]
 	_ = x[StopBeaconMock-4]
 	_ = x[StopValidatorAPI-5]
-	_ = x[StopTracing-6]
-	_ = x[StopP2PPeerDB-7]
-	_ = x[StopP2PTCPNode-8]
-	_ = x[StopP2PUDPNode-9]
-	_ = x[StopDebugAPI-10]
-	_ = x[StopMonitoringAPI-11]
+	_ = x[StopBuilderRelayAPI-6]
+	_ = x[StopKeymanagerAPI-7]
+	_ = x[StopWeb3SignerAPI-8]
+	_ = x[StopTracing-9]
+	_ = x[StopP2PPeerDB-10]
+	_ = x[StopP2PTCPNode-11]
+	_ = x[StopP2PUDPNode-12]
+	_ = x[StopDebugAPI-13]
+	_ = x[StopMonitoringAPI-14]
 }
 
-const _OrderStop_name = "SchedulerPrivkeyLockRetryerDutyDBBeaconMockValidatorAPITracingP2PPeerDBP2PTCPNodeP2PUDPNodeDebugAPIMonitoringAPI"
+const _OrderStop_name = "SchedulerPrivkeyLockRetryerDutyDBBeaconMockValidatorAPIBuilderRelayAPIKeymanagerAPIWeb3SignerAPITracingP2PPeerDBP2PTCPNodeP2PUDPNodeDebugAPIMonitoringAPI"
 
-var _OrderStop_index = [...]uint8{0, 9, 20, 27, 33, 43, 55, 62, 71, 81, 91, 99, 112}
+var _OrderStop_index = [...]uint8{0, 9, 20, 27, 33, 43, 55, 70, 83, 96, 103, 112, 122, 132, 140, 153}
 
 func (i OrderStop) String() string {
 	if i < 0 || i >= OrderStop(len(_OrderStop_index)-1) {
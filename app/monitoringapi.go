@@ -43,12 +43,12 @@ var (
 )
 
 // wireMonitoringAPI constructs the monitoring API and registers it with the life cycle manager.
-// It serves prometheus metrics, pprof profiling and the runtime enr.
+// It serves prometheus metrics, pprof profiling, the runtime enr and the operator status API.
 func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, debugAddr string,
 	tcpNode host.Host, eth2Cl eth2wrap.Client,
 	peerIDs []peer.ID, registry *prometheus.Registry, consensusDebugger http.Handler,
 	pubkeys []core.PubKey, seenPubkeys <-chan core.PubKey, vapiCalls <-chan struct{},
-	numValidators int,
+	numValidators int, status *statusAPI,
 ) {
 	beaconNodeVersionMetric(ctx, eth2Cl, clockwork.NewRealClock())
 
@@ -77,6 +77,9 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, d
 		writeResponse(w, http.StatusOK, "ok")
 	})
 
+	status.readyErr = readyErrFunc
+	status.register(mux)
+
 	server := &http.Server{
 		Addr:              promAddr,
 		Handler:           mux,
@@ -89,6 +92,7 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, d
 		NumPeers:      len(peerIDs),
 		QuorumPeers:   cluster.Threshold(len(peerIDs)),
 	}, registry, numValidators)
+	status.checker = checker
 
 	if debugAddr != "" {
 		debugMux := http.NewServeMux()
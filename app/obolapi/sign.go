@@ -0,0 +1,150 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package obolapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+)
+
+const (
+	partialSigBaseTmpl = "/exp/partial_signatures/" + lockHashPath
+	partialSigsEndTmpl = "/" + shareIndexPath + "/" + valPubkeyPath
+)
+
+// PartialSignature is a signing root of an eth2 message signed with an operator's validator key share.
+type PartialSignature struct {
+	// Type is the message type, e.g. "builder-registration".
+	Type string `json:"type"`
+	// ValidatorPubkey is the 0x-prefixed hex validator public key.
+	ValidatorPubkey string `json:"validator_public_key"`
+	// ShareIdx is the 1-indexed share index of the signing operator.
+	ShareIdx uint64 `json:"share_idx"`
+	// Message is the JSON encoded message.
+	Message json.RawMessage `json:"message"`
+	// Domain is the 0x-prefixed hex signature domain.
+	Domain string `json:"domain"`
+	// SigningRoot is the 0x-prefixed hex signing root of the message and domain.
+	SigningRoot string `json:"signing_root"`
+	// Signature is the 0x-prefixed hex partial signature of the signing root.
+	Signature string `json:"signature"`
+}
+
+// PartialSignaturesRequest is the request body of partial signatures posted to the Obol API.
+// Signature is the EC signature of the partial signatures hash done with the Charon node identity key.
+type PartialSignaturesRequest struct {
+	PartialSignatures []PartialSignature `json:"partial_signatures"`
+	ShareIdx          uint64             `json:"share_idx"`
+	Signature         string             `json:"signature"`
+}
+
+// PartialSignaturesHash returns the hash of the partial signatures signed by the operator's identity key.
+func PartialSignaturesHash(shareIdx uint64, sigs []PartialSignature) ([]byte, error) {
+	b, err := json.Marshal(sigs)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal partial signatures")
+	}
+
+	h := sha256.New()
+	_ = binary.Write(h, binary.BigEndian, shareIdx)
+	_, _ = h.Write(b)
+
+	return h.Sum(nil), nil
+}
+
+// PartialSignaturesAuthHash returns the hash signed by the operator's identity key to fetch partial signatures.
+func PartialSignaturesAuthHash(lockHash []byte, valPubkey string, shareIdx uint64) []byte {
+	h := sha256.New()
+	_, _ = h.Write(lockHash)
+	_, _ = h.Write([]byte(strings.ToLower(valPubkey)))
+	_ = binary.Write(h, binary.BigEndian, shareIdx)
+
+	return h.Sum(nil)
+}
+
+// PostPartialSignatures POSTs the operator's partial signatures to the Obol API, for a given lock hash.
+// It respects the timeout specified in the Client instance.
+func (c Client) PostPartialSignatures(ctx context.Context, lockHash []byte, shareIdx uint64, identityKey *k1.PrivateKey, sigs ...PartialSignature) error {
+	u, err := url.ParseRequestURI(c.baseURL)
+	if err != nil {
+		return errors.Wrap(err, "bad Obol API url")
+	}
+
+	u.Path = strings.NewReplacer(lockHashPath, "0x"+hex.EncodeToString(lockHash)).Replace(partialSigBaseTmpl)
+
+	hash, err := PartialSignaturesHash(shareIdx, sigs)
+	if err != nil {
+		return err
+	}
+
+	signature, err := k1util.Sign(identityKey, hash)
+	if err != nil {
+		return errors.Wrap(err, "k1 sign")
+	}
+
+	data, err := json.Marshal(PartialSignaturesRequest{
+		PartialSignatures: sigs,
+		ShareIdx:          shareIdx,
+		Signature:         "0x" + hex.EncodeToString(signature),
+	})
+	if err != nil {
+		return errors.Wrap(err, "json marshal error")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.reqTimeout)
+	defer cancel()
+
+	if err := httpPost(ctx, u, data, nil); err != nil {
+		return errors.Wrap(err, "http Obol API POST request")
+	}
+
+	return nil
+}
+
+// GetPartialSignatures returns all partial signatures of the validator stored by the Obol API, for a given lock hash.
+// The request is authenticated by the operator with the provided share index.
+// It respects the timeout specified in the Client instance.
+func (c Client) GetPartialSignatures(ctx context.Context, lockHash []byte, valPubkey string, shareIdx uint64, identityKey *k1.PrivateKey) ([]PartialSignature, error) {
+	u, err := url.ParseRequestURI(c.baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad Obol API url")
+	}
+
+	u.Path = strings.NewReplacer(
+		lockHashPath, "0x"+hex.EncodeToString(lockHash),
+		shareIndexPath, strconv.FormatUint(shareIdx, 10),
+		valPubkeyPath, valPubkey,
+	).Replace(partialSigBaseTmpl + partialSigsEndTmpl)
+
+	signature, err := k1util.Sign(identityKey, PartialSignaturesAuthHash(lockHash, valPubkey, shareIdx))
+	if err != nil {
+		return nil, errors.Wrap(err, "k1 sign")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.reqTimeout)
+	defer cancel()
+
+	respBody, err := httpGet(ctx, u, map[string]string{"Authorization": bearerString(signature)})
+	if err != nil {
+		return nil, errors.Wrap(err, "http Obol API GET request")
+	}
+	defer respBody.Close()
+
+	var resp []PartialSignature
+	if err := json.NewDecoder(respBody).Decode(&resp); err != nil {
+		return nil, errors.Wrap(err, "json unmarshal error")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,535 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package overrides provides runtime-reloadable per-validator fee recipient, gas limit and builder overrides
+// of the proposer config defined in the cluster lock.
+package overrides
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/obolnetwork/charon/app/atomicfile"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+// maxGraffitiLen is the maximum length of block graffiti in bytes.
+const maxGraffitiLen = 32
+
+// ErrInvalid is returned when overrides fail validation.
+var ErrInvalid = errors.NewSentinel("invalid proposer overrides")
+
+// Override defines proposer config overrides. Nil fields are not overridden.
+// Note that the builder API can only be disabled for validators, since it must be enabled for the node.
+type Override struct {
+	FeeRecipient   *string `json:"fee_recipient,omitempty"   yaml:"fee_recipient,omitempty"`
+	GasLimit       *uint64 `json:"gas_limit,omitempty"       yaml:"gas_limit,omitempty"`
+	BuilderEnabled *bool   `json:"builder_enabled,omitempty" yaml:"builder_enabled,omitempty"`
+	Graffiti       *string `json:"graffiti,omitempty"        yaml:"graffiti,omitempty"`
+}
+
+// merge returns the override with nil fields replaced by the fields of the other override.
+func (o Override) merge(other Override) Override {
+	if o.FeeRecipient == nil {
+		o.FeeRecipient = other.FeeRecipient
+	}
+	if o.GasLimit == nil {
+		o.GasLimit = other.GasLimit
+	}
+	if o.BuilderEnabled == nil {
+		o.BuilderEnabled = other.BuilderEnabled
+	}
+	if o.Graffiti == nil {
+		o.Graffiti = other.Graffiti
+	}
+
+	return o
+}
+
+// File defines the overrides file format, either YAML or JSON (.json extension).
+type File struct {
+	// Timestamp is the unix timestamp of builder registrations of validators with overridden fee recipient or gas limit.
+	// It must be increased when changing overrides, since builder relays ignore registrations with older timestamps.
+	// Updates via Update derive it from the previous timestamp, so peers applying the same updates derive the same timestamp.
+	Timestamp int64 `json:"timestamp" yaml:"timestamp"`
+	// Default overrides apply to all validators.
+	Default Override `json:"default" yaml:"default"`
+	// Validators overrides by 0x-prefixed hex validator public key take precedence over default overrides.
+	Validators map[core.PubKey]Override `json:"validators" yaml:"validators"`
+}
+
+// New returns the overrides loaded from the file, or empty overrides if the filename is empty or the file doesn't exist.
+// Overrides of validators not included in the pubkeys are refused. Updated registration timestamps
+// are after the minimum timestamp, which should be the timestamp of default builder registrations.
+func New(filename string, pubkeys []core.PubKey, minTimestamp time.Time) (*Overrides, error) {
+	o := &Overrides{
+		filename:     filename,
+		pubkeys:      pubkeys,
+		minTimestamp: minTimestamp.Unix(),
+	}
+
+	if err := o.set(File{}); err != nil {
+		return nil, err
+	}
+
+	if _, err := o.reload(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Overrides provides the current overrides of the overrides file.
+type Overrides struct {
+	filename     string
+	pubkeys      []core.PubKey
+	minTimestamp int64
+	updateMu     sync.Mutex // Serialises updates.
+
+	mu      sync.RWMutex
+	file    File
+	hash    string
+	modTime time.Time
+}
+
+// FeeRecipient returns the validator's overridden fee recipient or the provided fee recipient if not overridden.
+func (o *Overrides) FeeRecipient(pubkey core.PubKey, feeRecipient string) string {
+	if override := o.get(pubkey); override.FeeRecipient != nil {
+		return *override.FeeRecipient
+	}
+
+	return feeRecipient
+}
+
+// GasLimit returns the validator's overridden gas limit or the provided gas limit if not overridden.
+func (o *Overrides) GasLimit(pubkey core.PubKey, gasLimit uint64) uint64 {
+	if override := o.get(pubkey); override.GasLimit != nil {
+		return *override.GasLimit
+	}
+
+	return gasLimit
+}
+
+// BuilderEnabled returns the validator's overridden builder enablement or the provided enablement if not overridden.
+func (o *Overrides) BuilderEnabled(pubkey core.PubKey, enabled bool) bool {
+	if override := o.get(pubkey); override.BuilderEnabled != nil {
+		return *override.BuilderEnabled
+	}
+
+	return enabled
+}
+
+// Graffiti returns the validator's overridden graffiti or the provided graffiti if not overridden.
+func (o *Overrides) Graffiti(pubkey core.PubKey, graffiti string) string {
+	if override := o.get(pubkey); override.Graffiti != nil {
+		return *override.Graffiti
+	}
+
+	return graffiti
+}
+
+// RegistrationTimestamp returns the builder registration timestamp of the validator
+// and true if its fee recipient or gas limit is overridden.
+func (o *Overrides) RegistrationTimestamp(pubkey core.PubKey) (time.Time, bool) {
+	override := o.get(pubkey)
+	if override.FeeRecipient == nil && override.GasLimit == nil {
+		return time.Time{}, false
+	}
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return time.Unix(o.file.Timestamp, 0), true
+}
+
+// Hash returns the hex encoded hash of the current overrides, used to verify that all peers use the same overrides.
+func (o *Overrides) Hash() string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.hash
+}
+
+// SlotTicked reloads the overrides file if it changed. It is a scheduler slot subscriber.
+// The previous overrides are retained if reloading fails.
+func (o *Overrides) SlotTicked(ctx context.Context, _ core.Slot) error {
+	reloaded, err := o.reload()
+	if err != nil {
+		log.Warn(ctx, "Failed reloading proposer overrides file, retaining previous", err, z.Str("file", o.filename))
+		return nil
+	} else if reloaded {
+		log.Info(ctx, "Reloaded proposer overrides file", z.Str("file", o.filename), z.Str("hash", o.Hash()))
+	}
+
+	return nil
+}
+
+// Update updates the validator's overrides and persists them to the overrides file.
+// The registration timestamp is increased if the validator's fee recipient or gas limit changed.
+// Note that the same updates must be applied to all peers. Validation errors wrap ErrInvalid.
+func (o *Overrides) Update(pubkey core.PubKey, fn func(*Override)) error {
+	if o.filename == "" {
+		return errors.New("proposer overrides file not configured")
+	} else if !slices.Contains(o.pubkeys, pubkey) {
+		return errors.New("unknown validator", z.Str("pubkey", string(pubkey)))
+	}
+
+	o.updateMu.Lock()
+	defer o.updateMu.Unlock()
+
+	// Apply the update to the latest overrides file, since it may have been edited since the last reload.
+	if _, err := o.reload(); err != nil {
+		return err
+	}
+
+	o.mu.RLock()
+	file, err := cloneFile(o.file)
+	o.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	before := file.Validators[pubkey].merge(file.Default)
+
+	override := file.Validators[pubkey]
+	fn(&override)
+	if override == (Override{}) {
+		delete(file.Validators, pubkey)
+	} else {
+		file.Validators[pubkey] = override
+	}
+
+	after := file.Validators[pubkey].merge(file.Default)
+	if !equalPtr(before.FeeRecipient, after.FeeRecipient) || !equalPtr(before.GasLimit, after.GasLimit) {
+		file.Timestamp = nextTimestamp(file.Timestamp, o.minTimestamp)
+	}
+
+	if err := o.set(file); err != nil {
+		return err
+	}
+
+	if err := writeFile(o.filename, file, pubkey); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(o.filename)
+	if err != nil {
+		return errors.Wrap(err, "stat overrides file")
+	}
+
+	o.mu.Lock()
+	o.modTime = info.ModTime()
+	o.mu.Unlock()
+
+	return nil
+}
+
+// get returns the validator's overrides including default overrides.
+func (o *Overrides) get(pubkey core.PubKey) Override {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.file.Validators[pubkey].merge(o.file.Default)
+}
+
+// reload loads the overrides file if it changed since the previous load.
+// It returns true if the overrides were reloaded.
+func (o *Overrides) reload() (bool, error) {
+	if o.filename == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(o.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "stat overrides file")
+	}
+
+	o.mu.RLock()
+	unchanged := info.ModTime().Equal(o.modTime)
+	o.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	file, err := loadFile(o.filename)
+	if err != nil {
+		return false, err
+	}
+
+	if err := o.set(file); err != nil {
+		return false, err
+	}
+
+	o.mu.Lock()
+	o.modTime = info.ModTime()
+	o.mu.Unlock()
+
+	return true, nil
+}
+
+// set validates and sets the overrides.
+func (o *Overrides) set(file File) error {
+	if err := validateOverride(file.Default); err != nil {
+		return errors.Wrap(err, "invalid default overrides")
+	}
+
+	requireTimestamp := file.Default.FeeRecipient != nil || file.Default.GasLimit != nil
+
+	validators := make(map[core.PubKey]Override)
+	for pubkey, override := range file.Validators {
+		pubkey = core.PubKey(strings.ToLower(string(pubkey)))
+		if !slices.Contains(o.pubkeys, pubkey) {
+			return errors.Wrap(ErrInvalid, "overrides of unknown validator", z.Str("pubkey", string(pubkey)))
+		}
+
+		if err := validateOverride(override); err != nil {
+			return errors.Wrap(err, "invalid validator overrides", z.Str("pubkey", string(pubkey)))
+		}
+
+		requireTimestamp = requireTimestamp || override.FeeRecipient != nil || override.GasLimit != nil
+		validators[pubkey] = override
+	}
+	file.Validators = validators
+
+	if requireTimestamp && file.Timestamp <= 0 {
+		return errors.Wrap(ErrInvalid, "timestamp required when overriding fee recipient or gas limit")
+	}
+
+	hash, err := hashFile(file)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.file = file
+	o.hash = hash
+
+	return nil
+}
+
+// loadFile returns the overrides file, decoded as JSON if it has a .json extension and as YAML otherwise.
+func loadFile(filename string) (File, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return File{}, errors.Wrap(err, "read overrides file")
+	}
+
+	var file File
+	if filepath.Ext(filename) == ".json" {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&file)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		err = dec.Decode(&file)
+	}
+	if err != nil {
+		return File{}, errors.Wrap(err, "decode overrides file", z.Str("file", filename))
+	}
+
+	return file, nil
+}
+
+// validateOverride returns an error if the override is invalid.
+// Fee recipient addresses are normalised to EIP55 checksummed addresses.
+func validateOverride(override Override) error {
+	if override.FeeRecipient != nil {
+		checksummed, err := eth2util.ChecksumAddress(*override.FeeRecipient)
+		if err != nil {
+			return errors.Wrap(ErrInvalid, "invalid fee recipient", z.Str("fee_recipient", *override.FeeRecipient))
+		}
+		*override.FeeRecipient = checksummed
+	}
+
+	if override.GasLimit != nil && *override.GasLimit == 0 {
+		return errors.Wrap(ErrInvalid, "invalid zero gas limit")
+	}
+
+	if override.Graffiti != nil && len(*override.Graffiti) > maxGraffitiLen {
+		return errors.Wrap(ErrInvalid, "graffiti too long", z.Int("max", maxGraffitiLen))
+	}
+
+	return nil
+}
+
+// hashFile returns the hex encoded hash of the overrides, which is independent of the file format.
+func hashFile(file File) (string, error) {
+	// JSON encoding of maps is sorted by key, so it is deterministic.
+	b, err := json.Marshal(file)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal overrides")
+	}
+
+	hash := sha256.Sum256(b)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// writeFile atomically writes the overrides file, encoded as JSON if it has a .json extension and as YAML otherwise.
+// Existing YAML files are patched with the updated validator's overrides, retaining comments and formatting.
+func writeFile(filename string, file File, pubkey core.PubKey) error {
+	var (
+		b   []byte
+		err error
+	)
+	if filepath.Ext(filename) == ".json" {
+		b, err = json.MarshalIndent(file, "", "  ")
+	} else {
+		b, err = patchYAML(filename, file, pubkey)
+	}
+	if err != nil {
+		return errors.Wrap(err, "marshal overrides file")
+	}
+
+	if err := atomicfile.Write(filename, b, 0o644); err != nil {
+		return errors.Wrap(err, "write overrides file")
+	}
+
+	return nil
+}
+
+// patchYAML returns the existing YAML overrides file with only the timestamp and the validator's overrides replaced
+// by those of the updated overrides, or the marshalled overrides if the file doesn't exist.
+func patchYAML(filename string, file File, pubkey core.PubKey) ([]byte, error) {
+	existing, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return yaml.Marshal(file)
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read overrides file")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(existing, &doc); err != nil {
+		return nil, errors.Wrap(err, "decode overrides file")
+	} else if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return yaml.Marshal(file)
+	}
+	root := doc.Content[0]
+
+	timestamp := new(yaml.Node)
+	if err := timestamp.Encode(file.Timestamp); err != nil {
+		return nil, errors.Wrap(err, "encode timestamp")
+	}
+	setYAMLValue(root, "timestamp", timestamp)
+
+	validators := getYAMLValue(root, "validators")
+	if validators == nil || validators.Kind != yaml.MappingNode {
+		validators = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setYAMLValue(root, "validators", validators)
+	}
+
+	if override, ok := file.Validators[pubkey]; ok {
+		node := new(yaml.Node)
+		if err := node.Encode(override); err != nil {
+			return nil, errors.Wrap(err, "encode validator overrides")
+		}
+		setYAMLValue(validators, string(pubkey), node)
+	} else {
+		deleteYAMLKey(validators, string(pubkey))
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, errors.Wrap(err, "encode overrides file")
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "close encoder")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// getYAMLValue returns the value of the case-insensitive key of the YAML mapping node or nil if not found.
+func getYAMLValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setYAMLValue replaces the value of the case-insensitive key of the YAML mapping node, retaining its comments,
+// or appends the key and value if not found.
+func setYAMLValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if !strings.EqualFold(mapping.Content[i].Value, key) {
+			continue
+		}
+
+		prev := mapping.Content[i+1]
+		value.HeadComment, value.LineComment, value.FootComment = prev.HeadComment, prev.LineComment, prev.FootComment
+		mapping.Content[i+1] = value
+
+		return
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// deleteYAMLKey deletes the case-insensitive key and its value from the YAML mapping node.
+func deleteYAMLKey(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			mapping.Content = slices.Delete(mapping.Content, i, i+2)
+			return
+		}
+	}
+}
+
+// cloneFile returns a deep copy of the overrides file.
+func cloneFile(file File) (File, error) {
+	b, err := json.Marshal(file)
+	if err != nil {
+		return File{}, errors.Wrap(err, "marshal overrides")
+	}
+
+	var resp File
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return File{}, errors.Wrap(err, "unmarshal overrides")
+	}
+
+	if resp.Validators == nil {
+		resp.Validators = make(map[core.PubKey]Override)
+	}
+
+	return resp, nil
+}
+
+// nextTimestamp returns the next registration timestamp after the previous and minimum timestamps.
+// It doesn't depend on the current time, so peers applying identical updates derive identical timestamps.
+func nextTimestamp(prev int64, minimum int64) int64 {
+	return max(prev, minimum) + 1
+}
+
+// equalPtr returns true if both pointers are nil or point to equal values.
+func equalPtr[T comparable](x, y *T) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+
+	return *x == *y
+}
@@ -0,0 +1,269 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package overrides_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/overrides"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+const (
+	feeRecipient1 = "0x000000000000000000000000000000000000dEaD"
+	feeRecipient2 = "0x0000000000000000000000000000000000000001"
+	fallback      = "0x0000000000000000000000000000000000000002"
+)
+
+func TestOverrides(t *testing.T) {
+	pubkey1 := testutil.RandomCorePubKey(t)
+	pubkey2 := testutil.RandomCorePubKey(t)
+	pubkeys := []core.PubKey{pubkey1, pubkey2}
+
+	yamlFile := filepath.Join(t.TempDir(), "overrides.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte(fmt.Sprintf(`
+timestamp: 1700000000
+default:
+  gas_limit: 36000000
+validators:
+  %s:
+    fee_recipient: "%s"
+    builder_enabled: false
+`, strings.ToUpper(string(pubkey1)), strings.ToLower(feeRecipient1))), 0o644))
+
+	o, err := overrides.New(yamlFile, pubkeys, time.Time{})
+	require.NoError(t, err)
+
+	require.Equal(t, feeRecipient1, o.FeeRecipient(pubkey1, fallback))
+	require.Equal(t, fallback, o.FeeRecipient(pubkey2, fallback))
+	require.EqualValues(t, 36000000, o.GasLimit(pubkey1, 30000000))
+	require.EqualValues(t, 36000000, o.GasLimit(pubkey2, 30000000))
+	require.False(t, o.BuilderEnabled(pubkey1, true))
+	require.True(t, o.BuilderEnabled(pubkey2, true))
+
+	timestamp, ok := o.RegistrationTimestamp(pubkey2)
+	require.True(t, ok)
+	require.Equal(t, time.Unix(1700000000, 0), timestamp)
+
+	// Identical JSON overrides result in the same hash.
+	jsonFile := filepath.Join(t.TempDir(), "overrides.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(fmt.Sprintf(`{
+		"timestamp": 1700000000,
+		"default": {"gas_limit": 36000000},
+		"validators": {"%s": {"fee_recipient": "%s", "builder_enabled": false}}
+	}`, string(pubkey1), feeRecipient1)), 0o644))
+
+	o2, err := overrides.New(jsonFile, pubkeys, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, o.Hash(), o2.Hash())
+
+	// Changed overrides are reloaded.
+	require.NoError(t, os.WriteFile(yamlFile, []byte(fmt.Sprintf(`
+timestamp: 1700000001
+validators:
+  %s:
+    fee_recipient: "%s"
+`, string(pubkey2), feeRecipient2)), 0o644))
+	require.NoError(t, os.Chtimes(yamlFile, time.Now(), time.Now().Add(time.Second)))
+
+	require.NoError(t, o.SlotTicked(context.Background(), core.Slot{}))
+	require.Equal(t, fallback, o.FeeRecipient(pubkey1, fallback))
+	require.Equal(t, feeRecipient2, o.FeeRecipient(pubkey2, fallback))
+	require.EqualValues(t, 30000000, o.GasLimit(pubkey1, 30000000))
+	require.NotEqual(t, o.Hash(), o2.Hash())
+
+	_, ok = o.RegistrationTimestamp(pubkey1)
+	require.False(t, ok)
+
+	// Invalid overrides are not reloaded.
+	hash := o.Hash()
+	require.NoError(t, os.WriteFile(yamlFile, []byte("default:\n  gas_limit: 0\n"), 0o644))
+	require.NoError(t, os.Chtimes(yamlFile, time.Now(), time.Now().Add(2*time.Second)))
+	require.NoError(t, o.SlotTicked(context.Background(), core.Slot{}))
+	require.Equal(t, hash, o.Hash())
+}
+
+func TestInvalidOverrides(t *testing.T) {
+	pubkey := testutil.RandomCorePubKey(t)
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "unknown validator",
+			content: fmt.Sprintf("timestamp: 1\nvalidators:\n  %s:\n    gas_limit: 1\n", string(testutil.RandomCorePubKey(t))),
+			err:     "overrides of unknown validator",
+		},
+		{
+			name:    "invalid fee recipient",
+			content: "timestamp: 1\ndefault:\n  fee_recipient: 0x1234\n",
+			err:     "invalid fee recipient",
+		},
+		{
+			name:    "zero gas limit",
+			content: "timestamp: 1\ndefault:\n  gas_limit: 0\n",
+			err:     "invalid zero gas limit",
+		},
+		{
+			name:    "missing timestamp",
+			content: fmt.Sprintf("default:\n  fee_recipient: %s\n", feeRecipient1),
+			err:     "timestamp required",
+		},
+		{
+			name:    "unknown field",
+			content: "default:\n  fee_recipients: []\n",
+			err:     "decode overrides file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "overrides.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(test.content), 0o644))
+
+			_, err := overrides.New(file, []core.PubKey{pubkey}, time.Time{})
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestNoOverrides(t *testing.T) {
+	pubkey := testutil.RandomCorePubKey(t)
+
+	o, err := overrides.New("", []core.PubKey{pubkey}, time.Time{})
+	require.NoError(t, err)
+
+	require.Equal(t, fallback, o.FeeRecipient(pubkey, fallback))
+	require.True(t, o.BuilderEnabled(pubkey, true))
+	require.NotEmpty(t, o.Hash())
+	require.NoError(t, o.SlotTicked(context.Background(), core.Slot{}))
+}
+
+func TestUpdate(t *testing.T) {
+	pubkey := testutil.RandomCorePubKey(t)
+	file := filepath.Join(t.TempDir(), "overrides.json")
+	minTimestamp := time.Unix(1700000000, 0)
+
+	o, err := overrides.New(file, []core.PubKey{pubkey}, minTimestamp)
+	require.NoError(t, err)
+
+	err = o.Update(testutil.RandomCorePubKey(t), func(*overrides.Override) {})
+	require.ErrorContains(t, err, "unknown validator")
+
+	var zero uint64
+	err = o.Update(pubkey, func(override *overrides.Override) {
+		override.GasLimit = &zero
+	})
+	require.ErrorIs(t, err, overrides.ErrInvalid)
+
+	graffiti := "obol"
+	require.NoError(t, o.Update(pubkey, func(override *overrides.Override) {
+		override.Graffiti = &graffiti
+	}))
+	require.Equal(t, graffiti, o.Graffiti(pubkey, ""))
+	_, ok := o.RegistrationTimestamp(pubkey)
+	require.False(t, ok)
+
+	feeRecipient := feeRecipient1
+	require.NoError(t, o.Update(pubkey, func(override *overrides.Override) {
+		override.FeeRecipient = &feeRecipient
+	}))
+	timestamp, ok := o.RegistrationTimestamp(pubkey)
+	require.True(t, ok)
+	require.Equal(t, minTimestamp.Add(time.Second), timestamp)
+
+	// Updates are persisted.
+	o2, err := overrides.New(file, []core.PubKey{pubkey}, minTimestamp)
+	require.NoError(t, err)
+	require.Equal(t, o.Hash(), o2.Hash())
+	require.Equal(t, feeRecipient1, o2.FeeRecipient(pubkey, fallback))
+
+	// Changed fee recipients increase the timestamp.
+	require.NoError(t, o.Update(pubkey, func(override *overrides.Override) {
+		override.FeeRecipient = nil
+	}))
+	_, ok = o.RegistrationTimestamp(pubkey)
+	require.False(t, ok)
+
+	require.NoError(t, o.Update(pubkey, func(override *overrides.Override) {
+		override.FeeRecipient = &feeRecipient
+	}))
+	updated, ok := o.RegistrationTimestamp(pubkey)
+	require.True(t, ok)
+	require.Equal(t, timestamp.Add(2*time.Second), updated)
+}
+
+func TestUpdateYAML(t *testing.T) {
+	pubkey1 := testutil.RandomCorePubKey(t)
+	pubkey2 := testutil.RandomCorePubKey(t)
+	file := filepath.Join(t.TempDir(), "overrides.yaml")
+
+	require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf(`# Cluster proposer overrides.
+timestamp: 1700000000
+default:
+  gas_limit: 36000000 # Agreed by all operators.
+validators:
+  # Operator A's validator.
+  %s:
+    graffiti: obol
+`, strings.ToUpper(string(pubkey1)))), 0o644))
+
+	o, err := overrides.New(file, []core.PubKey{pubkey1, pubkey2}, time.Time{})
+	require.NoError(t, err)
+
+	feeRecipient := feeRecipient1
+	require.NoError(t, o.Update(pubkey1, func(override *overrides.Override) {
+		override.FeeRecipient = &feeRecipient
+	}))
+	require.NoError(t, o.Update(pubkey2, func(override *overrides.Override) {
+		override.FeeRecipient = &feeRecipient
+	}))
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(`# Cluster proposer overrides.
+timestamp: 1700000002
+default:
+  gas_limit: 36000000 # Agreed by all operators.
+validators:
+  # Operator A's validator.
+  %s:
+    fee_recipient: "%s"
+    graffiti: obol
+  %s:
+    fee_recipient: "%s"
+`, strings.ToUpper(string(pubkey1)), feeRecipient1, string(pubkey2), feeRecipient1), string(b))
+
+	// Deleted overrides are removed from the file.
+	require.NoError(t, o.Update(pubkey2, func(override *overrides.Override) {
+		override.FeeRecipient = nil
+	}))
+
+	b, err = os.ReadFile(file)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), string(pubkey2))
+	require.Contains(t, string(b), "# Operator A's validator.")
+
+	// Files edited since the last reload are updated.
+	require.NoError(t, os.WriteFile(file, []byte("timestamp: 1800000000\n"), 0o644))
+	require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Second)))
+	require.NoError(t, o.Update(pubkey2, func(override *overrides.Override) {
+		override.FeeRecipient = &feeRecipient
+	}))
+
+	timestamp, ok := o.RegistrationTimestamp(pubkey2)
+	require.True(t, ok)
+	require.Equal(t, time.Unix(1800000001, 0), timestamp)
+	require.Equal(t, fallback, o.FeeRecipient(pubkey1, fallback))
+}
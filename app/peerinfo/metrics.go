@@ -60,4 +60,11 @@ var (
 		Name:      "builder_api_enabled",
 		Help:      "Set to 1 if builder API is enabled on this peer, else 0 if disabled.",
 	}, []string{"peer"})
+
+	peerDuplicateGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "peerinfo",
+		Name:      "duplicate_peer_id",
+		Help:      "Set to 1 if multiple instances are running with the peer's ID (including this node's), else 0.",
+	}, []string{"peer"})
 )
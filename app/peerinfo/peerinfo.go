@@ -5,8 +5,12 @@ package peerinfo
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -26,6 +30,8 @@ import (
 
 const (
 	period                  = time.Minute
+	nonceLen                = 16
+	reportTTL               = 3 * period // Duration after which missing peer instances or reports are considered stopped.
 	protocolID2 protocol.ID = "/charon/peerinfo/2.0.0"
 )
 
@@ -89,20 +95,8 @@ func newInternal(tcpNode host.Host, peers []peer.ID, version version.SemVer, loc
 ) *PeerInfo {
 	startTime := timestamppb.New(nowFunc())
 
-	// Register a simple handler that returns our info and ignores the request.
-	registerHandler("peerinfo", tcpNode, protocolID2,
-		func() proto.Message { return new(pbv1.PeerInfo) },
-		func(context.Context, peer.ID, proto.Message) (proto.Message, bool, error) {
-			return &pbv1.PeerInfo{
-				CharonVersion:     version.String(),
-				LockHash:          lockHash,
-				GitHash:           gitHash,
-				SentAt:            timestamppb.New(nowFunc()),
-				StartedAt:         startTime,
-				BuilderApiEnabled: builderAPIEnabled,
-			}, true, nil
-		},
-	)
+	nonce := make([]byte, nonceLen)
+	_, _ = rand.Read(nonce)
 
 	// Create log filters
 	lockHashFilters := make(map[peer.ID]z.Field)
@@ -110,22 +104,42 @@ func newInternal(tcpNode host.Host, peers []peer.ID, version version.SemVer, loc
 	for _, peerID := range peers {
 		lockHashFilters[peerID] = log.Filter()
 		versionFilters[peerID] = log.Filter()
+		peerDuplicateGauge.WithLabelValues(p2p.PeerName(peerID)).Set(0)
 	}
 
-	return &PeerInfo{
+	p := &PeerInfo{
 		sendFunc:          sendFunc,
 		tcpNode:           tcpNode,
 		peers:             peers,
 		version:           version,
 		lockHash:          lockHash,
+		gitHash:           gitHash,
 		startTime:         startTime,
+		nonce:             nonce,
 		builderAPIEnabled: builderAPIEnabled,
 		metricSubmitter:   metricSubmitter,
 		tickerProvider:    tickerProvider,
 		nowFunc:           nowFunc,
 		lockHashFilters:   lockHashFilters,
 		versionFilters:    versionFilters,
+		boots:             make(map[peer.ID]boot),
+		retired:           make(map[peer.ID]map[string]time.Time),
+		duplicates:        make(map[peer.ID]map[string]bool),
+		selfReports:       make(map[peer.ID]time.Time),
+		infos:             make(map[peer.ID]Info),
 	}
+
+	// Register a simple handler that returns our info and only observes the requesting peer's instance.
+	registerHandler("peerinfo", tcpNode, protocolID2,
+		func() proto.Message { return new(pbv1.PeerInfo) },
+		func(ctx context.Context, peerID peer.ID, req proto.Message) (proto.Message, bool, error) {
+			p.observe(ctx, peerID, req.(*pbv1.PeerInfo))
+
+			return p.newPeerInfo(peerID, nowFunc()), true, nil
+		},
+	)
+
+	return p
 }
 
 type PeerInfo struct {
@@ -136,12 +150,213 @@ type PeerInfo struct {
 	lockHash          []byte
 	gitHash           string
 	startTime         *timestamppb.Timestamp
+	nonce             []byte
 	builderAPIEnabled bool
 	tickerProvider    tickerProvider
 	metricSubmitter   metricSubmitter
 	nowFunc           func() time.Time
 	lockHashFilters   map[peer.ID]z.Field
 	versionFilters    map[peer.ID]z.Field
+
+	mu            sync.Mutex
+	boots         map[peer.ID]boot                 // Latest instance by peer.
+	retired       map[peer.ID]map[string]time.Time // First seen times of previous (restarted) instances by nonce by peer.
+	duplicates    map[peer.ID]map[string]bool      // Nonces of duplicate instances by peer.
+	selfReports   map[peer.ID]time.Time            // Latest times peers reported this node as a duplicate instance.
+	selfDuplicate bool
+	infos         map[peer.ID]Info // Latest info by peer.
+}
+
+// Info is the latest peer info received from a peer.
+type Info struct {
+	Version           string
+	GitHash           string
+	Compatible        bool
+	ClockOffset       time.Duration
+	StartedAt         time.Time
+	BuilderAPIEnabled bool
+	LockHashMatch     bool
+	UpdatedAt         time.Time
+}
+
+// Infos returns the latest peer info received from each peer, excluding peers not received from yet.
+func (p *PeerInfo) Infos() map[peer.ID]Info {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return maps.Clone(p.infos)
+}
+
+// boot identifies a running instance of a peer. It is ordered by local observation times,
+// since peer provided start times can be forged.
+type boot struct {
+	Nonce     string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// newPeerInfo returns this node's peer info message sent to the peer.
+func (p *PeerInfo) newPeerInfo(peerID peer.ID, now time.Time) *pbv1.PeerInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var duplicateNonces [][]byte
+	for nonce := range p.duplicates[peerID] {
+		duplicateNonces = append(duplicateNonces, []byte(nonce))
+	}
+
+	return &pbv1.PeerInfo{
+		CharonVersion:     p.version.String(),
+		LockHash:          p.lockHash,
+		GitHash:           p.gitHash,
+		SentAt:            timestamppb.New(now),
+		StartedAt:         p.startTime,
+		BuilderApiEnabled: p.builderAPIEnabled,
+		Nonce:             p.nonce,
+		DuplicateNonces:   duplicateNonces,
+	}
+}
+
+// DuplicatePeers returns the cluster peers that were detected to be running multiple instances with the same peer ID.
+func (p *PeerInfo) DuplicatePeers() []peer.ID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var resp []peer.ID
+	for _, peerID := range p.peers {
+		if len(p.duplicates[peerID]) > 0 {
+			resp = append(resp, peerID)
+		}
+	}
+
+	return resp
+}
+
+// CheckSelf returns an error if more than the faulty number of cluster peers recently detected that this node is
+// a duplicate instance of another node running with the same peer ID, in which case it must not participate.
+func (p *PeerInfo) CheckSelf() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.selfDuplicate {
+		return errors.New("duplicate instance of this node's peer ID detected, refusing to participate")
+	}
+
+	return nil
+}
+
+// observe records the instance of the peer identified by the nonce in its peer info message. Restarted instances
+// have new nonces, while previous nonces reappearing indicate multiple instances with the same peer ID.
+// Of conflicting instances, the one first seen later is considered the duplicate, until the original stops.
+// It also records whether the peer detected this node as a duplicate instance.
+func (p *PeerInfo) observe(ctx context.Context, peerID peer.ID, info *pbv1.PeerInfo) {
+	if info.GetStartedAt() == nil {
+		return
+	}
+
+	now := p.nowFunc()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.reportSelf(peerID, info.GetDuplicateNonces(), now)
+	p.updateSelf(ctx, now)
+
+	nonce := string(info.GetNonce())
+	if nonce == "" {
+		// Peers not sending nonces are identified by start time.
+		nonce = info.GetStartedAt().AsTime().String()
+	}
+
+	latest, ok := p.boots[peerID]
+	if !ok {
+		p.boots[peerID] = boot{Nonce: nonce, FirstSeen: now, LastSeen: now}
+		return
+	} else if nonce == latest.Nonce {
+		latest.LastSeen = now
+		p.boots[peerID] = latest
+
+		return
+	} else if p.duplicates[peerID][nonce] {
+		if now.Sub(latest.LastSeen) <= reportTTL {
+			return
+		}
+
+		// The original instance stopped, so the duplicate instance is the only remaining instance.
+		delete(p.duplicates[peerID], nonce)
+		if len(p.duplicates[peerID]) == 0 {
+			peerDuplicateGauge.WithLabelValues(p2p.PeerName(peerID)).Set(0)
+		}
+		log.Info(ctx, "Original instance of duplicate peer stopped", z.Str("peer", p2p.PeerName(peerID)))
+	}
+
+	firstSeen, ok := p.retired[peerID][nonce]
+	if !ok {
+		// New instance, the peer restarted.
+		addNonce(p.retired, peerID, latest.Nonce, latest.FirstSeen)
+		p.boots[peerID] = boot{Nonce: nonce, FirstSeen: now, LastSeen: now}
+
+		return
+	}
+
+	// A previous instance reappeared, so multiple instances are running with the same peer ID.
+	// The latest instance was first seen after all previous instances.
+	duplicate, original := latest, boot{Nonce: nonce, FirstSeen: firstSeen, LastSeen: now}
+
+	delete(p.retired[peerID], nonce)
+	addNonce(p.duplicates, peerID, duplicate.Nonce, true)
+	p.boots[peerID] = original
+	peerDuplicateGauge.WithLabelValues(p2p.PeerName(peerID)).Set(1)
+
+	log.Error(ctx, "Duplicate peer detected, multiple instances are running with the same peer ID", nil,
+		z.Str("peer", p2p.PeerName(peerID)),
+		z.Any("first_seen", original.FirstSeen),
+		z.Any("duplicate_first_seen", duplicate.FirstSeen),
+	)
+}
+
+// reportSelf records whether the peer reported this node's nonce as a duplicate instance.
+func (p *PeerInfo) reportSelf(peerID peer.ID, duplicateNonces [][]byte, now time.Time) {
+	if slices.ContainsFunc(duplicateNonces, func(nonce []byte) bool { return bytes.Equal(nonce, p.nonce) }) {
+		p.selfReports[peerID] = now
+	} else {
+		delete(p.selfReports, peerID)
+	}
+}
+
+// updateSelf flags this node as a duplicate instance if more than the faulty number of peers recently reported it,
+// since faulty peers may report it falsely. The flag is cleared when reports stop.
+func (p *PeerInfo) updateSelf(ctx context.Context, now time.Time) {
+	for peerID, reportedAt := range p.selfReports {
+		if now.Sub(reportedAt) > reportTTL {
+			delete(p.selfReports, peerID)
+		}
+	}
+
+	faulty := (len(p.peers) - 1) / 3
+	duplicate := len(p.selfReports) > faulty
+	if duplicate == p.selfDuplicate {
+		return
+	}
+
+	p.selfDuplicate = duplicate
+
+	if duplicate {
+		peerDuplicateGauge.WithLabelValues(p2p.PeerName(p.tcpNode.ID())).Set(1)
+		log.Error(ctx, "This node is a duplicate instance of another node running with the same peer ID, "+
+			"refusing to participate; stop this instance", nil, z.Int("reports", len(p.selfReports)))
+	} else {
+		peerDuplicateGauge.WithLabelValues(p2p.PeerName(p.tcpNode.ID())).Set(0)
+		log.Info(ctx, "Peers stopped reporting this node as a duplicate instance, participating again")
+	}
+}
+
+// addNonce adds the nonce and its value to the set of the peer.
+func addNonce[V any](nonces map[peer.ID]map[string]V, peerID peer.ID, nonce string, value V) {
+	if nonces[peerID] == nil {
+		nonces[peerID] = make(map[string]V)
+	}
+	nonces[peerID][nonce] = value
 }
 
 // Run runs the peer info protocol until the context is cancelled.
@@ -156,6 +371,10 @@ func (p *PeerInfo) Run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case now := <-ticks:
+			p.mu.Lock()
+			p.updateSelf(ctx, p.nowFunc())
+			p.mu.Unlock()
+
 			p.sendOnce(ctx, now)
 		}
 	}
@@ -168,14 +387,7 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 			continue // Do not send to self.
 		}
 
-		req := &pbv1.PeerInfo{
-			CharonVersion:     p.version.String(),
-			LockHash:          p.lockHash,
-			GitHash:           p.gitHash,
-			SentAt:            timestamppb.New(now),
-			StartedAt:         p.startTime,
-			BuilderApiEnabled: p.builderAPIEnabled,
-		}
+		req := p.newPeerInfo(peerID, now)
 
 		go func(peerID peer.ID) {
 			var rtt time.Duration
@@ -192,6 +404,8 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 				return
 			}
 
+			p.observe(ctx, peerID, resp)
+
 			name := p2p.PeerName(peerID)
 
 			// Validator git hash with regex.
@@ -204,7 +418,22 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 			actualSentAt := resp.GetSentAt().AsTime()
 			clockOffset := actualSentAt.Sub(expectedSentAt)
 
-			if err := supportedPeerVersion(resp.GetCharonVersion(), version.Supported()); err != nil {
+			err = supportedPeerVersion(resp.GetCharonVersion(), version.Supported())
+
+			p.mu.Lock()
+			p.infos[peerID] = Info{
+				Version:           resp.GetCharonVersion(),
+				GitHash:           resp.GetGitHash(),
+				Compatible:        err == nil,
+				ClockOffset:       clockOffset,
+				StartedAt:         resp.GetStartedAt().AsTime(),
+				BuilderAPIEnabled: resp.GetBuilderApiEnabled(),
+				LockHashMatch:     bytes.Equal(resp.GetLockHash(), p.lockHash),
+				UpdatedAt:         p.nowFunc(),
+			}
+			p.mu.Unlock()
+
+			if err != nil {
 				peerCompatibleGauge.WithLabelValues(name).Set(0) // Set to false
 
 				// Log as error since user action required
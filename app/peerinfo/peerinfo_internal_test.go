@@ -3,14 +3,18 @@
 package peerinfo
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/testutil"
@@ -99,6 +103,78 @@ func TestPeerBuilderAPIEnabledGauge(t *testing.T) {
 	}
 }
 
+func TestDuplicatePeers(t *testing.T) {
+	ctx := context.Background()
+	server := testutil.CreateHost(t, testutil.AvailableAddr(t))
+	peer1 := testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()
+	peer2 := testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()
+	peer3 := testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()
+
+	p := New(server, []peer.ID{server.ID(), peer1, peer2, peer3}, version.Version, []byte("123"), "abc", nil, false)
+
+	now := time.Now()
+	p.nowFunc = func() time.Time { return now }
+
+	info := func(nonce string, startedAt time.Time, duplicateNonces ...[]byte) *pbv1.PeerInfo {
+		return &pbv1.PeerInfo{
+			Nonce:           []byte(nonce),
+			StartedAt:       timestamppb.New(startedAt),
+			DuplicateNonces: duplicateNonces,
+		}
+	}
+
+	// Restarts result in new nonces.
+	p.observe(ctx, peer1, info("a", now))
+	p.observe(ctx, peer1, info("a", now))
+	p.observe(ctx, peer1, info("b", now.Add(time.Minute)))
+	p.observe(ctx, peer1, info("b", now.Add(time.Minute)))
+	require.Empty(t, p.DuplicatePeers())
+
+	// Reappearing nonces indicate duplicate instances. The instance first seen later is the duplicate,
+	// even if it claims an earlier start time.
+	p.observe(ctx, peer2, info("a", now))
+	p.observe(ctx, peer2, info("b", now.Add(-time.Hour)))
+	p.observe(ctx, peer2, info("a", now))
+	p.observe(ctx, peer3, info("a", now))
+	p.observe(ctx, peer3, info("b", now.Add(time.Minute)))
+	p.observe(ctx, peer3, info("a", now))
+	require.Equal(t, []peer.ID{peer2, peer3}, p.DuplicatePeers())
+
+	// The duplicate instances are reported to the peers.
+	require.Equal(t, [][]byte{[]byte("b")}, p.newPeerInfo(peer2, now).GetDuplicateNonces())
+	require.Equal(t, [][]byte{[]byte("b")}, p.newPeerInfo(peer3, now).GetDuplicateNonces())
+	require.Empty(t, p.newPeerInfo(peer1, now).GetDuplicateNonces())
+
+	// Duplicate instances are no longer reported once the original instance stopped.
+	now = now.Add(reportTTL)
+	p.observe(ctx, peer2, info("a", now))
+	now = now.Add(time.Second)
+	p.observe(ctx, peer2, info("b", now))
+	p.observe(ctx, peer3, info("b", now))
+	require.Equal(t, []peer.ID{peer2}, p.DuplicatePeers())
+
+	// This node refuses to participate when reported as a duplicate by more than the faulty number of peers.
+	require.NoError(t, p.CheckSelf())
+	p.observe(ctx, peer1, info("b", now, []byte("other")))
+	require.NoError(t, p.CheckSelf())
+	p.observe(ctx, peer1, info("b", now, p.nonce))
+	require.NoError(t, p.CheckSelf())
+	p.observe(ctx, peer2, info("a", now, p.nonce))
+	require.ErrorContains(t, p.CheckSelf(), "refusing to participate")
+
+	// This node participates again when peers stop reporting it.
+	p.observe(ctx, peer1, info("b", now))
+	require.NoError(t, p.CheckSelf())
+	p.observe(ctx, peer1, info("b", now, p.nonce))
+	require.Error(t, p.CheckSelf())
+
+	now = now.Add(reportTTL + time.Second)
+	p.mu.Lock()
+	p.updateSelf(ctx, now)
+	p.mu.Unlock()
+	require.NoError(t, p.CheckSelf())
+}
+
 func semvers(s ...string) []version.SemVer {
 	var resp []version.SemVer
 	for _, v := range s {
@@ -146,6 +146,19 @@ func TestPeerInfo(t *testing.T) {
 
 	<-ctx.Done()
 	cancel()
+
+	// Expect infos from everyone but ourselves, the incompatible ignored node may not have responded yet.
+	infos := peerInfos[0].Infos()
+	for i := 1; i < n; i++ {
+		info, ok := infos[peers[i]]
+		if !ok {
+			require.True(t, nodes[i].Ignore)
+			continue
+		}
+		require.Equal(t, nodes[i].Version.String(), info.Version)
+		require.Equal(t, !nodes[i].Ignore, info.Compatible)
+		require.Equal(t, string(nodes[i].LockHash) == string(nodes[0].LockHash), info.LockHashMatch)
+	}
 }
 
 func semver(t *testing.T, v string) version.SemVer {
@@ -141,6 +141,7 @@ func TestFuzz(t *testing.T) {
 		new(manifestpb.SignedMutationList),
 		new(manifestpb.LegacyLock),
 		new(corepb.QBFTMsg),
+		new(corepb.HotStuffConsensusMsg),
 		new(corepb.PriorityScoredResult),
 		new(corepb.SniffedConsensusInstance),
 	}
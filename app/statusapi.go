@@ -0,0 +1,326 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/dutyhistory"
+	"github.com/obolnetwork/charon/app/health"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/peerinfo"
+	"github.com/obolnetwork/charon/app/version"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/infosync"
+	"github.com/obolnetwork/charon/core/tracker"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// maxStatusDuties is the number of most recent duty outcomes served by the status API.
+const maxStatusDuties = 256
+
+// newStatusAPI returns a new status API serving the node's operational status as JSON.
+// The duty history is served from the directory if not empty.
+func newStatusAPI(cluster *manifestpb.Cluster, peers []p2p.Peer, tcpNode host.Host, peerInfo *peerinfo.PeerInfo,
+	historyDir string,
+) *statusAPI {
+	return &statusAPI{
+		cluster:    cluster,
+		peers:      peers,
+		tcpNode:    tcpNode,
+		peerInfo:   peerInfo,
+		historyDir: historyDir,
+	}
+}
+
+// statusAPI serves read-only JSON endpoints of the node's operational status on the monitoring port.
+// Components wired after the monitoring API are set before the life cycle manager starts the server.
+type statusAPI struct {
+	cluster    *manifestpb.Cluster
+	peers      []p2p.Peer
+	tcpNode    host.Host
+	peerInfo   *peerinfo.PeerInfo
+	historyDir string
+
+	checker   *health.Checker
+	readyErr  func() error
+	consensus core.ConsensusController
+	infoSync  *infosync.Component
+
+	mu     sync.Mutex
+	duties []dutyhistory.Record
+}
+
+// register registers the status endpoints with the mux.
+func (s *statusAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /status/cluster", s.serveCluster)
+	mux.HandleFunc("GET /status/peers", s.servePeers)
+	mux.HandleFunc("GET /status/consensus", s.serveConsensus)
+	mux.HandleFunc("GET /status/duties", s.serveDuties)
+	mux.HandleFunc("GET /status/duty-history", s.serveDutyHistory)
+	mux.HandleFunc("GET /status/health", s.serveHealth)
+}
+
+type clusterStatus struct {
+	Name          string            `json:"name"`
+	LockHash      string            `json:"lock_hash"`
+	Network       string            `json:"network"`
+	Threshold     int               `json:"threshold"`
+	CharonVersion string            `json:"charon_version"`
+	Validators    []validatorStatus `json:"validators"`
+	Peers         []peerStatus      `json:"peers"`
+}
+
+type validatorStatus struct {
+	PublicKey         string `json:"public_key"`
+	FeeRecipient      string `json:"fee_recipient_address"`
+	WithdrawalAddress string `json:"withdrawal_address"`
+}
+
+type peerStatus struct {
+	Index     int    `json:"index"`
+	Name      string `json:"name"`
+	PeerID    string `json:"peer_id"`
+	ENR       string `json:"enr"`
+	Self      bool   `json:"self"`
+	Connected bool   `json:"connected"`
+}
+
+func (s *statusAPI) serveCluster(w http.ResponseWriter, r *http.Request) {
+	network, err := eth2util.ForkVersionToNetwork(s.cluster.GetForkVersion())
+	if err != nil {
+		network = "unknown"
+	}
+
+	resp := clusterStatus{
+		Name:          s.cluster.GetName(),
+		LockHash:      "0x" + hex.EncodeToString(s.cluster.GetInitialMutationHash()),
+		Network:       network,
+		Threshold:     int(s.cluster.GetThreshold()),
+		CharonVersion: version.Version.String(),
+	}
+
+	for _, val := range s.cluster.GetValidators() {
+		resp.Validators = append(resp.Validators, validatorStatus{
+			PublicKey:         "0x" + hex.EncodeToString(val.GetPublicKey()),
+			FeeRecipient:      val.GetFeeRecipientAddress(),
+			WithdrawalAddress: val.GetWithdrawalAddress(),
+		})
+	}
+
+	for _, p := range s.peers {
+		var enr string
+		if p.Index < len(s.cluster.GetOperators()) {
+			enr = s.cluster.GetOperators()[p.Index].GetEnr()
+		}
+
+		resp.Peers = append(resp.Peers, peerStatus{
+			Index:     p.Index,
+			Name:      p.Name,
+			PeerID:    p.ID.String(),
+			ENR:       enr,
+			Self:      p.ID == s.tcpNode.ID(),
+			Connected: s.connected(p.ID),
+		})
+	}
+
+	writeStatusJSON(r.Context(), w, resp)
+}
+
+type peerInfoStatus struct {
+	Index             int        `json:"index"`
+	Name              string     `json:"name"`
+	Connected         bool       `json:"connected"`
+	Version           string     `json:"version,omitempty"`
+	GitHash           string     `json:"git_hash,omitempty"`
+	Compatible        bool       `json:"compatible"`
+	ClockOffset       string     `json:"clock_offset,omitempty"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	BuilderAPIEnabled bool       `json:"builder_api_enabled"`
+	LockHashMatch     bool       `json:"lock_hash_match"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+func (s *statusAPI) servePeers(w http.ResponseWriter, r *http.Request) {
+	infos := s.peerInfo.Infos()
+
+	resp := make([]peerInfoStatus, 0, len(s.peers))
+	for _, p := range s.peers {
+		if p.ID == s.tcpNode.ID() {
+			continue
+		}
+
+		status := peerInfoStatus{
+			Index:     p.Index,
+			Name:      p.Name,
+			Connected: s.connected(p.ID),
+		}
+
+		if info, ok := infos[p.ID]; ok {
+			status.Version = info.Version
+			status.GitHash = info.GitHash
+			status.Compatible = info.Compatible
+			status.ClockOffset = info.ClockOffset.String()
+			status.StartedAt = &info.StartedAt
+			status.BuilderAPIEnabled = info.BuilderAPIEnabled
+			status.LockHashMatch = info.LockHashMatch
+			status.UpdatedAt = &info.UpdatedAt
+		}
+
+		resp = append(resp, status)
+	}
+
+	writeStatusJSON(r.Context(), w, resp)
+}
+
+type consensusStatus struct {
+	CurrentProtocol  string   `json:"current_protocol,omitempty"`
+	DefaultProtocol  string   `json:"default_protocol,omitempty"`
+	ClusterProtocols []string `json:"cluster_protocols,omitempty"`
+}
+
+func (s *statusAPI) serveConsensus(w http.ResponseWriter, r *http.Request) {
+	var resp consensusStatus
+	if s.consensus != nil {
+		resp.CurrentProtocol = string(s.consensus.CurrentConsensus().ProtocolID())
+		resp.DefaultProtocol = string(s.consensus.DefaultConsensus().ProtocolID())
+	}
+
+	if s.infoSync != nil {
+		// The latest cluster wide agreed protocols, in order of priority.
+		for _, protocolID := range s.infoSync.Protocols(math.MaxUint64) {
+			resp.ClusterProtocols = append(resp.ClusterProtocols, string(protocolID))
+		}
+	}
+
+	writeStatusJSON(r.Context(), w, resp)
+}
+
+// dutyAnalysed is a tracker subscriber storing the most recent duty outcomes.
+func (s *statusAPI) dutyAnalysed(_ context.Context, outcome tracker.Outcome) {
+	record := dutyhistory.NewRecord(outcome, s.peers, time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.duties = append(s.duties, record)
+	if len(s.duties) > maxStatusDuties {
+		s.duties = s.duties[1:]
+	}
+}
+
+// serveDuties serves the most recent duty outcomes, newest first, optionally limited by the "limit" query parameter.
+func (s *statusAPI) serveDuties(w http.ResponseWriter, r *http.Request) {
+	limit := maxStatusDuties
+	if str := r.URL.Query().Get("limit"); str != "" {
+		var err error
+		limit, err = strconv.Atoi(str)
+		if err != nil || limit < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	resp := make([]dutyhistory.Record, 0, min(limit, len(s.duties)))
+	for i := len(s.duties) - 1; i >= 0 && len(resp) < limit; i-- {
+		resp = append(resp, s.duties[i])
+	}
+	s.mu.Unlock()
+
+	writeStatusJSON(r.Context(), w, resp)
+}
+
+// serveDutyHistory serves the persisted duty history, optionally filtered by the "slot" and "pubkey" query parameters.
+func (s *statusAPI) serveDutyHistory(w http.ResponseWriter, r *http.Request) {
+	if s.historyDir == "" {
+		http.Error(w, "duty history disabled", http.StatusNotFound)
+		return
+	}
+
+	var filter dutyhistory.Filter
+	for _, str := range r.URL.Query()["slot"] {
+		slot, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid slot", http.StatusBadRequest)
+			return
+		}
+		filter.Slots = append(filter.Slots, slot)
+	}
+	for _, pubkey := range r.URL.Query()["pubkey"] {
+		filter.PubKeys = append(filter.PubKeys, core.PubKey(pubkey))
+	}
+
+	records, err := dutyhistory.Query(s.historyDir, filter)
+	if err != nil {
+		log.Warn(r.Context(), "Failed querying duty history", err)
+		http.Error(w, "failed querying duty history", http.StatusInternalServerError)
+
+		return
+	}
+
+	if records == nil {
+		records = []dutyhistory.Record{}
+	}
+
+	writeStatusJSON(r.Context(), w, records)
+}
+
+type healthStatus struct {
+	Ready      bool                 `json:"ready"`
+	ReadyError string               `json:"ready_error,omitempty"`
+	Checks     []health.CheckResult `json:"checks"`
+}
+
+func (s *statusAPI) serveHealth(w http.ResponseWriter, r *http.Request) {
+	resp := healthStatus{
+		Ready:  true,
+		Checks: []health.CheckResult{},
+	}
+
+	if s.readyErr != nil {
+		if err := s.readyErr(); err != nil {
+			resp.Ready = false
+			resp.ReadyError = err.Error()
+		}
+	}
+
+	if s.checker != nil {
+		if results := s.checker.Results(); results != nil {
+			resp.Checks = results
+		}
+	}
+
+	writeStatusJSON(r.Context(), w, resp)
+}
+
+// connected returns true if the node is connected to the peer, which is always the case for itself.
+func (s *statusAPI) connected(peerID peer.ID) bool {
+	return peerID == s.tcpNode.ID() || len(s.tcpNode.Network().ConnsToPeer(peerID)) > 0
+}
+
+// writeStatusJSON writes the JSON status response.
+func writeStatusJSON(ctx context.Context, w http.ResponseWriter, resp any) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		log.Error(ctx, "Failed marshalling status response", err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
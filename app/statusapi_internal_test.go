@@ -0,0 +1,188 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/dutyhistory"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/peerinfo"
+	"github.com/obolnetwork/charon/app/version"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/tracker"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestStatusAPI(t *testing.T) {
+	const n = 3
+
+	var (
+		hosts   []host.Host
+		peers   []p2p.Peer
+		peerIDs []peer.ID
+	)
+	for i := range n {
+		h := testutil.CreateHost(t, testutil.AvailableAddr(t))
+		hosts = append(hosts, h)
+		peers = append(peers, p2p.Peer{ID: h.ID(), Index: i, Name: p2p.PeerName(h.ID())})
+		peerIDs = append(peerIDs, h.ID())
+	}
+
+	// Only connect to the second peer.
+	require.NoError(t, hosts[0].Connect(context.Background(), peer.AddrInfo{ID: hosts[1].ID(), Addrs: hosts[1].Addrs()}))
+
+	cluster := &manifestpb.Cluster{
+		Name:                "test",
+		Threshold:           2,
+		ForkVersion:         []byte{0x00, 0x00, 0x10, 0x20},
+		InitialMutationHash: []byte{0xab, 0xcd},
+		Operators:           []*manifestpb.Operator{{Enr: "enr:0"}, {Enr: "enr:1"}, {Enr: "enr:2"}},
+		Validators: []*manifestpb.Validator{{
+			PublicKey:           []byte{0x01, 0x02},
+			FeeRecipientAddress: "0xfee",
+			WithdrawalAddress:   "0xdead",
+		}},
+	}
+
+	peerInfo := peerinfo.New(hosts[0], peerIDs, version.Version, cluster.GetInitialMutationHash(), "", nil, false)
+
+	status := newStatusAPI(cluster, peers, hosts[0], peerInfo, "")
+	status.readyErr = func() error { return errors.New("not ready") }
+
+	mux := http.NewServeMux()
+	status.register(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	get := func(t *testing.T, path string, resp any) {
+		t.Helper()
+
+		res, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(res.Body).Decode(resp))
+	}
+
+	t.Run("cluster", func(t *testing.T) {
+		var resp clusterStatus
+		get(t, "/status/cluster", &resp)
+
+		require.Equal(t, "test", resp.Name)
+		require.Equal(t, "0xabcd", resp.LockHash)
+		require.Equal(t, "goerli", resp.Network)
+		require.Equal(t, 2, resp.Threshold)
+		require.Equal(t, []validatorStatus{{PublicKey: "0x0102", FeeRecipient: "0xfee", WithdrawalAddress: "0xdead"}}, resp.Validators)
+		require.Len(t, resp.Peers, n)
+		require.True(t, resp.Peers[0].Self)
+		require.True(t, resp.Peers[1].Connected)
+		require.False(t, resp.Peers[2].Connected)
+		require.Equal(t, "enr:2", resp.Peers[2].ENR)
+	})
+
+	t.Run("peers", func(t *testing.T) {
+		var resp []peerInfoStatus
+		get(t, "/status/peers", &resp)
+
+		require.Len(t, resp, n-1)
+		require.Equal(t, peers[1].Name, resp[0].Name)
+		require.True(t, resp[0].Connected)
+		require.Empty(t, resp[0].Version)
+	})
+
+	t.Run("health", func(t *testing.T) {
+		var resp healthStatus
+		get(t, "/status/health", &resp)
+
+		require.False(t, resp.Ready)
+		require.Equal(t, "not ready", resp.ReadyError)
+		require.Empty(t, resp.Checks)
+	})
+
+	t.Run("duties", func(t *testing.T) {
+		for slot := range uint64(maxStatusDuties + 2) {
+			status.dutyAnalysed(context.Background(), tracker.Outcome{
+				Duty:            core.NewAttesterDuty(slot),
+				Failed:          slot%2 == 0,
+				Step:            "bcast",
+				Participation:   map[int]int{1: 1, 2: 1},
+				ExpectedPerPeer: 1,
+			})
+		}
+
+		var resp []dutyhistory.Record
+		get(t, "/status/duties?limit=2", &resp)
+
+		require.Len(t, resp, 2)
+		require.EqualValues(t, maxStatusDuties+1, resp[0].Slot)
+		require.False(t, resp[0].Failed)
+		require.Equal(t, map[string]int{peers[0].Name: 1, peers[1].Name: 1, peers[2].Name: 0}, resp[0].Participation)
+		require.EqualValues(t, maxStatusDuties, resp[1].Slot)
+		require.True(t, resp[1].Failed)
+
+		get(t, "/status/duties", &resp)
+		require.Len(t, resp, maxStatusDuties)
+		require.EqualValues(t, 2, resp[len(resp)-1].Slot)
+
+		res, err := http.Get(srv.URL + "/status/duties?limit=x")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("consensus", func(t *testing.T) {
+		var resp consensusStatus
+		get(t, "/status/consensus", &resp)
+
+		require.Empty(t, resp.CurrentProtocol)
+	})
+}
+
+func TestStatusAPIDutyHistory(t *testing.T) {
+	dir := t.TempDir()
+	pubkey := testutil.RandomCorePubKey(t)
+
+	history, err := dutyhistory.New(dir, 1<<20, nil)
+	require.NoError(t, err)
+	for slot := range uint64(3) {
+		history.Add(context.Background(), tracker.Outcome{
+			Duty:    core.NewAttesterDuty(slot),
+			PubKeys: []core.PubKey{pubkey},
+		})
+	}
+
+	mux := http.NewServeMux()
+	newStatusAPI(nil, nil, nil, nil, dir).register(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/status/duty-history?slot=1&slot=2&pubkey=" + string(pubkey))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var records []dutyhistory.Record
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&records))
+	require.Len(t, records, 2)
+	require.EqualValues(t, 1, records[0].Slot)
+	require.EqualValues(t, 2, records[1].Slot)
+
+	mux = http.NewServeMux()
+	newStatusAPI(nil, nil, nil, nil, "").register(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status/duty-history", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -6,17 +6,27 @@ package tracer
 import (
 	"context"
 	"io"
-	"net"
+	"net/url"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Supported OTLP exporter protocols.
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
 )
 
 // tracer is the global app level tracer, it defaults to a noop tracer.
@@ -29,16 +39,23 @@ func Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption)
 }
 
 // RootedCtx returns a copy of the parent context containing a tracing span context
-// rooted to the trace ID. All spans started from the context will be rooted to the trace ID.
+// rooted to the trace ID. All spans started from the context will be rooted to the trace ID
+// and be children of a remote parent span derived from the trace ID. Spans of different processes
+// rooted to the same trace ID therefore join up as siblings in a single trace.
 func RootedCtx(ctx context.Context, traceID trace.TraceID) context.Context {
+	var spanID trace.SpanID
+	copy(spanID[:], traceID[len(traceID)-len(spanID):])
+
 	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
 		TraceID: traceID,
+		SpanID:  spanID,
+		Remote:  true,
 	}))
 }
 
 // Init initialises the global tracer via the option(s) defaulting to a noop tracer. It returns a shutdown function.
 func Init(opts ...func(*options)) (func(context.Context) error, error) {
-	var o options
+	o := options{sampleRatio: 1}
 	for _, opt := range opts {
 		opt(&o)
 	}
@@ -54,18 +71,21 @@ func Init(opts ...func(*options)) (func(context.Context) error, error) {
 		return nil, err
 	}
 
-	tp := newTraceProvider(exp, o.jaegerService)
+	tp := newTraceProvider(exp, o)
 
 	// Set globals
 	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
 	tracer = tp.Tracer("")
 
 	return tp.Shutdown, nil
 }
 
 type options struct {
-	jaegerService string
-	expFunc       func() (sdktrace.SpanExporter, error)
+	service     string
+	sampleRatio float64
+	attrs       []attribute.KeyValue
+	expFunc     func() (sdktrace.SpanExporter, error)
 }
 
 // WithStdOut returns an option to configure an OpenTelemetry exporter for tracing
@@ -75,7 +95,7 @@ func WithStdOut(w io.Writer) func(*options) {
 		o.expFunc = func() (sdktrace.SpanExporter, error) {
 			ex, err := stdouttrace.New(stdouttrace.WithWriter(w))
 			if err != nil {
-				return nil, errors.Wrap(err, "jaeger exporter")
+				return nil, errors.Wrap(err, "stdout exporter")
 			}
 
 			return ex, nil
@@ -83,38 +103,66 @@ func WithStdOut(w io.Writer) func(*options) {
 	}
 }
 
-// WithJaegerOrNoop returns an option to configure an OpenTelemetry tracing exporter for Jaeger
+// WithOTLPOrNoop returns an option to configure an OpenTelemetry tracing exporter for an OTLP collector
 // if the address is not empty, else the default noop tracer is retained.
-func WithJaegerOrNoop(jaegerAddr string) func(*options) {
-	if jaegerAddr == "" {
+func WithOTLPOrNoop(protocol, addr string) func(*options) {
+	if addr == "" {
 		return func(*options) {}
 	}
 
-	return WithJaeger(jaegerAddr)
+	return WithOTLP(protocol, addr)
+}
+
+// WithServiceName returns an option to configure the traced service name.
+func WithServiceName(service string) func(*options) {
+	return func(o *options) {
+		o.service = service
+	}
+}
+
+// WithSampleRatio returns an option to configure the ratio of traces sampled, it defaults to 1.
+// Sampling decisions are based on trace IDs, so traces rooted to the same trace ID are
+// consistently sampled by all processes.
+func WithSampleRatio(ratio float64) func(*options) {
+	return func(o *options) {
+		o.sampleRatio = ratio
+	}
 }
 
-// WithJaegerService returns an option to configure the jaeger service name.
-func WithJaegerService(service string) func(*options) {
+// WithAttributes returns an option to add resource attributes identifying the traced process.
+func WithAttributes(attrs ...attribute.KeyValue) func(*options) {
 	return func(o *options) {
-		o.jaegerService = service
+		o.attrs = append(o.attrs, attrs...)
 	}
 }
 
-// WithJaeger returns an option to configure an OpenTelemetry tracing exporter for Jaeger.
-func WithJaeger(addr string) func(*options) {
+// WithOTLP returns an option to configure an OpenTelemetry tracing exporter for an OTLP collector
+// at the endpoint URL via either the "grpc" or "http" protocol. Plain text is used for "http" URLs.
+func WithOTLP(protocol, addr string) func(*options) {
 	return func(o *options) {
 		o.expFunc = func() (sdktrace.SpanExporter, error) {
-			host, port, err := net.SplitHostPort(addr)
+			u, err := url.Parse(addr)
 			if err != nil {
-				return nil, errors.Wrap(err, "parse jaeger address (host:port)")
+				return nil, errors.Wrap(err, "parse otlp address")
+			} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, errors.New("invalid otlp address, expected http(s)://host:port", z.Str("address", addr))
 			}
 
-			ex, err := jaeger.New(jaeger.WithAgentEndpoint(
-				jaeger.WithAgentHost(host),
-				jaeger.WithAgentPort(port),
-			))
+			var ex sdktrace.SpanExporter
+			switch protocol {
+			case ProtocolGRPC:
+				ex, err = otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpointURL(addr))
+			case ProtocolHTTP:
+				opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(addr)}
+				if u.Path == "" || u.Path == "/" {
+					opts = append(opts, otlptracehttp.WithURLPath("/v1/traces"))
+				}
+				ex, err = otlptracehttp.New(context.Background(), opts...)
+			default:
+				return nil, errors.New("unsupported otlp protocol", z.Str("protocol", protocol))
+			}
 			if err != nil {
-				return nil, errors.Wrap(err, "jaeger exporter")
+				return nil, errors.Wrap(err, "otlp exporter", z.Str("protocol", protocol))
 			}
 
 			return ex, nil
@@ -122,14 +170,15 @@ func WithJaeger(addr string) func(*options) {
 	}
 }
 
-func newTraceProvider(exp sdktrace.SpanExporter, service string) *sdktrace.TracerProvider {
-	r := resource.NewWithAttributes(
-		semconv.SchemaURL,
-		semconv.ServiceNameKey.String(service),
-	)
+func newTraceProvider(exp sdktrace.SpanExporter, o options) *sdktrace.TracerProvider {
+	attrs := append([]attribute.KeyValue{semconv.ServiceNameKey.String(o.service)}, o.attrs...)
+	r := resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+
+	// Sample by trace ID ratio both new roots and remote parents, which is what duty traces are rooted to.
+	sampler := sdktrace.TraceIDRatioBased(o.sampleRatio)
 
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler, sdktrace.WithRemoteParentNotSampled(sampler))),
 		sdktrace.WithBatcher(exp),
 		sdktrace.WithResource(r),
 	)
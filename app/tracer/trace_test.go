@@ -6,10 +6,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/obolnetwork/charon/app/tracer"
 )
@@ -48,6 +55,88 @@ func TestStdOutTracer(t *testing.T) {
 	require.Equal(t, "root", m["Name"])
 }
 
+func TestOTLPHTTPTracer(t *testing.T) {
+	ctx := context.Background()
+
+	reqs := make(chan *coltracepb.ExportTraceServiceRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req := new(coltracepb.ExportTraceServiceRequest)
+		require.NoError(t, proto.Unmarshal(b, req))
+		reqs <- req
+	}))
+	defer srv.Close()
+
+	stop, err := tracer.Init(
+		tracer.WithOTLP(tracer.ProtocolHTTP, srv.URL),
+		tracer.WithServiceName("node0"),
+		tracer.WithAttributes(attribute.String("cluster_hash", "abcdef0")),
+	)
+	require.NoError(t, err)
+
+	_, span := tracer.Start(ctx, "root")
+	span.End()
+
+	require.NoError(t, stop(ctx))
+
+	req := <-reqs
+	require.Len(t, req.GetResourceSpans(), 1)
+
+	attrs := make(map[string]string)
+	for _, attr := range req.GetResourceSpans()[0].GetResource().GetAttributes() {
+		attrs[attr.GetKey()] = attr.GetValue().GetStringValue()
+	}
+	require.Equal(t, "node0", attrs["service.name"])
+	require.Equal(t, "abcdef0", attrs["cluster_hash"])
+
+	spans := req.GetResourceSpans()[0].GetScopeSpans()[0].GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "root", spans[0].GetName())
+}
+
+func TestOTLPInvalid(t *testing.T) {
+	_, err := tracer.Init(tracer.WithOTLP("udp", "http://localhost:4317"))
+	require.ErrorContains(t, err, "unsupported otlp protocol")
+
+	_, err = tracer.Init(tracer.WithOTLP(tracer.ProtocolGRPC, "localhost:4317"))
+	require.ErrorContains(t, err, "invalid otlp address")
+}
+
+func TestRootedCtx(t *testing.T) {
+	ctx := context.Background()
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	for _, ratio := range []float64{0, 1} {
+		stop, err := tracer.Init(tracer.WithStdOut(io.Discard), tracer.WithSampleRatio(ratio))
+		require.NoError(t, err)
+
+		_, span1 := tracer.Start(tracer.RootedCtx(ctx, traceID), "span1")
+		_, span2 := tracer.Start(tracer.RootedCtx(ctx, traceID), "span2")
+
+		require.Equal(t, traceID, span1.SpanContext().TraceID())
+		require.Equal(t, traceID, span2.SpanContext().TraceID())
+		require.NotEqual(t, span1.SpanContext().SpanID(), span2.SpanContext().SpanID())
+		require.Equal(t, ratio == 1, span1.SpanContext().IsSampled())
+		require.Equal(t, ratio == 1, span2.SpanContext().IsSampled())
+
+		// Sampled spans rooted to the same trace ID share a common remote parent.
+		if ratio == 1 {
+			parent1 := span1.(sdktrace.ReadOnlySpan).Parent()
+			parent2 := span2.(sdktrace.ReadOnlySpan).Parent()
+			require.True(t, parent1.IsRemote())
+			require.Equal(t, parent1.SpanID(), parent2.SpanID())
+		}
+
+		span1.End()
+		span2.End()
+		require.NoError(t, stop(ctx))
+	}
+}
+
 func inner(ctx context.Context) {
 	var span trace.Span
 	_, span = tracer.Start(ctx, "inner")
@@ -4,6 +4,7 @@ package app
 
 import (
 	"context"
+	"crypto/sha256"
 	"sync"
 	"time"
 
@@ -17,6 +18,9 @@ import (
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/sharesigner"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
 	"github.com/obolnetwork/charon/testutil/validatormock" // Allow testutil
 )
 
@@ -26,7 +30,7 @@ func wireValidatorMock(ctx context.Context, conf Config, eth2Cl eth2wrap.Client,
 		return nil
 	}
 
-	signer, err := newVMockSigner(conf, pubshares)
+	signer, err := newVMockSigner(ctx, conf, pubshares)
 	if err != nil {
 		return err
 	}
@@ -104,34 +108,57 @@ func newVMockEth2Provider(conf Config, pubshares []eth2p0.BLSPubKey) func() (eth
 	}
 }
 
-// newVMockSigner returns a validator mock sign function using keystore loaded from disk.
-func newVMockSigner(conf Config, pubshares []eth2p0.BLSPubKey) (validatormock.SignFunc, error) {
-	secrets := conf.TestConfig.SimnetKeys
-	if len(secrets) == 0 {
-		keyFiles, err := keystore.LoadFilesUnordered(conf.SimnetValidatorKeysDir)
+// newVMockSigner returns a validator mock sign function using keystore loaded from disk
+// or using the remote signer if configured.
+func newVMockSigner(ctx context.Context, conf Config, pubshares []eth2p0.BLSPubKey) (validatormock.SignFunc, error) {
+	var (
+		shareSigner sharesigner.Signer
+		err         error
+	)
+	if conf.SimnetRemoteSignerAddr != "" {
+		shareSigner, err = sharesigner.NewRemote(conf.SimnetRemoteSignerAddr)
 		if err != nil {
 			return nil, err
 		}
+	} else {
+		secrets := conf.TestConfig.SimnetKeys
+		if len(secrets) == 0 {
+			keyFiles, err := keystore.LoadFilesUnordered(conf.SimnetValidatorKeysDir)
+			if err != nil {
+				return nil, err
+			}
 
-		secrets, err = keyFiles.SequencedKeys()
+			secrets, err = keyFiles.SequencedKeys()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(secrets) == 0 && len(pubshares) != 0 {
+			return nil, errors.New("validator mock keys empty")
+		}
+		if len(secrets) < len(pubshares) {
+			return nil, errors.New("some validator mock keys missing", z.Int("expect", len(pubshares)), z.Int("found", len(secrets)))
+		}
+
+		shareSigner, err = sharesigner.NewLocal(secrets...)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	signer, err := validatormock.NewSigner(secrets...)
-	if err != nil {
-		return nil, err
-	}
+	signer := func(pubshare eth2p0.BLSPubKey, msg []byte) (eth2p0.BLSSignature, error) {
+		sig, err := shareSigner.Sign(ctx, tbls.PublicKey(pubshare), msg)
+		if err != nil {
+			return eth2p0.BLSSignature{}, err
+		}
 
-	if len(secrets) == 0 && len(pubshares) != 0 {
-		return nil, errors.New("validator mock keys empty")
-	}
-	if len(secrets) < len(pubshares) {
-		return nil, errors.New("some validator mock keys missing", z.Int("expect", len(pubshares)), z.Int("found", len(secrets)))
+		return tblsconv.SigToETH2(sig), nil
 	}
+
+	testRoot := sha256.Sum256([]byte("test signing"))
 	for i, pubshare := range pubshares {
-		_, err := signer(pubshare, []byte("test signing"))
+		_, err := signer(pubshare, testRoot[:])
 		if err != nil {
 			return nil, errors.Wrap(err, "validator mock key missing", z.Int("index", i))
 		}
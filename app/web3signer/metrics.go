@@ -0,0 +1,16 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package web3signer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var requestCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "app",
+	Subsystem: "web3signer",
+	Name:      "requests_total",
+	Help:      "Total number of web3signer signing requests by type and result (signed, refused, invalid or error)",
+}, []string{"type", "result"})
@@ -0,0 +1,590 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package web3signer provides a Web3Signer (https://docs.web3signer.consensys.io/) compatible remote signing
+// server for validator clients, signing with the node's validator key shares.
+package web3signer
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// Web3Signer signing request types.
+const (
+	typeBlock                  = "BLOCK_V2"
+	typeAttestation            = "ATTESTATION"
+	typeAggregateAndProof      = "AGGREGATE_AND_PROOF"
+	typeContributionAndProof   = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+	typeRandaoReveal           = "RANDAO_REVEAL"
+	typeAggregationSlot        = "AGGREGATION_SLOT"
+	typeSyncCommitteeMessage   = "SYNC_COMMITTEE_MESSAGE"
+	typeSyncCommitteeSelection = "SYNC_COMMITTEE_SELECTION_PROOF"
+	typeValidatorRegistration  = "VALIDATOR_REGISTRATION"
+	typeVoluntaryExit          = "VOLUNTARY_EXIT"
+	typeDeposit                = "DEPOSIT"
+)
+
+// awaitTimeout is the maximum duration to wait for consensus data in the duty database.
+const awaitTimeout = 5 * time.Second
+
+// errNoConsensus is returned if the signing request data doesn't match the cluster's consensus data.
+var errNoConsensus = errors.New("signing data doesn't match cluster consensus data")
+
+// Share is a validator key share.
+type Share struct {
+	// PubKey is the distributed validator public key of the share.
+	PubKey core.PubKey
+	// Secret is the secret key share.
+	Secret tbls.PrivateKey
+}
+
+// NewRouter returns a Web3Signer compatible router authenticated by the bearer token signing with the key shares
+// by public share. Blocks, attestations, aggregates and sync committee contributions are only signed if they
+// match the cluster's consensus data in the duty database, which also provides slashing protection.
+// Randao reveals and selection proofs are only signed for scheduled duties of the validator, sync committee
+// messages only for the beacon node's head block and builder registrations only for the validator's fee recipient
+// and gas limit. Voluntary exits and deposits are not signed.
+func NewRouter(ctx context.Context, token string, eth2Cl eth2wrap.Client, shares map[eth2p0.BLSPubKey]Share,
+	dutyDB core.DutyDB, dutyDefFunc func(context.Context, core.Duty) (core.DutyDefinitionSet, error),
+	feeRecipientFunc func(core.PubKey) string, gasLimitFunc func(core.PubKey) uint64,
+) http.Handler {
+	ctx = log.WithTopic(ctx, "web3signer")
+
+	s := server{
+		eth2Cl:           eth2Cl,
+		shares:           shares,
+		dutyDB:           dutyDB,
+		dutyDefFunc:      dutyDefFunc,
+		feeRecipientFunc: feeRecipientFunc,
+		gasLimitFunc:     gasLimitFunc,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/eth2/publicKeys", func(w http.ResponseWriter, _ *http.Request) {
+		s.publicKeys(ctx, w)
+	})
+	mux.HandleFunc("POST /api/v1/eth2/sign/{identifier}", func(w http.ResponseWriter, r *http.Request) {
+		s.sign(ctx, w, r)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Upcheck doesn't require authentication, since it is used by health checks.
+		if r.Method == http.MethodGet && r.URL.Path == "/upcheck" {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("OK"))
+
+			return
+		}
+
+		if !authorised(r, token) {
+			writeError(ctx, w, http.StatusUnauthorized, errors.New("unauthorised"))
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// server implements the Web3Signer handlers.
+type server struct {
+	eth2Cl           eth2wrap.Client
+	shares           map[eth2p0.BLSPubKey]Share
+	dutyDB           core.DutyDB
+	dutyDefFunc      func(context.Context, core.Duty) (core.DutyDefinitionSet, error)
+	feeRecipientFunc func(core.PubKey) string
+	gasLimitFunc     func(core.PubKey) uint64
+}
+
+// signRequest is a Web3Signer signing request, only the fields of the request type are populated.
+type signRequest struct {
+	Type                 string                       `json:"type"`
+	SigningRoot          *eth2p0.Root                 `json:"signingRoot,omitempty"`
+	BeaconBlock          *blockRequest                `json:"beacon_block,omitempty"`
+	Attestation          *eth2p0.AttestationData      `json:"attestation,omitempty"`
+	AggregateAndProof    *eth2p0.AggregateAndProof    `json:"aggregate_and_proof,omitempty"`
+	ContributionAndProof *altair.ContributionAndProof `json:"contribution_and_proof,omitempty"`
+	RandaoReveal         *struct {
+		Epoch string `json:"epoch"`
+	} `json:"randao_reveal,omitempty"`
+	AggregationSlot *struct {
+		Slot string `json:"slot"`
+	} `json:"aggregation_slot,omitempty"`
+	SyncCommitteeMessage *struct {
+		BeaconBlockRoot eth2p0.Root `json:"beacon_block_root"`
+		Slot            string      `json:"slot"`
+	} `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *struct {
+		Slot              string `json:"slot"`
+		SubcommitteeIndex string `json:"subcommittee_index"`
+	} `json:"sync_aggregator_selection_data,omitempty"`
+	ValidatorRegistration *eth2v1.ValidatorRegistration `json:"validator_registration,omitempty"`
+}
+
+// blockRequest is the block of a BLOCK_V2 signing request, only block headers are supported.
+type blockRequest struct {
+	Version     string                    `json:"version"`
+	BlockHeader *eth2p0.BeaconBlockHeader `json:"block_header"`
+}
+
+// publicKeys writes the public shares available for signing.
+func (s server) publicKeys(ctx context.Context, w http.ResponseWriter) {
+	resp := make([]string, 0, len(s.shares))
+	for pubshare := range s.shares {
+		resp = append(resp, "0x"+hex.EncodeToString(pubshare[:]))
+	}
+
+	writeJSON(ctx, w, resp)
+}
+
+// sign writes the signature of the signing request by the identifier's key share.
+func (s server) sign(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	pubshare, err := decodePubKey(r.PathValue("identifier"))
+	if err != nil {
+		writeError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	share, ok := s.shares[pubshare]
+	if !ok {
+		writeError(ctx, w, http.StatusNotFound, errors.New("public key not found"))
+		return
+	}
+
+	var req signRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(ctx, w, http.StatusBadRequest, errors.Wrap(err, "decode signing request"))
+		return
+	}
+
+	ctx = log.WithCtx(ctx, z.Str("type", req.Type))
+
+	reqCtx, cancel := context.WithTimeout(r.Context(), awaitTimeout)
+	defer cancel()
+
+	domain, epoch, msgRoot, err := s.messageRoot(reqCtx, share.PubKey, req)
+	if errors.Is(err, errNoConsensus) {
+		requestCounter.WithLabelValues(req.Type, "refused").Inc()
+		writeError(ctx, w, http.StatusPreconditionFailed, err)
+
+		return
+	} else if err != nil {
+		requestCounter.WithLabelValues(req.Type, "invalid").Inc()
+		writeError(ctx, w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	sigRoot, err := signing.GetDataRoot(reqCtx, s.eth2Cl, domain, epoch, msgRoot)
+	if err != nil {
+		requestCounter.WithLabelValues(req.Type, "error").Inc()
+		writeError(ctx, w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	if req.SigningRoot != nil && *req.SigningRoot != sigRoot {
+		requestCounter.WithLabelValues(req.Type, "invalid").Inc()
+		writeError(ctx, w, http.StatusBadRequest, errors.New("mismatching signing root"))
+
+		return
+	}
+
+	sig, err := tbls.Sign(share.Secret, sigRoot[:])
+	if err != nil {
+		requestCounter.WithLabelValues(req.Type, "error").Inc()
+		writeError(ctx, w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	requestCounter.WithLabelValues(req.Type, "signed").Inc()
+
+	sigHex := "0x" + hex.EncodeToString(sig[:])
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		writeJSON(ctx, w, struct {
+			Signature string `json:"signature"`
+		}{Signature: sigHex})
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(sigHex))
+}
+
+// messageRoot returns the domain, epoch and message root of the validator's signing request after verifying
+// consensus data against the duty database, or the validator's duties, beacon node head or proposer config.
+func (s server) messageRoot(ctx context.Context, pubkey core.PubKey, req signRequest) (signing.DomainName, eth2p0.Epoch, eth2p0.Root, error) {
+	slotsPerEpoch, err := s.eth2Cl.SlotsPerEpoch(ctx)
+	if err != nil {
+		return "", 0, eth2p0.Root{}, err
+	}
+	epochOf := func(slot eth2p0.Slot) eth2p0.Epoch {
+		return eth2p0.Epoch(uint64(slot) / slotsPerEpoch)
+	}
+
+	switch req.Type {
+	case typeBlock:
+		if req.BeaconBlock == nil || req.BeaconBlock.BlockHeader == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing block header, only BLOCK_V2 block headers are supported")
+		}
+		header := req.BeaconBlock.BlockHeader
+
+		root, err := header.HashTreeRoot()
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "hash block header")
+		}
+
+		proposal, err := s.dutyDB.AwaitProposal(ctx, uint64(header.Slot))
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(errNoConsensus, "await proposal", z.Str("reason", err.Error()))
+		}
+
+		consensusRoot, err := proposal.Root()
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "proposal root")
+		} else if consensusRoot != root {
+			return "", 0, eth2p0.Root{}, errNoConsensus
+		}
+
+		return signing.DomainBeaconProposer, epochOf(header.Slot), root, nil
+	case typeAttestation:
+		if req.Attestation == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing attestation")
+		}
+
+		commIdx := uint64(req.Attestation.Index)
+		if commIdx == 0 { // Electra attestation data doesn't contain the committee index.
+			commIdx, err = s.attesterCommittee(ctx, req.Attestation.Slot, pubkey)
+			if err != nil {
+				return "", 0, eth2p0.Root{}, err
+			}
+		}
+
+		attData, err := s.dutyDB.AwaitAttestation(ctx, uint64(req.Attestation.Slot), commIdx)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(errNoConsensus, "await attestation", z.Str("reason", err.Error()))
+		}
+
+		root, err := verifyRoot(req.Attestation, attData)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, err
+		}
+
+		return signing.DomainBeaconAttester, req.Attestation.Target.Epoch, root, nil
+	case typeAggregateAndProof:
+		aggProof := req.AggregateAndProof
+		if aggProof == nil || aggProof.Aggregate == nil || aggProof.Aggregate.Data == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing aggregate and proof")
+		}
+
+		dataRoot, err := aggProof.Aggregate.Data.HashTreeRoot()
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "hash attestation data")
+		}
+
+		// Only pre-electra aggregates are supported, their data root already commits to the committee index.
+		aggAtt, err := s.dutyDB.AwaitAggAttestation(ctx, uint64(aggProof.Aggregate.Data.Slot), 0, dataRoot)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(errNoConsensus, "await aggregate attestation", z.Str("reason", err.Error()))
+		}
+
+		if _, err := verifyRoot(aggProof.Aggregate, aggAtt); err != nil {
+			return "", 0, eth2p0.Root{}, err
+		}
+
+		root, err := aggProof.HashTreeRoot()
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "hash aggregate and proof")
+		}
+
+		return signing.DomainAggregateAndProof, epochOf(aggProof.Aggregate.Data.Slot), root, nil
+	case typeContributionAndProof:
+		contribProof := req.ContributionAndProof
+		if contribProof == nil || contribProof.Contribution == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing contribution and proof")
+		}
+		contrib := contribProof.Contribution
+
+		consensus, err := s.dutyDB.AwaitSyncContribution(ctx, uint64(contrib.Slot), contrib.SubcommitteeIndex, contrib.BeaconBlockRoot)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(errNoConsensus, "await sync contribution", z.Str("reason", err.Error()))
+		}
+
+		if _, err := verifyRoot(contrib, consensus); err != nil {
+			return "", 0, eth2p0.Root{}, err
+		}
+
+		root, err := contribProof.HashTreeRoot()
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "hash contribution and proof")
+		}
+
+		return signing.DomainContributionAndProof, epochOf(contrib.Slot), root, nil
+	case typeRandaoReveal:
+		if req.RandaoReveal == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing randao reveal")
+		}
+
+		epoch, err := strconv.ParseUint(req.RandaoReveal.Epoch, 10, 64)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "parse epoch")
+		}
+
+		// Randao reveals are only required by proposers.
+		var scheduled bool
+		for slot := epoch * slotsPerEpoch; slot < (epoch+1)*slotsPerEpoch && !scheduled; slot++ {
+			scheduled, err = s.isScheduled(ctx, core.NewProposerDuty(slot), pubkey)
+			if err != nil {
+				return "", 0, eth2p0.Root{}, err
+			}
+		}
+		if !scheduled {
+			return "", 0, eth2p0.Root{}, errors.Wrap(errNoConsensus, "no proposer duty in epoch")
+		}
+
+		root, err := eth2util.SlotHashRoot(eth2p0.Slot(epoch))
+		if err != nil {
+			return "", 0, eth2p0.Root{}, err
+		}
+
+		return signing.DomainRandao, eth2p0.Epoch(epoch), root, nil
+	case typeAggregationSlot:
+		if req.AggregationSlot == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing aggregation slot")
+		}
+
+		slot, err := strconv.ParseUint(req.AggregationSlot.Slot, 10, 64)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "parse slot")
+		}
+
+		// Selection proofs are only required by attesters.
+		if err := s.verifyScheduled(ctx, core.NewAttesterDuty(slot), pubkey); err != nil {
+			return "", 0, eth2p0.Root{}, err
+		}
+
+		root, err := eth2util.SlotHashRoot(eth2p0.Slot(slot))
+		if err != nil {
+			return "", 0, eth2p0.Root{}, err
+		}
+
+		return signing.DomainSelectionProof, epochOf(eth2p0.Slot(slot)), root, nil
+	case typeSyncCommitteeMessage:
+		if req.SyncCommitteeMessage == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing sync committee message")
+		}
+
+		slot, err := strconv.ParseUint(req.SyncCommitteeMessage.Slot, 10, 64)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "parse slot")
+		}
+
+		// Sync committee members are scheduled sync contribution duties every slot.
+		if err := s.verifyScheduled(ctx, core.NewSyncContributionDuty(slot), pubkey); err != nil {
+			return "", 0, eth2p0.Root{}, err
+		}
+
+		// Sync committee messages vote for the beacon node's head block, which isn't agreed by cluster consensus.
+		eth2Resp, err := s.eth2Cl.BeaconBlockRoot(ctx, &eth2api.BeaconBlockRootOpts{Block: "head"})
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "fetch head block root")
+		} else if *eth2Resp.Data != req.SyncCommitteeMessage.BeaconBlockRoot {
+			return "", 0, eth2p0.Root{}, errors.Wrap(errNoConsensus, "mismatching head block root")
+		}
+
+		return signing.DomainSyncCommittee, epochOf(eth2p0.Slot(slot)), req.SyncCommitteeMessage.BeaconBlockRoot, nil
+	case typeSyncCommitteeSelection:
+		if req.SyncAggregatorSelectionData == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing sync aggregator selection data")
+		}
+
+		slot, err := strconv.ParseUint(req.SyncAggregatorSelectionData.Slot, 10, 64)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "parse slot")
+		}
+
+		subcommIdx, err := strconv.ParseUint(req.SyncAggregatorSelectionData.SubcommitteeIndex, 10, 64)
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "parse subcommittee index")
+		}
+
+		// Sync committee selection proofs are only required by sync committee members.
+		if err := s.verifyScheduled(ctx, core.NewSyncContributionDuty(slot), pubkey); err != nil {
+			return "", 0, eth2p0.Root{}, err
+		}
+
+		root, err := (&altair.SyncAggregatorSelectionData{
+			Slot:              eth2p0.Slot(slot),
+			SubcommitteeIndex: subcommIdx,
+		}).HashTreeRoot()
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "hash sync aggregator selection data")
+		}
+
+		return signing.DomainSyncCommitteeSelectionProof, epochOf(eth2p0.Slot(slot)), root, nil
+	case typeValidatorRegistration:
+		reg := req.ValidatorRegistration
+		if reg == nil {
+			return "", 0, eth2p0.Root{}, errors.New("missing validator registration")
+		}
+
+		// Registrations must match the validator's proposer config, including proposer overrides.
+		if core.PubKeyFrom48Bytes(reg.Pubkey) != pubkey {
+			return "", 0, eth2p0.Root{}, errors.New("mismatching registration public key")
+		} else if !strings.EqualFold(fmt.Sprintf("%#x", reg.FeeRecipient), s.feeRecipientFunc(pubkey)) {
+			return "", 0, eth2p0.Root{}, errors.Wrap(errNoConsensus, "mismatching registration fee recipient")
+		} else if reg.GasLimit != s.gasLimitFunc(pubkey) {
+			return "", 0, eth2p0.Root{}, errors.Wrap(errNoConsensus, "mismatching registration gas limit")
+		}
+
+		root, err := reg.HashTreeRoot()
+		if err != nil {
+			return "", 0, eth2p0.Root{}, errors.Wrap(err, "hash validator registration")
+		}
+
+		// Always use epoch 0 for DomainApplicationBuilder.
+		return signing.DomainApplicationBuilder, 0, root, nil
+	case typeVoluntaryExit, typeDeposit:
+		return "", 0, eth2p0.Root{}, errors.New("signing request type not allowed, use charon exit and deposit commands",
+			z.Str("type", req.Type))
+	default:
+		return "", 0, eth2p0.Root{}, errors.New("unsupported signing request type", z.Str("type", req.Type))
+	}
+}
+
+// verifyScheduled returns errNoConsensus if the duty isn't scheduled for the validator.
+func (s server) verifyScheduled(ctx context.Context, duty core.Duty, pubkey core.PubKey) error {
+	scheduled, err := s.isScheduled(ctx, duty, pubkey)
+	if err != nil {
+		return err
+	} else if !scheduled {
+		return errors.Wrap(errNoConsensus, "duty not scheduled", z.Any("duty", duty))
+	}
+
+	return nil
+}
+
+// isScheduled returns true if the duty is scheduled for the validator or errNoConsensus if
+// the duty's epoch isn't resolved.
+func (s server) isScheduled(ctx context.Context, duty core.Duty, pubkey core.PubKey) (bool, error) {
+	defSet, err := s.dutyDefFunc(ctx, duty)
+	if errors.Is(err, core.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(errNoConsensus, "get duty definition", z.Str("reason", err.Error()))
+	}
+
+	_, ok := defSet[pubkey]
+
+	return ok, nil
+}
+
+// attesterCommittee returns the committee index of the validator's attester duty in the slot
+// or errNoConsensus if the duty isn't scheduled.
+func (s server) attesterCommittee(ctx context.Context, slot eth2p0.Slot, pubkey core.PubKey) (uint64, error) {
+	duty := core.NewAttesterDuty(uint64(slot))
+
+	defSet, err := s.dutyDefFunc(ctx, duty)
+	if err != nil && !errors.Is(err, core.ErrNotFound) {
+		return 0, errors.Wrap(errNoConsensus, "get duty definition", z.Str("reason", err.Error()))
+	}
+
+	def, ok := defSet[pubkey].(core.AttesterDefinition)
+	if !ok {
+		return 0, errors.Wrap(errNoConsensus, "duty not scheduled", z.Any("duty", duty))
+	}
+
+	return uint64(def.CommitteeIndex), nil
+}
+
+// hashRooter is a SSZ hash tree root provider.
+type hashRooter interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+// verifyRoot returns the hash tree root of the requested data or errNoConsensus if it differs from the consensus data.
+func verifyRoot(requested, consensus hashRooter) (eth2p0.Root, error) {
+	root, err := requested.HashTreeRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "hash requested data")
+	}
+
+	consensusRoot, err := consensus.HashTreeRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "hash consensus data")
+	}
+
+	if root != consensusRoot {
+		return eth2p0.Root{}, errNoConsensus
+	}
+
+	return root, nil
+}
+
+// decodePubKey returns the 0x-prefixed hex encoded public key.
+func decodePubKey(s string) (eth2p0.BLSPubKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return eth2p0.BLSPubKey{}, errors.Wrap(err, "decode public key")
+	} else if len(b) != len(eth2p0.BLSPubKey{}) {
+		return eth2p0.BLSPubKey{}, errors.New("invalid public key length")
+	}
+
+	return eth2p0.BLSPubKey(b), nil
+}
+
+// authorised returns true if the request contains the bearer token.
+func authorised(r *http.Request, token string) bool {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+}
+
+// writeJSON writes the JSON response.
+func writeJSON(ctx context.Context, w http.ResponseWriter, resp any) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		writeError(ctx, w, http.StatusInternalServerError, errors.Wrap(err, "marshal response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if _, err := w.Write(b); err != nil {
+		log.Error(ctx, "Failed writing web3signer response", err)
+	}
+}
+
+// writeError writes a Web3Signer error response.
+func writeError(ctx context.Context, w http.ResponseWriter, code int, err error) {
+	log.Warn(ctx, "Web3signer request failed", err, z.Int("code", code))
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(err.Error()))
+}
@@ -0,0 +1,259 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package web3signer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/web3signer"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+const token = "secret"
+
+func TestSign(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New(beaconmock.WithSlotsPerEpoch(32))
+	require.NoError(t, err)
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubshare, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	pubkey := testutil.RandomCorePubKey(t)
+	feeRecipient := testutil.RandomExecutionAddress()
+	const gasLimit = 36000000
+
+	db := dutydb.NewMemDB(new(testDeadliner))
+
+	const slot, commIdx = 64, 1
+	attData := testutil.RandomAttestationDataSeed(testutil.NewSeedRand())
+	attData.Slot = slot
+	attData.Index = commIdx
+	attData.Target.Epoch = 2
+
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{
+		testutil.RandomCorePubKey(t): core.AttestationData{
+			Data: *attData,
+			Duty: eth2v1.AttesterDuty{CommitteeIndex: commIdx, CommitteeLength: 4, CommitteesAtSlot: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	// Electra attestation data doesn't contain the committee index.
+	const electraSlot = 80
+	electraAttData := testutil.RandomAttestationDataSeed(testutil.NewSeedRand())
+	electraAttData.Slot = electraSlot
+	electraAttData.Index = 0
+	electraAttData.Target.Epoch = 2
+	electraDuty := &eth2v1.AttesterDuty{Slot: electraSlot, CommitteeIndex: commIdx, CommitteeLength: 4, CommitteesAtSlot: 2}
+
+	err = db.Store(ctx, core.NewAttesterDuty(electraSlot), core.UnsignedDataSet{
+		testutil.RandomCorePubKey(t): core.AttestationData{Data: *electraAttData, Duty: *electraDuty},
+	})
+	require.NoError(t, err)
+
+	// The validator proposes in slot 97 of epoch 3, attests in slots 64 and 80 and is a sync committee member in slot 66.
+	dutyDefFunc := func(_ context.Context, duty core.Duty) (core.DutyDefinitionSet, error) {
+		if duty == core.NewAttesterDuty(electraSlot) {
+			return core.DutyDefinitionSet{pubkey: core.NewAttesterDefinition(electraDuty)}, nil
+		}
+		if duty == core.NewProposerDuty(97) || duty == core.NewAttesterDuty(slot) || duty == core.NewSyncContributionDuty(66) {
+			return core.DutyDefinitionSet{pubkey: nil}, nil
+		}
+
+		return nil, errors.Wrap(core.ErrNotFound, "duty not found")
+	}
+
+	srv := httptest.NewServer(web3signer.NewRouter(ctx, token, bmock,
+		map[eth2p0.BLSPubKey]web3signer.Share{eth2p0.BLSPubKey(pubshare): {PubKey: pubkey, Secret: secret}}, db,
+		dutyDefFunc,
+		func(core.PubKey) string { return fmt.Sprintf("%#x", feeRecipient) },
+		func(core.PubKey) uint64 { return gasLimit },
+	))
+	defer srv.Close()
+
+	sign := func(identifier string, body any) (int, string) {
+		t.Helper()
+
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/eth2/sign/%s", srv.URL, identifier), bytes.NewReader(b))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(respBody)
+	}
+
+	// verifySig verifies the signature of the message root.
+	verifySig := func(sigHex string, domain signing.DomainName, epoch eth2p0.Epoch, msgRoot eth2p0.Root) {
+		t.Helper()
+
+		b, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+		require.NoError(t, err)
+
+		sigRoot, err := signing.GetDataRoot(ctx, bmock, domain, epoch, msgRoot)
+		require.NoError(t, err)
+
+		require.NoError(t, tbls.Verify(pubshare, sigRoot[:], tbls.Signature(b)))
+	}
+
+	identifier := fmt.Sprintf("%#x", pubshare[:])
+
+	// Consensus attestation data is signed.
+	code, sig := sign(identifier, map[string]any{"type": "ATTESTATION", "attestation": attData})
+	require.Equal(t, http.StatusOK, code, sig)
+	attRoot, err := attData.HashTreeRoot()
+	require.NoError(t, err)
+	verifySig(sig, signing.DomainBeaconAttester, attData.Target.Epoch, attRoot)
+
+	// Other attestation data is refused.
+	other := *attData
+	other.BeaconBlockRoot = testutil.RandomRoot()
+	code, _ = sign(identifier, map[string]any{"type": "ATTESTATION", "attestation": &other})
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	// Electra attestation data is found by the validator's committee.
+	code, sig = sign(identifier, map[string]any{"type": "ATTESTATION", "attestation": electraAttData})
+	require.Equal(t, http.StatusOK, code, sig)
+	electraAttRoot, err := electraAttData.HashTreeRoot()
+	require.NoError(t, err)
+	verifySig(sig, signing.DomainBeaconAttester, electraAttData.Target.Epoch, electraAttRoot)
+
+	// Randao reveals require a proposer duty in the epoch.
+	code, sig = sign(identifier, map[string]any{"type": "RANDAO_REVEAL", "randao_reveal": map[string]string{"epoch": "3"}})
+	require.Equal(t, http.StatusOK, code, sig)
+	epochRoot, err := eth2util.SlotHashRoot(3)
+	require.NoError(t, err)
+	verifySig(sig, signing.DomainRandao, 3, epochRoot)
+
+	code, _ = sign(identifier, map[string]any{"type": "RANDAO_REVEAL", "randao_reveal": map[string]string{"epoch": "4"}})
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	// Selection proofs require a duty in the slot.
+	code, sig = sign(identifier, map[string]any{"type": "AGGREGATION_SLOT", "aggregation_slot": map[string]string{"slot": "64"}})
+	require.Equal(t, http.StatusOK, code, sig)
+	slotRoot, err := eth2util.SlotHashRoot(slot)
+	require.NoError(t, err)
+	verifySig(sig, signing.DomainSelectionProof, 2, slotRoot)
+
+	code, _ = sign(identifier, map[string]any{"type": "AGGREGATION_SLOT", "aggregation_slot": map[string]string{"slot": "65"}})
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	code, _ = sign(identifier, map[string]any{
+		"type":                           "SYNC_COMMITTEE_SELECTION_PROOF",
+		"sync_aggregator_selection_data": map[string]string{"slot": "64", "subcommittee_index": "0"},
+	})
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	// Sync committee messages require the beacon node's head block root.
+	head, err := bmock.BeaconBlockRoot(ctx, &eth2api.BeaconBlockRootOpts{Block: "head"})
+	require.NoError(t, err)
+	code, sig = sign(identifier, map[string]any{
+		"type":                   "SYNC_COMMITTEE_MESSAGE",
+		"sync_committee_message": map[string]any{"beacon_block_root": head.Data, "slot": "66"},
+	})
+	require.Equal(t, http.StatusOK, code, sig)
+	verifySig(sig, signing.DomainSyncCommittee, 2, *head.Data)
+
+	code, _ = sign(identifier, map[string]any{
+		"type":                   "SYNC_COMMITTEE_MESSAGE",
+		"sync_committee_message": map[string]any{"beacon_block_root": eth2p0.Root(testutil.RandomRoot()), "slot": "66"},
+	})
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	// Registrations must match the proposer config.
+	reg := &eth2v1.ValidatorRegistration{
+		FeeRecipient: feeRecipient,
+		GasLimit:     gasLimit,
+		Timestamp:    time.Unix(1700000000, 0),
+	}
+	reg.Pubkey, err = pubkey.ToETH2()
+	require.NoError(t, err)
+	code, sig = sign(identifier, map[string]any{"type": "VALIDATOR_REGISTRATION", "validator_registration": reg})
+	require.Equal(t, http.StatusOK, code, sig)
+	regRoot, err := reg.HashTreeRoot()
+	require.NoError(t, err)
+	verifySig(sig, signing.DomainApplicationBuilder, 0, regRoot)
+
+	reg.GasLimit++
+	code, _ = sign(identifier, map[string]any{"type": "VALIDATOR_REGISTRATION", "validator_registration": reg})
+	require.Equal(t, http.StatusPreconditionFailed, code)
+
+	// Invalid signing roots are refused.
+	code, _ = sign(identifier, map[string]any{
+		"type":          "RANDAO_REVEAL",
+		"randao_reveal": map[string]string{"epoch": "3"},
+		"signingRoot":   eth2p0.Root(testutil.RandomRoot()),
+	})
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// Voluntary exits are not signed.
+	code, _ = sign(identifier, map[string]any{"type": "VOLUNTARY_EXIT"})
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// Unknown keys are not found.
+	code, _ = sign(fmt.Sprintf("%#x", testutil.RandomEth2PubKey(t)), map[string]any{"type": "ATTESTATION"})
+	require.Equal(t, http.StatusNotFound, code)
+
+	// Requests require the bearer token, except upcheck.
+	resp, err := http.Get(srv.URL + "/api/v1/eth2/publicKeys")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "/upcheck")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/eth2/publicKeys", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var pubkeys []string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pubkeys))
+	require.Equal(t, []string{identifier}, pubkeys)
+}
+
+type testDeadliner struct{}
+
+func (testDeadliner) Add(core.Duty) bool { return true }
+
+func (testDeadliner) C() <-chan core.Duty { return nil }
@@ -32,10 +32,19 @@ const (
 	TypeNodeApprovals MutationType = "dv/node_approvals/v0.0.1"
 	TypeGenValidators MutationType = "dv/gen_validators/v0.0.1"
 	TypeAddValidators MutationType = "dv/add_validators/v0.0.1"
+
+	// Operator mutations are proposals approved by a quorum of operators, see NewApprovedMutation.
+	TypeRemoveOperator  MutationType = "dv/remove_operator/v0.0.1"
+	TypeReplaceOperator MutationType = "dv/replace_operator/v0.0.1"
+	TypeChangeThreshold MutationType = "dv/change_threshold/v0.0.1"
+	TypeSetFeeRecipient MutationType = "dv/set_fee_recipient/v0.0.1"
+	TypeReshare         MutationType = "dv/reshare/v0.0.1"
 )
 
 type mutationDef struct {
 	TransformFunc func(*manifestpb.Cluster, *manifestpb.SignedMutation) (*manifestpb.Cluster, error)
+	// Approved is true if the mutation is a proposal that must be approved by a quorum of operators.
+	Approved bool
 }
 
 var mutationDefs = make(map[MutationType]mutationDef)
@@ -66,4 +75,29 @@ func init() {
 	mutationDefs[TypeAddValidators] = mutationDef{
 		TransformFunc: transformAddValidators,
 	}
+
+	mutationDefs[TypeRemoveOperator] = mutationDef{
+		TransformFunc: transformRemoveOperator,
+		Approved:      true,
+	}
+
+	mutationDefs[TypeReplaceOperator] = mutationDef{
+		TransformFunc: transformReplaceOperator,
+		Approved:      true,
+	}
+
+	mutationDefs[TypeChangeThreshold] = mutationDef{
+		TransformFunc: transformChangeThreshold,
+		Approved:      true,
+	}
+
+	mutationDefs[TypeSetFeeRecipient] = mutationDef{
+		TransformFunc: transformSetFeeRecipient,
+		Approved:      true,
+	}
+
+	mutationDefs[TypeReshare] = mutationDef{
+		TransformFunc: transformReshare,
+		Approved:      true,
+	}
 }
@@ -0,0 +1,124 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+// NewSetFeeRecipient returns a new unsigned set fee recipient mutation proposal for the validator.
+// The optional builder registration must be signed by the validator for the new fee recipient.
+// The proposal must be approved by a quorum of operators, see NewApprovedMutation.
+func NewSetFeeRecipient(parent []byte, pubkey []byte, feeRecipient string,
+	reg *eth2api.VersionedSignedValidatorRegistration,
+) (*manifestpb.SignedMutation, error) {
+	if _, err := tblsconv.PubkeyFromBytes(pubkey); err != nil {
+		return nil, errors.Wrap(err, "invalid validator public key")
+	}
+
+	if feeRecipient == "" {
+		return nil, errors.New("empty fee recipient address")
+	} else if _, err := from0xHex(feeRecipient, 20); err != nil {
+		return nil, errors.Wrap(err, "validate fee recipient address")
+	}
+
+	var regJSON []byte
+	if reg != nil {
+		var err error
+		regJSON, err = json.Marshal(reg)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal builder registration")
+		}
+	}
+
+	return newProposal(parent, TypeSetFeeRecipient, &manifestpb.SetFeeRecipient{
+		PublicKey:               pubkey,
+		FeeRecipientAddress:     feeRecipient,
+		BuilderRegistrationJson: regJSON,
+	})
+}
+
+// transformSetFeeRecipient transforms the cluster manifest by updating a validator's fee recipient.
+// The validator's pre-generated builder registration is replaced by the one provided by the mutation (if any).
+func transformSetFeeRecipient(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	set := new(manifestpb.SetFeeRecipient)
+	if err := verifyApprovedMutation(c, signed, TypeSetFeeRecipient, set); err != nil {
+		return c, errors.Wrap(err, "verify approved mutation")
+	}
+
+	var val *manifestpb.Validator
+	for _, v := range c.GetValidators() {
+		if bytes.Equal(v.GetPublicKey(), set.GetPublicKey()) {
+			val = v
+			break
+		}
+	}
+	if val == nil {
+		return c, errors.New("validator not found", z.Str("pubkey", to0xHex(set.GetPublicKey())))
+	}
+
+	if set.GetFeeRecipientAddress() == "" {
+		return c, errors.New("empty fee recipient address")
+	}
+
+	feeRecipient, err := from0xHex(set.GetFeeRecipientAddress(), 20)
+	if err != nil {
+		return c, errors.Wrap(err, "validate fee recipient address")
+	}
+
+	if len(set.GetBuilderRegistrationJson()) > 0 {
+		err := verifyBuilderRegistration(c.GetForkVersion(), set.GetPublicKey(), feeRecipient, set.GetBuilderRegistrationJson())
+		if err != nil {
+			return c, err
+		}
+	}
+
+	val.FeeRecipientAddress = set.GetFeeRecipientAddress()
+	val.BuilderRegistrationJson = set.GetBuilderRegistrationJson()
+
+	return c, nil
+}
+
+// verifyBuilderRegistration returns an error if the json-formatted builder registration
+// isn't for the validator and fee recipient or isn't signed by the validator.
+func verifyBuilderRegistration(forkVersion, pubkey, feeRecipient, regJSON []byte) error {
+	reg := new(eth2api.VersionedSignedValidatorRegistration)
+	if err := json.Unmarshal(regJSON, reg); err != nil {
+		return errors.Wrap(err, "unmarshal builder registration")
+	} else if reg.V1 == nil || reg.V1.Message == nil {
+		return errors.New("invalid builder registration")
+	}
+
+	if !bytes.Equal(reg.V1.Message.Pubkey[:], pubkey) {
+		return errors.New("builder registration validator mismatch")
+	} else if !bytes.Equal(reg.V1.Message.FeeRecipient[:], feeRecipient) {
+		return errors.New("builder registration fee recipient mismatch")
+	}
+
+	sigRoot, err := registration.GetMessageSigningRoot(reg.V1.Message, eth2p0.Version(forkVersion))
+	if err != nil {
+		return err
+	}
+
+	tblsPubkey, err := tblsconv.PubkeyFromBytes(pubkey)
+	if err != nil {
+		return errors.Wrap(err, "pubkey from bytes")
+	}
+
+	if err := tbls.Verify(tblsPubkey, sigRoot[:], tbls.Signature(reg.V1.Signature)); err != nil {
+		return errors.Wrap(err, "verify builder registration signature")
+	}
+
+	return nil
+}
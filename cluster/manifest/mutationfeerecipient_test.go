@@ -0,0 +1,139 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -update -run=TestSetFeeRecipient
+
+func TestSetFeeRecipient(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, shares := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	const feeRecipient = "0x000000000000000000000000000000000000dEaD"
+
+	secret, err := tbls.RecoverSecret(map[int]tbls.PrivateKey{1: shares[1][0], 2: shares[1][1], 3: shares[1][2]}, 4, 3)
+	require.NoError(t, err)
+	reg := signRegistration(t, secret, feeRecipient, lock.ForkVersion)
+
+	parent := testutil.RandomBytes32Seed(random)
+	proposal, err := manifest.NewSetFeeRecipient(parent, lock.Validators[1].PubKey, feeRecipient, reg)
+	require.NoError(t, err)
+
+	setFeeRecipient := approve(t, proposal, secrets[:3]...)
+
+	t.Run("proto", func(t *testing.T) {
+		testutil.RequireGoldenProto(t, setFeeRecipient)
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		cluster, err = manifest.Transform(cluster, setFeeRecipient)
+		require.NoError(t, err)
+
+		require.Equal(t, lock.ValidatorAddresses[0].FeeRecipientAddress, cluster.GetValidators()[0].GetFeeRecipientAddress())
+		require.Equal(t, feeRecipient, cluster.GetValidators()[1].GetFeeRecipientAddress())
+
+		regJSON, err := json.Marshal(reg)
+		require.NoError(t, err)
+		require.JSONEq(t, string(regJSON), string(cluster.GetValidators()[1].GetBuilderRegistrationJson()))
+	})
+
+	t.Run("without registration", func(t *testing.T) {
+		proposal, err := manifest.NewSetFeeRecipient(parent, lock.Validators[1].PubKey, feeRecipient, nil)
+		require.NoError(t, err)
+
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		cluster, err = manifest.Transform(cluster, approve(t, proposal, secrets[:3]...))
+		require.NoError(t, err)
+		require.Equal(t, feeRecipient, cluster.GetValidators()[1].GetFeeRecipientAddress())
+		require.Empty(t, cluster.GetValidators()[1].GetBuilderRegistrationJson())
+	})
+
+	t.Run("registration for other validator", func(t *testing.T) {
+		proposal, err := manifest.NewSetFeeRecipient(parent, lock.Validators[0].PubKey, feeRecipient, reg)
+		require.NoError(t, err)
+
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(cluster, approve(t, proposal, secrets[:3]...))
+		require.ErrorContains(t, err, "builder registration validator mismatch")
+	})
+
+	t.Run("registration for other fee recipient", func(t *testing.T) {
+		reg := signRegistration(t, secret, lock.ValidatorAddresses[1].FeeRecipientAddress, lock.ForkVersion)
+		proposal, err := manifest.NewSetFeeRecipient(parent, lock.Validators[1].PubKey, feeRecipient, reg)
+		require.NoError(t, err)
+
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(cluster, approve(t, proposal, secrets[:3]...))
+		require.ErrorContains(t, err, "builder registration fee recipient mismatch")
+	})
+
+	t.Run("invalid registration signature", func(t *testing.T) {
+		// Signed by a share instead of the validator key.
+		reg := signRegistration(t, shares[1][0], feeRecipient, lock.ForkVersion)
+		reg.V1.Message.Pubkey = eth2p0.BLSPubKey(lock.Validators[1].PubKey)
+		proposal, err := manifest.NewSetFeeRecipient(parent, lock.Validators[1].PubKey, feeRecipient, reg)
+		require.NoError(t, err)
+
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(cluster, approve(t, proposal, secrets[:3]...))
+		require.ErrorContains(t, err, "verify builder registration signature")
+	})
+}
+
+// signRegistration returns a builder registration for the fee recipient signed by the secret.
+func signRegistration(t *testing.T, secret tbls.PrivateKey, feeRecipient string, forkVersion []byte) *eth2api.VersionedSignedValidatorRegistration {
+	t.Helper()
+
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	msg, err := registration.NewMessage(eth2p0.BLSPubKey(pubkey), feeRecipient, registration.DefaultGasLimit,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	sigRoot, err := registration.GetMessageSigningRoot(msg, eth2p0.Version(forkVersion))
+	require.NoError(t, err)
+
+	sig, err := tbls.Sign(secret, sigRoot[:])
+	require.NoError(t, err)
+
+	return &eth2api.VersionedSignedValidatorRegistration{
+		Version: eth2spec.BuilderVersionV1,
+		V1: &eth2v1.SignedValidatorRegistration{
+			Message:   msg,
+			Signature: eth2p0.BLSSignature(sig),
+		},
+	}
+}
@@ -0,0 +1,431 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"bytes"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+// curve is the BLS12-381 G1 curve of validator public keys.
+var curve = curves.BLS12381G1()
+
+// NewRemoveOperator returns a new unsigned remove operator mutation proposal.
+// The pubShares are the reshared public shares of the remaining operators, ordered by validator.
+// The proposal must be approved by a quorum of operators, see NewApprovedMutation.
+func NewRemoveOperator(parent []byte, operatorENR string, pubShares [][][]byte) (*manifestpb.SignedMutation, error) {
+	if _, err := enr.Parse(operatorENR); err != nil {
+		return nil, errors.Wrap(err, "parse operator enr")
+	}
+
+	return newProposal(parent, TypeRemoveOperator, &manifestpb.RemoveOperator{
+		Enr:                operatorENR,
+		ValidatorPubShares: pubSharesToProto(pubShares),
+	})
+}
+
+// NewReplaceOperator returns a new unsigned replace operator mutation proposal.
+// The proposal must be approved by a quorum of operators, see NewApprovedMutation.
+func NewReplaceOperator(parent []byte, oldENR, newENR string) (*manifestpb.SignedMutation, error) {
+	if _, err := enr.Parse(oldENR); err != nil {
+		return nil, errors.Wrap(err, "parse old operator enr")
+	} else if _, err := enr.Parse(newENR); err != nil {
+		return nil, errors.Wrap(err, "parse new operator enr")
+	} else if oldENR == newENR {
+		return nil, errors.New("identical old and new operator enr")
+	}
+
+	return newProposal(parent, TypeReplaceOperator, &manifestpb.ReplaceOperator{
+		OldEnr: oldENR,
+		NewEnr: newENR,
+	})
+}
+
+// NewChangeThreshold returns a new unsigned change threshold mutation proposal.
+// The pubShares are the reshared public shares of the operators, ordered by validator.
+// The proposal must be approved by a quorum of operators, see NewApprovedMutation.
+func NewChangeThreshold(parent []byte, threshold int, pubShares [][][]byte) (*manifestpb.SignedMutation, error) {
+	if threshold < 2 {
+		return nil, errors.New("threshold must be greater than 1", z.Int("threshold", threshold))
+	}
+
+	return newProposal(parent, TypeChangeThreshold, &manifestpb.ChangeThreshold{
+		Threshold:          int32(threshold),
+		ValidatorPubShares: pubSharesToProto(pubShares),
+	})
+}
+
+// NewApprovedMutation returns a new approved mutation from the provided mutation proposal and
+// node approvals of the proposal. Note the approvals must be from a quorum of cluster operators.
+func NewApprovedMutation(proposal *manifestpb.SignedMutation, approvals []*manifestpb.SignedMutation) (*manifestpb.SignedMutation, error) {
+	if err := verifyEmptySig(proposal); err != nil {
+		return nil, errors.Wrap(err, "verify empty sig")
+	}
+
+	typ := MutationType(proposal.GetMutation().GetType())
+	if !typ.Valid() || !mutationDefs[typ].Approved {
+		return nil, errors.New("invalid proposal mutation type")
+	}
+
+	if len(approvals) == 0 {
+		return nil, errors.New("empty node approvals")
+	}
+
+	hash, err := Hash(proposal)
+	if err != nil {
+		return nil, errors.Wrap(err, "hash proposal")
+	}
+
+	for i, approval := range approvals {
+		if !bytes.Equal(hash, approval.GetMutation().GetParent()) {
+			return nil, errors.New("invalid node approval parent", z.Int("index", i))
+		}
+
+		if err := verifyNodeApproval(approval); err != nil {
+			return nil, errors.Wrap(err, "verify node approval", z.Int("index", i))
+		}
+	}
+
+	dataAny, err := anypb.New(&manifestpb.ApprovedMutation{
+		Proposal:  proposal.GetMutation().GetData(),
+		Approvals: approvals,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal approved mutation")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: proposal.GetMutation().GetParent(),
+			Type:   string(typ),
+			Data:   dataAny,
+		},
+		// Approved mutations have no signer or signature.
+	}, nil
+}
+
+// newProposal returns a new unsigned mutation proposal of the provided type and data.
+func newProposal(parent []byte, typ MutationType, data proto.Message) (*manifestpb.SignedMutation, error) {
+	if len(parent) != hashLen {
+		return nil, errors.New("invalid parent hash")
+	}
+
+	dataAny, err := anypb.New(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal proposal")
+	}
+
+	return &manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: parent,
+			Type:   string(typ),
+			Data:   dataAny,
+		},
+		// Proposals have no signer or signature.
+	}, nil
+}
+
+// verifyApprovedMutation verifies that the approved mutation is approved by a quorum of cluster operators
+// and unmarshals the approved proposal data into the provided proposal.
+func verifyApprovedMutation(c *manifestpb.Cluster, signed *manifestpb.SignedMutation, typ MutationType, proposal proto.Message) error {
+	if err := verifyEmptySig(signed); err != nil {
+		return errors.Wrap(err, "verify empty sig")
+	}
+
+	if MutationType(signed.GetMutation().GetType()) != typ {
+		return errors.New("invalid mutation type")
+	}
+
+	approved := new(manifestpb.ApprovedMutation)
+	if err := signed.GetMutation().GetData().UnmarshalTo(approved); err != nil {
+		return errors.Wrap(err, "unmarshal approved mutation")
+	}
+
+	// Approvals are for the hash of the unsigned proposal.
+	hash, err := Hash(&manifestpb.SignedMutation{
+		Mutation: &manifestpb.Mutation{
+			Parent: signed.GetMutation().GetParent(),
+			Type:   string(typ),
+			Data:   approved.GetProposal(),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "hash proposal")
+	}
+
+	if err := verifyQuorumApprovals(c, hash, approved.GetApprovals()); err != nil {
+		return err
+	}
+
+	if err := approved.GetProposal().UnmarshalTo(proposal); err != nil {
+		return errors.Wrap(err, "unmarshal proposal")
+	}
+
+	return nil
+}
+
+// verifyQuorumApprovals returns an error if the node approvals of the parent hash
+// are not from a quorum (threshold) of distinct cluster operators.
+func verifyQuorumApprovals(c *manifestpb.Cluster, parent []byte, approvals []*manifestpb.SignedMutation) error {
+	peers, err := ClusterPeers(c)
+	if err != nil {
+		return errors.Wrap(err, "get peers")
+	}
+
+	operators := make(map[string]bool)
+	for _, p := range peers {
+		pubkey, err := p.PublicKey()
+		if err != nil {
+			return errors.Wrap(err, "get peer public key")
+		}
+
+		operators[string(pubkey.SerializeCompressed())] = true
+	}
+
+	approved := make(map[string]bool)
+	for i, approval := range approvals {
+		if !bytes.Equal(parent, approval.GetMutation().GetParent()) {
+			return errors.New("invalid node approval parent", z.Int("index", i))
+		}
+
+		signer := string(approval.GetSigner())
+		if !operators[signer] {
+			return errors.New("node approval signer not a cluster operator", z.Int("index", i))
+		} else if approved[signer] {
+			return errors.New("duplicate node approval", z.Int("index", i))
+		}
+
+		if err := verifyNodeApproval(approval); err != nil {
+			return errors.Wrap(err, "verify node approval", z.Int("index", i))
+		}
+
+		approved[signer] = true
+	}
+
+	if len(approved) < int(c.GetThreshold()) {
+		return errors.New("insufficient node approvals for quorum",
+			z.Int("approvals", len(approved)), z.Int("quorum", int(c.GetThreshold())))
+	}
+
+	return nil
+}
+
+// transformRemoveOperator transforms the cluster manifest by removing an operator.
+func transformRemoveOperator(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	remove := new(manifestpb.RemoveOperator)
+	if err := verifyApprovedMutation(c, signed, TypeRemoveOperator, remove); err != nil {
+		return c, errors.Wrap(err, "verify approved mutation")
+	}
+
+	idx, ok := operatorIndex(c, remove.GetEnr())
+	if !ok {
+		return c, errors.New("operator to remove not found", z.Str("enr", remove.GetEnr()))
+	}
+
+	remaining := len(c.GetOperators()) - 1
+	if remaining < int(c.GetThreshold()) {
+		return c, errors.New("insufficient remaining operators for threshold",
+			z.Int("remaining", remaining), z.Int("threshold", int(c.GetThreshold())))
+	}
+
+	if err := verifyValidatorPubShares(c, remove.GetValidatorPubShares(), remaining, int(c.GetThreshold())); err != nil {
+		return c, err
+	}
+
+	var operators []*manifestpb.Operator
+	operators = append(operators, c.GetOperators()[:idx]...)
+	operators = append(operators, c.GetOperators()[idx+1:]...)
+	c.Operators = operators
+
+	setValidatorPubShares(c, remove.GetValidatorPubShares())
+
+	return c, nil
+}
+
+// transformReplaceOperator transforms the cluster manifest by replacing an operator's ENR.
+func transformReplaceOperator(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	replace := new(manifestpb.ReplaceOperator)
+	if err := verifyApprovedMutation(c, signed, TypeReplaceOperator, replace); err != nil {
+		return c, errors.Wrap(err, "verify approved mutation")
+	}
+
+	idx, ok := operatorIndex(c, replace.GetOldEnr())
+	if !ok {
+		return c, errors.New("operator to replace not found", z.Str("enr", replace.GetOldEnr()))
+	}
+
+	if _, ok := operatorIndex(c, replace.GetNewEnr()); ok {
+		return c, errors.New("new operator already in cluster", z.Str("enr", replace.GetNewEnr()))
+	}
+
+	if _, err := enr.Parse(replace.GetNewEnr()); err != nil {
+		return c, errors.Wrap(err, "parse new operator enr")
+	}
+
+	c.Operators[idx] = &manifestpb.Operator{
+		Address: c.GetOperators()[idx].GetAddress(),
+		Enr:     replace.GetNewEnr(),
+	}
+
+	return c, nil
+}
+
+// transformChangeThreshold transforms the cluster manifest by changing the threshold.
+func transformChangeThreshold(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	change := new(manifestpb.ChangeThreshold)
+	if err := verifyApprovedMutation(c, signed, TypeChangeThreshold, change); err != nil {
+		return c, errors.Wrap(err, "verify approved mutation")
+	}
+
+	threshold := int(change.GetThreshold())
+	if err := verifyThreshold(threshold, len(c.GetOperators())); err != nil {
+		return c, err
+	} else if threshold == int(c.GetThreshold()) {
+		return c, errors.New("threshold unchanged", z.Int("threshold", threshold))
+	}
+
+	if err := verifyValidatorPubShares(c, change.GetValidatorPubShares(), len(c.GetOperators()), threshold); err != nil {
+		return c, err
+	}
+
+	c.Threshold = change.GetThreshold()
+	setValidatorPubShares(c, change.GetValidatorPubShares())
+
+	return c, nil
+}
+
+// operatorIndex returns the index of the operator with the provided ENR and true, or false if not found.
+func operatorIndex(c *manifestpb.Cluster, operatorENR string) (int, bool) {
+	for i, operator := range c.GetOperators() {
+		if operator.GetEnr() == operatorENR {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// verifyThreshold returns an error if the threshold is invalid for the number of operators.
+func verifyThreshold(threshold, operators int) error {
+	if threshold < 2 {
+		return errors.New("threshold must be greater than 1", z.Int("threshold", threshold))
+	} else if threshold > operators {
+		return errors.New("threshold cannot be greater than number of operators",
+			z.Int("threshold", threshold), z.Int("operators", operators))
+	}
+
+	return nil
+}
+
+// verifyValidatorPubShares returns an error if the reshared public shares don't contain a valid public share
+// per operator for each cluster validator that interpolate to the validator's public key at the threshold.
+func verifyValidatorPubShares(c *manifestpb.Cluster, pubShares []*manifestpb.ValidatorPubShares, operators, threshold int) error {
+	if len(pubShares) != len(c.GetValidators()) {
+		return errors.New("invalid number of validator public shares",
+			z.Int("expect", len(c.GetValidators())), z.Int("actual", len(pubShares)))
+	}
+
+	for i, shares := range pubShares {
+		if len(shares.GetPubShares()) != operators {
+			return errors.New("invalid number of public shares",
+				z.Int("validator", i), z.Int("expect", operators), z.Int("actual", len(shares.GetPubShares())))
+		}
+
+		for _, share := range shares.GetPubShares() {
+			if _, err := tblsconv.PubkeyFromBytes(share); err != nil {
+				return errors.Wrap(err, "invalid public share", z.Int("validator", i))
+			}
+		}
+
+		if err := verifyPubShareInterpolation(c.GetValidators()[i].GetPublicKey(), shares.GetPubShares(), threshold); err != nil {
+			return errors.Wrap(err, "invalid public shares", z.Int("validator", i))
+		}
+	}
+
+	return nil
+}
+
+// verifyPubShareInterpolation returns an error if the public shares (ordered by share index starting at 1) aren't
+// evaluations of a polynomial of degree threshold-1 with the public key as constant term. In other words,
+// it ensures that any threshold of the corresponding secret shares recovers the validator's secret key.
+func verifyPubShareInterpolation(pubkey []byte, pubShares [][]byte, threshold int) error {
+	var points []curves.Point
+	for _, share := range pubShares {
+		point, err := curve.Point.FromAffineCompressed(share)
+		if err != nil {
+			return errors.Wrap(err, "decode public share")
+		}
+		points = append(points, point)
+	}
+
+	expect, err := curve.Point.FromAffineCompressed(pubkey)
+	if err != nil {
+		return errors.Wrap(err, "decode public key")
+	}
+
+	// The first threshold public shares define the polynomial, which must match the public key and remaining shares.
+	for x := 0; x <= len(points); x++ {
+		if x > 0 && x <= threshold {
+			continue
+		} else if x > 0 {
+			expect = points[x-1]
+		}
+
+		if !interpolate(points[:threshold], x).Equal(expect) {
+			if x == 0 {
+				return errors.New("public shares don't interpolate to validator public key")
+			}
+
+			return errors.New("public share doesn't interpolate", z.Int("share_idx", x))
+		}
+	}
+
+	return nil
+}
+
+// interpolate returns the lagrange interpolation at x of the points with x-coordinates 1 to len(points).
+func interpolate(points []curves.Point, x int) curves.Point {
+	resp := curve.Point.Identity()
+	for i, point := range points {
+		xi := i + 1
+		num, den := curve.Scalar.One(), curve.Scalar.One()
+		for j := range points {
+			xj := j + 1
+			if xj == xi {
+				continue
+			}
+			num = num.Mul(curve.Scalar.New(x - xj))
+			den = den.Mul(curve.Scalar.New(xi - xj))
+		}
+
+		resp = resp.Add(point.Mul(num.Div(den)))
+	}
+
+	return resp
+}
+
+// setValidatorPubShares replaces the public shares of the cluster validators.
+func setValidatorPubShares(c *manifestpb.Cluster, pubShares []*manifestpb.ValidatorPubShares) {
+	for i, val := range c.GetValidators() {
+		val.PubShares = pubShares[i].GetPubShares()
+	}
+}
+
+// pubSharesToProto converts validator public shares to protobuf.
+func pubSharesToProto(pubShares [][][]byte) []*manifestpb.ValidatorPubShares {
+	var resp []*manifestpb.ValidatorPubShares
+	for _, shares := range pubShares {
+		resp = append(resp, &manifestpb.ValidatorPubShares{PubShares: shares})
+	}
+
+	return resp
+}
@@ -0,0 +1,256 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"math/rand"
+	"testing"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -update -run=TestRemoveOperator
+
+func TestRemoveOperator(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, shares := cluster.NewForT(t, 2, 3, 4, seed, random)
+
+	// Reshared public shares of the remaining operators.
+	pubShares := resharePubShares(t, shares, 3, 3, random)
+
+	proposal, err := manifest.NewRemoveOperator(testutil.RandomBytes32Seed(random), lock.Operators[0].ENR, pubShares)
+	require.NoError(t, err)
+
+	removeOp := approve(t, proposal, secrets[1:]...)
+
+	t.Run("proto", func(t *testing.T) {
+		testutil.RequireGoldenProto(t, removeOp)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		b, err := proto.Marshal(removeOp)
+		require.NoError(t, err)
+
+		removeOp2 := new(manifestpb.SignedMutation)
+		require.NoError(t, proto.Unmarshal(b, removeOp2))
+
+		testutil.RequireProtoEqual(t, removeOp, removeOp2)
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		cluster, err = manifest.Transform(cluster, removeOp)
+		require.NoError(t, err)
+
+		require.Len(t, cluster.GetOperators(), 3)
+		for i, operator := range cluster.GetOperators() {
+			require.Equal(t, lock.Operators[i+1].ENR, operator.GetEnr())
+		}
+		for i, val := range cluster.GetValidators() {
+			require.Equal(t, pubShares[i], val.GetPubShares())
+		}
+	})
+
+	t.Run("invalid pub shares", func(t *testing.T) {
+		proposal, err := manifest.NewRemoveOperator(proposal.GetMutation().GetParent(), lock.Operators[0].ENR, pubShares[:1])
+		require.NoError(t, err)
+
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(cluster, approve(t, proposal, secrets...))
+		require.ErrorContains(t, err, "invalid number of validator public shares")
+	})
+
+	t.Run("non-interpolating pub shares", func(t *testing.T) {
+		// Existing public shares of the remaining operators don't interpolate after removing the first operator.
+		pubShares := [][][]byte{lock.Validators[0].PubShares[1:], lock.Validators[1].PubShares[1:]}
+		proposal, err := manifest.NewRemoveOperator(proposal.GetMutation().GetParent(), lock.Operators[0].ENR, pubShares)
+		require.NoError(t, err)
+
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(cluster, approve(t, proposal, secrets...))
+		require.ErrorContains(t, err, "public shares don't interpolate to validator public key")
+	})
+}
+
+func TestOperatorMutations(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, shares := cluster.NewForT(t, 1, 3, 4, seed, random)
+	newSecret, newENR := testutil.RandomENR(t, 100)
+
+	dag, err := manifest.NewDAGFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	// latest returns the hash of the latest mutation in the DAG.
+	latest := func() []byte {
+		t.Helper()
+
+		hash, err := manifest.Hash(dag.GetMutations()[len(dag.GetMutations())-1])
+		require.NoError(t, err)
+
+		return hash
+	}
+
+	// Replace the first operator.
+	proposal, err := manifest.NewReplaceOperator(latest(), lock.Operators[0].ENR, newENR.String())
+	require.NoError(t, err)
+	dag.Mutations = append(dag.Mutations, approve(t, proposal, secrets[:3]...))
+
+	// Lower the threshold, approved by the new operator.
+	secrets[0] = newSecret
+	proposal, err = manifest.NewChangeThreshold(latest(), 2, resharePubShares(t, shares, 4, 2, random))
+	require.NoError(t, err)
+	dag.Mutations = append(dag.Mutations, approve(t, proposal, secrets[:3]...))
+
+	// Remove the last operator, approved by the new threshold.
+	pubShares := resharePubShares(t, shares, 3, 2, random)
+	proposal, err = manifest.NewRemoveOperator(latest(), lock.Operators[3].ENR, pubShares)
+	require.NoError(t, err)
+	dag.Mutations = append(dag.Mutations, approve(t, proposal, secrets[1:3]...))
+
+	cluster, err := manifest.Materialise(dag)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 2, cluster.GetThreshold())
+	require.Len(t, cluster.GetOperators(), 3)
+	require.Equal(t, newENR.String(), cluster.GetOperators()[0].GetEnr())
+	require.Equal(t, lock.Operators[0].Address, cluster.GetOperators()[0].GetAddress())
+	require.Equal(t, lock.Operators[1].ENR, cluster.GetOperators()[1].GetEnr())
+	require.Equal(t, lock.Operators[2].ENR, cluster.GetOperators()[2].GetEnr())
+	require.Equal(t, pubShares[0], cluster.GetValidators()[0].GetPubShares())
+	require.Equal(t, latest(), cluster.GetLatestMutationHash())
+}
+
+func TestChangeThresholdInvalid(t *testing.T) {
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, shares := cluster.NewForT(t, 1, 3, 4, seed, random)
+	parent := testutil.RandomBytes32Seed(random)
+
+	_, err := manifest.NewChangeThreshold(parent, 1, nil)
+	require.ErrorContains(t, err, "threshold must be greater than 1")
+
+	// Public shares reshared at a different threshold don't interpolate at the new threshold.
+	proposal, err := manifest.NewChangeThreshold(parent, 2, resharePubShares(t, shares, 4, 3, random))
+	require.NoError(t, err)
+
+	cluster, err := manifest.NewClusterFromLockForT(t, lock)
+	require.NoError(t, err)
+
+	_, err = manifest.Transform(cluster, approve(t, proposal, secrets...))
+	require.ErrorContains(t, err, "public shares don't interpolate to validator public key")
+
+	// A public share not on the polynomial of the other public shares.
+	pubShares := resharePubShares(t, shares, 4, 2, random)
+	pubShares[0][3] = pubShares[0][0]
+	proposal, err = manifest.NewChangeThreshold(parent, 2, pubShares)
+	require.NoError(t, err)
+
+	_, err = manifest.Transform(cluster, approve(t, proposal, secrets...))
+	require.ErrorContains(t, err, "public share doesn't interpolate")
+}
+
+func TestApprovedMutationQuorum(t *testing.T) {
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, _ := cluster.NewForT(t, 1, 3, 4, seed, random)
+	otherSecret, newENR := testutil.RandomENR(t, 100)
+
+	proposal, err := manifest.NewReplaceOperator(testutil.RandomBytes32Seed(random), lock.Operators[0].ENR, newENR.String())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		secrets []*k1.PrivateKey
+		errMsg  string
+	}{
+		{
+			name:    "quorum",
+			secrets: secrets[1:],
+		},
+		{
+			name:    "insufficient approvals",
+			secrets: secrets[:2],
+			errMsg:  "insufficient node approvals for quorum",
+		},
+		{
+			name:    "duplicate approvals",
+			secrets: []*k1.PrivateKey{secrets[0], secrets[1], secrets[1]},
+			errMsg:  "duplicate node approval",
+		},
+		{
+			name:    "non-operator approval",
+			secrets: []*k1.PrivateKey{secrets[0], secrets[1], otherSecret},
+			errMsg:  "node approval signer not a cluster operator",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster, err := manifest.NewClusterFromLockForT(t, lock)
+			require.NoError(t, err)
+
+			_, err = manifest.Transform(cluster, approve(t, proposal, test.secrets...))
+			if test.errMsg != "" {
+				require.ErrorContains(t, err, test.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("unapproved proposal", func(t *testing.T) {
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(cluster, proposal)
+		require.ErrorContains(t, err, "unmarshal approved mutation")
+	})
+
+	t.Run("invalid approval parent", func(t *testing.T) {
+		approval, err := manifest.SignNodeApproval(testutil.RandomBytes32Seed(random), secrets[0])
+		require.NoError(t, err)
+
+		_, err = manifest.NewApprovedMutation(proposal, []*manifestpb.SignedMutation{approval})
+		require.ErrorContains(t, err, "invalid node approval parent")
+	})
+}
+
+// approve returns the approved mutation of the proposal signed by the provided secrets.
+func approve(t *testing.T, proposal *manifestpb.SignedMutation, secrets ...*k1.PrivateKey) *manifestpb.SignedMutation {
+	t.Helper()
+
+	hash, err := manifest.Hash(proposal)
+	require.NoError(t, err)
+
+	var approvals []*manifestpb.SignedMutation
+	for _, secret := range secrets {
+		approval, err := manifest.SignNodeApproval(hash, secret)
+		require.NoError(t, err)
+
+		approvals = append(approvals, approval)
+	}
+
+	approved, err := manifest.NewApprovedMutation(proposal, approvals)
+	require.NoError(t, err)
+
+	return approved
+}
@@ -0,0 +1,83 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest
+
+import (
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/enr"
+)
+
+// NewReshare returns a new unsigned reshare mutation proposal replacing the operators and threshold of the cluster.
+// The pubShares are the reshared public shares of the new operators, ordered by validator.
+// The proposal must be approved by a quorum of the existing operators, see NewApprovedMutation.
+func NewReshare(parent []byte, operatorENRs []string, threshold int, pubShares [][][]byte) (*manifestpb.SignedMutation, error) {
+	var operators []*manifestpb.Operator
+	for _, operatorENR := range operatorENRs {
+		if _, err := enr.Parse(operatorENR); err != nil {
+			return nil, errors.Wrap(err, "parse operator enr")
+		}
+
+		operators = append(operators, &manifestpb.Operator{Enr: operatorENR})
+	}
+
+	if err := verifyThreshold(threshold, len(operators)); err != nil {
+		return nil, err
+	}
+
+	return newProposal(parent, TypeReshare, &manifestpb.Reshare{
+		Operators:          operators,
+		Threshold:          int32(threshold),
+		ValidatorPubShares: pubSharesToProto(pubShares),
+	})
+}
+
+// transformReshare transforms the cluster manifest by replacing the operators, threshold and validator public shares.
+// The addresses of existing operators are retained.
+func transformReshare(c *manifestpb.Cluster, signed *manifestpb.SignedMutation) (*manifestpb.Cluster, error) {
+	reshare := new(manifestpb.Reshare)
+	if err := verifyApprovedMutation(c, signed, TypeReshare, reshare); err != nil {
+		return c, errors.Wrap(err, "verify approved mutation")
+	}
+
+	threshold := int(reshare.GetThreshold())
+	if err := verifyThreshold(threshold, len(reshare.GetOperators())); err != nil {
+		return c, err
+	}
+
+	var (
+		operators []*manifestpb.Operator
+		dedup     = make(map[string]bool)
+	)
+	for _, operator := range reshare.GetOperators() {
+		if dedup[operator.GetEnr()] {
+			return c, errors.New("duplicate operator enr", z.Str("enr", operator.GetEnr()))
+		}
+		dedup[operator.GetEnr()] = true
+
+		if _, err := enr.Parse(operator.GetEnr()); err != nil {
+			return c, errors.Wrap(err, "parse operator enr")
+		}
+
+		var address string
+		if idx, ok := operatorIndex(c, operator.GetEnr()); ok {
+			address = c.GetOperators()[idx].GetAddress()
+		}
+
+		operators = append(operators, &manifestpb.Operator{
+			Address: address,
+			Enr:     operator.GetEnr(),
+		})
+	}
+
+	if err := verifyValidatorPubShares(c, reshare.GetValidatorPubShares(), len(operators), threshold); err != nil {
+		return c, err
+	}
+
+	c.Operators = operators
+	c.Threshold = reshare.GetThreshold()
+	setValidatorPubShares(c, reshare.GetValidatorPubShares())
+
+	return c, nil
+}
@@ -0,0 +1,142 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package manifest_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -update -run=TestReshare
+
+func TestReshare(t *testing.T) {
+	setIncrementingTime(t)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, secrets, shares := cluster.NewForT(t, 2, 3, 4, seed, random)
+	_, newENR := testutil.RandomENR(t, 100)
+
+	// Reshare to the last three operators and a new operator with a threshold of 2.
+	enrs := []string{lock.Operators[1].ENR, lock.Operators[2].ENR, lock.Operators[3].ENR, newENR.String()}
+	pubShares := resharePubShares(t, shares, 4, 2, random)
+
+	proposal, err := manifest.NewReshare(testutil.RandomBytes32Seed(random), enrs, 2, pubShares)
+	require.NoError(t, err)
+
+	// Approved by the old operators.
+	reshare := approve(t, proposal, secrets[:3]...)
+
+	t.Run("proto", func(t *testing.T) {
+		testutil.RequireGoldenProto(t, reshare)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		b, err := proto.Marshal(reshare)
+		require.NoError(t, err)
+
+		reshare2 := new(manifestpb.SignedMutation)
+		require.NoError(t, proto.Unmarshal(b, reshare2))
+
+		testutil.RequireProtoEqual(t, reshare, reshare2)
+	})
+
+	t.Run("transform", func(t *testing.T) {
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		cluster, err = manifest.Transform(cluster, reshare)
+		require.NoError(t, err)
+
+		require.EqualValues(t, 2, cluster.GetThreshold())
+		require.Len(t, cluster.GetOperators(), 4)
+		for i, operator := range cluster.GetOperators() {
+			require.Equal(t, enrs[i], operator.GetEnr())
+		}
+		require.Equal(t, lock.Operators[1].Address, cluster.GetOperators()[0].GetAddress())
+		require.Empty(t, cluster.GetOperators()[3].GetAddress())
+		for i, val := range cluster.GetValidators() {
+			require.Equal(t, pubShares[i], val.GetPubShares())
+		}
+	})
+
+	t.Run("new operator approval", func(t *testing.T) {
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		newSecret, _ := testutil.RandomENR(t, 101)
+		_, err = manifest.Transform(cluster, approve(t, proposal, secrets[0], secrets[1], newSecret))
+		require.ErrorContains(t, err, "node approval signer not a cluster operator")
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		_, err := manifest.NewReshare(proposal.GetMutation().GetParent(), enrs, 1, pubShares)
+		require.ErrorContains(t, err, "threshold must be greater than 1")
+
+		_, err = manifest.NewReshare(proposal.GetMutation().GetParent(), enrs, 5, pubShares)
+		require.ErrorContains(t, err, "threshold cannot be greater than number of operators")
+	})
+
+	t.Run("duplicate operator", func(t *testing.T) {
+		proposal, err := manifest.NewReshare(proposal.GetMutation().GetParent(),
+			[]string{enrs[0], enrs[1], enrs[2], enrs[0]}, 2, pubShares)
+		require.NoError(t, err)
+
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(cluster, approve(t, proposal, secrets...))
+		require.ErrorContains(t, err, "duplicate operator enr")
+	})
+
+	t.Run("invalid pub shares", func(t *testing.T) {
+		proposal, err := manifest.NewReshare(proposal.GetMutation().GetParent(), enrs[:3], 2, pubShares)
+		require.NoError(t, err)
+
+		cluster, err := manifest.NewClusterFromLockForT(t, lock)
+		require.NoError(t, err)
+
+		_, err = manifest.Transform(cluster, approve(t, proposal, secrets...))
+		require.ErrorContains(t, err, "invalid number of public shares")
+	})
+}
+
+// resharePubShares returns the public shares of the validator secrets reshared to the provided total and threshold.
+func resharePubShares(t *testing.T, shares [][]tbls.PrivateKey, total, threshold int, random *rand.Rand) [][][]byte {
+	t.Helper()
+
+	var resp [][][]byte
+	for _, valShares := range shares {
+		shareMap := make(map[int]tbls.PrivateKey)
+		for i, share := range valShares {
+			shareMap[i+1] = share
+		}
+
+		secret, err := tbls.RecoverSecret(shareMap, uint(len(valShares)), uint(len(valShares)))
+		require.NoError(t, err)
+
+		reshared, err := tbls.ThresholdSplitInsecure(t, secret, uint(total), uint(threshold), random)
+		require.NoError(t, err)
+
+		var pubShares [][]byte
+		for i := 1; i <= total; i++ {
+			pubShare, err := tbls.SecretToPublicKey(reshared[i])
+			require.NoError(t, err)
+
+			pubShares = append(pubShares, pubShare[:])
+		}
+
+		resp = append(resp, pubShares)
+	}
+
+	return resp
+}
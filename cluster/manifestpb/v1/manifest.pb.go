@@ -562,6 +562,392 @@ func (*Empty) Descriptor() ([]byte, []int) {
 	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{8}
 }
 
+// ApprovedMutation is a mutation proposal approved by a quorum of cluster operators.
+type ApprovedMutation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proposal  *anypb.Any        `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`   // Proposal is the data of the approved mutation proposal.
+	Approvals []*SignedMutation `protobuf:"bytes,2,rep,name=approvals,proto3" json:"approvals,omitempty"` // Approvals is the list of node approvals of the proposal.
+}
+
+func (x *ApprovedMutation) Reset() {
+	*x = ApprovedMutation{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovedMutation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovedMutation) ProtoMessage() {}
+
+func (x *ApprovedMutation) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovedMutation.ProtoReflect.Descriptor instead.
+func (*ApprovedMutation) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApprovedMutation) GetProposal() *anypb.Any {
+	if x != nil {
+		return x.Proposal
+	}
+	return nil
+}
+
+func (x *ApprovedMutation) GetApprovals() []*SignedMutation {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+// ValidatorPubShares is the ordered list of public shares of a validator.
+type ValidatorPubShares struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PubShares [][]byte `protobuf:"bytes,1,rep,name=pub_shares,json=pubShares,proto3" json:"pub_shares,omitempty"` // PubShares is the ordered list of public shares of the validator.
+}
+
+func (x *ValidatorPubShares) Reset() {
+	*x = ValidatorPubShares{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatorPubShares) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorPubShares) ProtoMessage() {}
+
+func (x *ValidatorPubShares) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorPubShares.ProtoReflect.Descriptor instead.
+func (*ValidatorPubShares) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValidatorPubShares) GetPubShares() [][]byte {
+	if x != nil {
+		return x.PubShares
+	}
+	return nil
+}
+
+// RemoveOperator removes an operator from the cluster.
+type RemoveOperator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enr                string                `protobuf:"bytes,1,opt,name=enr,proto3" json:"enr,omitempty"`                                                           // ENR identifies the charon node of the operator to remove.
+	ValidatorPubShares []*ValidatorPubShares `protobuf:"bytes,2,rep,name=validator_pub_shares,json=validatorPubShares,proto3" json:"validator_pub_shares,omitempty"` // ValidatorPubShares are the reshared public shares of the remaining operators, ordered by validator.
+}
+
+func (x *RemoveOperator) Reset() {
+	*x = RemoveOperator{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOperator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOperator) ProtoMessage() {}
+
+func (x *RemoveOperator) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveOperator.ProtoReflect.Descriptor instead.
+func (*RemoveOperator) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoveOperator) GetEnr() string {
+	if x != nil {
+		return x.Enr
+	}
+	return ""
+}
+
+func (x *RemoveOperator) GetValidatorPubShares() []*ValidatorPubShares {
+	if x != nil {
+		return x.ValidatorPubShares
+	}
+	return nil
+}
+
+// ReplaceOperator replaces the ENR of an operator.
+type ReplaceOperator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OldEnr string `protobuf:"bytes,1,opt,name=old_enr,json=oldEnr,proto3" json:"old_enr,omitempty"` // OldENR identifies the charon node of the operator to replace.
+	NewEnr string `protobuf:"bytes,2,opt,name=new_enr,json=newEnr,proto3" json:"new_enr,omitempty"` // NewENR identifies the new charon node of the operator.
+}
+
+func (x *ReplaceOperator) Reset() {
+	*x = ReplaceOperator{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceOperator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceOperator) ProtoMessage() {}
+
+func (x *ReplaceOperator) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceOperator.ProtoReflect.Descriptor instead.
+func (*ReplaceOperator) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReplaceOperator) GetOldEnr() string {
+	if x != nil {
+		return x.OldEnr
+	}
+	return ""
+}
+
+func (x *ReplaceOperator) GetNewEnr() string {
+	if x != nil {
+		return x.NewEnr
+	}
+	return ""
+}
+
+// ChangeThreshold changes the threshold of the cluster.
+type ChangeThreshold struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Threshold          int32                 `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold,omitempty"`                                              // Threshold is the new threshold of the cluster.
+	ValidatorPubShares []*ValidatorPubShares `protobuf:"bytes,2,rep,name=validator_pub_shares,json=validatorPubShares,proto3" json:"validator_pub_shares,omitempty"` // ValidatorPubShares are the reshared public shares of the operators, ordered by validator.
+}
+
+func (x *ChangeThreshold) Reset() {
+	*x = ChangeThreshold{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeThreshold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeThreshold) ProtoMessage() {}
+
+func (x *ChangeThreshold) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeThreshold.ProtoReflect.Descriptor instead.
+func (*ChangeThreshold) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChangeThreshold) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *ChangeThreshold) GetValidatorPubShares() []*ValidatorPubShares {
+	if x != nil {
+		return x.ValidatorPubShares
+	}
+	return nil
+}
+
+// Reshare replaces the operators and threshold of the cluster after resharing the validator keys.
+type Reshare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operators          []*Operator           `protobuf:"bytes,1,rep,name=operators,proto3" json:"operators,omitempty"`                                               // Operators is the new list of operators of the cluster.
+	Threshold          int32                 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`                                              // Threshold is the new threshold of the cluster.
+	ValidatorPubShares []*ValidatorPubShares `protobuf:"bytes,3,rep,name=validator_pub_shares,json=validatorPubShares,proto3" json:"validator_pub_shares,omitempty"` // ValidatorPubShares are the reshared public shares of the new operators, ordered by validator.
+}
+
+func (x *Reshare) Reset() {
+	*x = Reshare{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reshare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reshare) ProtoMessage() {}
+
+func (x *Reshare) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reshare.ProtoReflect.Descriptor instead.
+func (*Reshare) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Reshare) GetOperators() []*Operator {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+func (x *Reshare) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Reshare) GetValidatorPubShares() []*ValidatorPubShares {
+	if x != nil {
+		return x.ValidatorPubShares
+	}
+	return nil
+}
+
+// SetFeeRecipient updates the fee recipient address of a validator.
+type SetFeeRecipient struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey               []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`                                             // PublicKey is the group public key of the validator.
+	FeeRecipientAddress     string `protobuf:"bytes,2,opt,name=fee_recipient_address,json=feeRecipientAddress,proto3" json:"fee_recipient_address,omitempty"`             // FeeRecipientAddress is the new fee recipient Ethereum address of the validator.
+	BuilderRegistrationJson []byte `protobuf:"bytes,3,opt,name=builder_registration_json,json=builderRegistrationJson,proto3" json:"builder_registration_json,omitempty"` // BuilderRegistration is the optional pre-generated json-formatted builder-API validator registration with the new fee recipient.
+}
+
+func (x *SetFeeRecipient) Reset() {
+	*x = SetFeeRecipient{}
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeeRecipient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeeRecipient) ProtoMessage() {}
+
+func (x *SetFeeRecipient) ProtoReflect() protoreflect.Message {
+	mi := &file_cluster_manifestpb_v1_manifest_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeeRecipient.ProtoReflect.Descriptor instead.
+func (*SetFeeRecipient) Descriptor() ([]byte, []int) {
+	return file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetFeeRecipient) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SetFeeRecipient) GetFeeRecipientAddress() string {
+	if x != nil {
+		return x.FeeRecipientAddress
+	}
+	return ""
+}
+
+func (x *SetFeeRecipient) GetBuilderRegistrationJson() []byte {
+	if x != nil {
+		return x.BuilderRegistrationJson
+	}
+	return nil
+}
+
 var File_cluster_manifestpb_v1_manifest_proto protoreflect.FileDescriptor
 
 var file_cluster_manifestpb_v1_manifest_proto_rawDesc = []byte{
@@ -642,11 +1028,67 @@ var file_cluster_manifestpb_v1_manifest_proto_rawDesc = []byte{
 	0x72, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x20, 0x0a,
 	0x0a, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6a,
 	0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x22,
-	0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
-	0x72, 0x2f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x89, 0x01, 0x0a, 0x10, 0x41, 0x70, 0x70,
+	0x72, 0x6f, 0x76, 0x65, 0x64, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12,
+	0x43, 0x0a, 0x09, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6d, 0x61, 0x6e,
+	0x69, 0x66, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x61, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x61, 0x6c, 0x73, 0x22, 0x33, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x50, 0x75, 0x62, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75,
+	0x62, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09,
+	0x70, 0x75, 0x62, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x22, 0x7f, 0x0a, 0x0e, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x6e, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x72, 0x12, 0x5b, 0x0a,
+	0x14, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x70, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x52, 0x12, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x50, 0x75, 0x62, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x22, 0x43, 0x0a, 0x0f, 0x52, 0x65,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x17, 0x0a,
+	0x07, 0x6f, 0x6c, 0x64, 0x5f, 0x65, 0x6e, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6f, 0x6c, 0x64, 0x45, 0x6e, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x5f, 0x65, 0x6e,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x77, 0x45, 0x6e, 0x72, 0x22,
+	0x8c, 0x01, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x12, 0x5b, 0x0a, 0x14, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x70,
+	0x75, 0x62, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x50, 0x75, 0x62, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x52, 0x12, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x22, 0xc3,
+	0x01, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x3d, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74,
+	0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x09,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x5b, 0x0a, 0x14, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73,
+	0x52, 0x12, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x46, 0x65, 0x65, 0x52,
+	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x66, 0x65, 0x65, 0x5f, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x66, 0x65, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70,
+	0x69, 0x65, 0x6e, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4a, 0x73, 0x6f, 0x6e, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x2f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -661,7 +1103,7 @@ func file_cluster_manifestpb_v1_manifest_proto_rawDescGZIP() []byte {
 	return file_cluster_manifestpb_v1_manifest_proto_rawDescData
 }
 
-var file_cluster_manifestpb_v1_manifest_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_cluster_manifestpb_v1_manifest_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_cluster_manifestpb_v1_manifest_proto_goTypes = []any{
 	(*Cluster)(nil),            // 0: cluster.manifestpb.v1.Cluster
 	(*Mutation)(nil),           // 1: cluster.manifestpb.v1.Mutation
@@ -672,20 +1114,33 @@ var file_cluster_manifestpb_v1_manifest_proto_goTypes = []any{
 	(*ValidatorList)(nil),      // 6: cluster.manifestpb.v1.ValidatorList
 	(*LegacyLock)(nil),         // 7: cluster.manifestpb.v1.LegacyLock
 	(*Empty)(nil),              // 8: cluster.manifestpb.v1.Empty
-	(*anypb.Any)(nil),          // 9: google.protobuf.Any
+	(*ApprovedMutation)(nil),   // 9: cluster.manifestpb.v1.ApprovedMutation
+	(*ValidatorPubShares)(nil), // 10: cluster.manifestpb.v1.ValidatorPubShares
+	(*RemoveOperator)(nil),     // 11: cluster.manifestpb.v1.RemoveOperator
+	(*ReplaceOperator)(nil),    // 12: cluster.manifestpb.v1.ReplaceOperator
+	(*ChangeThreshold)(nil),    // 13: cluster.manifestpb.v1.ChangeThreshold
+	(*Reshare)(nil),            // 14: cluster.manifestpb.v1.Reshare
+	(*SetFeeRecipient)(nil),    // 15: cluster.manifestpb.v1.SetFeeRecipient
+	(*anypb.Any)(nil),          // 16: google.protobuf.Any
 }
 var file_cluster_manifestpb_v1_manifest_proto_depIdxs = []int32{
-	4, // 0: cluster.manifestpb.v1.Cluster.operators:type_name -> cluster.manifestpb.v1.Operator
-	5, // 1: cluster.manifestpb.v1.Cluster.validators:type_name -> cluster.manifestpb.v1.Validator
-	9, // 2: cluster.manifestpb.v1.Mutation.data:type_name -> google.protobuf.Any
-	1, // 3: cluster.manifestpb.v1.SignedMutation.mutation:type_name -> cluster.manifestpb.v1.Mutation
-	2, // 4: cluster.manifestpb.v1.SignedMutationList.mutations:type_name -> cluster.manifestpb.v1.SignedMutation
-	5, // 5: cluster.manifestpb.v1.ValidatorList.validators:type_name -> cluster.manifestpb.v1.Validator
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	4,  // 0: cluster.manifestpb.v1.Cluster.operators:type_name -> cluster.manifestpb.v1.Operator
+	5,  // 1: cluster.manifestpb.v1.Cluster.validators:type_name -> cluster.manifestpb.v1.Validator
+	16, // 2: cluster.manifestpb.v1.Mutation.data:type_name -> google.protobuf.Any
+	1,  // 3: cluster.manifestpb.v1.SignedMutation.mutation:type_name -> cluster.manifestpb.v1.Mutation
+	2,  // 4: cluster.manifestpb.v1.SignedMutationList.mutations:type_name -> cluster.manifestpb.v1.SignedMutation
+	5,  // 5: cluster.manifestpb.v1.ValidatorList.validators:type_name -> cluster.manifestpb.v1.Validator
+	16, // 6: cluster.manifestpb.v1.ApprovedMutation.proposal:type_name -> google.protobuf.Any
+	2,  // 7: cluster.manifestpb.v1.ApprovedMutation.approvals:type_name -> cluster.manifestpb.v1.SignedMutation
+	10, // 8: cluster.manifestpb.v1.RemoveOperator.validator_pub_shares:type_name -> cluster.manifestpb.v1.ValidatorPubShares
+	10, // 9: cluster.manifestpb.v1.ChangeThreshold.validator_pub_shares:type_name -> cluster.manifestpb.v1.ValidatorPubShares
+	4,  // 10: cluster.manifestpb.v1.Reshare.operators:type_name -> cluster.manifestpb.v1.Operator
+	10, // 11: cluster.manifestpb.v1.Reshare.validator_pub_shares:type_name -> cluster.manifestpb.v1.ValidatorPubShares
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_cluster_manifestpb_v1_manifest_proto_init() }
@@ -699,7 +1154,7 @@ func file_cluster_manifestpb_v1_manifest_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cluster_manifestpb_v1_manifest_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -48,6 +48,7 @@ func New() *cobra.Command {
 		newCombineCmd(newCombineFunc),
 		newAlphaCmd(
 			newAddValidatorsCmd(runAddValidatorsSolo),
+			newReshareCmd(dkg.Reshare),
 			newViewClusterManifestCmd(runViewClusterManifest),
 			newTestCmd(
 				newTestAllCmd(runTestAll),
@@ -57,6 +58,10 @@ func New() *cobra.Command {
 				newTestMEVCmd(runTestMEV),
 				newTestInfraCmd(runTestInfra),
 			),
+			newSignCmd(
+				newSignPartialCmd(runSignPartial),
+				newSignAggregateCmd(runSignAggregate),
+			),
 		),
 		newExitCmd(
 			newListActiveValidatorsCmd(runListActiveValidatorsCmd),
@@ -64,6 +69,14 @@ func New() *cobra.Command {
 			newBcastFullExitCmd(runBcastFullExit),
 			newFetchExitCmd(runFetchExit),
 		),
+		newSlashingProtectionCmd(
+			newSlashingProtectionImportCmd(runSlashingProtectionImport),
+			newSlashingProtectionExportCmd(runSlashingProtectionExport),
+		),
+		newDebugCmd(
+			newDebugDutiesCmd(runDebugDuties),
+			newDebugConsensusCmd(runDebugConsensus),
+		),
 		newUnsafeCmd(newRunCmd(app.Run, true)),
 	)
 }
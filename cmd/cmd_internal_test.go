@@ -81,8 +81,56 @@ func TestCmdFlags(t *testing.T) {
 				BeaconNodeAddrs:         []string{"http://beacon.node"},
 				BeaconNodeTimeout:       2 * time.Second,
 				BeaconNodeSubmitTimeout: 2 * time.Second,
-				JaegerAddr:              "",
-				JaegerService:           "charon",
+				BuilderMinBid:           "0",
+				BuilderRelayAPIAddr:     "127.0.0.1:18550",
+				Web3SignerKeysDir:       ".charon/validator_keys",
+				OTLPProtocol:            "grpc",
+				OTLPServiceName:         "charon",
+				OTLPSampleRatio:         1,
+				DutyHistoryMaxSizeMB:    64,
+			},
+		},
+		{
+			Name: "run command with deprecated jaeger flags",
+			Args: slice("run", "--jaeger-address=localhost:6831"),
+			Envs: map[string]string{
+				"CHARON_BEACON_NODE_ENDPOINTS": "http://beacon.node",
+				"CHARON_JAEGER_SERVICE":        "charon",
+			},
+			AppConfig: &app.Config{
+				Log: log.Config{
+					Level:       "info",
+					Format:      "console",
+					Color:       "auto",
+					LokiService: "charon",
+				},
+				P2P: p2p.Config{
+					Relays:   []string{"https://0.relay.obol.tech", "https://2.relay.obol.dev", "https://1.relay.obol.tech"},
+					TCPAddrs: nil,
+				},
+				Feature: featureset.Config{
+					MinStatus: "stable",
+					Enabled:   nil,
+					Disabled:  nil,
+				},
+				LockFile:                ".charon/cluster-lock.json",
+				ManifestFile:            ".charon/cluster-manifest.pb",
+				PrivKeyFile:             ".charon/charon-enr-private-key",
+				PrivKeyLocking:          false,
+				SimnetValidatorKeysDir:  ".charon/validator_keys",
+				SimnetSlotDuration:      time.Second,
+				MonitoringAddr:          "127.0.0.1:3620",
+				ValidatorAPIAddr:        "127.0.0.1:3600",
+				BeaconNodeAddrs:         []string{"http://beacon.node"},
+				BeaconNodeTimeout:       2 * time.Second,
+				BeaconNodeSubmitTimeout: 2 * time.Second,
+				BuilderMinBid:           "0",
+				BuilderRelayAPIAddr:     "127.0.0.1:18550",
+				Web3SignerKeysDir:       ".charon/validator_keys",
+				OTLPProtocol:            "grpc",
+				OTLPServiceName:         "charon",
+				OTLPSampleRatio:         1,
+				DutyHistoryMaxSizeMB:    64,
 			},
 		},
 		{
@@ -132,8 +180,13 @@ func TestCmdFlags(t *testing.T) {
 				BeaconNodeAddrs:         []string{"http://beacon.node"},
 				BeaconNodeTimeout:       2 * time.Second,
 				BeaconNodeSubmitTimeout: 2 * time.Second,
-				JaegerAddr:              "",
-				JaegerService:           "charon",
+				BuilderMinBid:           "0",
+				BuilderRelayAPIAddr:     "127.0.0.1:18550",
+				Web3SignerKeysDir:       ".charon/validator_keys",
+				OTLPProtocol:            "grpc",
+				OTLPServiceName:         "charon",
+				OTLPSampleRatio:         1,
+				DutyHistoryMaxSizeMB:    64,
 				TestConfig: app.TestConfig{
 					P2PFuzz: true,
 				},
@@ -0,0 +1,19 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newDebugCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "debug",
+		Short: "Debug a charon node after the fact.",
+		Long:  "Inspect data persisted or served by charon nodes to explain their behaviour after the fact.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
@@ -0,0 +1,231 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/hotstuff"
+	"github.com/obolnetwork/charon/core/consensus/qbft"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+type debugConsensusConfig struct {
+	DumpFiles     []string
+	Slots         []uint
+	ReplayTimeout time.Duration
+}
+
+func newDebugConsensusCmd(runFunc func(context.Context, io.Writer, debugConsensusConfig) error) *cobra.Command {
+	var config debugConsensusConfig
+
+	cmd := &cobra.Command{
+		Use:   "consensus",
+		Short: "Replay consensus instances of debug API dumps.",
+		Long: "Loads one or more sniffed consensus instance dumps downloaded from the '/debug/consensus' endpoint of the peers' debug API, " +
+			"merges them by duty and replays them through the QBFT or HotStuff algorithm of the instance's protocol. " +
+			"It prints a per-round (QBFT) or per-view (HotStuff) timeline of the consensus messages, the leader of each round or view and why it timed out.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&config.DumpFiles, "dump-files", nil, "Comma separated list of gzipped consensus dump files downloaded from the '/debug/consensus' endpoint of one or more peers. [REQUIRED]")
+	cmd.Flags().UintSliceVar(&config.Slots, "slots", nil, "Comma separated list of slots to replay duties of. All slots if empty.")
+	cmd.Flags().DurationVar(&config.ReplayTimeout, "replay-timeout", time.Second, "Timeout replaying each consensus instance that doesn't decide.")
+	mustMarkFlagRequired(cmd, "dump-files")
+
+	return cmd
+}
+
+func runDebugConsensus(ctx context.Context, w io.Writer, config debugConsensusConfig) error {
+	var instances []*pbv1.SniffedConsensusInstance
+	for _, file := range config.DumpFiles {
+		dump, err := loadConsensusDump(file)
+		if err != nil {
+			return err
+		}
+
+		for _, instance := range dump.GetInstances() {
+			if len(config.Slots) > 0 && !slices.Contains(config.Slots, instanceSlot(instance)) {
+				continue
+			}
+
+			instances = append(instances, instance)
+		}
+	}
+
+	qbftReplayed, err := qbft.ReplayInstances(ctx, instances, config.ReplayTimeout)
+	if err != nil {
+		return err
+	}
+
+	hotstuffReplayed, err := hotstuff.ReplayInstances(ctx, instances, config.ReplayTimeout)
+	if err != nil {
+		return err
+	}
+
+	// Timelines of both protocols are ordered by duty.
+	type timeline struct {
+		Duty  core.Duty
+		Write func(io.Writer)
+	}
+
+	var timelines []timeline
+	for _, instance := range qbftReplayed {
+		timelines = append(timelines, timeline{Duty: instance.Duty, Write: func(w io.Writer) {
+			writeConsensusTimeline(w, instance)
+		}})
+	}
+	for _, instance := range hotstuffReplayed {
+		timelines = append(timelines, timeline{Duty: instance.Duty, Write: func(w io.Writer) {
+			writeHotStuffTimeline(w, instance)
+		}})
+	}
+	slices.SortStableFunc(timelines, func(a, b timeline) int {
+		if a.Duty.Slot != b.Duty.Slot {
+			return cmp.Compare(a.Duty.Slot, b.Duty.Slot)
+		}
+
+		return cmp.Compare(a.Duty.Type, b.Duty.Type)
+	})
+
+	if len(timelines) == 0 {
+		_, err := fmt.Fprintln(w, "No consensus instances found")
+		if err != nil {
+			return errors.Wrap(err, "write consensus timeline")
+		}
+
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, timeline := range timelines {
+		timeline.Write(&buf)
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return errors.Wrap(err, "write consensus timeline")
+	}
+
+	return nil
+}
+
+// loadConsensusDump returns the sniffed consensus instances of the gzipped protobuf dump file.
+func loadConsensusDump(file string) (*pbv1.SniffedConsensusInstances, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "open consensus dump file", z.Str("file", file))
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "read gzipped consensus dump file", z.Str("file", file))
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "read gzipped consensus dump file", z.Str("file", file))
+	}
+
+	resp := new(pbv1.SniffedConsensusInstances)
+	if err := proto.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal consensus dump file", z.Str("file", file))
+	}
+
+	return resp, nil
+}
+
+// instanceSlot returns the duty slot of the sniffed instance.
+func instanceSlot(instance *pbv1.SniffedConsensusInstance) uint {
+	if len(instance.GetMsgs()) == 0 {
+		return 0
+	}
+
+	msg := instance.GetMsgs()[0]
+	if msg.GetHotstuffMsg() != nil {
+		return uint(msg.GetHotstuffMsg().GetMsg().GetDuty().GetSlot())
+	}
+
+	return uint(msg.GetMsg().GetMsg().GetDuty().GetSlot())
+}
+
+// writeConsensusTimeline writes the per-round timeline of the replayed QBFT instance.
+func writeConsensusTimeline(w io.Writer, instance qbft.ReplayedInstance) {
+	result := "not decided"
+	if instance.Decided {
+		result = fmt.Sprintf("decided in round %d with value %#x", instance.DecidedRound, instance.DecidedValue[:4])
+	}
+
+	_, _ = fmt.Fprintf(w, "Duty %s: protocol=qbft nodes=%d sniffed_by=%v started_at=%s, %s\n",
+		instance.Duty, instance.Nodes, instance.Peers, instance.StartedAt.UTC().Format(time.RFC3339Nano), result)
+
+	for _, round := range instance.Rounds {
+		status := "decided"
+		if round.TimeoutReason != "" {
+			status = "timed out: " + round.TimeoutReason
+		}
+
+		_, _ = fmt.Fprintf(w, "  Round %d, leader %d, %s\n", round.Round, round.Leader, status)
+		_, _ = fmt.Fprintf(w, "    %s\n", round.Steps)
+
+		for _, msg := range round.Msgs {
+			_, _ = fmt.Fprintf(w, "    %+10s  %-12s  peer %d  value %#x\n",
+				msg.Offset.Round(time.Millisecond), msg.Type, msg.Source, msg.Value[:4])
+		}
+	}
+
+	for _, change := range instance.RoundChanges {
+		_, _ = fmt.Fprintf(w, "  Replay changed round %d to %d: %s\n", change.Round, change.NewRound, change.Rule)
+	}
+
+	_, _ = fmt.Fprintln(w)
+}
+
+// writeHotStuffTimeline writes the per-view timeline of the replayed HotStuff instance.
+func writeHotStuffTimeline(w io.Writer, instance hotstuff.ReplayedInstance) {
+	result := "not decided"
+	if instance.Decided {
+		result = fmt.Sprintf("decided in view %d with value %#x", instance.DecidedView, instance.DecidedValue[:4])
+	}
+
+	_, _ = fmt.Fprintf(w, "Duty %s: protocol=hotstuff nodes=%d sniffed_by=%v started_at=%s, %s\n",
+		instance.Duty, instance.Nodes, instance.Peers, instance.StartedAt.UTC().Format(time.RFC3339Nano), result)
+
+	for _, view := range instance.Views {
+		status := "decided"
+		if view.TimeoutReason != "" {
+			status = "timed out: " + view.TimeoutReason
+		}
+
+		_, _ = fmt.Fprintf(w, "  View %d, leader %d, %s\n", view.View, view.Leader, status)
+		_, _ = fmt.Fprintf(w, "    %s\n", view.Steps)
+
+		for _, msg := range view.Msgs {
+			_, _ = fmt.Fprintf(w, "    %+10s  %-15s  peer %d  value %#x\n",
+				msg.Offset.Round(time.Millisecond), msg.Step, msg.Source, msg.Value[:4])
+		}
+	}
+
+	for _, change := range instance.ViewChanges {
+		_, _ = fmt.Fprintf(w, "  Replay changed view %d to %d: %s\n", change.View, change.NewView, change.Reason)
+	}
+
+	_, _ = fmt.Fprintln(w)
+}
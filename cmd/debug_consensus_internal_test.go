@@ -0,0 +1,160 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+func TestDebugConsensus(t *testing.T) {
+	const nodes = 4
+
+	start := time.Unix(1700000000, 0)
+	duty := core.NewAttesterDuty(1) // Leader of round 1 is peer 0.
+
+	var msgs []*pbv1.SniffedConsensusMsg
+	add := func(typ qbft.MsgType, source int64) {
+		msgs = append(msgs, &pbv1.SniffedConsensusMsg{
+			Timestamp: timestamppb.New(start.Add(time.Duration(len(msgs)+1) * time.Millisecond)),
+			Msg: &pbv1.QBFTConsensusMsg{
+				Msg: &pbv1.QBFTMsg{
+					Type:    int64(typ),
+					Duty:    core.DutyToProto(duty),
+					PeerIdx: source,
+					Round:   1,
+				},
+			},
+		})
+	}
+
+	add(qbft.MsgPrePrepare, 0)
+	for _, typ := range []qbft.MsgType{qbft.MsgPrepare, qbft.MsgCommit} {
+		for source := int64(0); source < 3; source++ {
+			add(typ, source)
+		}
+	}
+
+	file := writeConsensusDump(t, &pbv1.SniffedConsensusInstances{
+		Instances: []*pbv1.SniffedConsensusInstance{
+			{Nodes: nodes, PeerIdx: 1, StartedAt: timestamppb.New(start), Msgs: msgs},
+		},
+	})
+
+	t.Run("timeline", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runDebugConsensus(context.Background(), &buf, debugConsensusConfig{
+			DumpFiles:     []string{file},
+			ReplayTimeout: time.Second,
+		}))
+		require.Equal(t, ""+
+			"Duty 1/attester: protocol=qbft nodes=4 sniffed_by=[1] started_at=2023-11-14T22:13:20Z, decided in round 1 with value 0x00000000\n"+
+			"  Round 1, leader 0, decided\n"+
+			"    pre_prepare=*___ prepare=***? commit=***? round_change=____\n"+
+			"           1ms  pre_prepare   peer 0  value 0x00000000\n"+
+			"           2ms  prepare       peer 0  value 0x00000000\n"+
+			"           3ms  prepare       peer 1  value 0x00000000\n"+
+			"           4ms  prepare       peer 2  value 0x00000000\n"+
+			"           5ms  commit        peer 0  value 0x00000000\n"+
+			"           6ms  commit        peer 1  value 0x00000000\n"+
+			"           7ms  commit        peer 2  value 0x00000000\n"+
+			"\n",
+			buf.String())
+	})
+
+	t.Run("hotstuff timeline", func(t *testing.T) {
+		// Peer 1 sniffed the proposals of the view 1 leader, votes are only sent to the leader.
+		value := bytes.Repeat([]byte{1}, 32)
+		var hotstuffMsgs []*pbv1.SniffedConsensusMsg
+		for phase := int64(1); phase <= 4; phase++ {
+			msg := &pbv1.HotStuffMsg{
+				Type:      2, // Proposal
+				Duty:      core.DutyToProto(duty),
+				View:      1,
+				Phase:     phase,
+				ValueHash: value,
+			}
+			if phase > 1 {
+				msg.Justify = &pbv1.HotStuffQC{Phase: phase - 1, View: 1, ValueHash: value}
+			}
+
+			hotstuffMsgs = append(hotstuffMsgs, &pbv1.SniffedConsensusMsg{
+				Timestamp:   timestamppb.New(start.Add(time.Duration(phase) * time.Millisecond)),
+				HotstuffMsg: &pbv1.HotStuffConsensusMsg{Msg: msg},
+			})
+		}
+
+		hotstuffFile := writeConsensusDump(t, &pbv1.SniffedConsensusInstances{
+			Instances: []*pbv1.SniffedConsensusInstance{
+				{Nodes: nodes, PeerIdx: 1, StartedAt: timestamppb.New(start), Msgs: hotstuffMsgs, ProtocolId: protocols.HotStuffv1ProtocolID},
+			},
+		})
+
+		var buf bytes.Buffer
+		require.NoError(t, runDebugConsensus(context.Background(), &buf, debugConsensusConfig{
+			DumpFiles:     []string{hotstuffFile},
+			ReplayTimeout: time.Second,
+		}))
+		require.Equal(t, ""+
+			"Duty 1/attester: protocol=hotstuff nodes=4 sniffed_by=[1] started_at=2023-11-14T22:13:20Z, decided in view 1 with value 0x01010101\n"+
+			"  View 1, leader 0, decided\n"+
+			"    new_view=____ prepare=*___ prepare_vote=???? pre_commit=*___ pre_commit_vote=???? commit=*___ commit_vote=???? decide=*___\n"+
+			"           1ms  prepare          peer 0  value 0x01010101\n"+
+			"           2ms  pre_commit       peer 0  value 0x01010101\n"+
+			"           3ms  commit           peer 0  value 0x01010101\n"+
+			"           4ms  decide           peer 0  value 0x01010101\n"+
+			"\n",
+			buf.String())
+	})
+
+	t.Run("filtered slots", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runDebugConsensus(context.Background(), &buf, debugConsensusConfig{
+			DumpFiles:     []string{file},
+			Slots:         []uint{2},
+			ReplayTimeout: time.Second,
+		}))
+		require.Equal(t, "No consensus instances found\n", buf.String())
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid.pb.gz")
+		require.NoError(t, os.WriteFile(invalid, []byte("invalid"), 0o644))
+
+		err := runDebugConsensus(context.Background(), new(bytes.Buffer), debugConsensusConfig{DumpFiles: []string{invalid}})
+		require.ErrorContains(t, err, "read gzipped consensus dump file")
+	})
+}
+
+// writeConsensusDump writes the instances as a gzipped consensus dump file and returns its path.
+func writeConsensusDump(t *testing.T, instances *pbv1.SniffedConsensusInstances) string {
+	t.Helper()
+
+	b, err := proto.Marshal(instances)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err = zw.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	file := filepath.Join(t.TempDir(), "consensus.pb.gz")
+	require.NoError(t, os.WriteFile(file, buf.Bytes(), 0o644))
+
+	return file
+}
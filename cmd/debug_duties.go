@@ -0,0 +1,160 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/dutyhistory"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+)
+
+type debugDutiesConfig struct {
+	DutyHistoryDir   string
+	Slots            []uint
+	ValidatorPubkeys []string
+	JSON             bool
+}
+
+func newDebugDutiesCmd(runFunc func(io.Writer, debugDutiesConfig) error) *cobra.Command {
+	var config debugDutiesConfig
+
+	cmd := &cobra.Command{
+		Use:   "duties",
+		Short: "Explain the outcome of past duties.",
+		Long: "Prints the duties persisted in the duty history of a node, including the step each duty reached, " +
+			"why failed duties failed, the participation of each peer and the on-chain inclusion of each validator.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.DutyHistoryDir, "duty-history-dir", "", "The path to the duty history directory configured via 'charon run --duty-history-dir'. [REQUIRED]")
+	cmd.Flags().UintSliceVar(&config.Slots, "slots", nil, "Comma separated list of slots to print duties of. All slots if empty.")
+	cmd.Flags().StringSliceVar(&config.ValidatorPubkeys, "validator-public-keys", nil, "Comma separated list of validator public keys to print duties of. All validators if empty.")
+	cmd.Flags().BoolVar(&config.JSON, "json", false, "Print the full duty records as JSON.")
+	mustMarkFlagRequired(cmd, "duty-history-dir")
+
+	return cmd
+}
+
+func runDebugDuties(w io.Writer, config debugDutiesConfig) error {
+	var filter dutyhistory.Filter
+	for _, slot := range config.Slots {
+		filter.Slots = append(filter.Slots, uint64(slot))
+	}
+	for _, pubkey := range config.ValidatorPubkeys {
+		filter.PubKeys = append(filter.PubKeys, core.PubKey(pubkey))
+	}
+
+	records, err := dutyhistory.Query(config.DutyHistoryDir, filter)
+	if err != nil {
+		return err
+	}
+
+	if config.JSON {
+		b, err := json.MarshalIndent(records, "", " ")
+		if err != nil {
+			return errors.Wrap(err, "marshal duty records")
+		}
+
+		if _, err := fmt.Fprintln(w, string(b)); err != nil {
+			return errors.Wrap(err, "write duty records")
+		}
+
+		return nil
+	}
+
+	if len(records) == 0 {
+		if _, err := fmt.Fprintln(w, "No duties found"); err != nil {
+			return errors.Wrap(err, "write duty records")
+		}
+
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SLOT\tDUTY\tRESULT\tSTEP\tINCLUDED\tABSENT PEERS\tREASON")
+
+	for _, record := range records {
+		result := "success"
+		if record.Failed {
+			result = "failed"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			record.Slot, record.DutyType, result, record.Step,
+			formatInclusion(record.Validators), formatAbsentPeers(record), formatReason(record))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "write duty records")
+	}
+
+	return nil
+}
+
+// formatInclusion returns the number of validators included on-chain out of those checked, or "-" if none were checked.
+func formatInclusion(vals []dutyhistory.Validator) string {
+	var checked, included int
+	for _, val := range vals {
+		switch val.Inclusion {
+		case dutyhistory.InclusionIncluded:
+			checked++
+			included++
+		case dutyhistory.InclusionNotIncluded:
+			checked++
+		}
+	}
+
+	if checked == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d/%d", included, checked)
+}
+
+// formatAbsentPeers returns the sorted names of peers that didn't submit the expected partial signatures.
+func formatAbsentPeers(record dutyhistory.Record) string {
+	if record.ExpectedPerPeer == 0 {
+		return "-"
+	}
+
+	var absent []string
+	for name, count := range record.Participation {
+		if count < record.ExpectedPerPeer {
+			absent = append(absent, name)
+		}
+	}
+
+	if len(absent) == 0 {
+		return "-"
+	}
+
+	slices.Sort(absent)
+
+	return strings.Join(absent, ",")
+}
+
+// formatReason returns the failure reason and error of the record, or "-" if it didn't fail.
+func formatReason(record dutyhistory.Record) string {
+	if !record.Failed {
+		return "-"
+	}
+
+	reason := record.Reason
+	if record.Error != "" {
+		reason += ": " + record.Error
+	}
+
+	return reason
+}
@@ -0,0 +1,80 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/dutyhistory"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/tracker"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestDebugDuties(t *testing.T) {
+	dir := t.TempDir()
+
+	const (
+		pk1 = core.PubKey("0x1111")
+		pk2 = core.PubKey("0x2222")
+	)
+
+	history, err := dutyhistory.New(dir, 1<<20, []p2p.Peer{{Name: "alice", Index: 0}, {Name: "bob", Index: 1}})
+	require.NoError(t, err)
+
+	history.Add(context.Background(), tracker.Outcome{
+		Duty:            core.NewAttesterDuty(1),
+		Step:            "chain_inclusion",
+		Participation:   map[int]int{1: 1},
+		ExpectedPerPeer: 1,
+		PubKeys:         []core.PubKey{pk1, pk2},
+		Inclusions:      map[core.PubKey]error{pk1: nil, pk2: errors.New("duty not included on-chain")},
+	})
+	history.Add(context.Background(), tracker.Outcome{
+		Duty:       core.NewProposerDuty(2),
+		Failed:     true,
+		Step:       "consensus",
+		ReasonCode: "no_consensus",
+		Reason:     "consensus algorithm didn't complete",
+		Error:      "consensus timeout",
+		PubKeys:    []core.PubKey{pk2},
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runDebugDuties(&buf, debugDutiesConfig{DutyHistoryDir: dir}))
+		require.Equal(t, ""+
+			"SLOT  DUTY      RESULT   STEP             INCLUDED  ABSENT PEERS  REASON\n"+
+			"1     attester  success  chain_inclusion  1/2       bob           -\n"+
+			"2     proposer  failed   consensus        -         -             consensus algorithm didn't complete: consensus timeout\n",
+			buf.String())
+	})
+
+	t.Run("filtered json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runDebugDuties(&buf, debugDutiesConfig{
+			DutyHistoryDir:   dir,
+			Slots:            []uint{1, 2},
+			ValidatorPubkeys: []string{string(pk1)},
+			JSON:             true,
+		}))
+
+		var records []dutyhistory.Record
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+		require.Len(t, records, 1)
+		require.EqualValues(t, 1, records[0].Slot)
+		require.Equal(t, []dutyhistory.Validator{{PubKey: string(pk1), Inclusion: dutyhistory.InclusionIncluded}}, records[0].Validators)
+	})
+
+	t.Run("no duties", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runDebugDuties(&buf, debugDutiesConfig{DutyHistoryDir: dir, Slots: []uint{3}}))
+		require.Equal(t, "No duties found\n", buf.String())
+	})
+}
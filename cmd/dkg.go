@@ -39,6 +39,7 @@ this command at the same time.`,
 
 	bindDataDirFlag(cmd.Flags(), &config.DataDir)
 	bindKeymanagerFlags(cmd.Flags(), &config.KeymanagerAddr, &config.KeymanagerAuthToken)
+	cmd.Flags().StringVar(&config.RemoteSignerAddr, "remote-signer-address", "", "Base URL of a remote signer holding this node's keyshares. The keyshares are imported to it via --keymanager-address after the cluster lock is signed and are not stored on disk.")
 	bindDefDirFlag(cmd.Flags(), &config.DefFile)
 	bindNoVerifyFlag(cmd.Flags(), &config.NoVerify)
 	bindP2PFlags(cmd, &config.P2P)
@@ -14,6 +14,7 @@ import (
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/sharesigner"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	"github.com/obolnetwork/charon/tbls"
 )
@@ -26,6 +27,7 @@ type exitConfig struct {
 	SkipBeaconNodeCheck   bool
 	PrivateKeyPath        string
 	ValidatorKeysDir      string
+	RemoteSignerAddress   string
 	LockFilePath          string
 	PublishAddress        string
 	PublishTimeout        time.Duration
@@ -60,6 +62,7 @@ const (
 	privateKeyPath
 	lockFilePath
 	validatorKeysDir
+	remoteSignerAddress
 	validatorPubkey
 	exitEpoch
 	exitFromFile
@@ -88,6 +91,8 @@ func (ef exitFlag) String() string {
 		return "lock-file"
 	case validatorKeysDir:
 		return "validator-keys-dir"
+	case remoteSignerAddress:
+		return "remote-signer-address"
 	case validatorPubkey:
 		return "validator-public-key"
 	case exitEpoch:
@@ -149,6 +154,8 @@ func bindExitFlags(cmd *cobra.Command, config *exitConfig, flags []exitCLIFlag)
 			cmd.Flags().StringVar(&config.LockFilePath, lockFilePath.String(), ".charon/cluster-lock.json", maybeRequired("The path to the cluster lock file defining the distributed validator cluster."))
 		case validatorKeysDir:
 			cmd.Flags().StringVar(&config.ValidatorKeysDir, validatorKeysDir.String(), ".charon/validator_keys", maybeRequired("Path to the directory containing the validator private key share files and passwords."))
+		case remoteSignerAddress:
+			cmd.Flags().StringVar(&config.RemoteSignerAddress, remoteSignerAddress.String(), "", maybeRequired("Base URL of a remote Web3Signer-style signer holding the validator private key shares. If set, key shares are not loaded from --validator-keys-dir."))
 		case validatorPubkey:
 			cmd.Flags().StringVar(&config.ValidatorPubkey, validatorPubkey.String(), "", maybeRequired("Public key of the validator to exit, must be present in the cluster lock manifest. If --validator-index is also provided, validator liveliness won't be checked on the beacon chain."))
 		case exitEpoch:
@@ -198,8 +205,8 @@ func eth2Client(ctx context.Context, u []string, timeout time.Duration, forkVers
 	return cl, nil
 }
 
-// signExit signs a voluntary exit message for valIdx with the given keyShare.
-func signExit(ctx context.Context, eth2Cl eth2wrap.Client, valIdx eth2p0.ValidatorIndex, keyShare tbls.PrivateKey, exitEpoch eth2p0.Epoch) (eth2p0.SignedVoluntaryExit, error) {
+// signExit signs a voluntary exit message for valIdx with the key share of the given pubshare.
+func signExit(ctx context.Context, eth2Cl eth2wrap.Client, valIdx eth2p0.ValidatorIndex, signer sharesigner.Signer, pubshare tbls.PublicKey, exitEpoch eth2p0.Epoch) (eth2p0.SignedVoluntaryExit, error) {
 	exit := &eth2p0.VoluntaryExit{
 		Epoch:          exitEpoch,
 		ValidatorIndex: valIdx,
@@ -210,7 +217,7 @@ func signExit(ctx context.Context, eth2Cl eth2wrap.Client, valIdx eth2p0.Validat
 		return eth2p0.SignedVoluntaryExit{}, errors.Wrap(err, "exit hash tree root")
 	}
 
-	sig, err := tbls.Sign(keyShare, sigData[:])
+	sig, err := signer.Sign(ctx, pubshare, sigData[:])
 	if err != nil {
 		return eth2p0.SignedVoluntaryExit{}, errors.Wrap(err, "signing error")
 	}
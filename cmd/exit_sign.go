@@ -18,9 +18,12 @@ import (
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/obolapi"
 	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/sharesigner"
+	"github.com/obolnetwork/charon/tbls"
 )
 
 func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
@@ -48,6 +51,7 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 		{privateKeyPath, false},
 		{lockFilePath, false},
 		{validatorKeysDir, false},
+		{remoteSignerAddress, false},
 		{exitEpoch, false},
 		{validatorPubkey, false},
 		{validatorIndex, false},
@@ -104,24 +108,14 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
 	}
 
-	rawValKeys, err := keystore.LoadFilesUnordered(config.ValidatorKeysDir)
-	if err != nil {
-		return errors.Wrap(err, "load keystore, check if path exists", z.Str("validator_keys_dir", config.ValidatorKeysDir))
-	}
-
-	valKeys, err := rawValKeys.SequencedKeys()
-	if err != nil {
-		return errors.Wrap(err, "load keystore")
-	}
-
-	shares, err := keystore.KeysharesToValidatorPubkey(cl, valKeys)
+	shareIdx, err := keystore.ShareIdxForCluster(cl, *identityKey.PubKey())
 	if err != nil {
-		return errors.Wrap(err, "match local validator key shares with their counterparty in cluster lock")
+		return errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
 	}
 
-	shareIdx, err := keystore.ShareIdxForCluster(cl, *identityKey.PubKey())
+	signer, pubshares, err := loadShareSigner(config.ValidatorKeysDir, config.RemoteSignerAddress, cl, shareIdx)
 	if err != nil {
-		return errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
+		return err
 	}
 
 	oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
@@ -147,12 +141,12 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 
 	var exitBlobs []obolapi.ExitBlob
 	if config.All {
-		exitBlobs, err = signAllValidatorsExits(ctx, config, eth2Cl, shares)
+		exitBlobs, err = signAllValidatorsExits(ctx, config, eth2Cl, signer, pubshares)
 		if err != nil {
 			return errors.Wrap(err, "sign exits for all validators")
 		}
 	} else {
-		exitBlobs, err = signSingleValidatorExit(ctx, config, eth2Cl, shares)
+		exitBlobs, err = signSingleValidatorExit(ctx, config, eth2Cl, signer, pubshares)
 		if err != nil {
 			return errors.Wrap(err, "sign exit for validator")
 		}
@@ -165,7 +159,61 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 	return nil
 }
 
-func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
+// loadShareSigner returns the signer of partial signatures and the public shares of this operator by validator.
+// Secret shares are loaded from the validator keys dir unless a remote signer address is configured.
+func loadShareSigner(validatorKeysDir, remoteSignerAddr string, cl *manifestpb.Cluster, shareIdx uint64) (sharesigner.Signer, map[core.PubKey]tbls.PublicKey, error) {
+	pubshares := make(map[core.PubKey]tbls.PublicKey)
+
+	if remoteSignerAddr != "" {
+		for _, val := range cl.GetValidators() {
+			if shareIdx == 0 || int(shareIdx) > len(val.GetPubShares()) {
+				return nil, nil, errors.New("invalid cluster lock public shares", z.Hex("validator", val.GetPublicKey()))
+			}
+
+			pubshares[core.PubKey(fmt.Sprintf("%#x", val.GetPublicKey()))] = tbls.PublicKey(val.GetPubShares()[shareIdx-1])
+		}
+
+		signer, err := sharesigner.NewRemote(remoteSignerAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return signer, pubshares, nil
+	}
+
+	rawValKeys, err := keystore.LoadFilesUnordered(validatorKeysDir)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "load keystore, check if path exists", z.Str("validator_keys_dir", validatorKeysDir))
+	}
+
+	valKeys, err := rawValKeys.SequencedKeys()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "load keystore")
+	}
+
+	shares, err := keystore.KeysharesToValidatorPubkey(cl, valKeys)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "match local validator key shares with their counterparty in cluster lock")
+	}
+
+	for pk, share := range shares {
+		pubshare, err := tbls.SecretToPublicKey(share.Share)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "private share to public share")
+		}
+
+		pubshares[pk] = pubshare
+	}
+
+	signer, err := sharesigner.NewLocal(valKeys...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return signer, pubshares, nil
+}
+
+func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, signer sharesigner.Signer, pubshares map[core.PubKey]tbls.PublicKey) ([]obolapi.ExitBlob, error) {
 	valEth2, err := fetchValidatorBLSPubKey(ctx, config, eth2Cl)
 	if err != nil {
 		return nil, errors.Wrap(err, "fetch validator public key")
@@ -173,7 +221,7 @@ func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2
 
 	validator := core.PubKeyFrom48Bytes(valEth2)
 
-	ourShare, ok := shares[validator]
+	pubshare, ok := pubshares[validator]
 	if !ok {
 		return nil, errors.New("validator not present in cluster lock", z.Str("validator", validator.String()))
 	}
@@ -185,7 +233,7 @@ func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2
 
 	log.Info(ctx, "Signing partial exit message for validator", z.Str("validator_public_key", valEth2.String()), z.U64("validator_index", uint64(valIndex)))
 
-	exitMsg, err := signExit(ctx, eth2Cl, valIndex, ourShare.Share, eth2p0.Epoch(config.ExitEpoch))
+	exitMsg, err := signExit(ctx, eth2Cl, valIndex, signer, pubshare, eth2p0.Epoch(config.ExitEpoch))
 	if err != nil {
 		return nil, errors.Wrap(err, "sign partial exit message", z.Str("validator_public_key", valEth2.String()), z.U64("validator_index", uint64(valIndex)), z.Int("exit_epoch", int(config.ExitEpoch)))
 	}
@@ -198,9 +246,9 @@ func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2
 	}, nil
 }
 
-func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
+func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, signer sharesigner.Signer, pubshares map[core.PubKey]tbls.PublicKey) ([]obolapi.ExitBlob, error) {
 	var valsEth2 []eth2p0.BLSPubKey
-	for pk := range shares {
+	for pk := range pubshares {
 		eth2PK, err := pk.ToETH2()
 		if err != nil {
 			return nil, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Str("pub_key", eth2PK.String()))
@@ -213,22 +261,23 @@ func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2w
 		return nil, errors.Wrap(err, "fetch all validators indices from beacon")
 	}
 
+	valIndices := make(map[core.PubKey]eth2p0.ValidatorIndex)
 	for _, val := range rawValData.Data {
-		share, ok := shares[core.PubKeyFrom48Bytes(val.Validator.PublicKey)]
-		if !ok {
+		pk := core.PubKeyFrom48Bytes(val.Validator.PublicKey)
+		if _, ok := pubshares[pk]; !ok {
 			return nil, errors.New("validator public key not found in cluster lock", z.Str("validator_public_key", val.Validator.PublicKey.String()))
 		}
-		share.Index = int(val.Index)
-		shares[core.PubKeyFrom48Bytes(val.Validator.PublicKey)] = share
+		valIndices[pk] = val.Index
 	}
 
 	log.Info(ctx, "Signing partial exit message for all active validators")
 
 	var exitBlobs []obolapi.ExitBlob
-	for pk, share := range shares {
-		exitMsg, err := signExit(ctx, eth2Cl, eth2p0.ValidatorIndex(share.Index), share.Share, eth2p0.Epoch(config.ExitEpoch))
+	for pk, pubshare := range pubshares {
+		valIndex := valIndices[pk]
+		exitMsg, err := signExit(ctx, eth2Cl, valIndex, signer, pubshare, eth2p0.Epoch(config.ExitEpoch))
 		if err != nil {
-			return nil, errors.Wrap(err, "sign partial exit message", z.Str("validator_public_key", pk.String()), z.Int("validator_index", share.Index), z.Int("exit_epoch", int(config.ExitEpoch)))
+			return nil, errors.Wrap(err, "sign partial exit message", z.Str("validator_public_key", pk.String()), z.U64("validator_index", uint64(valIndex)), z.Int("exit_epoch", int(config.ExitEpoch)))
 		}
 		eth2PK, err := pk.ToETH2()
 		if err != nil {
@@ -239,7 +288,7 @@ func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2w
 			SignedExitMessage: exitMsg,
 		}
 		exitBlobs = append(exitBlobs, exitBlob)
-		log.Info(ctx, "Successfully signed exit message", z.Str("validator_public_key", pk.String()), z.Int("validator_index", share.Index))
+		log.Info(ctx, "Successfully signed exit message", z.Str("validator_public_key", pk.String()), z.U64("validator_index", uint64(valIndex)))
 	}
 
 	return exitBlobs, nil
@@ -27,6 +27,7 @@ import (
 	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
 	"github.com/obolnetwork/charon/testutil/obolapimock"
+	"github.com/obolnetwork/charon/testutil/remotesignermock"
 )
 
 //nolint:unparam // we mostly pass "4" for operatorAmt but we might change it later.
@@ -68,6 +69,7 @@ func Test_runSubmitPartialExit(t *testing.T) {
 			0,
 			"convert core pubkey to eth2 pubkey",
 			false,
+			false,
 		)
 	})
 
@@ -80,6 +82,7 @@ func Test_runSubmitPartialExit(t *testing.T) {
 			0,
 			"validator not present in cluster lock",
 			false,
+			false,
 		)
 	})
 
@@ -92,6 +95,7 @@ func Test_runSubmitPartialExit(t *testing.T) {
 			9999,
 			"validator index not found in beacon node response",
 			false,
+			false,
 		)
 	})
 
@@ -104,6 +108,7 @@ func Test_runSubmitPartialExit(t *testing.T) {
 			9999,
 			"convert core pubkey to eth2 pubkey",
 			false,
+			false,
 		)
 	})
 
@@ -116,26 +121,33 @@ func Test_runSubmitPartialExit(t *testing.T) {
 			9999,
 			"validator not present in cluster lock",
 			false,
+			false,
 		)
 	})
 
 	t.Run("main flow with pubkey", func(t *testing.T) {
-		runSubmitPartialExitFlowTest(t, false, false, "", 0, "", false)
+		runSubmitPartialExitFlowTest(t, false, false, "", 0, "", false, false)
 	})
 	t.Run("main flow with validator index", func(t *testing.T) {
-		runSubmitPartialExitFlowTest(t, true, false, "", 0, "", false)
+		runSubmitPartialExitFlowTest(t, true, false, "", 0, "", false, false)
 	})
 	t.Run("main flow with skipBeaconNodeCheck mode", func(t *testing.T) {
-		runSubmitPartialExitFlowTest(t, true, true, "", 0, "", false)
+		runSubmitPartialExitFlowTest(t, true, true, "", 0, "", false, false)
 	})
 	t.Run("main flow with all mode", func(t *testing.T) {
-		runSubmitPartialExitFlowTest(t, false, false, "", 0, "", true)
+		runSubmitPartialExitFlowTest(t, false, false, "", 0, "", true, false)
+	})
+	t.Run("main flow with remote signer", func(t *testing.T) {
+		runSubmitPartialExitFlowTest(t, false, false, "", 0, "", false, true)
+	})
+	t.Run("main flow with all mode and remote signer", func(t *testing.T) {
+		runSubmitPartialExitFlowTest(t, false, false, "", 0, "", true, true)
 	})
 
 	t.Run("config", Test_runSubmitPartialExit_Config)
 }
 
-func runSubmitPartialExitFlowTest(t *testing.T, useValIdx bool, skipBeaconNodeCheck bool, valPubkey string, valIndex uint64, errString string, all bool) {
+func runSubmitPartialExitFlowTest(t *testing.T, useValIdx bool, skipBeaconNodeCheck bool, valPubkey string, valIndex uint64, errString string, all bool, remoteSigner bool) {
 	t.Helper()
 	t.Parallel()
 	ctx := context.Background()
@@ -213,6 +225,16 @@ func runSubmitPartialExitFlowTest(t *testing.T, useValIdx bool, skipBeaconNodeCh
 		All:                 all,
 	}
 
+	if remoteSigner {
+		signerHandler, _, err := remotesignermock.MockServer(operatorShares[0]...)
+		require.NoError(t, err)
+		signerSrv := httptest.NewServer(signerHandler)
+		defer signerSrv.Close()
+
+		config.RemoteSignerAddress = signerSrv.URL
+		config.ValidatorKeysDir = filepath.Join(baseDir, "missing")
+	}
+
 	index := uint64(0)
 	pubkey := lock.Validators[0].PublicKeyHex()
 
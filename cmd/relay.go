@@ -51,6 +51,7 @@ func bindRelayFlag(cmd *cobra.Command, config *relay.Config) {
 	// Decrease defaults after this has been addressed https://github.com/libp2p/go-libp2p/issues/1713
 	cmd.Flags().IntVar(&config.MaxResPerPeer, "p2p-max-reservations", 512, "Updates max circuit reservations per peer (each valid for 30min)")
 	cmd.Flags().IntVar(&config.MaxConns, "p2p-max-connections", 16384, "Libp2p maximum number of peers that can connect to this relay.")
+	cmd.Flags().StringSliceVar(&config.AllowlistPaths, "allowlist-cluster-files", nil, "Comma-separated list of cluster lock (.json) or manifest (.pb) files, or directories containing them. If set, relay circuits are limited to the operator peers of these clusters. Changed files are reloaded automatically.")
 
 	var advertisePriv bool
 	cmd.Flags().BoolVar(&advertisePriv, "p2p-advertise-private-addresses", false, "Enable advertising of libp2p auto-detected private addresses. This doesn't affect manually provided p2p-external-ip/hostname.")
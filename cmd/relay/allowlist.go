@@ -0,0 +1,203 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package relay
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+)
+
+// allowlistReloadPeriod is the period at which allowlist files are checked for changes.
+const allowlistReloadPeriod = 30 * time.Second
+
+var _ relay.ACLFilter = (*allowlist)(nil)
+
+// newAllowlist returns a new allowlist of the operator peers of all cluster lock or manifest files
+// in the provided paths. Paths may be files or directories containing them.
+func newAllowlist(paths []string) (*allowlist, error) {
+	a := &allowlist{paths: paths}
+	if _, err := a.reload(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// allowlist is a relay ACL filter that limits circuit reservations and relayed connections
+// to the operator peers of a set of clusters.
+type allowlist struct {
+	paths []string
+
+	mu       sync.RWMutex
+	peers    map[peer.ID]bool
+	modTimes map[string]time.Time
+}
+
+// AllowReserve returns true if the peer is allowed to reserve a relay circuit.
+func (a *allowlist) AllowReserve(p peer.ID, _ ma.Multiaddr) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.peers[p]
+}
+
+// AllowConnect returns true if both the source and destination peers are allowed to use the relay.
+func (a *allowlist) AllowConnect(src peer.ID, _ ma.Multiaddr, dest peer.ID) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.peers[src] && a.peers[dest]
+}
+
+// Run blocks and reloads the allowlist when its files change until the context is cancelled.
+// The previous allowlist is retained if reloading fails.
+func (a *allowlist) Run(ctx context.Context) {
+	ticker := time.NewTicker(allowlistReloadPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := a.reload()
+			if err != nil {
+				log.Warn(ctx, "Failed reloading relay allowlist, retaining previous", err)
+			} else if reloaded {
+				log.Info(ctx, "Reloaded relay allowlist", z.Int("peers", a.size()))
+			}
+		}
+	}
+}
+
+// size returns the number of allowed peers.
+func (a *allowlist) size() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return len(a.peers)
+}
+
+// reload loads the allowed peers from the allowlist files if they changed since the previous load.
+// It returns true if the allowlist was reloaded.
+func (a *allowlist) reload() (bool, error) {
+	files, err := allowlistFiles(a.paths)
+	if err != nil {
+		return false, err
+	}
+
+	modTimes := make(map[string]time.Time)
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, errors.Wrap(err, "stat allowlist file", z.Str("file", file))
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	a.mu.RLock()
+	unchanged := a.peers != nil && maps.EqualFunc(a.modTimes, modTimes, time.Time.Equal)
+	a.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	peers := make(map[peer.ID]bool)
+	for _, file := range files {
+		peerIDs, err := loadClusterPeerIDs(file)
+		if err != nil {
+			return false, err
+		}
+
+		for _, pID := range peerIDs {
+			peers[pID] = true
+		}
+	}
+
+	allowlistPeersGauge.Set(float64(len(peers)))
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.peers = peers
+	a.modTimes = modTimes
+
+	return true, nil
+}
+
+// allowlistFiles returns the cluster lock (.json) and manifest (.pb) files of the paths.
+// Directories are not searched recursively.
+func allowlistFiles(paths []string) ([]string, error) {
+	var resp []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "stat allowlist path", z.Str("path", path))
+		}
+
+		if !info.IsDir() {
+			resp = append(resp, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "read allowlist dir", z.Str("path", path))
+		}
+
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".json" && ext != ".pb") {
+				continue
+			}
+
+			resp = append(resp, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	if len(resp) == 0 {
+		return nil, errors.New("no cluster lock or manifest files found in allowlist paths")
+	}
+
+	slices.Sort(resp)
+
+	return slices.Compact(resp), nil
+}
+
+// loadClusterPeerIDs returns the operator peer IDs of the cluster lock (.json) or manifest file.
+func loadClusterPeerIDs(file string) ([]peer.ID, error) {
+	var manifestFile, lockFile string
+	if strings.HasSuffix(file, ".json") {
+		lockFile = file
+	} else {
+		manifestFile = file
+	}
+
+	cluster, err := manifest.LoadCluster(manifestFile, lockFile, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "load allowlist cluster", z.Str("file", file))
+	}
+
+	peerIDs, err := manifest.ClusterPeerIDs(cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "allowlist cluster peer ids", z.Str("file", file))
+	}
+
+	return peerIDs, nil
+}
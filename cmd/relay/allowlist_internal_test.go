@@ -0,0 +1,97 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package relay
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+)
+
+func TestAllowlist(t *testing.T) {
+	dir := t.TempDir()
+
+	lock1, _, _ := cluster.NewForT(t, 1, 3, 4, 10, rand.New(rand.NewSource(10)))
+	lock2, _, _ := cluster.NewForT(t, 1, 3, 4, 20, rand.New(rand.NewSource(20)))
+	lock3, _, _ := cluster.NewForT(t, 1, 3, 4, 30, rand.New(rand.NewSource(30)))
+
+	// Cluster 1 is a lock file, cluster 2 a manifest file in a directory.
+	lockFile := filepath.Join(t.TempDir(), "cluster-lock.json")
+	writeLock(t, lockFile, lock1)
+
+	dag, err := manifest.NewDAGFromLockForT(t, lock2)
+	require.NoError(t, err)
+	b, err := proto.Marshal(dag)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cluster-manifest.pb"), b, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644))
+
+	acl, err := newAllowlist([]string{lockFile, dir})
+	require.NoError(t, err)
+
+	peers1 := lockPeerIDs(t, lock1)
+	peers2 := lockPeerIDs(t, lock2)
+	peers3 := lockPeerIDs(t, lock3)
+
+	require.Equal(t, 8, acl.size())
+	require.True(t, acl.AllowReserve(peers1[0], nil))
+	require.True(t, acl.AllowReserve(peers2[0], nil))
+	require.False(t, acl.AllowReserve(peers3[0], nil))
+	require.True(t, acl.AllowConnect(peers1[0], nil, peers1[1]))
+	require.False(t, acl.AllowConnect(peers3[0], nil, peers1[1]))
+	require.False(t, acl.AllowConnect(peers1[0], nil, peers3[1]))
+
+	// Unchanged files are not reloaded.
+	reloaded, err := acl.reload()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	// Replace cluster 1 with cluster 3.
+	writeLock(t, lockFile, lock3)
+	require.NoError(t, os.Chtimes(lockFile, time.Time{}, time.Now().Add(time.Minute)))
+
+	reloaded, err = acl.reload()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	require.False(t, acl.AllowReserve(peers1[0], nil))
+	require.True(t, acl.AllowReserve(peers2[0], nil))
+	require.True(t, acl.AllowReserve(peers3[0], nil))
+
+	// Invalid files fail to reload, retaining the previous allowlist.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.json"), []byte("invalid"), 0o644))
+
+	_, err = acl.reload()
+	require.ErrorContains(t, err, "load allowlist cluster")
+	require.True(t, acl.AllowReserve(peers3[0], nil))
+
+	// No cluster files
+	_, err = newAllowlist([]string{t.TempDir()})
+	require.ErrorContains(t, err, "no cluster lock or manifest files found")
+}
+
+func writeLock(t *testing.T, filename string, lock cluster.Lock) {
+	t.Helper()
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filename, b, 0o644))
+}
+
+func lockPeerIDs(t *testing.T, lock cluster.Lock) []peer.ID {
+	t.Helper()
+
+	peerIDs, err := lock.PeerIDs()
+	require.NoError(t, err)
+
+	return peerIDs
+}
@@ -48,6 +48,13 @@ var (
 		Name:      "ping_latency",
 		Help:      "Ping latency by peer and cluster",
 	}, []string{"peer", "peer_cluster"})
+
+	allowlistPeersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "relay",
+		Subsystem: "p2p",
+		Name:      "allowlist_peers",
+		Help:      "Current number of peers allowed to use the relay if limited to an allowlist of clusters",
+	})
 )
 
 // newBandwidthCounter returns a new bandwidth counter that stops counting when the context is cancelled.
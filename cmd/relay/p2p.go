@@ -67,7 +67,21 @@ func startP2P(ctx context.Context, config Config, key *k1.PrivateKey, reporter m
 
 	// This enables relay metrics: https://github.com/libp2p/go-libp2p/blob/master/p2p/protocol/circuitv2/relay/metrics.go
 	mt := relay.NewMetricsTracer(relay.WithRegisterer(promRegistry))
-	relayService, err := relay.New(tcpNode, relay.WithResources(relayResources), relay.WithMetricsTracer(mt))
+	relayOpts := []relay.Option{relay.WithResources(relayResources), relay.WithMetricsTracer(mt)}
+
+	if len(config.AllowlistPaths) > 0 {
+		acl, err := newAllowlist(config.AllowlistPaths)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "load relay allowlist")
+		}
+		go acl.Run(ctx)
+
+		log.Info(ctx, "Relay limited to allowlisted cluster peers", z.Int("peers", acl.size()))
+
+		relayOpts = append(relayOpts, relay.WithACL(acl))
+	}
+
+	relayService, err := relay.New(tcpNode, relayOpts...)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "new relay service")
 	}
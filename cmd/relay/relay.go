@@ -41,6 +41,7 @@ type Config struct {
 	MaxConns        int
 	FilterPrivAddrs bool
 	LibP2PLogLevel  string
+	AllowlistPaths  []string
 }
 
 // Run starts an Obol libp2p-tcp-relay and udp-discv5 bootnode.
@@ -210,14 +211,27 @@ func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey
 			return false
 		})
 
-		// Use first address (ip and port).
-		addr, err := manet.ToNetAddr(addrs[0])
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to convert multiaddr to net addr")
+		// Use first TCP address (ip and port) and first QUIC address (port) if any.
+		var (
+			tcpAddr  *net.TCPAddr
+			quicPort int
+		)
+		for _, maddr := range addrs {
+			addr, err := manet.ToNetAddr(maddr)
+			if err != nil {
+				continue // Ignore unsupported addresses.
+			}
+
+			if a, ok := addr.(*net.TCPAddr); ok && tcpAddr == nil {
+				tcpAddr = a
+			} else if a, ok := addr.(*net.UDPAddr); ok && quicPort == 0 {
+				if _, err := maddr.ValueForProtocol(ma.P_QUIC_V1); err == nil {
+					quicPort = a.Port
+				}
+			}
 		}
-		tcpAddr, ok := addr.(*net.TCPAddr)
-		if !ok {
-			return nil, errors.New("invalid TCP address")
+		if tcpAddr == nil {
+			return nil, errors.New("no TCP address")
 		}
 
 		// Override IP with external IP or external hostname if set.
@@ -227,8 +241,17 @@ func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey
 			tcpAddr.IP = extHostIP
 		}
 
+		opts := []enr.Option{
+			enr.WithIP(tcpAddr.IP),
+			enr.WithTCP(tcpAddr.Port),
+			enr.WithUDP(9999), // Include invalid dummy UDP port so v0.13 can parse the ENR.
+		}
+		if quicPort != 0 {
+			opts = append(opts, enr.WithQUIC(quicPort))
+		}
+
 		// Build the ENR
-		r, err := enr.New(p2pKey, enr.WithIP(tcpAddr.IP), enr.WithTCP(tcpAddr.Port), enr.WithUDP(9999)) // Include invalid dummy UDP port so v0.13 can parse the ENR.
+		r, err := enr.New(p2pKey, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,60 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/dkg"
+)
+
+func newReshareCmd(runFunc func(context.Context, dkg.ReshareConfig) error) *cobra.Command {
+	var config dkg.ReshareConfig
+
+	cmd := &cobra.Command{
+		Use:   "reshare",
+		Short: "Participate in a key resharing ceremony",
+		Long: `Participate in a key resharing ceremony that changes the operators or threshold of an existing cluster
+without changing the distributed validator public keys. All existing operators reshare their validator key shares to the
+new operator set, creating new key shares, a new cluster lock and a cluster manifest, without reconstructing the full
+validator private keys. The existing operators also approve the resulting reshare cluster manifest mutation. Note that all
+existing and new operators should run this command at the same time, existing operators not part of the new operator set
+only deal and approve. The new cluster manifest and new cluster lock are alternatives, since the cluster manifest starts
+from the existing cluster lock, so only one of them should be used.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printLicense(cmd.Context())
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindDataDirFlag(cmd.Flags(), &config.DataDir)
+	bindNoVerifyFlag(cmd.Flags(), &config.NoVerify)
+	bindP2PFlags(cmd, &config.P2P)
+	bindLogFlags(cmd.Flags(), &config.Log)
+	bindShutdownDelayFlag(cmd.Flags(), &config.ShutdownDelay)
+
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the existing cluster lock file.")
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the existing cluster manifest file. A new cluster manifest is created from the cluster lock if it doesn't exist.")
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "The directory containing the existing validator key shares. Only required for existing operators.")
+	cmd.Flags().StringSliceVar(&config.NewOperatorENRs, "new-operator-enrs", nil, "[REQUIRED] Comma-separated list of each new operator's Charon ENR address.")
+	cmd.Flags().IntVar(&config.NewThreshold, "new-threshold", 0, "Optional override of the new threshold required for signature reconstruction. Defaults to ceil(n*2/3) if zero. Warning, non-default values decrease security.")
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".charon/reshare", "The directory where the new validator key shares, cluster lock and cluster manifest are written to.")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 1*time.Minute, "Timeout for the reshare process, should be increased if reshare times out.")
+
+	mustMarkFlagRequired(cmd, "new-operator-enrs")
+
+	return cmd
+}
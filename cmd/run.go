@@ -12,9 +12,11 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/builderrelay"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/tracer"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/p2p"
 )
@@ -77,12 +79,25 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", eth2ClientTimeout, "Timeout for the HTTP requests Charon makes to the configured beacon nodes.")
 	cmd.Flags().DurationVar(&config.BeaconNodeSubmitTimeout, "beacon-node-submit-timeout", eth2ClientTimeout, "Timeout for the submission-related HTTP requests Charon makes to the configured beacon nodes.")
 	cmd.Flags().StringVar(&config.ValidatorAPIAddr, "validator-api-address", "127.0.0.1:3600", "Listening address (ip and port) for validator-facing traffic proxying the beacon-node API.")
-	cmd.Flags().StringVar(&config.JaegerAddr, "jaeger-address", "", "Listening address for jaeger tracing.")
-	cmd.Flags().StringVar(&config.JaegerService, "jaeger-service", "charon", "Service name used for jaeger tracing.")
+	cmd.Flags().StringVar(&config.OTLPAddress, "otlp-address", "", "Endpoint URL of the OpenTelemetry collector traces are exported to, e.g. http://localhost:4317. Tracing is disabled if empty.")
+	cmd.Flags().StringVar(&config.OTLPProtocol, "otlp-protocol", tracer.ProtocolGRPC, "OTLP protocol used to export traces: grpc or http.")
+	cmd.Flags().StringVar(&config.OTLPServiceName, "otlp-service-name", "charon", "Service name of exported traces.")
+	cmd.Flags().Float64Var(&config.OTLPSampleRatio, "otlp-sample-ratio", 1, "Ratio of duty traces exported, between 0 and 1. Sampling is consistent across the cluster's nodes.")
 	cmd.Flags().BoolVar(&config.SimnetBMock, "simnet-beacon-mock", false, "Enables an internal mock beacon node for running a simnet.")
 	cmd.Flags().BoolVar(&config.SimnetVMock, "simnet-validator-mock", false, "Enables an internal mock validator client when running a simnet. Requires simnet-beacon-mock.")
 	cmd.Flags().StringVar(&config.SimnetValidatorKeysDir, "simnet-validator-keys-dir", ".charon/validator_keys", "The directory containing the simnet validator key shares.")
+	cmd.Flags().StringVar(&config.SimnetRemoteSignerAddr, "simnet-remote-signer-address", "", "Base URL of a remote Web3Signer-style signer holding the simnet validator key shares. If set, key shares are not loaded from simnet-validator-keys-dir.")
 	cmd.Flags().BoolVar(&config.BuilderAPI, "builder-api", false, "Enables the builder api. Will only produce builder blocks. Builder API must also be enabled on the validator client. Beacon node must be connected to a builder-relay to access the builder network.")
+	cmd.Flags().StringSliceVar(&config.BuilderRelays, "builder-relays", nil, "Comma separated list of builder relay URLs charon submits validator registrations to and obtains bids from directly, replacing MEV-Boost. Each relay URL must include the relay public key as URL user to verify bids, e.g. https://0xpubkey@relay.example.com. Requires builder-api. The beacon node's builder endpoint must be configured to builder-relay-api-address.")
+	cmd.Flags().StringVar(&config.BuilderMinBid, "builder-min-bid", "0", "Minimum builder relay bid value in ETH as a decimal, e.g. 0.05. Local blocks are proposed if no relay bid meets the minimum.")
+	cmd.Flags().StringVar(&config.BuilderRelayAPIAddr, "builder-relay-api-address", "127.0.0.1:18550", "Listening address (ip and port) for the builder API served to the beacon node when builder-relays are configured.")
+	cmd.Flags().StringVar(&config.ProposerOverridesFile, "proposer-overrides-file", "", "Path to a YAML or JSON file overriding fee recipient, gas limit and builder enablement of validators. The file is reloaded when changed and must be identical for all peers.")
+	cmd.Flags().StringVar(&config.KeymanagerAPIAddr, "keymanager-api-address", "", "Listening address (ip and port) for the Keymanager API listing distributed validators and updating their fee recipient, gas limit and graffiti. Disabled if empty.")
+	cmd.Flags().StringVar(&config.KeymanagerAPITokenFile, "keymanager-api-token-file", "", "Path to the file containing the bearer token authenticating Keymanager API requests. Required if keymanager-api-address is set.")
+	cmd.Flags().StringVar(&config.Web3SignerAddr, "web3signer-address", "", "Listening address (ip and port) for the Web3Signer compatible remote signing API for validator clients, signing with the key shares in web3signer-keys-dir. Disabled if empty.")
+	cmd.Flags().StringVar(&config.Web3SignerKeysDir, "web3signer-keys-dir", ".charon/validator_keys", "Directory containing the node's validator key share keystores used by the Web3Signer API.")
+	cmd.Flags().StringVar(&config.Web3SignerTokenFile, "web3signer-token-file", "", "Path to the file containing the bearer token authenticating Web3Signer API requests. Required if web3signer-address is set.")
+	cmd.Flags().Uint64Var(&config.DoppelgangerEpochs, "doppelganger-epochs", 0, "Enables doppelganger protection by refusing partial signatures for the number of epochs after startup until no duplicate validators or cluster peers are detected. Zero disables it.")
 	cmd.Flags().BoolVar(&config.SyntheticBlockProposals, "synthetic-block-proposals", false, "Enables additional synthetic block proposal duties. Used for testing of rare duties.")
 	cmd.Flags().DurationVar(&config.SimnetSlotDuration, "simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock.")
 	cmd.Flags().BoolVar(&config.SimnetBMockFuzz, "simnet-beacon-mock-fuzz", false, "Configures simnet beaconmock to return fuzzed responses.")
@@ -93,12 +108,70 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().StringVar(&config.TestnetConfig.CapellaHardFork, "testnet-capella-hard-fork", "", "Capella hard fork version of the custom test network.")
 	cmd.Flags().StringVar(&config.ProcDirectory, "proc-directory", "", "Directory to look into in order to detect other stack components running on the host.")
 	cmd.Flags().StringVar(&config.ConsensusProtocol, "consensus-protocol", "", "Preferred consensus protocol name for the node. Selected automatically when not specified.")
+	cmd.Flags().StringVar(&config.DutyDBFile, "dutydb-file", "", "Path to the file persisting slashing protection records of the duty database across restarts. Disk persistence is disabled if empty.")
+	cmd.Flags().StringVar(&config.AggSigDBDir, "aggsigdb-dir", "", "Path to the directory persisting aggregated signed duty data (like randao reveals and selection proofs) across restarts. Disk persistence is disabled if empty.")
+	cmd.Flags().StringVar(&config.DutyHistoryDir, "duty-history-dir", "", "Path to the directory persisting the history of analysed duties, explaining their outcomes via 'charon debug duties' and the monitoring API. Disabled if empty.")
+	cmd.Flags().IntVar(&config.DutyHistoryMaxSizeMB, "duty-history-max-size-mb", 64, "Maximum size in megabytes of the duty history, the oldest duties are discarded when exceeded.")
+	bindDeprecatedJaegerFlags(cmd)
 
 	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
 		if len(config.BeaconNodeAddrs) == 0 && !config.SimnetBMock {
 			return errors.New("either flag 'beacon-node-endpoints' or flag 'simnet-beacon-mock=true' must be specified")
 		}
 
+		if config.KeymanagerAPIAddr != "" && config.KeymanagerAPITokenFile == "" {
+			return errors.New("flag 'keymanager-api-address' requires flag 'keymanager-api-token-file'")
+		}
+
+		if config.Web3SignerAddr != "" && config.Web3SignerTokenFile == "" {
+			return errors.New("flag 'web3signer-address' requires flag 'web3signer-token-file'")
+		}
+
+		if len(config.BuilderRelays) > 0 && !config.BuilderAPI {
+			return errors.New("flag 'builder-relays' requires flag 'builder-api=true'")
+		}
+
+		if _, err := builderrelay.ETHToWei(config.BuilderMinBid); err != nil {
+			return errors.New("flag 'builder-min-bid' must be a decimal ETH amount, e.g. 0.05")
+		}
+
+		if config.OTLPProtocol != tracer.ProtocolGRPC && config.OTLPProtocol != tracer.ProtocolHTTP {
+			return errors.New("flag 'otlp-protocol' must be either 'grpc' or 'http'")
+		}
+
+		if config.OTLPSampleRatio < 0 || config.OTLPSampleRatio > 1 {
+			return errors.New("flag 'otlp-sample-ratio' must be between 0 and 1")
+		}
+
+		if config.DutyHistoryDir != "" && config.DutyHistoryMaxSizeMB <= 0 {
+			return errors.New("flag 'duty-history-max-size-mb' must be positive")
+		}
+
+		return nil
+	})
+}
+
+// bindDeprecatedJaegerFlags binds the hidden jaeger flags replaced by the otlp flags,
+// so existing deployments keep starting, logging a warning if they are set.
+func bindDeprecatedJaegerFlags(cmd *cobra.Command) {
+	replacements := []struct{ Deprecated, Replacement string }{
+		{"jaeger-address", "otlp-address"},
+		{"jaeger-service", "otlp-service-name"},
+	}
+
+	for _, r := range replacements {
+		cmd.Flags().String(r.Deprecated, "", "Deprecated, use --"+r.Replacement+" instead.")
+		_ = cmd.Flags().MarkHidden(r.Deprecated)
+	}
+
+	wrapPreRunE(cmd, func(cmd *cobra.Command, _ []string) error {
+		for _, r := range replacements {
+			if cmd.Flags().Changed(r.Deprecated) {
+				log.Warn(cmd.Context(), "Ignoring deprecated flag, jaeger tracing is replaced by OTLP tracing", nil,
+					z.Str("flag", r.Deprecated), z.Str("replacement", r.Replacement))
+			}
+		}
+
 		return nil
 	})
 }
@@ -124,6 +197,7 @@ func bindP2PFlags(cmd *cobra.Command, config *p2p.Config) {
 	cmd.Flags().StringVar(&config.ExternalIP, "p2p-external-ip", "", "The IP address advertised by libp2p. This may be used to advertise an external IP.")
 	cmd.Flags().StringVar(&config.ExternalHost, "p2p-external-hostname", "", "The DNS hostname advertised by libp2p. This may be used to advertise an external DNS.")
 	cmd.Flags().StringSliceVar(&config.TCPAddrs, "p2p-tcp-address", nil, "Comma-separated list of listening TCP addresses (ip and port) for libP2P traffic. Empty default doesn't bind to local port therefore only supports outgoing connections.")
+	cmd.Flags().StringSliceVar(&config.UDPAddrs, "p2p-udp-addresses", nil, "Comma-separated list of listening UDP addresses (ip and port) for libP2P QUIC traffic. Empty default doesn't bind to local port therefore only supports outgoing QUIC connections.")
 	cmd.Flags().BoolVar(&config.DisableReuseport, "p2p-disable-reuseport", false, "Disables TCP port reuse for outgoing libp2p connections.")
 
 	wrapPreRunE(cmd, func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
@@ -0,0 +1,277 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+)
+
+// Supported message types of the sign command.
+const (
+	signTypeBLSChange           = "bls-to-execution-change"
+	signTypeBuilderRegistration = "builder-registration"
+	signTypeCustomDomain        = "custom-domain"
+	signTypeDeposit             = "deposit"
+)
+
+// signDomainTypes defines the signature domain types of the supported message types, except custom domains.
+var signDomainTypes = map[string]eth2p0.DomainType{
+	signTypeBLSChange:           {0x0a, 0x00, 0x00, 0x00},
+	signTypeBuilderRegistration: {0x00, 0x00, 0x00, 0x01},
+	signTypeDeposit:             {0x03, 0x00, 0x00, 0x00},
+}
+
+type signConfig struct {
+	LockFilePath          string
+	PrivateKeyPath        string
+	ValidatorKeysDir      string
+	RemoteSignerAddress   string
+	PublishAddress        string
+	PublishTimeout        time.Duration
+	PartialsDir           string
+	OutputDir             string
+	Type                  string
+	ValidatorPubkeys      []string
+	ValidatorIndex        uint64
+	ExecutionAddress      string
+	GasLimit              uint64
+	Timestamp             int64
+	DepositAmount         uint64
+	DomainType            string
+	MessageRoot           string
+	GenesisValidatorsRoot string
+	Log                   log.Config
+}
+
+func newSignCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "sign",
+		Short: "Threshold sign eth2 messages with distributed validator key shares.",
+		Long: "Sign BLS to execution changes, builder registrations, deposit data or custom domain messages with each operator's " +
+			"validator key shares, then aggregate the partial signatures exchanged via files or a remote API.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// bindSignCommonFlags binds the flags shared by the partial and aggregate sign commands.
+func bindSignCommonFlags(cmd *cobra.Command, config *signConfig) {
+	cmd.Flags().StringVar(&config.LockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster.")
+	cmd.Flags().StringVar(&config.PrivateKeyPath, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
+	cmd.Flags().StringVar(&config.PublishAddress, "publish-address", "", "The URL of the remote API partial signatures are exchanged via. Partial signatures are exchanged via files in --partials-dir if empty.")
+	cmd.Flags().DurationVar(&config.PublishTimeout, "publish-timeout", 5*time.Minute, "Timeout for remote API requests.")
+	cmd.Flags().StringVar(&config.PartialsDir, "partials-dir", "partial_signatures", "Directory partial signature files are written to and aggregated from, if --publish-address is empty.")
+	cmd.Flags().StringSliceVar(&config.ValidatorPubkeys, "validator-public-keys", nil, "Comma separated list of public keys of the cluster's validators to sign messages for.")
+
+	bindLogFlags(cmd.Flags(), &config.Log)
+}
+
+// signMessage is an eth2 message signed by the sign command.
+type signMessage interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+// customMessage is a custom domain message identified by its root.
+type customMessage struct {
+	Root eth2p0.Root `json:"root"`
+}
+
+func (m customMessage) HashTreeRoot() ([32]byte, error) {
+	return m.Root, nil
+}
+
+// newSignMessage returns the message of the provided type for the validator as configured.
+func newSignMessage(config signConfig, val *manifestpb.Validator) (signMessage, error) {
+	pubkey := eth2p0.BLSPubKey(val.GetPublicKey())
+
+	switch config.Type {
+	case signTypeBLSChange:
+		if len(config.ValidatorPubkeys) != 1 {
+			return nil, errors.New("bls to execution changes are signed for a single validator with its validator index")
+		}
+
+		addr, err := executionAddress(config.ExecutionAddress, val.GetWithdrawalAddress())
+		if err != nil {
+			return nil, err
+		}
+
+		// Only valid for validators with BLS withdrawal credentials derived from the distributed validator key.
+		return &capella.BLSToExecutionChange{
+			ValidatorIndex:     eth2p0.ValidatorIndex(config.ValidatorIndex),
+			FromBLSPubkey:      pubkey,
+			ToExecutionAddress: addr,
+		}, nil
+	case signTypeBuilderRegistration:
+		if config.Timestamp <= 0 {
+			return nil, errors.New("builder registrations require a timestamp identical for all operators")
+		}
+
+		addr, err := executionAddress(config.ExecutionAddress, val.GetFeeRecipientAddress())
+		if err != nil {
+			return nil, err
+		}
+
+		return &eth2v1.ValidatorRegistration{
+			FeeRecipient: addr,
+			GasLimit:     config.GasLimit,
+			Timestamp:    time.Unix(config.Timestamp, 0),
+			Pubkey:       pubkey,
+		}, nil
+	case signTypeDeposit:
+		addr := config.ExecutionAddress
+		if addr == "" {
+			addr = val.GetWithdrawalAddress()
+		}
+
+		msg, err := deposit.NewMessage(pubkey, addr, eth2p0.Gwei(config.DepositAmount))
+		if err != nil {
+			return nil, err
+		}
+
+		return &msg, nil
+	case signTypeCustomDomain:
+		root, err := hex.DecodeString(strings.TrimPrefix(config.MessageRoot, "0x"))
+		if err != nil || len(root) != len(eth2p0.Root{}) {
+			return nil, errors.New("invalid message root", z.Str("message_root", config.MessageRoot))
+		}
+
+		return customMessage{Root: eth2p0.Root(root)}, nil
+	default:
+		return nil, errors.New("unsupported message type", z.Str("type", config.Type))
+	}
+}
+
+// decodeSignMessage returns the JSON encoded message of the provided type.
+func decodeSignMessage(typ string, data json.RawMessage) (signMessage, error) {
+	var msg signMessage
+	switch typ {
+	case signTypeBLSChange:
+		msg = new(capella.BLSToExecutionChange)
+	case signTypeBuilderRegistration:
+		msg = new(eth2v1.ValidatorRegistration)
+	case signTypeDeposit:
+		msg = new(eth2p0.DepositMessage)
+	case signTypeCustomDomain:
+		msg = new(customMessage)
+	default:
+		return nil, errors.New("unsupported message type", z.Str("type", typ))
+	}
+
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, errors.Wrap(err, "unmarshal message", z.Str("type", typ))
+	}
+
+	return msg, nil
+}
+
+// signDomain returns the signature domain of the message type computed with the genesis fork version
+// and the genesis validators root, which is zero for deposits and builder registrations.
+func signDomain(config signConfig, forkVersion []byte) (eth2p0.Domain, error) {
+	domainType, ok := signDomainTypes[config.Type]
+	if config.Type == signTypeCustomDomain {
+		b, err := hex.DecodeString(strings.TrimPrefix(config.DomainType, "0x"))
+		if err != nil || len(b) != len(eth2p0.DomainType{}) {
+			return eth2p0.Domain{}, errors.New("invalid domain type", z.Str("domain_type", config.DomainType))
+		}
+		domainType = eth2p0.DomainType(b)
+	} else if !ok {
+		return eth2p0.Domain{}, errors.New("unsupported message type", z.Str("type", config.Type))
+	}
+
+	var genesisValidatorsRoot eth2p0.Root
+	if config.Type == signTypeBLSChange && config.GenesisValidatorsRoot == "" {
+		return eth2p0.Domain{}, errors.New("bls to execution changes require the genesis validators root")
+	} else if config.GenesisValidatorsRoot != "" && (config.Type == signTypeBLSChange || config.Type == signTypeCustomDomain) {
+		b, err := hex.DecodeString(strings.TrimPrefix(config.GenesisValidatorsRoot, "0x"))
+		if err != nil || len(b) != len(eth2p0.Root{}) {
+			return eth2p0.Domain{}, errors.New("invalid genesis validators root")
+		}
+		genesisValidatorsRoot = eth2p0.Root(b)
+	}
+
+	forkDataRoot, err := (&eth2p0.ForkData{
+		CurrentVersion:        eth2p0.Version(forkVersion),
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return eth2p0.Domain{}, errors.Wrap(err, "hash fork data")
+	}
+
+	var domain eth2p0.Domain
+	copy(domain[:], domainType[:])
+	copy(domain[4:], forkDataRoot[:])
+
+	return domain, nil
+}
+
+// signingRoot returns the signing root of the message and domain.
+func signingRoot(msg signMessage, domain eth2p0.Domain) (eth2p0.Root, error) {
+	msgRoot, err := msg.HashTreeRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "message hash tree root")
+	}
+
+	root, err := (&eth2p0.SigningData{ObjectRoot: msgRoot, Domain: domain}).HashTreeRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "signing data hash tree root")
+	}
+
+	return root, nil
+}
+
+// executionAddress returns the provided execution address, or the fallback if empty.
+func executionAddress(addr, fallback string) (bellatrix.ExecutionAddress, error) {
+	if addr == "" {
+		addr = fallback
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(addr, "0x"))
+	if err != nil || len(b) != len(bellatrix.ExecutionAddress{}) {
+		return bellatrix.ExecutionAddress{}, errors.New("invalid execution address", z.Str("address", addr))
+	}
+
+	return bellatrix.ExecutionAddress(b), nil
+}
+
+// lockValidators returns the cluster's validators with the provided public keys, or all validators if none are provided.
+func lockValidators(cl *manifestpb.Cluster, pubkeys []string) ([]*manifestpb.Validator, error) {
+	if len(pubkeys) == 0 {
+		return cl.GetValidators(), nil
+	}
+
+	var resp []*manifestpb.Validator
+	for _, pubkey := range pubkeys {
+		var found bool
+		for _, val := range cl.GetValidators() {
+			if strings.EqualFold(pubkey, "0x"+hex.EncodeToString(val.GetPublicKey())) {
+				resp = append(resp, val)
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return nil, errors.New("validator not present in cluster lock", z.Str("validator", pubkey))
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,376 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+func newSignAggregateCmd(runFunc func(context.Context, signConfig) error) *cobra.Command {
+	var config signConfig
+
+	cmd := &cobra.Command{
+		Use:   "aggregate",
+		Short: "Aggregate partial signatures of eth2 messages into fully signed messages.",
+		Long: "Aggregates the partial signatures of the cluster's operators read from files or fetched from a remote API. " +
+			"Messages with at least threshold valid partial signatures are written to the output directory.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindSignCommonFlags(cmd, &config)
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".", "Directory the fully signed messages are written to.")
+
+	return cmd
+}
+
+func runSignAggregate(ctx context.Context, config signConfig) error {
+	cl, err := loadClusterManifest("", config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+	}
+
+	vals, err := lockValidators(cl, config.ValidatorPubkeys)
+	if err != nil {
+		return err
+	}
+
+	var psigs []obolapi.PartialSignature
+	if config.PublishAddress != "" {
+		psigs, err = fetchPartialSignatures(ctx, config, cl, vals)
+	} else {
+		psigs, err = readPartialSignatures(config.PartialsDir)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Group the valid partial signatures by validator and signing root.
+	type groupKey struct {
+		Pubkey string
+		Root   string
+	}
+	groups := make(map[groupKey][]obolapi.PartialSignature)
+	for _, psig := range psigs {
+		if err := verifyPartialSignature(cl, vals, psig); err != nil {
+			log.Warn(ctx, "Ignoring invalid partial signature", err, z.Str("validator", psig.ValidatorPubkey), z.U64("share_idx", psig.ShareIdx))
+			continue
+		}
+
+		key := groupKey{Pubkey: strings.ToLower(psig.ValidatorPubkey), Root: strings.ToLower(psig.SigningRoot)}
+		groups[key] = append(groups[key], psig)
+	}
+
+	var written int
+	for key, group := range groups {
+		sigsByIdx := make(map[int]tbls.Signature)
+		for _, psig := range group {
+			sig, err := decodeHex(psig.Signature, len(tbls.Signature{}))
+			if err != nil {
+				return err
+			}
+			sigsByIdx[int(psig.ShareIdx)] = tbls.Signature(sig)
+		}
+
+		if len(sigsByIdx) < int(cl.GetThreshold()) {
+			log.Warn(ctx, "Insufficient partial signatures to aggregate", nil, z.Str("validator", key.Pubkey),
+				z.Str("signing_root", key.Root), z.Int("partials", len(sigsByIdx)), z.U64("threshold", uint64(cl.GetThreshold())))
+
+			continue
+		}
+
+		if err := writeAggregateSignature(ctx, config.OutputDir, cl.GetForkVersion(), group[0], sigsByIdx); err != nil {
+			return err
+		}
+		written++
+	}
+
+	if written == 0 {
+		return errors.New("no messages with sufficient valid partial signatures")
+	}
+
+	return nil
+}
+
+// fetchPartialSignatures returns the partial signatures of the validators stored by the remote API.
+func fetchPartialSignatures(ctx context.Context, config signConfig, cl *manifestpb.Cluster, vals []*manifestpb.Validator) ([]obolapi.PartialSignature, error) {
+	if len(config.ValidatorPubkeys) == 0 {
+		return nil, errors.New("validator public keys must be specified when fetching partial signatures from a remote API")
+	}
+
+	identityKey, err := k1util.Load(config.PrivateKeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
+	}
+
+	shareIdx, err := keystore.ShareIdxForCluster(cl, *identityKey.PubKey())
+	if err != nil {
+		return nil, errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
+	}
+
+	oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
+	if err != nil {
+		return nil, errors.Wrap(err, "create Obol API client", z.Str("publish_address", config.PublishAddress))
+	}
+
+	var resp []obolapi.PartialSignature
+	for _, val := range vals {
+		pubkey := "0x" + hex.EncodeToString(val.GetPublicKey())
+
+		psigs, err := oAPI.GetPartialSignatures(ctx, cl.GetInitialMutationHash(), pubkey, shareIdx, identityKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch partial signatures from Obol API", z.Str("validator", pubkey))
+		}
+
+		resp = append(resp, psigs...)
+	}
+
+	return resp, nil
+}
+
+// readPartialSignatures returns the partial signatures of all JSON files in the directory.
+func readPartialSignatures(dir string) ([]obolapi.PartialSignature, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "read partials dir", z.Str("dir", dir))
+	}
+
+	var resp []obolapi.PartialSignature
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "read partial signature file", z.Str("filename", file))
+		}
+
+		var psig obolapi.PartialSignature
+		if err := json.Unmarshal(b, &psig); err != nil {
+			return nil, errors.Wrap(err, "unmarshal partial signature file", z.Str("filename", file))
+		}
+
+		resp = append(resp, psig)
+	}
+
+	return resp, nil
+}
+
+// verifyPartialSignature returns an error if the partial signature isn't a valid signature of its message
+// by one of the validator's key shares.
+func verifyPartialSignature(cl *manifestpb.Cluster, vals []*manifestpb.Validator, psig obolapi.PartialSignature) error {
+	var val *manifestpb.Validator
+	for _, v := range vals {
+		if strings.EqualFold(psig.ValidatorPubkey, "0x"+hex.EncodeToString(v.GetPublicKey())) {
+			val = v
+			break
+		}
+	}
+	if val == nil {
+		return errors.New("unknown validator")
+	} else if psig.ShareIdx == 0 || psig.ShareIdx > uint64(len(val.GetPubShares())) {
+		return errors.New("invalid share index")
+	}
+
+	msg, err := decodeSignMessage(psig.Type, psig.Message)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyMessagePubkey(msg, val.GetPublicKey()); err != nil {
+		return err
+	}
+
+	domain, err := decodeHex(psig.Domain, len(eth2p0.Domain{}))
+	if err != nil {
+		return err
+	}
+
+	// Only custom domain messages may use arbitrary domains, others must match their type's domain type. Since the
+	// genesis validators root isn't known, only the domain type of BLS to execution changes can be verified.
+	if psig.Type != signTypeCustomDomain {
+		domainType, ok := signDomainTypes[psig.Type]
+		if !ok {
+			return errors.New("unsupported message type", z.Str("type", psig.Type))
+		} else if !bytes.Equal(domainType[:], domain[:len(domainType)]) {
+			return errors.New("invalid domain type")
+		}
+
+		if psig.Type != signTypeBLSChange {
+			expected, err := signDomain(signConfig{Type: psig.Type}, cl.GetForkVersion())
+			if err != nil {
+				return err
+			} else if !bytes.Equal(expected[:], domain) {
+				return errors.New("invalid domain")
+			}
+		}
+	}
+
+	root, err := signingRoot(msg, eth2p0.Domain(domain))
+	if err != nil {
+		return err
+	}
+
+	expectedRoot, err := decodeHex(psig.SigningRoot, len(eth2p0.Root{}))
+	if err != nil {
+		return err
+	} else if !bytes.Equal(root[:], expectedRoot) {
+		return errors.New("signing root mismatch")
+	}
+
+	sig, err := decodeHex(psig.Signature, len(tbls.Signature{}))
+	if err != nil {
+		return err
+	}
+
+	pubshare, err := tblsconv.PubkeyFromBytes(val.GetPubShares()[psig.ShareIdx-1])
+	if err != nil {
+		return err
+	}
+
+	if err := tbls.Verify(pubshare, root[:], tbls.Signature(sig)); err != nil {
+		return errors.Wrap(err, "verify partial signature")
+	}
+
+	return nil
+}
+
+// verifyMessagePubkey returns an error if the message isn't for the validator public key.
+func verifyMessagePubkey(msg signMessage, pubkey []byte) error {
+	var msgPubkey eth2p0.BLSPubKey
+	switch m := msg.(type) {
+	case *capella.BLSToExecutionChange:
+		msgPubkey = m.FromBLSPubkey
+	case *eth2v1.ValidatorRegistration:
+		msgPubkey = m.Pubkey
+	case *eth2p0.DepositMessage:
+		msgPubkey = m.PublicKey
+	default:
+		return nil
+	}
+
+	if !bytes.Equal(msgPubkey[:], pubkey) {
+		return errors.New("message public key mismatch")
+	}
+
+	return nil
+}
+
+// writeAggregateSignature aggregates the partial signatures of the message, verifies the result
+// and writes the fully signed message to a file in the directory.
+func writeAggregateSignature(ctx context.Context, dir string, forkVersion []byte, psig obolapi.PartialSignature, sigsByIdx map[int]tbls.Signature) error {
+	sig, err := tbls.ThresholdAggregate(sigsByIdx)
+	if err != nil {
+		return errors.Wrap(err, "aggregate partial signatures")
+	}
+
+	pubkeyBytes, err := decodeHex(psig.ValidatorPubkey, len(tbls.PublicKey{}))
+	if err != nil {
+		return err
+	}
+
+	root, err := decodeHex(psig.SigningRoot, len(eth2p0.Root{}))
+	if err != nil {
+		return err
+	}
+
+	if err := tbls.Verify(tbls.PublicKey(pubkeyBytes), root, sig); err != nil {
+		return errors.Wrap(err, "verify aggregate signature", z.Str("validator", psig.ValidatorPubkey))
+	}
+
+	msg, err := decodeSignMessage(psig.Type, psig.Message)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	switch m := msg.(type) {
+	case *capella.BLSToExecutionChange:
+		b, err = json.MarshalIndent(&capella.SignedBLSToExecutionChange{Message: m, Signature: eth2p0.BLSSignature(sig)}, "", " ")
+	case *eth2v1.ValidatorRegistration:
+		b, err = json.MarshalIndent(&eth2v1.SignedValidatorRegistration{Message: m, Signature: eth2p0.BLSSignature(sig)}, "", " ")
+	case *eth2p0.DepositMessage:
+		var network string
+		network, err = eth2util.ForkVersionToNetwork(forkVersion)
+		if err != nil {
+			return err
+		}
+
+		b, err = deposit.MarshalDepositData([]eth2p0.DepositData{{
+			PublicKey:             m.PublicKey,
+			WithdrawalCredentials: m.WithdrawalCredentials,
+			Amount:                m.Amount,
+			Signature:             eth2p0.BLSSignature(sig),
+		}}, network)
+	default:
+		b, err = json.MarshalIndent(struct {
+			MessageRoot string `json:"message_root"`
+			Domain      string `json:"domain"`
+			Signature   string `json:"signature"`
+		}{
+			MessageRoot: fmt.Sprintf("%#x", msg.(*customMessage).Root[:]),
+			Domain:      psig.Domain,
+			Signature:   fmt.Sprintf("%#x", sig[:]),
+		}, "", " ")
+	}
+	if err != nil {
+		return errors.Wrap(err, "marshal signed message")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "create output dir", z.Str("dir", dir))
+	}
+
+	filename := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.json", psig.Type, strings.ToLower(psig.ValidatorPubkey), strings.TrimPrefix(psig.SigningRoot, "0x")[:8]))
+	if err := os.WriteFile(filename, b, 0o644); err != nil { //nolint:gosec // Signed messages aren't secret.
+		return errors.Wrap(err, "write signed message file", z.Str("filename", filename))
+	}
+
+	log.Info(ctx, "Aggregated partial signatures", z.Str("validator", psig.ValidatorPubkey),
+		z.Str("type", psig.Type), z.Int("partials", len(sigsByIdx)), z.Str("filename", filename))
+
+	return nil
+}
+
+// decodeHex returns the 0x-prefixed hex string decoded to bytes of the expected length.
+func decodeHex(s string, length int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode hex", z.Str("hex", s))
+	} else if len(b) != length {
+		return nil, errors.New("invalid hex length", z.Str("hex", s), z.Int("expected", length))
+	}
+
+	return b, nil
+}
@@ -0,0 +1,187 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+	"github.com/obolnetwork/charon/testutil/obolapimock"
+)
+
+// newSignTestCluster writes the lock, identity keys and key shares of a 4 operator cluster with threshold 3
+// to operator directories in root and returns the lock.
+func newSignTestCluster(t *testing.T, root string) cluster.Lock {
+	t.Helper()
+
+	const (
+		valAmt      = 2
+		operatorAmt = 4
+	)
+
+	lock, enrs, keyShares := cluster.NewForT(t, valAmt, 3, operatorAmt, 0, rand.New(rand.NewSource(0)))
+
+	operatorShares := make([][]tbls.PrivateKey, operatorAmt)
+	for opIdx := range operatorAmt {
+		for _, share := range keyShares {
+			operatorShares[opIdx] = append(operatorShares[opIdx], share[opIdx])
+		}
+	}
+
+	mBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	writeAllLockData(t, root, operatorAmt, enrs, operatorShares, mBytes)
+
+	return lock
+}
+
+func signTestConfig(root string, opIdx int, typ string) signConfig {
+	opDir := filepath.Join(root, fmt.Sprintf("op%d", opIdx))
+
+	return signConfig{
+		LockFilePath:     filepath.Join(opDir, "cluster-lock.json"),
+		PrivateKeyPath:   filepath.Join(opDir, "charon-enr-private-key"),
+		ValidatorKeysDir: filepath.Join(opDir, "validator_keys"),
+		PartialsDir:      filepath.Join(root, "partials"),
+		OutputDir:        filepath.Join(root, "output"),
+		PublishTimeout:   10 * time.Second,
+		Type:             typ,
+		GasLimit:         30000000,
+		Timestamp:        1700000000,
+		DepositAmount:    32000000000,
+		DomainType:       "0x07000000",
+		MessageRoot:      "0x0101010101010101010101010101010101010101010101010101010101010101",
+	}
+}
+
+func TestSignFiles(t *testing.T) {
+	for _, typ := range []string{signTypeBuilderRegistration, signTypeDeposit, signTypeCustomDomain} {
+		t.Run(typ, func(t *testing.T) {
+			ctx := context.Background()
+			root := t.TempDir()
+			newSignTestCluster(t, root)
+
+			for opIdx := range 3 {
+				require.NoError(t, runSignPartial(ctx, signTestConfig(root, opIdx, typ)))
+			}
+
+			files, err := filepath.Glob(filepath.Join(root, "partials", "*.json"))
+			require.NoError(t, err)
+			require.Len(t, files, 6)
+
+			// Threshold partial signatures aren't sufficient for a validator if one is invalid.
+			tamperPartialSignature(t, files[0])
+			require.NoError(t, runSignAggregate(ctx, signTestConfig(root, 0, "")))
+
+			outputs, err := filepath.Glob(filepath.Join(root, "output", typ+"-*.json"))
+			require.NoError(t, err)
+			require.Len(t, outputs, 1)
+
+			// Another operator's partial signatures reach the threshold again.
+			require.NoError(t, runSignPartial(ctx, signTestConfig(root, 3, typ)))
+			require.NoError(t, runSignAggregate(ctx, signTestConfig(root, 0, "")))
+
+			outputs, err = filepath.Glob(filepath.Join(root, "output", typ+"-*.json"))
+			require.NoError(t, err)
+			require.Len(t, outputs, 2)
+
+			if typ == signTypeBuilderRegistration {
+				b, err := os.ReadFile(outputs[0])
+				require.NoError(t, err)
+
+				var reg eth2v1.SignedValidatorRegistration
+				require.NoError(t, json.Unmarshal(b, &reg))
+				require.EqualValues(t, 30000000, reg.Message.GasLimit)
+			}
+		})
+	}
+}
+
+// tamperPartialSignature replaces the signing root of the partial signature file with an altered one.
+func tamperPartialSignature(t *testing.T, file string) {
+	t.Helper()
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var psig map[string]any
+	require.NoError(t, json.Unmarshal(b, &psig))
+
+	psig["signing_root"] = "0x0202020202020202020202020202020202020202020202020202020202020202"
+
+	tampered, err := json.Marshal(psig)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, tampered, 0o644))
+}
+
+func TestSignObolAPI(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	lock := newSignTestCluster(t, root)
+
+	beaconMock, err := beaconmock.New()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, beaconMock.Close())
+	}()
+
+	eth2Cl, err := eth2Client(ctx, []string{beaconMock.Address()}, 10*time.Second, [4]byte(lock.ForkVersion))
+	require.NoError(t, err)
+
+	handler, addLockFiles := obolapimock.MockServer(false, eth2Cl)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	addLockFiles(lock)
+
+	newConfig := func(opIdx int) signConfig {
+		config := signTestConfig(root, opIdx, signTypeBLSChange)
+		config.PublishAddress = srv.URL
+		config.ValidatorPubkeys = []string{lock.Validators[0].PublicKeyHex()}
+		config.ValidatorIndex = 42
+		config.ExecutionAddress = "0x0000000000000000000000000000000000000042"
+		config.GenesisValidatorsRoot = "0x0303030303030303030303030303030303030303030303030303030303030303"
+
+		return config
+	}
+
+	t.Run("missing genesis validators root", func(t *testing.T) {
+		config := newConfig(0)
+		config.GenesisValidatorsRoot = ""
+		require.ErrorContains(t, runSignPartial(ctx, config), "require the genesis validators root")
+	})
+
+	for opIdx := range 2 {
+		require.NoError(t, runSignPartial(ctx, newConfig(opIdx)))
+	}
+	require.ErrorContains(t, runSignAggregate(ctx, newConfig(3)), "no messages with sufficient valid partial signatures")
+
+	require.NoError(t, runSignPartial(ctx, newConfig(2)))
+	require.NoError(t, runSignAggregate(ctx, newConfig(3)))
+
+	outputs, err := filepath.Glob(filepath.Join(root, "output", signTypeBLSChange+"-*.json"))
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+
+	b, err := os.ReadFile(outputs[0])
+	require.NoError(t, err)
+
+	var change capella.SignedBLSToExecutionChange
+	require.NoError(t, json.Unmarshal(b, &change))
+	require.EqualValues(t, 42, change.Message.ValidatorIndex)
+	require.Equal(t, lock.Validators[0].PubKey, change.Message.FromBLSPubkey[:])
+}
@@ -0,0 +1,173 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+)
+
+func newSignPartialCmd(runFunc func(context.Context, signConfig) error) *cobra.Command {
+	var config signConfig
+
+	cmd := &cobra.Command{
+		Use:   "partial",
+		Short: "Sign a partial signature of an eth2 message for distributed validators.",
+		Long: "Sign a message of the provided type for the cluster's validators with this operator's validator key shares. " +
+			"The partial signatures are written to files or submitted to a remote API for aggregation.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindSignCommonFlags(cmd, &config)
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "Path to the directory containing the validator private key share files and passwords.")
+	cmd.Flags().StringVar(&config.RemoteSignerAddress, "remote-signer-address", "", "Base URL of a remote Web3Signer-style signer holding the validator private key shares. If set, key shares are not loaded from --validator-keys-dir.")
+	cmd.Flags().StringVar(&config.Type, "type", "", fmt.Sprintf("Type of the message to sign: %s, %s, %s or %s. [REQUIRED]",
+		signTypeBLSChange, signTypeBuilderRegistration, signTypeDeposit, signTypeCustomDomain))
+	cmd.Flags().Uint64Var(&config.ValidatorIndex, "validator-index", 0, "Validator index of the single validator of a BLS to execution change.")
+	cmd.Flags().StringVar(&config.ExecutionAddress, "execution-address", "", "Execution address of BLS to execution changes and deposits, or fee recipient of builder registrations. Defaults to the cluster lock's withdrawal or fee recipient address.")
+	cmd.Flags().Uint64Var(&config.GasLimit, "gas-limit", 30000000, "Gas limit of builder registrations.")
+	cmd.Flags().Int64Var(&config.Timestamp, "timestamp", 0, "Unix timestamp of builder registrations, must be identical for all operators.")
+	cmd.Flags().Uint64Var(&config.DepositAmount, "deposit-amount", uint64(deposit.MaxDepositAmount), "Amount in gwei of deposits, e.g. for top-ups.")
+	cmd.Flags().StringVar(&config.DomainType, "domain-type", "", "Hex encoded 4 byte domain type of custom domain messages.")
+	cmd.Flags().StringVar(&config.MessageRoot, "message-root", "", "Hex encoded 32 byte root of custom domain messages.")
+	cmd.Flags().StringVar(&config.GenesisValidatorsRoot, "genesis-validators-root", "", "Hex encoded genesis validators root of the network, required for BLS to execution changes and optional for custom domain messages.")
+	mustMarkFlagRequired(cmd, "type")
+
+	return cmd
+}
+
+func runSignPartial(ctx context.Context, config signConfig) error {
+	identityKey, err := k1util.Load(config.PrivateKeyPath)
+	if err != nil {
+		return errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
+	}
+
+	cl, err := loadClusterManifest("", config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+	}
+
+	shareIdx, err := keystore.ShareIdxForCluster(cl, *identityKey.PubKey())
+	if err != nil {
+		return errors.Wrap(err, "determine operator index from cluster lock for supplied identity key")
+	}
+
+	signer, pubshares, err := loadShareSigner(config.ValidatorKeysDir, config.RemoteSignerAddress, cl, shareIdx)
+	if err != nil {
+		return err
+	}
+
+	vals, err := lockValidators(cl, config.ValidatorPubkeys)
+	if err != nil {
+		return err
+	}
+
+	domain, err := signDomain(config, cl.GetForkVersion())
+	if err != nil {
+		return err
+	}
+
+	var psigs []obolapi.PartialSignature
+	for _, val := range vals {
+		pubkey := core.PubKey("0x" + hex.EncodeToString(val.GetPublicKey()))
+
+		pubshare, ok := pubshares[pubkey]
+		if !ok {
+			return errors.New("validator key share not found", z.Str("validator", string(pubkey)))
+		}
+
+		msg, err := newSignMessage(config, val)
+		if err != nil {
+			return err
+		}
+
+		root, err := signingRoot(msg, domain)
+		if err != nil {
+			return err
+		}
+
+		sig, err := signer.Sign(ctx, pubshare, root[:])
+		if err != nil {
+			return errors.Wrap(err, "sign partial signature", z.Str("validator", string(pubkey)))
+		}
+
+		msgJSON, err := json.Marshal(msg)
+		if err != nil {
+			return errors.Wrap(err, "marshal message")
+		}
+
+		psigs = append(psigs, obolapi.PartialSignature{
+			Type:            config.Type,
+			ValidatorPubkey: string(pubkey),
+			ShareIdx:        shareIdx,
+			Message:         msgJSON,
+			Domain:          fmt.Sprintf("%#x", domain[:]),
+			SigningRoot:     fmt.Sprintf("%#x", root[:]),
+			Signature:       fmt.Sprintf("%#x", sig[:]),
+		})
+
+		log.Info(ctx, "Signed partial signature", z.Str("validator", string(pubkey)), z.Str("type", config.Type))
+	}
+
+	if config.PublishAddress != "" {
+		oAPI, err := obolapi.New(config.PublishAddress, obolapi.WithTimeout(config.PublishTimeout))
+		if err != nil {
+			return errors.Wrap(err, "create Obol API client", z.Str("publish_address", config.PublishAddress))
+		}
+
+		if err := oAPI.PostPartialSignatures(ctx, cl.GetInitialMutationHash(), shareIdx, identityKey, psigs...); err != nil {
+			return errors.Wrap(err, "http POST partial signatures to Obol API")
+		}
+
+		return nil
+	}
+
+	return writePartialSignatures(config.PartialsDir, psigs)
+}
+
+// writePartialSignatures writes each partial signature to a file in the directory.
+func writePartialSignatures(dir string, psigs []obolapi.PartialSignature) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "create partials dir", z.Str("dir", dir))
+	}
+
+	for _, psig := range psigs {
+		b, err := json.MarshalIndent(psig, "", " ")
+		if err != nil {
+			return errors.Wrap(err, "marshal partial signature")
+		}
+
+		filename := filepath.Join(dir, fmt.Sprintf("%s-%s-%d.json", psig.Type, psig.ValidatorPubkey, psig.ShareIdx))
+		if err := os.WriteFile(filename, b, 0o644); err != nil { //nolint:gosec // Partial signatures aren't secret.
+			return errors.Wrap(err, "write partial signature file", z.Str("filename", filename))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/eth2util/interchange"
+)
+
+type slashingProtectionConfig struct {
+	LockFile            string
+	ManifestFile        string
+	DutyDBFile          string
+	InterchangeFile     string
+	AllowEmpty          bool
+	BeaconNodeEndpoints []string
+	BeaconNodeTimeout   time.Duration
+	Log                 log.Config
+}
+
+func newSlashingProtectionCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "slashing-protection",
+		Short: "Import or export slashing protection history.",
+		Long:  "Import or export the slashing protection history of the cluster's distributed validators using the EIP-3076 interchange format.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newSlashingProtectionImportCmd(runFunc func(context.Context, slashingProtectionConfig) error) *cobra.Command {
+	var config slashingProtectionConfig
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import an EIP-3076 slashing protection interchange file.",
+		Long: "Imports the EIP-3076 slashing protection history of the cluster's distributed validators into the duty database file. " +
+			"The node refuses to co-sign duty data conflicting with the imported history. The node must not be running during import. " +
+			"The imported history only takes effect if the node is run with 'charon run --dutydb-file' set to the same file, " +
+			"since the duty database is otherwise only kept in memory.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindSlashingProtectionFlags(cmd, &config)
+	cmd.Flags().StringVar(&config.InterchangeFile, "interchange-file", "", "The path to the EIP-3076 interchange file to import. [REQUIRED]")
+	mustMarkFlagRequired(cmd, "interchange-file")
+
+	return cmd
+}
+
+func newSlashingProtectionExportCmd(runFunc func(context.Context, slashingProtectionConfig) error) *cobra.Command {
+	var config slashingProtectionConfig
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export an EIP-3076 slashing protection interchange file.",
+		Long:  "Exports the slashing protection history of the cluster's distributed validators from the duty database file to an EIP-3076 interchange file.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindSlashingProtectionFlags(cmd, &config)
+	cmd.Flags().StringVar(&config.InterchangeFile, "interchange-file", "slashing-protection.json", "The path to write the EIP-3076 interchange file to.")
+	cmd.Flags().BoolVar(&config.AllowEmpty, "allow-empty", false, "Export an interchange file without history if the duty database file doesn't exist.")
+
+	return cmd
+}
+
+func bindSlashingProtectionFlags(cmd *cobra.Command, config *slashingProtectionConfig) {
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.DutyDBFile, "dutydb-file", "", "The path to the duty database file configured via 'charon run --dutydb-file'. [REQUIRED]")
+	cmd.Flags().StringSliceVar(&config.BeaconNodeEndpoints, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs used to fetch the genesis validators root. [REQUIRED]")
+	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", 30*time.Second, "Timeout for beacon node HTTP calls.")
+	mustMarkFlagRequired(cmd, "dutydb-file")
+	mustMarkFlagRequired(cmd, "beacon-node-endpoints")
+
+	bindLogFlags(cmd.Flags(), &config.Log)
+}
+
+func runSlashingProtectionImport(ctx context.Context, config slashingProtectionConfig) error {
+	pubkeys, genesisValidatorsRoot, err := loadSlashingProtectionInputs(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	file, err := interchange.Load(config.InterchangeFile, genesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+
+	imported, err := dutydb.ImportInterchange(config.DutyDBFile, file.Data, pubkeys)
+	if err != nil {
+		return errors.Wrap(err, "import interchange")
+	}
+
+	if ignored := len(file.Data) - len(imported); ignored > 0 {
+		log.Warn(ctx, "Ignored slashing protection data of validators not in the cluster", nil, z.Int("count", ignored))
+	}
+
+	log.Info(ctx, "Imported slashing protection history", z.Int("validators", len(imported)))
+
+	return nil
+}
+
+func runSlashingProtectionExport(ctx context.Context, config slashingProtectionConfig) error {
+	// A missing duty database file usually means the node wasn't run with --dutydb-file or the path is wrong,
+	// exporting an empty history would then be misleading.
+	if _, err := os.Stat(config.DutyDBFile); errors.Is(err, os.ErrNotExist) {
+		if !config.AllowEmpty {
+			return errors.New("duty database file not found, ensure it matches 'charon run --dutydb-file' or use --allow-empty to export an empty history",
+				z.Str("dutydb_file", config.DutyDBFile))
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "stat duty database file")
+	}
+
+	pubkeys, genesisValidatorsRoot, err := loadSlashingProtectionInputs(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	data, err := dutydb.ExportInterchange(config.DutyDBFile, pubkeys)
+	if err != nil {
+		return errors.Wrap(err, "export interchange")
+	}
+
+	if err := interchange.Write(config.InterchangeFile, interchange.New(genesisValidatorsRoot, data)); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Exported slashing protection history", z.Int("validators", len(data)), z.Str("file", config.InterchangeFile))
+
+	return nil
+}
+
+// loadSlashingProtectionInputs returns the cluster's DV public keys and the genesis validators root.
+func loadSlashingProtectionInputs(ctx context.Context, config slashingProtectionConfig) ([]core.PubKey, [32]byte, error) {
+	cluster, err := loadClusterManifest(config.ManifestFile, config.LockFile)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	var pubkeys []core.PubKey
+	for _, val := range cluster.GetValidators() {
+		pubkey, err := manifest.ValidatorPublicKey(val)
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+
+		corePubkey, err := core.PubKeyFromBytes(pubkey[:])
+		if err != nil {
+			return nil, [32]byte{}, err
+		}
+
+		pubkeys = append(pubkeys, corePubkey)
+	}
+
+	eth2Cl, err := eth2Client(ctx, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, [4]byte(cluster.GetForkVersion()))
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", config.BeaconNodeEndpoints))
+	}
+
+	genesis, err := eth2Cl.Genesis(ctx, &eth2api.GenesisOpts{})
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "fetch genesis")
+	}
+
+	return pubkeys, genesis.Data.GenesisValidatorsRoot, nil
+}
@@ -0,0 +1,102 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/interchange"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestSlashingProtectionImportExport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0, rand.New(rand.NewSource(0)))
+	lockBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, lockBytes, 0o644))
+
+	genesisValidatorsRoot := testutil.RandomRoot()
+	bmock, err := beaconmock.New(beaconmock.WithGenesisValidatorsRoot(genesisValidatorsRoot))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, bmock.Close())
+	}()
+
+	pubkey := lock.Validators[0].PublicKeyHex()
+	importFile := filepath.Join(dir, "import.json")
+	require.NoError(t, interchange.Write(importFile, interchange.New(genesisValidatorsRoot, []interchange.Data{
+		{
+			Pubkey:             pubkey,
+			SignedBlocks:       []interchange.SignedBlock{{Slot: 10}, {Slot: 12}},
+			SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+		},
+		{
+			Pubkey:       string(testutil.RandomCorePubKey(t)),
+			SignedBlocks: []interchange.SignedBlock{{Slot: 99}},
+		},
+	})))
+
+	config := slashingProtectionConfig{
+		LockFile:            lockFile,
+		DutyDBFile:          filepath.Join(dir, "dutydb.json"),
+		InterchangeFile:     importFile,
+		BeaconNodeEndpoints: []string{bmock.Address()},
+		BeaconNodeTimeout:   time.Second,
+	}
+	require.NoError(t, runSlashingProtectionImport(ctx, config))
+
+	config.InterchangeFile = filepath.Join(dir, "export.json")
+	require.NoError(t, runSlashingProtectionExport(ctx, config))
+
+	exported, err := interchange.Load(config.InterchangeFile, genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Len(t, exported.Data, len(lock.Validators))
+	require.Equal(t, interchange.Data{
+		Pubkey:             pubkey,
+		SignedBlocks:       []interchange.SignedBlock{{Slot: 12}},
+		SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+	}, exported.Data[0])
+	require.Empty(t, exported.Data[1].SignedBlocks)
+
+	t.Run("missing dutydb file", func(t *testing.T) {
+		config := config
+		config.DutyDBFile = filepath.Join(dir, "missing.json")
+		config.InterchangeFile = filepath.Join(dir, "empty.json")
+
+		err := runSlashingProtectionExport(ctx, config)
+		require.ErrorContains(t, err, "duty database file not found")
+		require.NoFileExists(t, config.InterchangeFile)
+
+		config.AllowEmpty = true
+		require.NoError(t, runSlashingProtectionExport(ctx, config))
+
+		exported, err := interchange.Load(config.InterchangeFile, genesisValidatorsRoot)
+		require.NoError(t, err)
+		require.Len(t, exported.Data, len(lock.Validators))
+		require.Empty(t, exported.Data[0].SignedBlocks)
+		require.Empty(t, exported.Data[0].SignedAttestations)
+	})
+
+	t.Run("mismatching genesis validators root", func(t *testing.T) {
+		config.InterchangeFile = filepath.Join(dir, "other.json")
+		require.NoError(t, interchange.Write(config.InterchangeFile, interchange.New(testutil.RandomRoot(), nil)))
+
+		err := runSlashingProtectionImport(ctx, config)
+		require.ErrorContains(t, err, "mismatching genesis validators root")
+	})
+}
@@ -130,7 +130,8 @@ func supportedPeerTestCases() map[testCaseName]testCasePeer {
 		{name: "Ping", order: 1}:        peerPingTest,
 		{name: "PingMeasure", order: 2}: peerPingMeasureTest,
 		{name: "PingLoad", order: 3}:    peerPingLoadTest,
-		{name: "DirectConn", order: 4}:  peerDirectConnTest,
+		{name: "HolePunch", order: 4}:   peerHolePunchTest,
+		{name: "DirectConn", order: 5}:  peerDirectConnTest,
 	}
 }
 
@@ -435,6 +436,39 @@ func peerPingLoadTest(ctx context.Context, conf *testPeersConfig, tcpNode host.H
 	return testRes
 }
 
+func peerHolePunchTest(ctx context.Context, conf *testPeersConfig, tcpNode host.Host, p2pPeer p2p.Peer) testResult {
+	testRes := testResult{Name: "HolePunch"}
+
+	log.Info(ctx, "Waiting for relay connection to be upgraded to direct connection...",
+		z.Any("timeout", conf.DirectConnectionTimeout),
+		z.Any("target", p2pPeer.Name))
+
+	// Hole punching is triggered by libp2p itself on relayed connections, so only wait for a direct connection to appear.
+	for range int(conf.DirectConnectionTimeout.Seconds()) + 1 {
+		conns := tcpNode.Network().ConnsToPeer(p2pPeer.ID)
+		if len(conns) == 0 {
+			return failedTestResult(testRes, errors.New("no connection to peer"))
+		}
+
+		for _, conn := range conns {
+			if !p2p.IsRelayAddr(conn.RemoteMultiaddr()) {
+				log.Info(ctx, "Direct connection to peer available", z.Any("target", p2pPeer.Name))
+				testRes.Verdict = testVerdictOk
+
+				return testRes
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return failedTestResult(testRes, errTimeoutInterrupted)
+		case <-time.After(time.Second):
+		}
+	}
+
+	return failedTestResult(testRes, errors.New("relay connection not upgraded to direct connection"))
+}
+
 func peerDirectConnTest(ctx context.Context, conf *testPeersConfig, tcpNode host.Host, p2pPeer p2p.Peer) testResult {
 	testRes := testResult{Name: "DirectConn"}
 
@@ -786,7 +820,7 @@ func setupP2P(ctx context.Context, privKey *k1.PrivateKey, conf p2p.Config, peer
 		return nil, nil, err
 	}
 
-	tcpNode, err := p2p.NewTCPNode(ctx, conf, privKey, connGater, false)
+	tcpNode, err := p2p.NewTCPNode(ctx, conf, privKey, connGater, false, p2p.WithHolePunching(ctx, peerIDs))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,324 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package aggsigdb
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/atomicfile"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// diskFileExt is the extension of persisted duty files.
+const diskFileExt = ".pb"
+
+// persistedDuties are the duty types whose aggregated signed data is persisted to disk,
+// since it is queried later by other duties, e.g., by the fetcher. Aggregated signed data
+// of other duty types, e.g., attestations, is only broadcast and therefore kept in memory only.
+var persistedDuties = map[core.DutyType]bool{
+	core.DutyRandao:                  true,
+	core.DutyPrepareAggregator:       true,
+	core.DutyPrepareSyncContribution: true,
+	core.DutySyncMessage:             true,
+	core.DutyBuilderRegistration:     true,
+}
+
+// retainedDuties are the persisted duty types that never expire, see core.NewDutyDeadlineFunc.
+// Since they are not scheduled by the deadliner, their aggregated signed data is kept on disk
+// until it is replaced by that of a later duty of the same validator.
+var retainedDuties = map[core.DutyType]bool{
+	core.DutyBuilderRegistration: true,
+}
+
+// NewDiskDB returns a new disk-backed AggSigDB. It wraps the in-memory AggSigDB with the aggregated
+// signed data of persisted duty types written to a file per duty in the provided directory, which is created
+// if it doesn't exist. Persisted data of duties that have not expired yet is restored into the in-memory AggSigDB
+// when it is run, so duties in flight can resume after a restart. Data of duties that never expire, i.e. builder
+// registrations, is restored until replaced. The deadliner must not be shared with the in-memory AggSigDB.
+func NewDiskDB(dir string, db core.AggSigDB, deadliner core.Deadliner) (*DiskDB, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create aggsigdb dir")
+	}
+
+	resp := &DiskDB{
+		db:        db,
+		dir:       dir,
+		deadliner: deadliner,
+		sets:      make(map[core.Duty]core.SignedDataSet),
+	}
+
+	if err := resp.load(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DiskDB is an AggSigDB implementation that persists aggregated signed data of some duty types
+// to disk until the duty's deadline, after which it is deleted from disk.
+type DiskDB struct {
+	db        core.AggSigDB // In-memory AggSigDB, either MemDB or MemDBV2.
+	dir       string
+	deadliner core.Deadliner
+
+	mu   sync.Mutex
+	sets map[core.Duty]core.SignedDataSet // Persisted aggregated signed data by duty.
+}
+
+// Store implements core.AggSigDB, see its godoc.
+// The aggregated signed data of persisted duty types is written to disk before returning.
+func (db *DiskDB) Store(ctx context.Context, duty core.Duty, set core.SignedDataSet) error {
+	if err := db.db.Store(ctx, duty, set); err != nil {
+		return err
+	}
+
+	if !persistedDuties[duty.Type] {
+		return nil
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if !retainedDuties[duty.Type] && !db.deadliner.Add(duty) {
+		return nil // Duty already expired.
+	}
+
+	if db.sets[duty] == nil {
+		db.sets[duty] = make(core.SignedDataSet)
+	}
+
+	for pubKey, data := range set {
+		clone, err := data.Clone()
+		if err != nil {
+			return err
+		}
+		db.sets[duty][pubKey] = clone
+	}
+
+	if err := db.writeDuty(duty); err != nil {
+		return err
+	}
+
+	if !retainedDuties[duty.Type] {
+		return nil
+	}
+
+	return db.deleteReplaced()
+}
+
+// Await implements core.AggSigDB, see its godoc.
+func (db *DiskDB) Await(ctx context.Context, duty core.Duty, pubKey core.PubKey) (core.SignedData, error) {
+	return db.db.Await(ctx, duty, pubKey)
+}
+
+// Run blocks and runs the database process until the context is cancelled.
+// The loaded aggregated signed data is restored into the in-memory AggSigDB,
+// and deadlined duties are deleted from disk.
+func (db *DiskDB) Run(ctx context.Context) {
+	go db.db.Run(ctx)
+
+	db.restore(ctx)
+
+	for {
+		select {
+		case duty := <-db.deadliner.C():
+			db.mu.Lock()
+			delete(db.sets, duty)
+			db.mu.Unlock()
+
+			err := os.Remove(dutyFilename(db.dir, duty))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Warn(ctx, "Failed deleting expired aggsigdb duty file", err, z.Any("duty", duty))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// restore stores the loaded aggregated signed data in the in-memory AggSigDB, which clones it.
+func (db *DiskDB) restore(ctx context.Context) {
+	db.mu.Lock()
+	sets := make(map[core.Duty]core.SignedDataSet, len(db.sets))
+	for duty, set := range db.sets {
+		sets[duty] = maps.Clone(set)
+	}
+	db.mu.Unlock()
+
+	for duty, set := range sets {
+		if err := db.db.Store(ctx, duty, set); err != nil {
+			log.Warn(ctx, "Failed restoring persisted aggsigdb duty", err, z.Any("duty", duty))
+		}
+	}
+}
+
+// writeDuty persists all the aggregated signed data of the duty. It must be called while holding the lock.
+func (db *DiskDB) writeDuty(duty core.Duty) error {
+	// Aggregated signed data is stored as partial signed data without a share index.
+	set := make(core.ParSignedDataSet)
+	for pubKey, data := range db.sets[duty] {
+		set[pubKey] = core.ParSignedData{SignedData: data}
+	}
+
+	pb, err := core.ParSignedDataSetToProto(set)
+	if err != nil {
+		return err
+	}
+
+	b, err := proto.Marshal(pb)
+	if err != nil {
+		return errors.Wrap(err, "marshal aggsigdb duty file")
+	}
+
+	return atomicfile.Write(dutyFilename(db.dir, duty), b, 0o644)
+}
+
+// deleteReplaced deletes the aggregated signed data of retained duties that is replaced by that of
+// a later duty of the same type and validator. It must be called while holding the lock.
+func (db *DiskDB) deleteReplaced() error {
+	type key struct {
+		Type   core.DutyType
+		PubKey core.PubKey
+	}
+
+	latest := make(map[key]uint64)
+	for duty, set := range db.sets {
+		if !retainedDuties[duty.Type] {
+			continue
+		}
+
+		for pubKey := range set {
+			k := key{Type: duty.Type, PubKey: pubKey}
+			latest[k] = max(latest[k], duty.Slot)
+		}
+	}
+
+	for duty, set := range db.sets {
+		if !retainedDuties[duty.Type] {
+			continue
+		}
+
+		var replaced bool
+		for pubKey := range set {
+			if latest[key{Type: duty.Type, PubKey: pubKey}] > duty.Slot {
+				delete(set, pubKey)
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			continue
+		}
+
+		if len(set) > 0 {
+			if err := db.writeDuty(duty); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		delete(db.sets, duty)
+
+		if err := os.Remove(dutyFilename(db.dir, duty)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrap(err, "delete replaced aggsigdb duty file")
+		}
+	}
+
+	return nil
+}
+
+// load reads the persisted aggregated signed data of all duties that have not expired yet
+// and of retained duties that have not been replaced. Files of expired duties are deleted.
+func (db *DiskDB) load() error {
+	entries, err := os.ReadDir(db.dir)
+	if err != nil {
+		return errors.Wrap(err, "read aggsigdb dir")
+	}
+
+	for _, entry := range entries {
+		duty, ok := dutyFromFilename(entry.Name())
+		if !ok {
+			continue
+		}
+
+		filename := filepath.Join(db.dir, entry.Name())
+
+		if !retainedDuties[duty.Type] && !db.deadliner.Add(duty) {
+			if err := os.Remove(filename); err != nil {
+				return errors.Wrap(err, "delete expired aggsigdb duty file")
+			}
+
+			continue
+		}
+
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			return errors.Wrap(err, "read aggsigdb duty file")
+		}
+
+		pb := new(pbv1.ParSignedDataSet)
+		if err := proto.Unmarshal(b, pb); err != nil {
+			return errors.Wrap(err, "unmarshal aggsigdb duty file", z.Str("file", entry.Name()))
+		}
+
+		set, err := core.ParSignedDataSetFromProto(duty.Type, pb)
+		if err != nil {
+			return errors.Wrap(err, "invalid aggsigdb duty file", z.Str("file", entry.Name()))
+		}
+
+		db.sets[duty] = make(core.SignedDataSet)
+		for pubKey, data := range set {
+			db.sets[duty][pubKey] = data.SignedData
+		}
+	}
+
+	return db.deleteReplaced()
+}
+
+// dutyFilename returns the path of the duty's file in the directory.
+func dutyFilename(dir string, duty core.Duty) string {
+	return filepath.Join(dir, fmt.Sprintf("%d_%d%s", duty.Slot, int(duty.Type), diskFileExt))
+}
+
+// dutyFromFilename returns the duty of the file name and true or false if it isn't a duty file.
+func dutyFromFilename(name string) (core.Duty, bool) {
+	if !strings.HasSuffix(name, diskFileExt) {
+		return core.Duty{}, false
+	}
+
+	slot, typ, ok := strings.Cut(strings.TrimSuffix(name, diskFileExt), "_")
+	if !ok {
+		return core.Duty{}, false
+	}
+
+	slotInt, err := strconv.ParseUint(slot, 10, 64)
+	if err != nil {
+		return core.Duty{}, false
+	}
+
+	typInt, err := strconv.Atoi(typ)
+	if err != nil {
+		return core.Duty{}, false
+	}
+
+	dutyType := core.DutyType(typInt)
+	if !dutyType.Valid() {
+		return core.Duty{}, false
+	}
+
+	return core.Duty{Slot: slotInt, Type: dutyType}, true
+}
@@ -0,0 +1,215 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package aggsigdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestDiskDBRestart(t *testing.T) {
+	for name, newMemDB := range map[string]func(core.Deadliner) core.AggSigDB{
+		"memdb":   func(d core.Deadliner) core.AggSigDB { return NewMemDB(d) },
+		"memdbv2": func(d core.Deadliner) core.AggSigDB { return NewMemDBV2(d) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDiskDBRestart(t, newMemDB)
+		})
+	}
+}
+
+func testDiskDBRestart(t *testing.T, newMemDB func(core.Deadliner) core.AggSigDB) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+
+	db, err := NewDiskDB(dir, newMemDB(newTestDeadliner()), newTestDeadliner())
+	require.NoError(t, err)
+	go db.Run(ctx)
+
+	randaoDuty := core.NewRandaoDuty(99)
+	regDuty := core.NewBuilderRegistrationDuty(99)
+	attDuty := core.NewAttesterDuty(99)
+	pubkey1 := testutil.RandomCorePubKey(t)
+	pubkey2 := testutil.RandomCorePubKey(t)
+	randao1 := testutil.RandomCoreSignedRandao()
+	randao2 := testutil.RandomCoreSignedRandao()
+	reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	att := core.NewAttestation(testutil.RandomAttestation())
+
+	require.NoError(t, db.Store(ctx, randaoDuty, core.SignedDataSet{pubkey1: randao1}))
+	require.NoError(t, db.Store(ctx, randaoDuty, core.SignedDataSet{pubkey2: randao2}))
+	require.NoError(t, db.Store(ctx, regDuty, core.SignedDataSet{pubkey1: reg}))
+	require.NoError(t, db.Store(ctx, attDuty, core.SignedDataSet{pubkey1: att}))
+
+	// Attestations are only kept in memory.
+	resp, err := db.Await(ctx, attDuty, pubkey1)
+	require.NoError(t, err)
+	require.Equal(t, att, resp)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// Restart
+	cancel()
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	deadliner := newTestDeadliner()
+	db, err = NewDiskDB(dir, newMemDB(newTestDeadliner()), deadliner)
+	require.NoError(t, err)
+	require.Contains(t, deadliner.added, randaoDuty)
+	require.NotContains(t, deadliner.added, regDuty) // Registrations never expire.
+	go db.Run(ctx)
+
+	resp, err = db.Await(ctx, randaoDuty, pubkey1)
+	require.NoError(t, err)
+	require.Equal(t, randao1, resp)
+
+	resp, err = db.Await(ctx, randaoDuty, pubkey2)
+	require.NoError(t, err)
+	require.Equal(t, randao2, resp)
+
+	resp, err = db.Await(ctx, regDuty, pubkey1)
+	require.NoError(t, err)
+	require.Equal(t, reg, resp)
+
+	// Mismatching data is still refused.
+	err = db.Store(ctx, randaoDuty, core.SignedDataSet{pubkey1: randao2})
+	require.ErrorContains(t, err, "mismatching data")
+
+	// Deadlined duties are deleted from disk, registrations are retained.
+	deadliner.Expire()
+
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, filepath.Base(dutyFilename(dir, regDuty)), entries[0].Name())
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	require.Len(t, db.sets, 1)
+	require.Contains(t, db.sets, regDuty)
+}
+
+func TestDiskDBRegistrations(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	// Use the production deadlines which never expire registrations.
+	deadlineFunc, err := core.NewDutyDeadlineFunc(ctx, bmock)
+	require.NoError(t, err)
+
+	newDiskDB := func(dir string) *DiskDB {
+		t.Helper()
+
+		db, err := NewDiskDB(dir, NewMemDBV2(newTestDeadliner()), core.NewDeadliner(ctx, "test", deadlineFunc))
+		require.NoError(t, err)
+		go db.Run(ctx)
+
+		return db
+	}
+
+	dir := t.TempDir()
+	db := newDiskDB(dir)
+
+	pubkey1 := testutil.RandomCorePubKey(t)
+	pubkey2 := testutil.RandomCorePubKey(t)
+	reg1 := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	reg2 := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	reg3 := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	duty1 := core.NewBuilderRegistrationDuty(1)
+	duty2 := core.NewBuilderRegistrationDuty(2)
+
+	require.NoError(t, db.Store(ctx, duty1, core.SignedDataSet{pubkey1: reg1, pubkey2: reg2}))
+	require.NoError(t, db.Store(ctx, duty2, core.SignedDataSet{pubkey1: reg3}))
+
+	// Registrations survive a restart, replaced registrations are deleted.
+	db = newDiskDB(dir)
+
+	resp, err := db.Await(ctx, duty1, pubkey2)
+	require.NoError(t, err)
+	require.Equal(t, reg2, resp)
+
+	resp, err = db.Await(ctx, duty2, pubkey1)
+	require.NoError(t, err)
+	require.Equal(t, reg3, resp)
+
+	db.mu.Lock()
+	require.Equal(t, map[core.Duty]core.SignedDataSet{
+		duty1: {pubkey2: reg2},
+		duty2: {pubkey1: reg3},
+	}, db.sets)
+	db.mu.Unlock()
+
+	// Fully replaced registration duties are deleted from disk.
+	require.NoError(t, db.Store(ctx, core.NewBuilderRegistrationDuty(3), core.SignedDataSet{pubkey1: reg3, pubkey2: reg2}))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, filepath.Base(dutyFilename(dir, core.NewBuilderRegistrationDuty(3))), entries[0].Name())
+}
+
+func TestDiskDBExpiredOnLoad(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	db, err := NewDiskDB(dir, NewMemDBV2(newTestDeadliner()), newTestDeadliner())
+	require.NoError(t, err)
+
+	duty := core.NewRandaoDuty(99)
+	pubkey := testutil.RandomCorePubKey(t)
+	require.NoError(t, db.Store(ctx, duty, core.SignedDataSet{pubkey: testutil.RandomCoreSignedRandao()}))
+
+	// Unrelated files are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), []byte("other"), 0o644))
+
+	db, err = NewDiskDB(dir, NewMemDBV2(newTestDeadliner()), expiredDeadliner{})
+	require.NoError(t, err)
+	require.Empty(t, db.sets)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "other.txt", entries[0].Name())
+}
+
+func TestDutyFilename(t *testing.T) {
+	duty := core.NewSyncContributionDuty(123)
+
+	parsed, ok := dutyFromFilename(dutyFilename("", duty))
+	require.True(t, ok)
+	require.Equal(t, duty, parsed)
+
+	for _, name := range []string{"123_12", "123" + diskFileExt, "abc_12" + diskFileExt, "123_99" + diskFileExt, "123_12.pb-tmp-1"} {
+		_, ok := dutyFromFilename(name)
+		require.False(t, ok, name)
+	}
+}
+
+// expiredDeadliner is a deadliner implementation that considers all duties expired.
+type expiredDeadliner struct{}
+
+func (expiredDeadliner) Add(core.Duty) bool {
+	return false
+}
+
+func (expiredDeadliner) C() <-chan core.Duty {
+	return nil
+}
@@ -119,29 +119,29 @@ func (m *MemDBV2) Await(ctx context.Context, duty core.Duty, pubKey core.PubKey)
 func (m *MemDBV2) Run(ctx context.Context) {
 	defer close(m.closed)
 
-	deadlineDel := func(duty core.Duty) {
-		// atomically delete deadlined keys
-		m.Lock()
-		defer m.Unlock()
-
-		keys, ok := m.keysByDuty[duty]
-		if !ok {
-			return
-		}
-
-		for _, key := range keys {
-			delete(m.data, key)
-		}
-
-		delete(m.keysByDuty, duty)
-	}
-
 	for {
 		select {
 		case duty := <-m.deadliner.C():
-			deadlineDel(duty)
+			m.deadlineDel(duty)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
+
+// deadlineDel atomically deletes the keys of the deadlined duty.
+func (m *MemDBV2) deadlineDel(duty core.Duty) {
+	m.Lock()
+	defer m.Unlock()
+
+	keys, ok := m.keysByDuty[duty]
+	if !ok {
+		return
+	}
+
+	for _, key := range keys {
+		delete(m.data, key)
+	}
+
+	delete(m.keysByDuty, duty)
+}
@@ -10,8 +10,8 @@ import (
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
-	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
@@ -54,7 +54,7 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 			return err
 		}
 
-		err = b.eth2Cl.SubmitAttestations(ctx, atts)
+		err = b.eth2Cl.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: atts})
 		if err != nil && strings.Contains(err.Error(), "PriorAttestationKnown") {
 			// Lighthouse isn't idempotent, so just swallow this non-issue.
 			// See reference github.com/attestantio/go-eth2-client@v0.11.7/multi/submitattestations.go:38
@@ -167,7 +167,9 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 			return err
 		}
 
-		err = b.eth2Cl.SubmitAggregateAttestations(ctx, aggAndProofs)
+		err = b.eth2Cl.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{
+			SignedAggregateAndProofs: aggAndProofs,
+		})
 		if err == nil {
 			log.Info(ctx, "Successfully submitted attestation aggregations to beacon node",
 				z.Any("delay", b.delayFunc(duty.Slot)))
@@ -239,15 +241,15 @@ func setToSyncMessages(set core.SignedDataSet) ([]*altair.SyncCommitteeMessage,
 }
 
 // setToAggAndProof converts a set of signed data into a list of aggregate and proofs.
-func setToAggAndProof(set core.SignedDataSet) ([]*eth2p0.SignedAggregateAndProof, error) {
-	var resp []*eth2p0.SignedAggregateAndProof
+func setToAggAndProof(set core.SignedDataSet) ([]*eth2spec.VersionedSignedAggregateAndProof, error) {
+	var resp []*eth2spec.VersionedSignedAggregateAndProof
 	for _, aggAndProof := range set {
-		aggAndProof, ok := aggAndProof.(core.SignedAggregateAndProof)
+		aggAndProof, ok := aggAndProof.(core.VersionedSignedAggregateAndProof)
 		if !ok {
 			return nil, errors.New("invalid aggregate and proof")
 		}
 
-		resp = append(resp, &aggAndProof.SignedAggregateAndProof)
+		resp = append(resp, &aggAndProof.VersionedSignedAggregateAndProof)
 	}
 
 	return resp, nil
@@ -282,14 +284,14 @@ func setToOne(set core.SignedDataSet) (core.PubKey, core.SignedData, error) {
 }
 
 // setToAttestations converts a set of signed data into a list of attestations.
-func setToAttestations(set core.SignedDataSet) ([]*eth2p0.Attestation, error) {
-	var resp []*eth2p0.Attestation
+func setToAttestations(set core.SignedDataSet) ([]*eth2spec.VersionedAttestation, error) {
+	var resp []*eth2spec.VersionedAttestation
 	for _, att := range set {
-		att, ok := att.(core.Attestation)
+		att, ok := att.(core.VersionedAttestation)
 		if !ok {
 			return nil, errors.New("invalid attestation")
 		}
-		resp = append(resp, &att.Attestation)
+		resp = append(resp, &att.VersionedAttestation)
 	}
 
 	return resp, nil
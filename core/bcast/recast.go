@@ -4,6 +4,7 @@ package bcast
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -102,6 +103,31 @@ func (r *Recaster) store(_ context.Context, duty core.Duty,
 	return nil
 }
 
+// DeleteFeeRecipientMismatch deletes the stored registration of the validator if its fee recipient doesn't match
+// the provided fee recipient, so stale registrations aren't rebroadcast. It returns true if a registration was deleted.
+func (r *Recaster) DeleteFeeRecipientMismatch(pubkey core.PubKey, feeRecipient string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tuple, ok := r.tuples[pubkey]
+	if !ok {
+		return false
+	}
+
+	reg, ok := tuple.aggData.(core.VersionedSignedValidatorRegistration)
+	if !ok || reg.V1 == nil || reg.V1.Message == nil {
+		return false
+	}
+
+	if strings.EqualFold(reg.V1.Message.FeeRecipient.String(), feeRecipient) {
+		return false
+	}
+
+	delete(r.tuples, pubkey)
+
+	return true
+}
+
 // SlotTicked is called when new slots tick.
 func (r *Recaster) SlotTicked(ctx context.Context, slot core.Slot) error {
 	if !slot.FirstInEpoch() {
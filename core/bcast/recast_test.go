@@ -0,0 +1,55 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package bcast_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/bcast"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRecasterDeleteFeeRecipientMismatch(t *testing.T) {
+	ctx := context.Background()
+	pubkey := testutil.RandomCorePubKey(t)
+
+	ethPk, err := pubkey.ToETH2()
+	require.NoError(t, err)
+
+	recaster, err := bcast.NewRecaster(func(context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
+		return map[eth2p0.BLSPubKey]struct{}{ethPk: {}}, nil
+	})
+	require.NoError(t, err)
+
+	var recast int
+	recaster.Subscribe(func(context.Context, core.Duty, core.SignedDataSet) error {
+		recast++
+		return nil
+	})
+
+	reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	feeRecipient := reg.V1.Message.FeeRecipient.String()
+
+	err = recaster.Store(ctx, core.NewBuilderRegistrationDuty(1), core.SignedDataSet{pubkey: reg})
+	require.NoError(t, err)
+
+	// Matching fee recipients are retained, regardless of case.
+	require.False(t, recaster.DeleteFeeRecipientMismatch(pubkey, strings.ToLower(feeRecipient)))
+	require.False(t, recaster.DeleteFeeRecipientMismatch(testutil.RandomCorePubKey(t), feeRecipient))
+
+	require.NoError(t, recaster.SlotTicked(ctx, core.Slot{Slot: 32, SlotsPerEpoch: 32}))
+	require.Equal(t, 1, recast)
+
+	// Mismatching fee recipients are deleted and no longer recast.
+	require.True(t, recaster.DeleteFeeRecipientMismatch(pubkey, "0x000000000000000000000000000000000000dEaD"))
+	require.False(t, recaster.DeleteFeeRecipientMismatch(pubkey, "0x000000000000000000000000000000000000dEaD"))
+
+	require.NoError(t, recaster.SlotTicked(ctx, core.Slot{Slot: 64, SlotsPerEpoch: 32}))
+	require.Equal(t, 1, recast)
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/hotstuff"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
 	"github.com/obolnetwork/charon/core/consensus/qbft"
 	"github.com/obolnetwork/charon/p2p"
 )
@@ -38,10 +40,10 @@ type consensusController struct {
 // NewConsensusController creates a new consensus controller with the default consensus protocol.
 func NewConsensusController(ctx context.Context, tcpNode host.Host, sender *p2p.Sender,
 	peers []p2p.Peer, p2pKey *k1.PrivateKey, deadlineFunc core.DeadlineFunc,
-	gaterFunc core.DutyGaterFunc, debugger Debugger,
+	gaterFunc core.DutyGaterFunc, debugger Debugger, feeRecipientFunc func(core.PubKey) string,
 ) (core.ConsensusController, error) {
 	qbftDeadliner := core.NewDeadliner(ctx, "consensus.qbft", deadlineFunc)
-	defaultConsensus, err := qbft.NewConsensus(tcpNode, sender, peers, p2pKey, qbftDeadliner, gaterFunc, debugger.AddInstance)
+	defaultConsensus, err := qbft.NewConsensus(tcpNode, sender, peers, p2pKey, qbftDeadliner, gaterFunc, debugger.AddInstance, feeRecipientFunc)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +87,7 @@ func (f *consensusController) CurrentConsensus() core.Consensus {
 }
 
 // SetCurrentConsensusForProtocol sets the current consensus instance for the given protocol id.
-func (f *consensusController) SetCurrentConsensusForProtocol(_ context.Context, protocol protocol.ID) error {
+func (f *consensusController) SetCurrentConsensusForProtocol(ctx context.Context, protocol protocol.ID) error {
 	if f.wrappedConsensus.ProtocolID() == protocol {
 		return nil
 	}
@@ -96,9 +98,16 @@ func (f *consensusController) SetCurrentConsensusForProtocol(_ context.Context,
 		return nil
 	}
 
-	// TODO: When introducing new consensus protocols, add them here as follow:
-	/*
-		cctx, cancel := context.WithCancel(ctx)
+	if protocol == protocols.HotStuffv1ProtocolID {
+		// The protocol outlives the calling context, it is stopped when replaced or when the controller stops.
+		cctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+		hotstuffDeadliner := core.NewDeadliner(cctx, "consensus.hotstuff", f.deadlineFunc)
+		hotstuffConsensus, err := hotstuff.NewConsensus(f.tcpNode, f.sender, f.peers, f.p2pKey, hotstuffDeadliner, f.gaterFunc, f.debugger.AddInstance)
+		if err != nil {
+			cancel()
+			return err
+		}
 
 		f.mutable.Lock()
 		defer f.mutable.Unlock()
@@ -108,14 +117,13 @@ func (f *consensusController) SetCurrentConsensusForProtocol(_ context.Context,
 			f.mutable.cancelWrappedCtx()
 		}
 
-		xyzDeadliner := core.NewDeadliner(cctx, "consensus.xyz", f.deadlineFunc)
-		xyzConsensus := xyz.NewConsensus(...)
-
 		f.mutable.cancelWrappedCtx = cancel
-		f.wrappedConsensus.SetImpl(xyzConsensus)
+		f.wrappedConsensus.SetImpl(hotstuffConsensus)
 
-		xyzConsensus.Start(cctx)
-	*/
+		hotstuffConsensus.Start(cctx)
+
+		return nil
+	}
 
 	return errors.New("unsupported protocol id")
 }
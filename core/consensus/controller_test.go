@@ -56,10 +56,11 @@ func TestConsensusController(t *testing.T) {
 	}
 
 	deadlineFunc := func(core.Duty) (time.Time, bool) { return time.Time{}, false }
+	feeRecipientFunc := func(core.PubKey) string { return "" }
 	debugger := csmocks.NewDebugger(t)
 	ctx := context.Background()
 
-	controller, err := consensus.NewConsensusController(ctx, hosts[0], new(p2p.Sender), peers, p2pkeys[0], deadlineFunc, gaterFunc, debugger)
+	controller, err := consensus.NewConsensusController(ctx, hosts[0], new(p2p.Sender), peers, p2pkeys[0], deadlineFunc, gaterFunc, debugger, feeRecipientFunc)
 	require.NoError(t, err)
 	require.NotNil(t, controller)
 
@@ -74,6 +75,16 @@ func TestConsensusController(t *testing.T) {
 		require.NotEqual(t, defaultConsensus, controller.CurrentConsensus()) // because the current is wrapped
 	})
 
+	t.Run("hotstuff protocol id", func(t *testing.T) {
+		err := controller.SetCurrentConsensusForProtocol(context.TODO(), protocols.HotStuffv1ProtocolID)
+		require.NoError(t, err)
+		require.EqualValues(t, protocols.HotStuffv1ProtocolID, controller.CurrentConsensus().ProtocolID())
+
+		err = controller.SetCurrentConsensusForProtocol(context.TODO(), protocols.QBFTv2ProtocolID)
+		require.NoError(t, err)
+		require.EqualValues(t, protocols.QBFTv2ProtocolID, controller.CurrentConsensus().ProtocolID())
+	})
+
 	t.Run("unsupported protocol id", func(t *testing.T) {
 		err := controller.SetCurrentConsensusForProtocol(context.TODO(), "boo")
 		require.ErrorContains(t, err, "unsupported protocol id")
@@ -0,0 +1,479 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package hotstuff
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/metrics"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
+	"github.com/obolnetwork/charon/core/consensus/utils"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+type subscriber func(ctx context.Context, duty core.Duty, value proto.Message) error
+
+// NewConsensus returns a new consensus HotStuff component.
+func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKey *k1.PrivateKey,
+	deadliner core.Deadliner, gaterFunc core.DutyGaterFunc, snifferFunc func(*pbv1.SniffedConsensusInstance),
+) (*Consensus, error) {
+	// Extract peer pubkeys.
+	keys := make(map[int64]*k1.PublicKey)
+	var labels []string
+	for i, p := range peers {
+		labels = append(labels, fmt.Sprintf("%d:%s", p.Index, p.Name))
+
+		pk, err := p.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		keys[int64(i)] = pk
+	}
+
+	c := &Consensus{
+		tcpNode:     tcpNode,
+		sender:      sender,
+		peers:       peers,
+		peerLabels:  labels,
+		privkey:     p2pKey,
+		pubkeys:     keys,
+		deadliner:   deadliner,
+		snifferFunc: snifferFunc,
+		gaterFunc:   gaterFunc,
+		dropFilter:  log.Filter(),
+		timerFunc:   utils.GetTimerFunc(),
+		metrics:     metrics.NewConsensusMetrics(protocols.HotStuffv1ProtocolID),
+	}
+	c.mutable.instances = make(map[core.Duty]*utils.InstanceIO[consensusMsg])
+
+	return c, nil
+}
+
+// Consensus implements core.Consensus.
+type Consensus struct {
+	// Immutable state
+	tcpNode     host.Host
+	sender      *p2p.Sender
+	peerLabels  []string
+	peers       []p2p.Peer
+	pubkeys     map[int64]*k1.PublicKey
+	privkey     *k1.PrivateKey
+	subs        []subscriber
+	deadliner   core.Deadliner
+	snifferFunc func(*pbv1.SniffedConsensusInstance)
+	gaterFunc   core.DutyGaterFunc
+	dropFilter  z.Field // Filter buffer overflow errors (possible DDoS)
+	timerFunc   utils.TimerFunc
+	metrics     metrics.ConsensusMetrics
+
+	// Mutable state
+	mutable struct {
+		sync.Mutex
+		instances map[core.Duty]*utils.InstanceIO[consensusMsg]
+	}
+}
+
+// ProtocolID returns the protocol ID.
+func (*Consensus) ProtocolID() protocol.ID {
+	return protocols.HotStuffv1ProtocolID
+}
+
+// Subscribe registers a callback for unsigned duty data proposals from leaders.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Consensus) Subscribe(fn func(ctx context.Context, duty core.Duty, set core.UnsignedDataSet) error) {
+	c.subs = append(c.subs, func(ctx context.Context, duty core.Duty, value proto.Message) error {
+		unsignedPB, ok := value.(*pbv1.UnsignedDataSet)
+		if !ok {
+			return nil
+		}
+
+		unsigned, err := core.UnsignedDataSetFromProto(duty.Type, unsignedPB)
+		if err != nil {
+			return err
+		}
+
+		return fn(ctx, duty, unsigned)
+	})
+}
+
+// Start registers libp2p handler and runs internal routines until the context is cancelled.
+func (c *Consensus) Start(ctx context.Context) {
+	p2p.RegisterHandler("hotstuff", c.tcpNode, protocols.HotStuffv1ProtocolID,
+		func() proto.Message { return new(pbv1.HotStuffConsensusMsg) },
+		c.handle)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				// Unregister the handler since this protocol can be replaced at runtime.
+				c.tcpNode.RemoveStreamHandler(protocols.HotStuffv1ProtocolID)
+				return
+			case duty := <-c.deadliner.C():
+				c.deleteInstanceIO(duty)
+			}
+		}
+	}()
+}
+
+// Propose enqueues the proposed value to a consensus instance input channels.
+// It either runs the consensus instance if it is not already running or
+// waits until it completes, in both cases it returns the resulting error.
+// Note this errors if called multiple times for the same duty.
+func (c *Consensus) Propose(ctx context.Context, duty core.Duty, data core.UnsignedDataSet) error {
+	// Hash the proposed data, since HotStuff only supports simple comparable values.
+	value, err := core.UnsignedDataSetToProto(data)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashProto(value)
+	if err != nil {
+		return err
+	}
+
+	inst := c.getInstanceIO(duty)
+
+	if err := inst.MarkProposed(); err != nil {
+		return errors.Wrap(err, "propose consensus", z.Any("duty", duty))
+	}
+
+	// Provide proposal inputs to the instance.
+	select {
+	case inst.ValueCh <- value:
+	default:
+		return errors.New("input channel full")
+	}
+
+	select {
+	case inst.HashCh <- hash:
+	default:
+		return errors.New("input channel full")
+	}
+
+	// Instrument consensus duration using decidedAt output.
+	proposedAt := time.Now()
+	defer func() {
+		select {
+		case decidedAt := <-inst.DecidedAtCh:
+			timerType := c.timerFunc(duty).Type()
+			duration := decidedAt.Sub(proposedAt)
+			c.metrics.ObserveConsensusDuration(duty.Type.String(), string(timerType), duration.Seconds())
+		default:
+		}
+	}()
+
+	if !inst.MaybeStart() { // Participate was already called, instance is running.
+		return <-inst.ErrCh
+	}
+
+	return c.runInstance(ctx, duty)
+}
+
+// Participate runs a new a consensus instance to participate while still waiting for
+// unsigned data from beacon node and Propose not already called.
+// Note Propose must still be called for this peer to propose a value when leading a view.
+// Note this errors if called multiple times for the same duty.
+func (c *Consensus) Participate(ctx context.Context, duty core.Duty) error {
+	if duty.Type == core.DutyAggregator || duty.Type == core.DutySyncContribution {
+		return nil // No consensus participate for potential no-op aggregation duties.
+	}
+
+	if !featureset.Enabled(featureset.ConsensusParticipate) {
+		return nil // Wait for Propose to start.
+	}
+
+	inst := c.getInstanceIO(duty)
+
+	if err := inst.MarkParticipated(); err != nil {
+		return errors.Wrap(err, "participate consensus", z.Any("duty", duty))
+	}
+
+	if !inst.MaybeStart() {
+		return nil // Instance already running.
+	}
+
+	return c.runInstance(ctx, duty)
+}
+
+// Broadcast implements sender interface.
+func (c *Consensus) Broadcast(ctx context.Context, msg *pbv1.HotStuffConsensusMsg) error {
+	for _, peer := range c.peers {
+		if peer.ID == c.tcpNode.ID() {
+			// Do not broadcast to self
+			continue
+		}
+
+		if err := c.sender.SendAsync(ctx, c.tcpNode, protocols.HotStuffv1ProtocolID, peer.ID, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendTo implements sender interface.
+func (c *Consensus) SendTo(ctx context.Context, peerIdx int64, msg *pbv1.HotStuffConsensusMsg) error {
+	if peerIdx < 0 || peerIdx >= int64(len(c.peers)) {
+		return errors.New("invalid peer index", z.I64("index", peerIdx))
+	}
+
+	return c.sender.SendAsync(ctx, c.tcpNode, protocols.HotStuffv1ProtocolID, c.peers[peerIdx].ID, msg)
+}
+
+// runInstance blocks and runs a consensus instance for the given duty.
+// It returns an error or nil when the context is cancelled.
+// Note each instance may only be run once.
+func (c *Consensus) runInstance(ctx context.Context, duty core.Duty) (err error) {
+	roundTimer := c.timerFunc(duty)
+	ctx = log.WithTopic(ctx, "hotstuff")
+	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	log.Debug(ctx, "HotStuff consensus instance starting",
+		z.Any("peers", c.peerLabels),
+		z.Any("timer", string(roundTimer.Type())),
+	)
+
+	inst := c.getInstanceIO(duty)
+	defer func() {
+		inst.ErrCh <- err // Send resulting error to errCh.
+	}()
+
+	if !c.deadliner.Add(duty) {
+		log.Warn(ctx, "Skipping consensus for expired duty", nil)
+		return nil
+	}
+
+	peerIdx, err := c.getPeerIdx()
+	if err != nil {
+		return err
+	}
+
+	var (
+		decided bool
+		nodes   = len(c.peers)
+	)
+
+	// Create a new transport that handles sending and receiving for this instance.
+	t := newInstanceTransport(c, c.privkey, peerIdx, inst.ValueCh, newSniffer(int64(nodes), peerIdx))
+
+	// Provide sniffed buffer to snifferFunc at the end.
+	defer func() {
+		c.snifferFunc(t.SnifferInstance())
+	}()
+
+	def := definition{
+		Nodes: nodes,
+		// Leader is a deterministic leader election function.
+		Leader: func(view int64) int64 {
+			return leader(duty, view, nodes)
+		},
+		NewTimer: roundTimer.Timer,
+		// Decide sends consensus output to subscribers.
+		Decide: func(ctx context.Context, value [32]byte, qc *pbv1.HotStuffQC) {
+			defer endCtxSpan(ctx) // End the parent tracing span when decided
+
+			anyValue, err := t.getValue(value)
+			if err != nil {
+				log.Error(ctx, "Invalid value hash", err)
+				return
+			}
+
+			unmarshalled, err := anyValue.UnmarshalNew()
+			if err != nil {
+				log.Error(ctx, "Invalid any value", err)
+				return
+			}
+
+			decided = true
+			inst.DecidedAtCh <- time.Now()
+
+			leaderIndex := leader(duty, qc.GetView(), nodes)
+			log.Debug(ctx, "HotStuff consensus decided",
+				z.I64("view", qc.GetView()),
+				z.I64("leader_index", leaderIndex),
+				z.Str("leader_name", c.peers[leaderIndex].Name))
+
+			c.metrics.SetDecidedLeaderIndex(duty.Type.String(), leaderIndex)
+			c.metrics.SetDecidedRounds(duty.Type.String(), string(roundTimer.Type()), qc.GetView())
+
+			for _, sub := range c.subs {
+				if err := sub(ctx, duty, unmarshalled); err != nil {
+					log.Warn(ctx, "Subscriber error", err)
+				}
+			}
+		},
+		// LogViewChange logs view changes at debug level.
+		LogViewChange: func(ctx context.Context, view, newView int64, reason string) {
+			log.Debug(ctx, "HotStuff view changed",
+				z.I64("view", view),
+				z.I64("new_view", newView),
+				z.Str("reason", reason))
+		},
+		// FIFOLimit caps the max buffered future view messages per peer.
+		FIFOLimit: utils.RecvBufferSize,
+	}
+
+	// Start a receiving goroutine.
+	go t.ProcessReceives(ctx, c.getRecvBuffer(duty))
+
+	// Create a HotStuff transport from the instance transport.
+	ht := transport{
+		Broadcast: t.Broadcast,
+		Send:      t.Send,
+		Receive:   t.RecvBuffer(),
+	}
+
+	// Run the algo, blocking until the context is cancelled.
+	err = run(ctx, def, ht, duty, peerIdx, inst.HashCh)
+	if err != nil && !isContextErr(err) {
+		c.metrics.IncConsensusError()
+		return err // Only return non-context errors.
+	}
+
+	if !decided {
+		c.metrics.IncConsensusTimeout(duty.Type.String(), string(roundTimer.Type()))
+
+		return errors.New("consensus timeout", z.Str("duty", duty.String()))
+	}
+
+	return nil
+}
+
+// handle processes an incoming consensus wire message.
+func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+	t0 := time.Now()
+
+	pbMsg, ok := req.(*pbv1.HotStuffConsensusMsg)
+	if !ok || pbMsg == nil {
+		return nil, false, errors.New("invalid consensus message")
+	}
+
+	if err := verifyMsg(pbMsg.GetMsg(), c.pubkeys, c.quorum()); err != nil {
+		return nil, false, err
+	}
+
+	duty := core.DutyFromProto(pbMsg.GetMsg().GetDuty())
+	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+
+	if !c.gaterFunc(duty) {
+		return nil, false, errors.New("invalid duty", z.Any("duty", duty))
+	}
+
+	values, err := valuesByHash(pbMsg.GetValues())
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Proposals and justified messages must include their values.
+	if msgType(pbMsg.GetMsg().GetType()) == msgProposal {
+		if _, ok := values[valueHash(pbMsg.GetMsg())]; !ok {
+			return nil, false, errors.New("value hash not found in values")
+		}
+	}
+	if justify := pbMsg.GetMsg().GetJustify(); justify != nil {
+		if _, ok := values[qcValueHash(justify)]; !ok {
+			return nil, false, errors.New("justification value hash not found in values")
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil, false, errors.Wrap(ctx.Err(), "receive cancelled during verification",
+			z.Any("duty", duty),
+			z.Any("after", time.Since(t0)),
+		)
+	}
+
+	if !c.deadliner.Add(duty) {
+		return nil, false, errors.New("duty expired", z.Any("duty", duty), c.dropFilter)
+	}
+
+	select {
+	case c.getRecvBuffer(duty) <- consensusMsg{msg: pbMsg.GetMsg(), values: values}:
+		return nil, false, nil
+	case <-ctx.Done():
+		return nil, false, errors.Wrap(ctx.Err(), "timeout enqueuing receive buffer",
+			z.Any("duty", duty), z.Any("after", time.Since(t0)))
+	}
+}
+
+// quorum returns the quorum count of the cluster.
+func (c *Consensus) quorum() int {
+	return definition{Nodes: len(c.peers)}.Quorum()
+}
+
+// getRecvBuffer returns a receive buffer for the duty.
+func (c *Consensus) getRecvBuffer(duty core.Duty) chan consensusMsg {
+	return c.getInstanceIO(duty).RecvBuffer
+}
+
+// getInstanceIO returns the duty's instance if it were previously created.
+func (c *Consensus) getInstanceIO(duty core.Duty) *utils.InstanceIO[consensusMsg] {
+	c.mutable.Lock()
+	defer c.mutable.Unlock()
+
+	inst, ok := c.mutable.instances[duty]
+	if !ok { // Create new instanceIO.
+		inst = utils.NewInstanceIO[consensusMsg]()
+		c.mutable.instances[duty] = inst
+	}
+
+	return inst
+}
+
+// deleteInstanceIO deletes the instanceIO for the duty.
+func (c *Consensus) deleteInstanceIO(duty core.Duty) {
+	c.mutable.Lock()
+	defer c.mutable.Unlock()
+
+	delete(c.mutable.instances, duty)
+}
+
+// getPeerIdx returns the local peer index.
+func (c *Consensus) getPeerIdx() (int64, error) {
+	peerIdx := int64(-1)
+	for i, p := range c.peers {
+		if c.tcpNode.ID() == p.ID {
+			peerIdx = int64(i)
+		}
+	}
+	if peerIdx == -1 {
+		return 0, errors.New("local libp2p host not in peer list")
+	}
+
+	return peerIdx, nil
+}
+
+// leader return the deterministic leader index of the view.
+// It is the same as the qbft leader of the same round.
+func leader(duty core.Duty, view int64, nodes int) int64 {
+	return (int64(duty.Slot) + int64(duty.Type) + view) % int64(nodes)
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
+
+// endCtxSpan ends the parent span if included in the context.
+func endCtxSpan(ctx context.Context) {
+	trace.SpanFromContext(ctx).End()
+}
@@ -0,0 +1,517 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package hotstuff implements a leader-rotating basic HotStuff consensus protocol.
+// See https://arxiv.org/pdf/1803.05069 for the paper.
+//
+// In contrast to QBFT, replicas send votes only to the leader which aggregates them
+// into quorum certificates (QCs) that justify its next proposal. This results in linear
+// message complexity per phase at the cost of an extra phase (prepare, pre-commit, commit
+// and decide).
+package hotstuff
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+// msgType defines the HotStuff message types.
+type msgType int64
+
+// Note that message type ordering MUST not change, since it breaks backwards compatibility.
+const (
+	msgUnknown  msgType = 0
+	msgNewView  msgType = 1
+	msgProposal msgType = 2
+	msgVote     msgType = 3
+	msgSentinel msgType = 4
+)
+
+func (t msgType) Valid() bool {
+	return t > msgUnknown && t < msgSentinel
+}
+
+func (t msgType) String() string {
+	return map[msgType]string{
+		msgUnknown:  "unknown",
+		msgNewView:  "new_view",
+		msgProposal: "proposal",
+		msgVote:     "vote",
+	}[t]
+}
+
+// phase defines the phases of a HotStuff view.
+type phase int64
+
+// Note that phase ordering MUST not change, since it breaks backwards compatibility.
+const (
+	phaseUnknown   phase = 0
+	phasePrepare   phase = 1
+	phasePreCommit phase = 2
+	phaseCommit    phase = 3
+	phaseDecide    phase = 4
+	phaseSentinel  phase = 5
+)
+
+func (p phase) Valid() bool {
+	return p > phaseUnknown && p < phaseSentinel
+}
+
+func (p phase) String() string {
+	return map[phase]string{
+		phaseUnknown:   "unknown",
+		phasePrepare:   "prepare",
+		phasePreCommit: "pre_commit",
+		phaseCommit:    "commit",
+		phaseDecide:    "decide",
+	}[p]
+}
+
+// definition defines the HotStuff consensus system, it is constant across all consensus instances.
+type definition struct {
+	// Nodes is the total number of nodes/processes participating in consensus.
+	Nodes int
+	// Leader returns the deterministic leader of the view.
+	Leader func(view int64) int64
+	// NewTimer returns a new timer channel and stop function for the view.
+	NewTimer func(view int64) (<-chan time.Time, func())
+	// Decide is called when consensus has been reached on a value, justified by the commit QC.
+	Decide func(ctx context.Context, value [32]byte, qc *pbv1.HotStuffQC)
+	// LogViewChange allows debug logging of view changes.
+	LogViewChange func(ctx context.Context, view, newView int64, reason string)
+	// FIFOLimit limits the amount of future view messages buffered for each peer.
+	FIFOLimit int
+}
+
+// Quorum returns the quorum count for the system.
+func (d definition) Quorum() int {
+	return qbft.Definition[int, int]{Nodes: d.Nodes}.Quorum()
+}
+
+// Faulty returns the maximum number of faulty/byzantium nodes supported in the system.
+func (d definition) Faulty() int {
+	return qbft.Definition[int, int]{Nodes: d.Nodes}.Faulty()
+}
+
+// transport abstracts the networking of a consensus instance.
+// Messages provided to Broadcast and Send are unsigned, they are signed by the transport.
+type transport struct {
+	// Broadcast signs and sends the message to all peers (including self).
+	Broadcast func(ctx context.Context, msg *pbv1.HotStuffMsg) error
+	// Send signs and sends the message to the peer (which may be self).
+	Send func(ctx context.Context, peerIdx int64, msg *pbv1.HotStuffMsg) error
+	// Receive returns a stream of verified messages received from peers (including self).
+	Receive <-chan *pbv1.HotStuffMsg
+}
+
+// run executes the consensus instance of the duty until the context is cancelled.
+// The process proposes the value provided by inputValueCh when leading a view that doesn't
+// extend a prior quorum certificate. It keeps running after deciding to help lagging peers decide.
+//
+// New-view messages are broadcast to all peers, not only to the leader of the view, so that
+// a process whose timer hasn't expired catches up once faulty+1 peers moved to a higher view.
+// Otherwise the leader may never receive a quorum of new-views if peers' views diverge.
+func run(ctx context.Context, d definition, t transport, duty core.Duty, process int64, inputValueCh <-chan [32]byte) error {
+	// === State ===
+
+	var (
+		view        int64 = 1
+		inputValue  [32]byte
+		prepareQC   *pbv1.HotStuffQC // Highest prepare QC, sent in new-view messages.
+		lockedQC    *pbv1.HotStuffQC // Highest pre-commit QC, proposals must extend it.
+		decided     *pbv1.HotStuffQC // Commit QC of the decided value.
+		voted       = make(map[phase]bool)
+		helped      = make(map[int64]bool)
+		aheadViews  = make(map[int64]int64) // Highest future view of each peer's new-view messages.
+		buffer      = make(map[int64][]*pbv1.HotStuffMsg)
+		timerChan   <-chan time.Time
+		stopTimer   = func() {}
+		quorum      = d.Quorum()
+		isLeader    = func() bool { return d.Leader(view) == process }
+		newMsg      = newMsgFunc(duty, process)
+		leaderState = newLeaderState()
+	)
+
+	// === Helpers ==
+
+	// vote sends a vote for the proposal to the leader of the view.
+	vote := func(proposal *pbv1.HotStuffMsg) error {
+		ph := phase(proposal.GetPhase())
+		if voted[ph] {
+			return nil
+		}
+		voted[ph] = true
+
+		return t.Send(ctx, d.Leader(view), newMsg(msgVote, view, ph, valueHash(proposal), nil))
+	}
+
+	// propose broadcasts the leader's prepare proposal if the view's new-views and a value are available.
+	propose := func() error {
+		if !isLeader() || leaderState.Proposed {
+			return nil
+		}
+
+		var highQC *pbv1.HotStuffQC
+		if view > 1 {
+			if len(leaderState.NewViews) < quorum {
+				return nil
+			}
+
+			for _, nv := range leaderState.NewViews {
+				if nv.GetJustify() != nil && nv.GetJustify().GetView() > highQC.GetView() {
+					highQC = nv.GetJustify()
+				}
+			}
+		}
+
+		value := inputValue
+		if highQC != nil {
+			value = qcValueHash(highQC) // Extend the highest prepared value.
+		} else if isZeroHash(value) {
+			return nil // Wait for an input value.
+		}
+
+		leaderState.Proposed = true
+		leaderState.Value = value
+
+		return t.Broadcast(ctx, newMsg(msgProposal, view, phasePrepare, value, highQC))
+	}
+
+	// changeView moves to the new view, resetting the view state and restarting the timer.
+	changeView := func(newView int64, reason string) {
+		d.LogViewChange(ctx, view, newView, reason)
+
+		view = newView
+		voted = make(map[phase]bool)
+		leaderState = newLeaderState()
+
+		stopTimer()
+		timerChan, stopTimer = d.NewTimer(view)
+	}
+
+	// flushBuffer returns and removes the buffered messages of the current view, dropping older ones.
+	flushBuffer := func() []*pbv1.HotStuffMsg {
+		var resp []*pbv1.HotStuffMsg
+		for source, msgs := range buffer {
+			var remaining []*pbv1.HotStuffMsg
+			for _, msg := range msgs {
+				if msg.GetView() == view {
+					resp = append(resp, msg)
+				} else if msg.GetView() > view {
+					remaining = append(remaining, msg)
+				}
+			}
+			buffer[source] = remaining
+		}
+
+		slices.SortFunc(resp, func(a, b *pbv1.HotStuffMsg) int {
+			return cmp.Compare(a.GetPeerIdx(), b.GetPeerIdx())
+		})
+
+		return resp
+	}
+
+	// bufferMsg adds the future view message to the peer's FIFO queue.
+	bufferMsg := func(msg *pbv1.HotStuffMsg) {
+		fifo := append(buffer[msg.GetPeerIdx()], msg)
+		if len(fifo) > d.FIFOLimit {
+			fifo = fifo[len(fifo)-d.FIFOLimit:]
+		}
+		buffer[msg.GetPeerIdx()] = fifo
+	}
+
+	// sendNewView broadcasts the process's new-view message of the current view.
+	sendNewView := func() error {
+		return t.Broadcast(ctx, newMsg(msgNewView, view, phaseUnknown, [32]byte{}, prepareQC))
+	}
+
+	// decide decides the value of the commit QC.
+	decide := func(qc *pbv1.HotStuffQC) {
+		if decided != nil {
+			return
+		}
+		decided = qc
+
+		stopTimer()
+		timerChan = nil
+
+		d.Decide(ctx, qcValueHash(qc), qc)
+	}
+
+	// help sends a decide message to a lagging peer, once.
+	help := func(peerIdx int64) error {
+		if peerIdx == process || helped[peerIdx] {
+			return nil
+		}
+		helped[peerIdx] = true
+
+		return t.Send(ctx, peerIdx, newMsg(msgProposal, decided.GetView(), phaseDecide, qcValueHash(decided), decided))
+	}
+
+	// handle processes a message of the current view, or a proposal moving to a future view.
+	var handle func(msg *pbv1.HotStuffMsg) error
+
+	// handleBuffered handles the buffered messages of the current view.
+	handleBuffered := func() error {
+		for _, msg := range flushBuffer() {
+			if err := handle(msg); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// catchUp records the future view of the new-view message and moves to the highest view
+	// reached by faulty+1 peers, since at least one of them is honest.
+	catchUp := func(msg *pbv1.HotStuffMsg) error {
+		aheadViews[msg.GetPeerIdx()] = max(aheadViews[msg.GetPeerIdx()], msg.GetView())
+
+		var ahead []int64
+		for _, v := range aheadViews {
+			if v > view {
+				ahead = append(ahead, v)
+			}
+		}
+
+		if len(ahead) < d.Faulty()+1 {
+			return nil
+		}
+
+		slices.Sort(ahead)
+		changeView(ahead[len(ahead)-d.Faulty()-1], "f+1 new-views")
+
+		if err := sendNewView(); err != nil {
+			return err
+		}
+
+		return handleBuffered()
+	}
+
+	handle = func(msg *pbv1.HotStuffMsg) error {
+		typ, ph := msgType(msg.GetType()), phase(msg.GetPhase())
+
+		if decided != nil {
+			return help(msg.GetPeerIdx())
+		}
+
+		if typ == msgProposal && ph == phaseDecide {
+			decide(msg.GetJustify()) // Commit QCs are valid in any view.
+			return nil
+		}
+
+		if msg.GetView() < view {
+			return nil // Ignore messages of old views.
+		}
+
+		if msg.GetView() > view {
+			if typ == msgNewView {
+				bufferMsg(msg)
+				return catchUp(msg)
+			}
+
+			if typ != msgProposal || msg.GetPeerIdx() != d.Leader(msg.GetView()) {
+				bufferMsg(msg)
+				return nil
+			}
+
+			// Follow the leader of the future view.
+			changeView(msg.GetView(), "leader proposal")
+			if err := handleBuffered(); err != nil {
+				return err
+			}
+		}
+
+		switch typ {
+		case msgNewView:
+			if !isLeader() {
+				return nil
+			}
+			leaderState.NewViews[msg.GetPeerIdx()] = msg
+
+			return propose()
+
+		case msgVote:
+			if !isLeader() || !leaderState.Proposed || valueHash(msg) != leaderState.Value || leaderState.Certified[ph] {
+				return nil
+			}
+
+			votes := leaderState.Votes[ph]
+			if votes == nil {
+				votes = make(map[int64]*pbv1.HotStuffMsg)
+				leaderState.Votes[ph] = votes
+			}
+			votes[msg.GetPeerIdx()] = msg
+
+			if len(votes) < quorum {
+				return nil
+			}
+			leaderState.Certified[ph] = true
+
+			qc := newQC(ph, view, leaderState.Value, votes)
+
+			return t.Broadcast(ctx, newMsg(msgProposal, view, ph+1, leaderState.Value, qc))
+
+		case msgProposal:
+			if msg.GetPeerIdx() != d.Leader(view) {
+				return nil
+			}
+
+			switch ph {
+			case phasePrepare:
+				if !safeProposal(msg, lockedQC) {
+					return nil
+				}
+			case phasePreCommit:
+				if msg.GetJustify().GetView() > prepareQC.GetView() {
+					prepareQC = msg.GetJustify()
+				}
+			case phaseCommit:
+				lockedQC = msg.GetJustify()
+			default:
+				return errors.New("bug: unexpected proposal phase", z.Any("phase", ph))
+			}
+
+			return vote(msg)
+
+		default:
+			return errors.New("bug: invalid message type", z.Any("type", typ))
+		}
+	}
+
+	// === Algorithm ===
+
+	timerChan, stopTimer = d.NewTimer(view)
+	defer func() {
+		stopTimer()
+	}()
+
+	for {
+		var err error
+		select {
+		case value := <-inputValueCh:
+			if isZeroHash(value) {
+				return errors.New("zero input value not supported")
+			}
+			inputValue = value
+			inputValueCh = nil // Don't read from this channel again.
+
+			if decided == nil {
+				err = propose()
+			}
+
+		case msg := <-t.Receive:
+			err = handle(msg)
+
+		case <-timerChan:
+			changeView(view+1, "timeout")
+			err = sendNewView()
+			if err == nil {
+				err = handleBuffered()
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// leaderState is the state of the leader of the current view.
+type leaderState struct {
+	NewViews  map[int64]*pbv1.HotStuffMsg
+	Votes     map[phase]map[int64]*pbv1.HotStuffMsg
+	Certified map[phase]bool
+	Proposed  bool
+	Value     [32]byte
+}
+
+func newLeaderState() *leaderState {
+	return &leaderState{
+		NewViews:  make(map[int64]*pbv1.HotStuffMsg),
+		Votes:     make(map[phase]map[int64]*pbv1.HotStuffMsg),
+		Certified: make(map[phase]bool),
+	}
+}
+
+// newMsgFunc returns a function creating unsigned messages of the process for the duty.
+func newMsgFunc(duty core.Duty, process int64) func(msgType, int64, phase, [32]byte, *pbv1.HotStuffQC) *pbv1.HotStuffMsg {
+	return func(typ msgType, view int64, ph phase, value [32]byte, justify *pbv1.HotStuffQC) *pbv1.HotStuffMsg {
+		msg := &pbv1.HotStuffMsg{
+			Type:    int64(typ),
+			Duty:    core.DutyToProto(duty),
+			PeerIdx: process,
+			View:    view,
+			Phase:   int64(ph),
+			Justify: justify,
+		}
+
+		if !isZeroHash(value) {
+			msg.ValueHash = value[:]
+		}
+
+		return msg
+	}
+}
+
+// newQC returns a quorum certificate of the signed votes ordered by peer index.
+func newQC(ph phase, view int64, value [32]byte, votes map[int64]*pbv1.HotStuffMsg) *pbv1.HotStuffQC {
+	qc := &pbv1.HotStuffQC{
+		Phase:     int64(ph),
+		View:      view,
+		ValueHash: value[:],
+	}
+
+	for _, vote := range votes {
+		qc.Votes = append(qc.Votes, &pbv1.HotStuffVote{
+			PeerIdx:   vote.GetPeerIdx(),
+			Signature: vote.GetSignature(),
+		})
+	}
+
+	slices.SortFunc(qc.Votes, func(a, b *pbv1.HotStuffVote) int {
+		return cmp.Compare(a.GetPeerIdx(), b.GetPeerIdx())
+	})
+
+	return qc
+}
+
+// safeProposal returns true if the prepare proposal extends the locked QC's value or
+// if it is justified by a prepare QC from a view higher than the locked QC's view.
+func safeProposal(proposal *pbv1.HotStuffMsg, lockedQC *pbv1.HotStuffQC) bool {
+	if lockedQC == nil {
+		return true
+	}
+
+	if valueHash(proposal) == qcValueHash(lockedQC) {
+		return true
+	}
+
+	return proposal.GetJustify().GetView() > lockedQC.GetView()
+}
+
+// valueHash returns the value hash of the message.
+func valueHash(msg *pbv1.HotStuffMsg) [32]byte {
+	hash, _ := toHash32(msg.GetValueHash())
+	return hash
+}
+
+// qcValueHash returns the value hash of the quorum certificate.
+func qcValueHash(qc *pbv1.HotStuffQC) [32]byte {
+	hash, _ := toHash32(qc.GetValueHash())
+	return hash
+}
+
+func isZeroHash(hash [32]byte) bool {
+	return hash == [32]byte{}
+}
@@ -0,0 +1,224 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package hotstuff
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+func TestHotStuff(t *testing.T) {
+	duty := core.NewAttesterDuty(1)
+
+	tests := []struct {
+		name     string
+		nodes    int
+		offline  int                          // Number of offline leaders of the first views
+		stalled  int                          // Number of other processes whose timers never expire
+		drop     func(*pbv1.HotStuffMsg) bool // Drops sent messages
+		view     int64                        // Expected decided view
+		proposer int64                        // Expected proposer view of the decided value
+	}{
+		{
+			name:     "happy 4",
+			nodes:    4,
+			view:     1,
+			proposer: 1,
+		},
+		{
+			name:     "happy 7",
+			nodes:    7,
+			view:     1,
+			proposer: 1,
+		},
+		{
+			name:     "leader offline",
+			nodes:    4,
+			offline:  1,
+			view:     2,
+			proposer: 2,
+		},
+		{
+			name:     "two leaders offline",
+			nodes:    7,
+			offline:  2,
+			view:     3,
+			proposer: 3,
+		},
+		{
+			name:     "stalled process catches up",
+			nodes:    4,
+			offline:  1,
+			stalled:  1, // The view 2 leader requires the stalled process's new-view for a quorum.
+			view:     2,
+			proposer: 2,
+		},
+		{
+			name:  "leader stops after prepare qc",
+			nodes: 4,
+			drop: func(msg *pbv1.HotStuffMsg) bool {
+				return msg.GetView() == 1 && phase(msg.GetPhase()) >= phaseCommit
+			},
+			view:     2,
+			proposer: 1, // Prepared value of view 1 is extended by view 2.
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testHotStuff(t, duty, test.nodes, test.offline, test.stalled, test.drop, test.view, test.proposer)
+		})
+	}
+}
+
+func testHotStuff(t *testing.T, duty core.Duty, nodes int, offlineLeaders int, stalledProcesses int, drop func(*pbv1.HotStuffMsg) bool,
+	expectView int64, expectProposer int64,
+) {
+	t.Helper()
+
+	offline := make(map[int64]bool)
+	for view := int64(1); view <= int64(offlineLeaders); view++ {
+		offline[leader(duty, view, nodes)] = true
+	}
+
+	// Stalled processes don't lead any view up to the expected decided view.
+	leaders := make(map[int64]bool)
+	for view := int64(1); view <= expectView; view++ {
+		leaders[leader(duty, view, nodes)] = true
+	}
+
+	stalled := make(map[int64]bool)
+	for i := range int64(nodes) {
+		if len(stalled) < stalledProcesses && !leaders[i] {
+			stalled[i] = true
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type result struct {
+		Process int64
+		Value   [32]byte
+		View    int64
+	}
+
+	var (
+		receives = make(map[int64]chan *pbv1.HotStuffMsg)
+		results  = make(chan result, nodes)
+		runErrs  = make(chan error, nodes)
+		wg       sync.WaitGroup
+	)
+
+	for i := range int64(nodes) {
+		receives[i] = make(chan *pbv1.HotStuffMsg, 1000)
+	}
+
+	deliver := func(to int64, msg *pbv1.HotStuffMsg) {
+		if offline[to] || (drop != nil && drop(msg)) {
+			return
+		}
+
+		receives[to] <- msg
+	}
+
+	for i := range int64(nodes) {
+		if offline[i] {
+			continue
+		}
+
+		def := definition{
+			Nodes: nodes,
+			Leader: func(view int64) int64 {
+				return leader(duty, view, nodes)
+			},
+			NewTimer: func(view int64) (<-chan time.Time, func()) {
+				if stalled[i] {
+					return nil, func() {}
+				}
+
+				timer := time.NewTimer(time.Duration(view) * 100 * time.Millisecond)
+				return timer.C, func() { timer.Stop() }
+			},
+			Decide: func(_ context.Context, value [32]byte, qc *pbv1.HotStuffQC) {
+				results <- result{Process: i, Value: value, View: qc.GetView()}
+			},
+			LogViewChange: func(_ context.Context, view, newView int64, reason string) {
+				t.Logf("%d: view %d -> %d: %s", i, view, newView, reason)
+			},
+			FIFOLimit: 100,
+		}
+
+		trans := transport{
+			Broadcast: func(_ context.Context, msg *pbv1.HotStuffMsg) error {
+				for to := range int64(nodes) {
+					deliver(to, msg)
+				}
+
+				return nil
+			},
+			Send: func(_ context.Context, to int64, msg *pbv1.HotStuffMsg) error {
+				deliver(to, msg)
+				return nil
+			},
+			Receive: receives[i],
+		}
+
+		// Each process proposes a different value.
+		input := make(chan [32]byte, 1)
+		input <- [32]byte{byte(i + 1)}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runErrs <- run(ctx, def, trans, duty, i, input)
+		}()
+	}
+
+	online := nodes - len(offline)
+
+	var decided []result
+	for len(decided) < online {
+		select {
+		case res := <-results:
+			decided = append(decided, res)
+		case err := <-runErrs:
+			require.Fail(t, "unexpected run error", err)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "timeout")
+		}
+	}
+
+	cancel()
+	wg.Wait()
+
+	for _, res := range decided {
+		require.Equal(t, expectView, res.View)
+		require.Equal(t, decided[0].Value, res.Value)
+	}
+
+	require.Equal(t, [32]byte{byte(leader(duty, expectProposer, nodes) + 1)}, decided[0].Value)
+}
+
+func TestSafeProposal(t *testing.T) {
+	valueA, valueB := [32]byte{1}, [32]byte{2}
+
+	newProposal := func(value [32]byte, justify *pbv1.HotStuffQC) *pbv1.HotStuffMsg {
+		return newMsgFunc(core.NewAttesterDuty(1), 0)(msgProposal, 3, phasePrepare, value, justify)
+	}
+
+	lockedQC := newQC(phasePreCommit, 1, valueA, nil)
+
+	require.True(t, safeProposal(newProposal(valueB, nil), nil), "no lock")
+	require.True(t, safeProposal(newProposal(valueA, nil), lockedQC), "extends lock")
+	require.False(t, safeProposal(newProposal(valueB, nil), lockedQC), "conflicts lock")
+	require.False(t, safeProposal(newProposal(valueB, newQC(phasePrepare, 1, valueB, nil)), lockedQC), "older justification")
+	require.True(t, safeProposal(newProposal(valueB, newQC(phasePrepare, 2, valueB, nil)), lockedQC), "newer justification")
+}
@@ -0,0 +1,244 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package hotstuff
+
+import (
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	ssz "github.com/ferranbt/fastssz"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// verifyMsg returns an error if the message or its quorum certificate is invalid.
+// Only stateless checks are done here, the algorithm checks the message against the instance state.
+func verifyMsg(msg *pbv1.HotStuffMsg, pubkeys map[int64]*k1.PublicKey, quorum int) error {
+	if err := verifySignedMsg(msg, pubkeys); err != nil {
+		return err
+	}
+
+	var (
+		typ          = msgType(msg.GetType())
+		ph           = phase(msg.GetPhase())
+		justify      = msg.GetJustify()
+		value, valid = toHash32(msg.GetValueHash())
+	)
+
+	switch typ {
+	case msgNewView:
+		if ph != phaseUnknown || valid {
+			return errors.New("invalid new-view message")
+		}
+		if justify != nil && (phase(justify.GetPhase()) != phasePrepare || justify.GetView() >= msg.GetView()) {
+			return errors.New("invalid new-view justification")
+		}
+	case msgVote:
+		if ph < phasePrepare || ph > phaseCommit || !valid || justify != nil {
+			return errors.New("invalid vote message", z.Any("phase", ph))
+		}
+	case msgProposal:
+		if !ph.Valid() || !valid {
+			return errors.New("invalid proposal message", z.Any("phase", ph))
+		}
+
+		if ph == phasePrepare {
+			// Prepare proposals extend the highest prepare QC of a previous view, if any.
+			if justify != nil && (phase(justify.GetPhase()) != phasePrepare || justify.GetView() >= msg.GetView() || qcValueHash(justify) != value) {
+				return errors.New("invalid prepare proposal justification")
+			}
+		} else if justify == nil || phase(justify.GetPhase()) != ph-1 || justify.GetView() != msg.GetView() || qcValueHash(justify) != value {
+			// Other proposals are justified by the QC of the previous phase of the same view.
+			return errors.New("invalid proposal justification", z.Any("phase", ph))
+		}
+	default:
+		return errors.New("bug: unexpected message type")
+	}
+
+	if justify == nil {
+		return nil
+	}
+
+	return verifyQC(justify, core.DutyFromProto(msg.GetDuty()), pubkeys, quorum)
+}
+
+// verifyQC returns an error if the quorum certificate doesn't contain a quorum of valid vote signatures
+// for the duty, phase, view and value.
+func verifyQC(qc *pbv1.HotStuffQC, duty core.Duty, pubkeys map[int64]*k1.PublicKey, quorum int) error {
+	ph := phase(qc.GetPhase())
+	if ph < phasePrepare || ph > phaseCommit {
+		return errors.New("invalid quorum certificate phase", z.Any("phase", ph))
+	}
+
+	value, ok := toHash32(qc.GetValueHash())
+	if !ok {
+		return errors.New("invalid quorum certificate value")
+	}
+
+	sources := make(map[int64]bool)
+	for _, vote := range qc.GetVotes() {
+		if sources[vote.GetPeerIdx()] {
+			return errors.New("duplicate quorum certificate vote", z.I64("peer", vote.GetPeerIdx()))
+		}
+		sources[vote.GetPeerIdx()] = true
+
+		// Reconstruct the signed vote message.
+		msg := newMsgFunc(duty, vote.GetPeerIdx())(msgVote, qc.GetView(), ph, value, nil)
+		msg.Signature = vote.GetSignature()
+
+		if err := verifySignedMsg(msg, pubkeys); err != nil {
+			return errors.Wrap(err, "invalid quorum certificate vote", z.I64("peer", vote.GetPeerIdx()))
+		}
+	}
+
+	if len(sources) < quorum {
+		return errors.New("insufficient quorum certificate votes", z.Int("votes", len(sources)))
+	}
+
+	return nil
+}
+
+// verifySignedMsg returns an error if the message fields are invalid or if it isn't signed by the source peer.
+func verifySignedMsg(msg *pbv1.HotStuffMsg, pubkeys map[int64]*k1.PublicKey) error {
+	if msg == nil || msg.GetDuty() == nil {
+		return errors.New("invalid consensus message")
+	}
+
+	if typ := msgType(msg.GetType()); !typ.Valid() {
+		return errors.New("invalid consensus message type", z.Int("type", int(typ)))
+	}
+
+	if typ := core.DutyType(msg.GetDuty().GetType()); !typ.Valid() {
+		return errors.New("invalid consensus message duty type", z.Int("type", int(typ)))
+	}
+
+	if msg.GetView() <= 0 {
+		return errors.New("invalid consensus message view", z.I64("view", msg.GetView()))
+	}
+
+	pubkey, ok := pubkeys[msg.GetPeerIdx()]
+	if !ok {
+		return errors.New("invalid peer index", z.I64("index", msg.GetPeerIdx()))
+	}
+
+	if ok, err := verifyMsgSig(msg, pubkey); err != nil {
+		return errors.Wrap(err, "verify consensus message signature")
+	} else if !ok {
+		return errors.New("invalid consensus message signature")
+	}
+
+	return nil
+}
+
+// verifyMsgSig returns true if the message was signed by pubkey.
+func verifyMsgSig(msg *pbv1.HotStuffMsg, pubkey *k1.PublicKey) (bool, error) {
+	if msg.GetSignature() == nil {
+		return false, errors.New("empty signature")
+	}
+
+	clone, ok := proto.Clone(msg).(*pbv1.HotStuffMsg)
+	if !ok {
+		return false, errors.New("type assert hotstuff msg")
+	}
+	clone.Signature = nil
+
+	hash, err := hashProto(clone)
+	if err != nil {
+		return false, err
+	}
+
+	recovered, err := k1util.Recover(hash[:], msg.GetSignature())
+	if err != nil {
+		return false, errors.Wrap(err, "recover pubkey")
+	}
+
+	return recovered.IsEqual(pubkey), nil
+}
+
+// signMsg returns a copy of the proto message with a populated signature signed by the provided private key.
+func signMsg(msg *pbv1.HotStuffMsg, privkey *k1.PrivateKey) (*pbv1.HotStuffMsg, error) {
+	clone, ok := proto.Clone(msg).(*pbv1.HotStuffMsg)
+	if !ok {
+		return nil, errors.New("type assert hotstuff msg")
+	}
+	clone.Signature = nil
+
+	hash, err := hashProto(clone)
+	if err != nil {
+		return nil, err
+	}
+
+	clone.Signature, err = k1util.Sign(privkey, hash[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "sign")
+	}
+
+	return clone, nil
+}
+
+// hashProto returns a deterministic ssz hash root of the proto message.
+// It is the same logic as that used by the qbft and priority packages.
+func hashProto(msg proto.Message) ([32]byte, error) {
+	if _, ok := msg.(*anypb.Any); ok {
+		return [32]byte{}, errors.New("cannot hash any proto, must hash inner value")
+	}
+
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+
+	index := hh.Index()
+
+	// Do deterministic marshalling.
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "marshal proto")
+	}
+	hh.PutBytes(b)
+
+	hh.Merkleize(index)
+
+	hash, err := hh.HashRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "hash proto")
+	}
+
+	return hash, nil
+}
+
+// valuesByHash returns a map of values by hash.
+func valuesByHash(values []*anypb.Any) (map[[32]byte]*anypb.Any, error) {
+	resp := make(map[[32]byte]*anypb.Any)
+	for _, v := range values {
+		inner, err := v.UnmarshalNew()
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal any")
+		}
+
+		hash, err := hashProto(inner)
+		if err != nil {
+			return nil, err
+		}
+
+		resp[hash] = v
+	}
+
+	return resp, nil
+}
+
+// toHash32 returns the value as a 32-byte hash and true or false if not a valid hash.
+func toHash32(val []byte) ([32]byte, bool) {
+	if len(val) != 32 {
+		return [32]byte{}, false // Nil hash
+	}
+
+	resp := [32]byte(val)
+	if resp == [32]byte{} {
+		return [32]byte{}, false // Zero hash
+	}
+
+	return resp, true
+}
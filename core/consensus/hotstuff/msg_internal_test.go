@@ -0,0 +1,136 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package hotstuff
+
+import (
+	"testing"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+func TestVerifyMsg(t *testing.T) {
+	const nodes = 4
+
+	var (
+		duty    = core.NewAttesterDuty(1)
+		value   = [32]byte{1}
+		privs   []*k1.PrivateKey
+		pubkeys = make(map[int64]*k1.PublicKey)
+		quorum  = definition{Nodes: nodes}.Quorum()
+	)
+
+	for i := range int64(nodes) {
+		priv, err := k1.GeneratePrivateKey()
+		require.NoError(t, err)
+
+		privs = append(privs, priv)
+		pubkeys[i] = priv.PubKey()
+	}
+
+	sign := func(msg *pbv1.HotStuffMsg) *pbv1.HotStuffMsg {
+		t.Helper()
+
+		signed, err := signMsg(msg, privs[msg.GetPeerIdx()])
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	newVotes := func(ph phase, view int64, peers ...int64) map[int64]*pbv1.HotStuffMsg {
+		votes := make(map[int64]*pbv1.HotStuffMsg)
+		for _, peer := range peers {
+			votes[peer] = sign(newMsgFunc(duty, peer)(msgVote, view, ph, value, nil))
+		}
+
+		return votes
+	}
+
+	prepareQC := newQC(phasePrepare, 1, value, newVotes(phasePrepare, 1, 0, 1, 2))
+
+	t.Run("valid", func(t *testing.T) {
+		msgs := []*pbv1.HotStuffMsg{
+			newMsgFunc(duty, 0)(msgProposal, 1, phasePrepare, value, nil),
+			newMsgFunc(duty, 1)(msgVote, 1, phasePrepare, value, nil),
+			newMsgFunc(duty, 0)(msgProposal, 1, phasePreCommit, value, prepareQC),
+			newMsgFunc(duty, 2)(msgNewView, 2, phaseUnknown, [32]byte{}, prepareQC),
+			newMsgFunc(duty, 1)(msgProposal, 2, phasePrepare, value, prepareQC),
+		}
+
+		for _, msg := range msgs {
+			require.NoError(t, verifyMsg(sign(msg), pubkeys, quorum))
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		msg := sign(newMsgFunc(duty, 0)(msgProposal, 1, phasePrepare, value, nil))
+		msg.PeerIdx = 1
+
+		require.ErrorContains(t, verifyMsg(msg, pubkeys, quorum), "invalid consensus message signature")
+	})
+
+	t.Run("invalid justification", func(t *testing.T) {
+		tests := []struct {
+			name string
+			msg  *pbv1.HotStuffMsg
+			err  string
+		}{
+			{
+				name: "pre-commit without qc",
+				msg:  newMsgFunc(duty, 0)(msgProposal, 1, phasePreCommit, value, nil),
+				err:  "invalid proposal justification",
+			},
+			{
+				name: "commit with prepare qc",
+				msg:  newMsgFunc(duty, 0)(msgProposal, 1, phaseCommit, value, prepareQC),
+				err:  "invalid proposal justification",
+			},
+			{
+				name: "prepare with qc of same view",
+				msg:  newMsgFunc(duty, 0)(msgProposal, 1, phasePrepare, value, prepareQC),
+				err:  "invalid prepare proposal justification",
+			},
+			{
+				name: "insufficient votes",
+				msg:  newMsgFunc(duty, 0)(msgProposal, 1, phasePreCommit, value, newQC(phasePrepare, 1, value, newVotes(phasePrepare, 1, 0, 1))),
+				err:  "insufficient quorum certificate votes",
+			},
+			{
+				name: "vote phase mismatch",
+				msg:  newMsgFunc(duty, 0)(msgProposal, 1, phasePreCommit, value, newQC(phasePrepare, 1, value, newVotes(phasePreCommit, 1, 0, 1, 2))),
+				err:  "invalid quorum certificate vote",
+			},
+			{
+				name: "vote with justification",
+				msg:  newMsgFunc(duty, 0)(msgVote, 1, phasePrepare, value, prepareQC),
+				err:  "invalid vote message",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				require.ErrorContains(t, verifyMsg(sign(test.msg), pubkeys, quorum), test.err)
+			})
+		}
+	})
+
+	t.Run("duplicate votes", func(t *testing.T) {
+		qc, ok := proto.Clone(prepareQC).(*pbv1.HotStuffQC)
+		require.True(t, ok)
+		qc.Votes[2] = qc.GetVotes()[1]
+
+		err := verifyMsg(sign(newMsgFunc(duty, 0)(msgProposal, 1, phasePreCommit, value, qc)), pubkeys, quorum)
+		require.ErrorContains(t, err, "duplicate quorum certificate vote")
+	})
+
+	t.Run("vote duty mismatch", func(t *testing.T) {
+		other := newMsgFunc(core.NewAttesterDuty(2), 0)
+		msg := sign(other(msgProposal, 1, phasePreCommit, value, prepareQC))
+
+		require.ErrorContains(t, verifyMsg(msg, pubkeys, quorum), "invalid quorum certificate vote")
+	})
+}
@@ -0,0 +1,407 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package hotstuff
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// ReplayedInstance is a consensus instance merged from the sniffed instances of one or more peers
+// and replayed through the hotstuff algorithm.
+type ReplayedInstance struct {
+	Duty  core.Duty
+	Nodes int
+	// Peers are the indexes of the peers the instance was sniffed by.
+	Peers []int64
+	// StartedAt is the earliest start of the sniffed instances.
+	StartedAt time.Time
+	Views     []ReplayedView
+	// ViewChanges are the view changes triggered while replaying.
+	ViewChanges []ReplayedViewChange
+	// Decided is true if replaying the messages decided a value in DecidedView.
+	Decided      bool
+	DecidedView  int64
+	DecidedValue [32]byte
+}
+
+// ReplayedView is a view of a replayed consensus instance.
+type ReplayedView struct {
+	View   int64
+	Leader int64
+	// Msgs are the view's messages ordered by the time they were first received by any of the sniffing peers.
+	Msgs []ReplayedMsg
+	// Steps summarises the peers present (*), missing (?) and not applicable (_) for each message step.
+	Steps string
+	// TimeoutReason explains why the view didn't decide, it is empty if it did.
+	TimeoutReason string
+}
+
+// ReplayedMsg is a message of a replayed consensus instance.
+type ReplayedMsg struct {
+	// Step is the message type and phase, e.g. new_view, prepare (proposal) or prepare_vote.
+	Step   string
+	Source int64
+	View   int64
+	Value  [32]byte
+	// Offset is the duration since StartedAt the message was first received by any of the sniffing peers.
+	Offset time.Duration
+}
+
+// ReplayedViewChange is a view change triggered while replaying.
+type ReplayedViewChange struct {
+	View    int64
+	NewView int64
+	Reason  string
+}
+
+// replayKey identifies messages received by multiple peers.
+type replayKey struct {
+	Type   msgType
+	Phase  phase
+	Source int64
+	View   int64
+	Value  [32]byte
+}
+
+// timedMsg is a message and the time it was first received.
+type timedMsg struct {
+	Msg       *pbv1.HotStuffMsg
+	Timestamp time.Time
+}
+
+// ReplayInstances merges the sniffed HotStuff instances of the same duty, typically dumped by different peers,
+// and replays each through the hotstuff algorithm. View timers never expire while replaying, so instances
+// only progress by the sniffed messages and undecided instances are replayed until the timeout.
+// It returns the replayed instances ordered by duty.
+func ReplayInstances(ctx context.Context, instances []*pbv1.SniffedConsensusInstance, timeout time.Duration) ([]ReplayedInstance, error) {
+	byDuty := make(map[core.Duty][]*pbv1.SniffedConsensusInstance)
+	for _, instance := range instances {
+		if instance.GetProtocolId() != protocols.HotStuffv1ProtocolID {
+			continue // Only HotStuff instances are supported.
+		}
+
+		if len(instance.GetMsgs()) == 0 {
+			continue
+		}
+
+		duty := core.DutyFromProto(instance.GetMsgs()[0].GetHotstuffMsg().GetMsg().GetDuty())
+		byDuty[duty] = append(byDuty[duty], instance)
+	}
+
+	duties := make([]core.Duty, 0, len(byDuty))
+	for duty := range byDuty {
+		duties = append(duties, duty)
+	}
+	slices.SortFunc(duties, func(a, b core.Duty) int {
+		if a.Slot != b.Slot {
+			return cmp.Compare(a.Slot, b.Slot)
+		}
+
+		return cmp.Compare(a.Type, b.Type)
+	})
+
+	var resp []ReplayedInstance
+	for _, duty := range duties {
+		replayed, err := replayDuty(ctx, duty, byDuty[duty], timeout)
+		if err != nil {
+			return nil, errors.Wrap(err, "replay instance", z.Any("duty", duty))
+		}
+
+		resp = append(resp, replayed)
+	}
+
+	return resp, nil
+}
+
+// replayDuty merges the sniffed instances of the duty and replays them.
+func replayDuty(ctx context.Context, duty core.Duty, instances []*pbv1.SniffedConsensusInstance, timeout time.Duration) (ReplayedInstance, error) {
+	resp := ReplayedInstance{
+		Duty:  duty,
+		Nodes: int(instances[0].GetNodes()),
+	}
+
+	msgs := make(map[replayKey]timedMsg)
+	for _, instance := range instances {
+		if int(instance.GetNodes()) != resp.Nodes {
+			return ReplayedInstance{}, errors.New("mismatching instance nodes")
+		}
+
+		if !slices.Contains(resp.Peers, instance.GetPeerIdx()) {
+			resp.Peers = append(resp.Peers, instance.GetPeerIdx())
+		}
+
+		if startedAt := instance.GetStartedAt().AsTime(); resp.StartedAt.IsZero() || startedAt.Before(resp.StartedAt) {
+			resp.StartedAt = startedAt
+		}
+
+		for _, sniffed := range instance.GetMsgs() {
+			msg := sniffed.GetHotstuffMsg().GetMsg()
+			if msg == nil || !msgType(msg.GetType()).Valid() {
+				return ReplayedInstance{}, errors.New("invalid hotstuff message")
+			}
+
+			if core.DutyFromProto(msg.GetDuty()) != duty {
+				return ReplayedInstance{}, errors.New("mismatching message duty")
+			}
+
+			key := replayKey{
+				Type:   msgType(msg.GetType()),
+				Phase:  phase(msg.GetPhase()),
+				Source: msg.GetPeerIdx(),
+				View:   msg.GetView(),
+				Value:  valueHash(msg),
+			}
+
+			timestamp := sniffed.GetTimestamp().AsTime()
+			if existing, ok := msgs[key]; ok && !timestamp.Before(existing.Timestamp) {
+				continue
+			}
+
+			msgs[key] = timedMsg{Msg: msg, Timestamp: timestamp}
+		}
+	}
+
+	slices.Sort(resp.Peers)
+
+	ordered := make([]timedMsg, 0, len(msgs))
+	for _, msg := range msgs {
+		ordered = append(ordered, msg)
+	}
+	slices.SortStableFunc(ordered, func(a, b timedMsg) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	if err := replay(ctx, &resp, ordered, timeout); err != nil {
+		return ReplayedInstance{}, err
+	}
+
+	resp.Views = replayedViews(resp, ordered)
+
+	return resp, nil
+}
+
+// replay runs the hotstuff algorithm as the first sniffing peer with the ordered messages,
+// populating the view changes and decided fields of the instance.
+func replay(ctx context.Context, instance *ReplayedInstance, ordered []timedMsg, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	def := definition{
+		Nodes: instance.Nodes,
+		Leader: func(view int64) int64 {
+			return leader(instance.Duty, view, instance.Nodes)
+		},
+		NewTimer: func(int64) (<-chan time.Time, func()) {
+			return nil, func() {} // Never expire, progress by sniffed messages only.
+		},
+		Decide: func(_ context.Context, value [32]byte, qc *pbv1.HotStuffQC) {
+			instance.Decided = true
+			instance.DecidedView = qc.GetView()
+			instance.DecidedValue = value
+			cancel()
+		},
+		LogViewChange: func(_ context.Context, view, newView int64, reason string) {
+			instance.ViewChanges = append(instance.ViewChanges, ReplayedViewChange{
+				View:    view,
+				NewView: newView,
+				Reason:  reason,
+			})
+		},
+		FIFOLimit: len(ordered) + 1,
+	}
+
+	recvBuffer := make(chan *pbv1.HotStuffMsg, len(ordered))
+	for _, msg := range ordered {
+		recvBuffer <- msg.Msg
+	}
+
+	// Messages sent by the replaying peer are already sniffed.
+	noop := func(context.Context, *pbv1.HotStuffMsg) error { return nil }
+
+	t := transport{
+		Broadcast: noop,
+		Send: func(ctx context.Context, _ int64, msg *pbv1.HotStuffMsg) error {
+			return noop(ctx, msg)
+		},
+		Receive: recvBuffer,
+	}
+
+	// The replaying peer never proposes, its sniffed proposals are replayed instead.
+	err := run(ctx, def, t, instance.Duty, instance.Peers[0], nil)
+	if err != nil && !isContextErr(err) {
+		return err
+	}
+
+	return nil
+}
+
+// viewStep groups the messages of a view step by peer status.
+type viewStep struct {
+	Name    string
+	Vote    bool
+	Present []int
+	Missing []int
+	Peers   int
+}
+
+// replayedViews returns the views of the replayed instance up to the highest view of any message.
+func replayedViews(instance ReplayedInstance, ordered []timedMsg) []ReplayedView {
+	var (
+		maxView int64 = 1
+		byView        = make(map[int64][]*pbv1.HotStuffMsg)
+		quorum        = definition{Nodes: instance.Nodes}.Quorum()
+	)
+
+	for _, msg := range ordered {
+		maxView = max(maxView, msg.Msg.GetView())
+		byView[msg.Msg.GetView()] = append(byView[msg.Msg.GetView()], msg.Msg)
+	}
+
+	var resp []ReplayedView
+	for view := int64(1); view <= maxView; view++ {
+		viewLeader := leader(instance.Duty, view, instance.Nodes)
+		steps := groupViewMessages(byView[view], instance.Nodes, view, int(viewLeader))
+
+		var summary []string
+		for _, step := range steps {
+			summary = append(summary, step.Name+"="+fmtStepPeers(step))
+		}
+
+		replayed := ReplayedView{
+			View:   view,
+			Leader: viewLeader,
+			Steps:  strings.Join(summary, " "),
+		}
+
+		if !instance.Decided || instance.DecidedView != view {
+			replayed.TimeoutReason = timeoutReason(steps, view, quorum)
+		}
+
+		for _, msg := range ordered {
+			if msg.Msg.GetView() != view {
+				continue
+			}
+
+			replayed.Msgs = append(replayed.Msgs, ReplayedMsg{
+				Step:   stepName(msgType(msg.Msg.GetType()), phase(msg.Msg.GetPhase())),
+				Source: msg.Msg.GetPeerIdx(),
+				View:   view,
+				Value:  valueHash(msg.Msg),
+				Offset: msg.Timestamp.Sub(instance.StartedAt),
+			})
+		}
+
+		resp = append(resp, replayed)
+	}
+
+	return resp
+}
+
+// stepName returns the name of the message step, the phase for proposals.
+func stepName(typ msgType, ph phase) string {
+	switch typ {
+	case msgNewView:
+		return typ.String()
+	case msgVote:
+		return ph.String() + "_vote"
+	default:
+		return ph.String()
+	}
+}
+
+// groupViewMessages groups messages by step and returns which peers were present and missing for each step.
+func groupViewMessages(msgs []*pbv1.HotStuffMsg, peers int, view int64, leader int) []viewStep {
+	type stepKey struct {
+		Type  msgType
+		Phase phase
+	}
+
+	keys := []stepKey{{Type: msgNewView}}
+	for _, ph := range []phase{phasePrepare, phasePreCommit, phaseCommit} {
+		keys = append(keys, stepKey{Type: msgProposal, Phase: ph}, stepKey{Type: msgVote, Phase: ph})
+	}
+	keys = append(keys, stepKey{Type: msgProposal, Phase: phaseDecide})
+
+	var resp []viewStep
+	for _, key := range keys {
+		step := viewStep{
+			Name:  stepName(key.Type, key.Phase),
+			Vote:  key.Type != msgProposal,
+			Peers: peers,
+		}
+
+		for i := range peers {
+			included := slices.ContainsFunc(msgs, func(msg *pbv1.HotStuffMsg) bool {
+				return msgType(msg.GetType()) == key.Type && phase(msg.GetPhase()) == key.Phase && msg.GetPeerIdx() == int64(i)
+			})
+			if included {
+				step.Present = append(step.Present, i)
+				continue
+			}
+
+			if key.Type == msgProposal && i != leader {
+				// Only leader can be missing for proposals.
+				continue
+			}
+
+			if key.Type == msgNewView && view == 1 {
+				// New-views only applicable to views > 1.
+				continue
+			}
+
+			step.Missing = append(step.Missing, i)
+		}
+
+		resp = append(resp, step)
+	}
+
+	return resp
+}
+
+// timeoutReason returns the first step of the view that didn't complete.
+func timeoutReason(steps []viewStep, view int64, quorum int) string {
+	for _, step := range steps {
+		if step.Name == msgNewView.String() && view == 1 {
+			continue
+		}
+
+		if step.Vote && len(step.Present) < quorum {
+			return fmt.Sprintf("insufficient %ss, missing peers=%v", step.Name, step.Missing)
+		}
+
+		if !step.Vote && len(step.Present) == 0 {
+			return fmt.Sprintf("no %s proposal, missing leader=%v", step.Name, step.Missing)
+		}
+	}
+
+	return "unknown reason"
+}
+
+// fmtStepPeers returns a string representing the present and missing peers.
+func fmtStepPeers(step viewStep) string {
+	var resp []string
+	for range step.Peers {
+		resp = append(resp, "_")
+	}
+
+	for _, i := range step.Present {
+		resp[i] = "*"
+	}
+
+	for _, i := range step.Missing {
+		resp[i] = "?"
+	}
+
+	return strings.Join(resp, "")
+}
@@ -0,0 +1,104 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package hotstuff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+func TestReplayInstances(t *testing.T) {
+	const nodes = 4
+
+	duty := core.NewAttesterDuty(1) // Leaders: view 1=0, view 2=1.
+	require.EqualValues(t, 0, leader(duty, 1, nodes))
+	require.EqualValues(t, 1, leader(duty, 2, nodes))
+
+	value := [32]byte{1}
+	start := time.Unix(1700000000, 0)
+
+	var offset time.Duration
+	newSniffed := func(typ msgType, ph phase, source int64, justify *pbv1.HotStuffQC) *pbv1.SniffedConsensusMsg {
+		var vHash [32]byte
+		if typ != msgNewView {
+			vHash = value
+		}
+
+		offset += time.Millisecond
+
+		return &pbv1.SniffedConsensusMsg{
+			Timestamp:   timestamppb.New(start.Add(offset)),
+			HotstuffMsg: &pbv1.HotStuffConsensusMsg{Msg: newMsgFunc(duty, source)(typ, 2, ph, vHash, justify)},
+		}
+	}
+
+	// View 1 times out since the leader (peer 0) is offline, all other peers broadcast new-views.
+	var leaderSniffed, replicaSniffed []*pbv1.SniffedConsensusMsg
+	for source := int64(1); source < nodes; source++ {
+		msg := newSniffed(msgNewView, phaseUnknown, source, nil)
+		leaderSniffed = append(leaderSniffed, msg)
+		replicaSniffed = append(replicaSniffed, msg)
+	}
+
+	// View 2 decides without peer 0, votes are only sniffed by the leader.
+	var justify *pbv1.HotStuffQC
+	for _, ph := range []phase{phasePrepare, phasePreCommit, phaseCommit, phaseDecide} {
+		proposal := newSniffed(msgProposal, ph, 1, justify)
+		leaderSniffed = append(leaderSniffed, proposal)
+		replicaSniffed = append(replicaSniffed, proposal)
+
+		if ph == phaseDecide {
+			break
+		}
+
+		for source := int64(1); source < nodes; source++ {
+			leaderSniffed = append(leaderSniffed, newSniffed(msgVote, ph, source, nil))
+		}
+
+		justify = newQC(ph, 2, value, nil)
+	}
+
+	instances := []*pbv1.SniffedConsensusInstance{
+		{Nodes: nodes, PeerIdx: 2, StartedAt: timestamppb.New(start), Msgs: replicaSniffed, ProtocolId: protocols.HotStuffv1ProtocolID},
+		{Nodes: nodes, PeerIdx: 1, StartedAt: timestamppb.New(start), Msgs: leaderSniffed, ProtocolId: protocols.HotStuffv1ProtocolID},
+		{Nodes: nodes, PeerIdx: 3, ProtocolId: protocols.QBFTv2ProtocolID},
+	}
+
+	replayed, err := ReplayInstances(context.Background(), instances, time.Second)
+	require.NoError(t, err)
+	require.Len(t, replayed, 1)
+
+	instance := replayed[0]
+	require.Equal(t, duty, instance.Duty)
+	require.Equal(t, []int64{1, 2}, instance.Peers)
+	require.True(t, instance.Decided)
+	require.EqualValues(t, 2, instance.DecidedView)
+	require.Equal(t, value, instance.DecidedValue)
+	require.Equal(t, []ReplayedViewChange{{View: 1, NewView: 2, Reason: "f+1 new-views"}}, instance.ViewChanges)
+
+	require.Len(t, instance.Views, 2)
+
+	view1 := instance.Views[0]
+	require.EqualValues(t, 0, view1.Leader)
+	require.Empty(t, view1.Msgs)
+	require.Equal(t, "no prepare proposal, missing leader=[0]", view1.TimeoutReason)
+
+	view2 := instance.Views[1]
+	require.EqualValues(t, 1, view2.Leader)
+	require.Empty(t, view2.TimeoutReason)
+	require.Equal(t, "new_view=?*** prepare=_*__ prepare_vote=?*** pre_commit=_*__ pre_commit_vote=?*** "+
+		"commit=_*__ commit_vote=?*** decide=_*__", view2.Steps)
+	require.Len(t, view2.Msgs, len(leaderSniffed))
+	for i, msg := range view2.Msgs {
+		// Merged messages are deduplicated with the earliest receive time.
+		require.Equal(t, time.Duration(i+1)*time.Millisecond, msg.Offset)
+	}
+}
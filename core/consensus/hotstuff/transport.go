@@ -0,0 +1,210 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package hotstuff
+
+import (
+	"context"
+	"sync"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// sender is an interface for sending messages asynchronously to other peers.
+type sender interface {
+	Broadcast(ctx context.Context, msg *pbv1.HotStuffConsensusMsg) error
+	SendTo(ctx context.Context, peerIdx int64, msg *pbv1.HotStuffConsensusMsg) error
+}
+
+// consensusMsg is a verified message and the values of its hashes.
+type consensusMsg struct {
+	msg    *pbv1.HotStuffMsg
+	values map[[32]byte]*anypb.Any
+}
+
+func (m consensusMsg) toProto() *pbv1.HotStuffConsensusMsg {
+	var values []*anypb.Any
+	for _, v := range m.values {
+		values = append(values, v)
+	}
+
+	return &pbv1.HotStuffConsensusMsg{
+		Msg:    m.msg,
+		Values: values,
+	}
+}
+
+// instanceTransport encapsulates receiving and sending for a consensus instance/duty.
+type instanceTransport struct {
+	// Immutable state
+	sender     sender
+	privkey    *k1.PrivateKey
+	peerIdx    int64
+	recvBuffer chan *pbv1.HotStuffMsg // Instance inner receive buffer.
+	sniffer    *sniffer
+
+	// Mutable state
+	valueMu sync.Mutex
+	valueCh <-chan proto.Message    // Channel providing lazy proposed values.
+	values  map[[32]byte]*anypb.Any // maps any-wrapped proposed values to their hashes
+}
+
+// newInstanceTransport creates a new instanceTransport.
+func newInstanceTransport(sender sender, privkey *k1.PrivateKey, peerIdx int64, valueCh <-chan proto.Message,
+	sniffer *sniffer,
+) *instanceTransport {
+	return &instanceTransport{
+		sender:     sender,
+		privkey:    privkey,
+		peerIdx:    peerIdx,
+		recvBuffer: make(chan *pbv1.HotStuffMsg),
+		sniffer:    sniffer,
+		valueCh:    valueCh,
+		values:     make(map[[32]byte]*anypb.Any),
+	}
+}
+
+// setValues caches the values and their hashes.
+func (t *instanceTransport) setValues(values map[[32]byte]*anypb.Any) {
+	t.valueMu.Lock()
+	defer t.valueMu.Unlock()
+
+	for k, v := range values {
+		t.values[k] = v
+	}
+}
+
+// getValue returns the value by its hash.
+func (t *instanceTransport) getValue(hash [32]byte) (*anypb.Any, error) {
+	t.valueMu.Lock()
+	defer t.valueMu.Unlock()
+
+	// First check if we have a new value.
+	select {
+	case value := <-t.valueCh:
+		valueHash, err := hashProto(value)
+		if err != nil {
+			return nil, err
+		}
+
+		anyValue, err := anypb.New(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "wrap any value")
+		}
+
+		t.values[valueHash] = anyValue
+	default:
+		// No new values
+	}
+
+	pb, ok := t.values[hash]
+	if !ok {
+		return nil, errors.New("unknown value")
+	}
+
+	return pb, nil
+}
+
+// createMsg signs the message and attaches the values required by the receivers.
+func (t *instanceTransport) createMsg(msg *pbv1.HotStuffMsg) (consensusMsg, error) {
+	// Proposals and justified messages require values, votes only require hashes.
+	var hashes [][32]byte
+	if msgType(msg.GetType()) == msgProposal {
+		hashes = append(hashes, valueHash(msg))
+	}
+	if msg.GetJustify() != nil {
+		hashes = append(hashes, qcValueHash(msg.GetJustify()))
+	}
+
+	values := make(map[[32]byte]*anypb.Any)
+	for _, hash := range hashes {
+		if values[hash] != nil {
+			continue
+		}
+
+		value, err := t.getValue(hash)
+		if err != nil {
+			return consensusMsg{}, err
+		}
+
+		values[hash] = value
+	}
+
+	signed, err := signMsg(msg, t.privkey)
+	if err != nil {
+		return consensusMsg{}, err
+	}
+
+	return consensusMsg{msg: signed, values: values}, nil
+}
+
+// Broadcast signs and sends the message to all peers (including self).
+func (t *instanceTransport) Broadcast(ctx context.Context, msg *pbv1.HotStuffMsg) error {
+	cMsg, err := t.createMsg(msg)
+	if err != nil {
+		return err
+	}
+
+	t.sendToSelf(ctx, cMsg)
+
+	return t.sender.Broadcast(ctx, cMsg.toProto())
+}
+
+// Send signs and sends the message to the peer (which may be self).
+func (t *instanceTransport) Send(ctx context.Context, peerIdx int64, msg *pbv1.HotStuffMsg) error {
+	cMsg, err := t.createMsg(msg)
+	if err != nil {
+		return err
+	}
+
+	if peerIdx == t.peerIdx {
+		t.sendToSelf(ctx, cMsg)
+		return nil
+	}
+
+	return t.sender.SendTo(ctx, peerIdx, cMsg.toProto())
+}
+
+// sendToSelf enqueues the message to the inner receive buffer (async since buffer is blocking).
+func (t *instanceTransport) sendToSelf(ctx context.Context, msg consensusMsg) {
+	go func() {
+		select {
+		case <-ctx.Done():
+		case t.recvBuffer <- msg.msg:
+			t.sniffer.Add(msg.toProto())
+		}
+	}()
+}
+
+// ProcessReceives processes received messages from the outer buffer until the context is closed.
+func (t *instanceTransport) ProcessReceives(ctx context.Context, outerBuffer chan consensusMsg) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-outerBuffer:
+			t.setValues(msg.values)
+
+			select {
+			case <-ctx.Done():
+				return
+			case t.recvBuffer <- msg.msg:
+				t.sniffer.Add(msg.toProto())
+			}
+		}
+	}
+}
+
+// SnifferInstance returns the current sniffed consensus instance.
+func (t *instanceTransport) SnifferInstance() *pbv1.SniffedConsensusInstance {
+	return t.sniffer.Instance()
+}
+
+// RecvBuffer returns the inner receive buffer.
+func (t *instanceTransport) RecvBuffer() chan *pbv1.HotStuffMsg {
+	return t.recvBuffer
+}
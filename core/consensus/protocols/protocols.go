@@ -12,11 +12,19 @@ const (
 	protocolIDPrefix = "/charon/consensus/"
 
 	QBFTv2ProtocolID = "/charon/consensus/qbft/2.0.0"
+
+	// HotStuffv1ProtocolID is the experimental leader-rotating HotStuff consensus protocol.
+	// It is only selected if prioritised by the cluster or the operators.
+	HotStuffv1ProtocolID = "/charon/consensus/hotstuff/1.0.0"
+
+	// QBFTCandidateProtocolID is the protocol used to share proposal candidates before QBFT consensus.
+	// It is not a consensus protocol, so it is not included in Protocols.
+	QBFTCandidateProtocolID = "/charon/consensus/qbft/candidate/1.0.0"
 )
 
 // Protocols returns the supported protocols of this package in order of precedence.
 func Protocols() []protocol.ID {
-	return []protocol.ID{QBFTv2ProtocolID}
+	return []protocol.ID{QBFTv2ProtocolID, HotStuffv1ProtocolID}
 }
 
 // MostPreferredConsensusProtocol returns the most preferred consensus protocol from the given list.
@@ -13,12 +13,14 @@ import (
 
 func TestIsSupportedProtocolName(t *testing.T) {
 	require.True(t, protocols.IsSupportedProtocolName("qbft"))
+	require.True(t, protocols.IsSupportedProtocolName("hotstuff"))
 	require.False(t, protocols.IsSupportedProtocolName("unreal"))
 }
 
 func TestProtocols(t *testing.T) {
 	require.Equal(t, []protocol.ID{
 		protocols.QBFTv2ProtocolID,
+		protocols.HotStuffv1ProtocolID,
 	}, protocols.Protocols())
 }
 
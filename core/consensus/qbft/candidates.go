@@ -0,0 +1,445 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package qbft
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+// candidateTimeout is the maximum duration to wait for proposal candidates from peers.
+const candidateTimeout = 300 * time.Millisecond
+
+// candidate is a proposer duty unsigned data set proposed by a peer, with its total block value.
+// Note that block values are reported by peers and cannot be verified locally, see corroboratedValue.
+type candidate struct {
+	data  core.UnsignedDataSet
+	value *big.Int
+	hash  [32]byte
+}
+
+// better returns true if the candidate is more valuable than the other candidate.
+// Ties are broken deterministically by the lowest data hash.
+func (c candidate) better(other candidate) bool {
+	if cmp := c.value.Cmp(other.value); cmp != 0 {
+		return cmp > 0
+	}
+
+	return bytes.Compare(c.hash[:], other.hash[:]) < 0
+}
+
+// candidateSet contains the candidates received from peers for a duty.
+type candidateSet struct {
+	byPeer  map[int64]candidate
+	updated chan struct{} // Closed and replaced when a candidate is added.
+}
+
+// newCandidate returns a candidate of the locally fetched proposer duty unsigned data set.
+func newCandidate(data core.UnsignedDataSet) (candidate, error) {
+	value := big.NewInt(0)
+	for pubkey, unsigned := range data {
+		proposal, ok := unsigned.(core.VersionedProposal)
+		if !ok {
+			return candidate{}, errors.New("invalid proposal candidate", z.Any("pubkey", pubkey))
+		}
+
+		value.Add(value, proposal.Value())
+	}
+
+	hash, err := hashCandidate(data)
+	if err != nil {
+		return candidate{}, err
+	}
+
+	return candidate{data: data, value: value, hash: hash}, nil
+}
+
+// candidateFromProto returns a candidate from the protobuf message.
+func candidateFromProto(duty core.Duty, msg *pbv1.QBFTCandidateMsg) (candidate, error) {
+	data, err := core.UnsignedDataSetFromProto(duty.Type, msg.GetProposals())
+	if err != nil {
+		return candidate{}, err
+	}
+
+	if len(data) == 0 || len(data) != len(msg.GetBlockValues()) {
+		return candidate{}, errors.New("mismatching proposal candidate block values")
+	}
+
+	value := big.NewInt(0)
+	for pubkey, unsigned := range data {
+		if _, ok := unsigned.(core.VersionedProposal); !ok {
+			return candidate{}, errors.New("invalid proposal candidate", z.Any("pubkey", pubkey))
+		}
+
+		blockValue, ok := new(big.Int).SetString(msg.GetBlockValues()[string(pubkey)], 10)
+		if !ok || blockValue.Sign() < 0 {
+			return candidate{}, errors.New("invalid proposal candidate block value", z.Any("pubkey", pubkey))
+		}
+
+		value.Add(value, blockValue)
+	}
+
+	hash, err := hashCandidate(data)
+	if err != nil {
+		return candidate{}, err
+	}
+
+	return candidate{data: data, value: value, hash: hash}, nil
+}
+
+// candidateToProto returns the protobuf message of the candidate.
+func candidateToProto(duty core.Duty, data core.UnsignedDataSet) (*pbv1.QBFTCandidateMsg, error) {
+	pb, err := core.UnsignedDataSetToProto(data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for pubkey, unsigned := range data {
+		proposal, ok := unsigned.(core.VersionedProposal)
+		if !ok {
+			return nil, errors.New("invalid proposal candidate", z.Any("pubkey", pubkey))
+		}
+
+		values[string(pubkey)] = proposal.Value().String()
+	}
+
+	return &pbv1.QBFTCandidateMsg{
+		Duty:        core.DutyToProto(duty),
+		Proposals:   pb,
+		BlockValues: values,
+	}, nil
+}
+
+// hashCandidate returns the hash of the unsigned data set.
+func hashCandidate(data core.UnsignedDataSet) ([32]byte, error) {
+	pb, err := core.UnsignedDataSetToProto(data)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return hashProto(pb)
+}
+
+// verifyCandidate returns an error if the peer's candidate doesn't propose blocks for the same validators,
+// slots, proposer indexes and parent roots as the local candidate, or with other than the configured fee recipients.
+func verifyCandidate(local, peerCandidate candidate, feeRecipientFunc func(core.PubKey) string) error {
+	if len(local.data) != len(peerCandidate.data) {
+		return errors.New("mismatching candidate validators")
+	}
+
+	for pubkey, unsigned := range local.data {
+		localProposal, ok := unsigned.(core.VersionedProposal)
+		if !ok {
+			return errors.New("invalid local proposal")
+		}
+
+		peerUnsigned, ok := peerCandidate.data[pubkey]
+		if !ok {
+			return errors.New("missing candidate validator", z.Any("pubkey", pubkey))
+		}
+
+		peerProposal, ok := peerUnsigned.(core.VersionedProposal)
+		if !ok {
+			return errors.New("invalid candidate proposal")
+		}
+
+		localSlot, err := localProposal.Slot()
+		if err != nil {
+			return errors.Wrap(err, "local proposal slot")
+		}
+		peerSlot, err := peerProposal.Slot()
+		if err != nil {
+			return errors.Wrap(err, "candidate proposal slot")
+		} else if localSlot != peerSlot {
+			return errors.New("mismatching candidate slot", z.U64("expected", uint64(localSlot)), z.U64("actual", uint64(peerSlot)))
+		}
+
+		localIndex, err := localProposal.ProposerIndex()
+		if err != nil {
+			return errors.Wrap(err, "local proposal proposer index")
+		}
+		peerIndex, err := peerProposal.ProposerIndex()
+		if err != nil {
+			return errors.Wrap(err, "candidate proposal proposer index")
+		} else if localIndex != peerIndex {
+			return errors.New("mismatching candidate proposer index", z.U64("expected", uint64(localIndex)), z.U64("actual", uint64(peerIndex)))
+		}
+
+		localParent, err := localProposal.ParentRoot()
+		if err != nil {
+			return errors.Wrap(err, "local proposal parent root")
+		}
+		peerParent, err := peerProposal.ParentRoot()
+		if err != nil {
+			return errors.Wrap(err, "candidate proposal parent root")
+		} else if localParent != peerParent {
+			return errors.New("mismatching candidate parent root")
+		}
+
+		if peerProposal.Version < eth2spec.DataVersionBellatrix {
+			continue // No execution payload
+		}
+
+		feeRecipient, err := peerProposal.FeeRecipient()
+		if err != nil {
+			return errors.Wrap(err, "candidate proposal fee recipient")
+		} else if expected := feeRecipientFunc(pubkey); !strings.EqualFold(fmt.Sprintf("%#x", feeRecipient), expected) {
+			return errors.New("mismatching candidate fee recipient", z.Str("expected", expected), z.Str("actual", fmt.Sprintf("%#x", feeRecipient)))
+		}
+	}
+
+	return nil
+}
+
+// corroboratedValue returns the (faulty+1)th highest of the reported block values and true, or false if
+// fewer values were reported. Since block values reported by peers cannot be verified locally, but are
+// attributable to the authenticated peer that reported them, a value is only trusted up to the highest value
+// reported by at least faulty+1 nodes, since at least one of them is honest. Faulty nodes cannot increase it.
+// Blocks differ per beacon node, so values are corroborated across all valid candidates of the duty, which
+// verifyCandidate ensures are for the same slots, proposers and parent roots.
+func corroboratedValue(values []*big.Int, faulty int) (*big.Int, bool) {
+	if len(values) < faulty+1 {
+		return nil, false
+	}
+
+	values = slices.Clone(values)
+	slices.SortFunc(values, func(a, b *big.Int) int {
+		return b.Cmp(a) // Highest first
+	})
+
+	return values[faulty], true
+}
+
+// bestCandidate returns the most valuable of the local and the valid peer candidates.
+// The local block value is reported by the local beacon node and therefore trusted.
+// Peer candidate values are capped at the corroborated value, and peer candidates are
+// only selected if their capped value exceeds the local value.
+func bestCandidate(local candidate, peerCandidates []candidate, faulty int) candidate {
+	values := []*big.Int{local.value}
+	for _, peerCandidate := range peerCandidates {
+		values = append(values, peerCandidate.value)
+	}
+
+	corroborated, ok := corroboratedValue(values, faulty)
+	if !ok {
+		return local
+	}
+
+	best := local
+	for _, peerCandidate := range peerCandidates {
+		capped := peerCandidate
+		if capped.value.Cmp(corroborated) > 0 {
+			capped.value = corroborated
+		}
+
+		if capped.value.Cmp(local.value) <= 0 {
+			continue
+		}
+
+		if capped.better(best) {
+			best = capped
+		}
+	}
+
+	return best
+}
+
+// alternativePossible returns true if a peer candidate can still be selected over the local candidate given
+// the received and the number of missing peer candidates. This requires at least faulty+1 peer candidates more
+// valuable than the local candidate, see bestCandidate.
+func alternativePossible(local candidate, byPeer map[int64]candidate, missing int, faulty int) bool {
+	var higher int
+	for _, peerCandidate := range byPeer {
+		if peerCandidate.value.Cmp(local.value) > 0 {
+			higher++
+		}
+	}
+
+	return higher+missing >= faulty+1
+}
+
+// selectCandidate shares the locally fetched proposer duty data with peers and returns the most valuable
+// valid candidate with a corroborated value received from peers within candidateTimeout, or the local data
+// if it is the most valuable. Since all peers apply the same deterministic selection, the cluster converges
+// on the most valuable block.
+func (c *Consensus) selectCandidate(ctx context.Context, duty core.Duty, data core.UnsignedDataSet) core.UnsignedDataSet {
+	local, err := newCandidate(data)
+	if err != nil {
+		log.Warn(ctx, "Invalid local proposal candidate", err)
+		return data
+	}
+
+	msg, err := candidateToProto(duty, data)
+	if err != nil {
+		log.Warn(ctx, "Failed creating proposal candidate message", err)
+		return data
+	}
+
+	for _, p := range c.peers {
+		if p.ID == c.tcpNode.ID() {
+			continue // Do not send to self
+		}
+
+		if err := c.sender.SendAsync(ctx, c.tcpNode, protocols.QBFTCandidateProtocolID, p.ID, msg); err != nil {
+			log.Warn(ctx, "Failed sending proposal candidate", err, z.Str("peer", p.Name))
+		}
+	}
+
+	faulty := qbft.Definition[int, int]{Nodes: len(c.peers)}.Faulty()
+
+	timer := time.NewTimer(candidateTimeout)
+	defer timer.Stop()
+
+	peerCandidates := c.awaitCandidates(ctx, duty, local, faulty, timer.C)
+
+	var valid []candidate
+	for i, p := range c.peers {
+		peerCandidate, ok := peerCandidates[int64(i)]
+		if !ok {
+			continue
+		}
+
+		if err := verifyCandidate(local, peerCandidate, c.feeRecipientFunc); err != nil {
+			log.Warn(ctx, "Ignoring invalid proposal candidate from peer", err, z.Str("peer", p.Name))
+			continue
+		}
+
+		valid = append(valid, peerCandidate)
+	}
+
+	best := bestCandidate(local, valid, faulty)
+
+	log.Debug(ctx, "Selected proposal candidate",
+		z.Bool("local", best.hash == local.hash),
+		z.Str("value", best.value.String()),
+		z.Str("local_value", local.value.String()),
+		z.Int("candidates", len(peerCandidates)+1),
+	)
+
+	return best.data
+}
+
+// awaitCandidates blocks until candidates from all other peers are received, until no peer candidate can be
+// selected over the local candidate anymore, or until the timeout or context is done.
+// It returns the received candidates by peer index.
+func (c *Consensus) awaitCandidates(ctx context.Context, duty core.Duty, local candidate, faulty int,
+	timeout <-chan time.Time,
+) map[int64]candidate {
+	for {
+		byPeer, updated := c.getCandidates(duty)
+		missing := len(c.peers) - 1 - len(byPeer)
+		if missing <= 0 || !alternativePossible(local, byPeer, missing, faulty) {
+			return byPeer
+		}
+
+		select {
+		case <-ctx.Done():
+			return byPeer
+		case <-timeout:
+			return byPeer
+		case <-updated:
+		}
+	}
+}
+
+// handleCandidate processes an incoming proposal candidate wire message.
+func (c *Consensus) handleCandidate(ctx context.Context, pID peer.ID, req proto.Message) (proto.Message, bool, error) {
+	pbMsg, ok := req.(*pbv1.QBFTCandidateMsg)
+	if !ok || pbMsg == nil || pbMsg.GetDuty() == nil {
+		return nil, false, errors.New("invalid proposal candidate message")
+	}
+
+	duty := core.DutyFromProto(pbMsg.GetDuty())
+	if duty.Type != core.DutyProposer || !c.gaterFunc(duty) {
+		return nil, false, errors.New("invalid duty", z.Any("duty", duty))
+	}
+
+	peerIdx := int64(-1)
+	for i, p := range c.peers {
+		if p.ID == pID {
+			peerIdx = int64(i)
+		}
+	}
+	if peerIdx == -1 {
+		return nil, false, errors.New("proposal candidate from unknown peer")
+	}
+
+	cand, err := candidateFromProto(duty, pbMsg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !c.deadliner.Add(duty) {
+		return nil, false, errors.New("duty expired", z.Any("duty", duty), c.dropFilter)
+	}
+
+	c.addCandidate(duty, peerIdx, cand)
+	log.Debug(ctx, "Received proposal candidate", z.Any("duty", duty), z.I64("peer_idx", peerIdx))
+
+	return nil, false, nil
+}
+
+// addCandidate stores the peer's candidate for the duty, replacing any previous candidate.
+func (c *Consensus) addCandidate(duty core.Duty, peerIdx int64, cand candidate) {
+	c.mutable.Lock()
+	defer c.mutable.Unlock()
+
+	set := c.candidateSetUnsafe(duty)
+	set.byPeer[peerIdx] = cand
+	close(set.updated)
+	set.updated = make(chan struct{})
+}
+
+// getCandidates returns a copy of the candidates received for the duty and
+// a channel that is closed when another candidate is received.
+func (c *Consensus) getCandidates(duty core.Duty) (map[int64]candidate, <-chan struct{}) {
+	c.mutable.Lock()
+	defer c.mutable.Unlock()
+
+	set := c.candidateSetUnsafe(duty)
+
+	return maps.Clone(set.byPeer), set.updated
+}
+
+// candidateSetUnsafe returns the duty's candidate set, creating it if it doesn't exist.
+// It is unsafe since it assumes the lock is held.
+func (c *Consensus) candidateSetUnsafe(duty core.Duty) *candidateSet {
+	set, ok := c.mutable.candidates[duty]
+	if !ok {
+		set = &candidateSet{
+			byPeer:  make(map[int64]candidate),
+			updated: make(chan struct{}),
+		}
+		c.mutable.candidates[duty] = set
+	}
+
+	return set
+}
+
+// deleteCandidates deletes the candidates of the duty.
+func (c *Consensus) deleteCandidates(duty core.Duty) {
+	c.mutable.Lock()
+	defer c.mutable.Unlock()
+
+	delete(c.mutable.candidates, duty)
+}
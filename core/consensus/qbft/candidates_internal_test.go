@@ -0,0 +1,145 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package qbft
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestCandidateSelection(t *testing.T) {
+	duty := core.NewProposerDuty(99)
+	pubkey := testutil.RandomCorePubKey(t)
+
+	newProposal := func(executionValue int64) core.VersionedProposal {
+		t.Helper()
+
+		proposal, err := core.NewVersionedProposal(testutil.RandomDenebVersionedProposal())
+		require.NoError(t, err)
+		proposal.ExecutionValue = big.NewInt(executionValue)
+		proposal.ConsensusValue = big.NewInt(1)
+
+		return proposal
+	}
+
+	// sameBlock returns a proposal with the same slot, proposer index and parent root as the provided proposal.
+	sameBlock := func(proposal core.VersionedProposal, executionValue int64) core.VersionedProposal {
+		t.Helper()
+
+		resp := newProposal(executionValue)
+		resp.Deneb.Block.Slot = proposal.Deneb.Block.Slot
+		resp.Deneb.Block.ProposerIndex = proposal.Deneb.Block.ProposerIndex
+		resp.Deneb.Block.ParentRoot = proposal.Deneb.Block.ParentRoot
+		resp.Deneb.Block.Body.ExecutionPayload.FeeRecipient = proposal.Deneb.Block.Body.ExecutionPayload.FeeRecipient
+
+		return resp
+	}
+
+	// viaProto returns the candidate received from a peer.
+	viaProto := func(proposal core.VersionedProposal) candidate {
+		t.Helper()
+
+		data := core.UnsignedDataSet{pubkey: proposal}
+
+		msg, err := candidateToProto(duty, data)
+		require.NoError(t, err)
+
+		cand, err := candidateFromProto(duty, msg)
+		require.NoError(t, err)
+
+		return cand
+	}
+
+	localProposal := newProposal(100)
+	feeRecipientFunc := func(core.PubKey) string {
+		return fmt.Sprintf("%#x", localProposal.Deneb.Block.Body.ExecutionPayload.FeeRecipient)
+	}
+	clone, err := localProposal.Clone()
+	require.NoError(t, err)
+	clonedProposal := clone.(core.VersionedProposal)
+	require.Equal(t, localProposal.Value(), clonedProposal.Value())
+
+	local, err := newCandidate(core.UnsignedDataSet{pubkey: localProposal})
+	require.NoError(t, err)
+	require.EqualValues(t, 101, local.value.Int64())
+
+	higher := viaProto(sameBlock(localProposal, 200))
+	require.EqualValues(t, 201, higher.value.Int64())
+	require.NoError(t, verifyCandidate(local, higher, feeRecipientFunc))
+	require.True(t, higher.better(local))
+	require.False(t, local.better(higher))
+
+	// Equal values are broken by the lowest hash.
+	equal := viaProto(sameBlock(localProposal, 100))
+	require.NoError(t, verifyCandidate(local, equal, feeRecipientFunc))
+	require.NotEqual(t, local.better(equal), equal.better(local))
+
+	// Proposals for other blocks are invalid.
+	other := viaProto(newProposal(300))
+	require.ErrorContains(t, verifyCandidate(local, other, feeRecipientFunc), "mismatching candidate slot")
+
+	// Proposals with other fee recipients are invalid.
+	otherFeeRecipient := sameBlock(localProposal, 300)
+	otherFeeRecipient.Deneb.Block.Body.ExecutionPayload.FeeRecipient = testutil.RandomExecutionAddress()
+	require.ErrorContains(t, verifyCandidate(local, viaProto(otherFeeRecipient), feeRecipientFunc), "mismatching candidate fee recipient")
+
+	// Peers' blocks differ, so values are corroborated across all candidates.
+	mid := viaProto(sameBlock(localProposal, 150))
+	low := viaProto(sameBlock(localProposal, 50))
+	require.NotEqual(t, higher.hash, mid.hash)
+	require.NotEqual(t, local.hash, mid.hash)
+
+	best := bestCandidate(local, []candidate{higher, mid, low}, 1)
+	require.NotEqual(t, local.hash, best.hash)
+	require.EqualValues(t, 151, best.value.Int64()) // Second highest of 201, 151, 101 and 51.
+
+	// Without other faulty nodes, all reported values are trusted.
+	best = bestCandidate(local, []candidate{higher, mid, low}, 0)
+	require.Equal(t, higher.hash, best.hash)
+	require.EqualValues(t, 201, best.value.Int64())
+
+	// A single inflated block value isn't trusted above the local value.
+	inflated := viaProto(sameBlock(localProposal, 1000))
+	best = bestCandidate(local, []candidate{inflated, low, equal}, 1)
+	require.Equal(t, local.hash, best.hash)
+	require.EqualValues(t, 101, best.value.Int64())
+
+	// The local candidate is selected if too few candidates are received.
+	best = bestCandidate(local, []candidate{higher}, 2)
+	require.Equal(t, local.hash, best.hash)
+
+	_, ok := corroboratedValue([]*big.Int{big.NewInt(1)}, 1)
+	require.False(t, ok)
+
+	c := &Consensus{peers: make([]p2p.Peer, 4)}
+	c.mutable.candidates = make(map[core.Duty]*candidateSet)
+	c.addCandidate(duty, 1, higher)
+
+	// Wait for the timeout if candidates are missing and a peer candidate may still be selected.
+	resp := c.awaitCandidates(context.Background(), duty, local, 1, time.After(time.Millisecond))
+	require.Len(t, resp, 1)
+
+	// Return immediately once no peer candidate can be selected anymore.
+	c.deleteCandidates(duty)
+	c.addCandidate(duty, 1, low)
+	c.addCandidate(duty, 2, equal)
+	resp = c.awaitCandidates(context.Background(), duty, local, 1, nil)
+	require.Len(t, resp, 2)
+
+	// Return immediately once all candidates are received.
+	c.addCandidate(duty, 3, higher)
+	resp = c.awaitCandidates(context.Background(), duty, local, 1, nil)
+	require.Len(t, resp, 3)
+
+	c.deleteCandidates(duty)
+	require.Empty(t, c.mutable.candidates)
+}
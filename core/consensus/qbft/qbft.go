@@ -120,8 +120,11 @@ func newDefinition(nodes int, subs func() []subscriber, roundTimer utils.RoundTi
 }
 
 // NewConsensus returns a new consensus QBFT component.
+// The feeRecipientFunc returns the configured fee recipient of a validator, proposal candidates from peers
+// with other fee recipients are ignored.
 func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKey *k1.PrivateKey,
 	deadliner core.Deadliner, gaterFunc core.DutyGaterFunc, snifferFunc func(*pbv1.SniffedConsensusInstance),
+	feeRecipientFunc func(core.PubKey) string,
 ) (*Consensus, error) {
 	// Extract peer pubkeys.
 	keys := make(map[int64]*k1.PublicKey)
@@ -138,20 +141,22 @@ func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKe
 	}
 
 	c := &Consensus{
-		tcpNode:     tcpNode,
-		sender:      sender,
-		peers:       peers,
-		peerLabels:  labels,
-		privkey:     p2pKey,
-		pubkeys:     keys,
-		deadliner:   deadliner,
-		snifferFunc: snifferFunc,
-		gaterFunc:   gaterFunc,
-		dropFilter:  log.Filter(),
-		timerFunc:   utils.GetTimerFunc(),
-		metrics:     metrics.NewConsensusMetrics(protocols.QBFTv2ProtocolID),
+		tcpNode:          tcpNode,
+		sender:           sender,
+		peers:            peers,
+		peerLabels:       labels,
+		privkey:          p2pKey,
+		pubkeys:          keys,
+		deadliner:        deadliner,
+		snifferFunc:      snifferFunc,
+		gaterFunc:        gaterFunc,
+		feeRecipientFunc: feeRecipientFunc,
+		dropFilter:       log.Filter(),
+		timerFunc:        utils.GetTimerFunc(),
+		metrics:          metrics.NewConsensusMetrics(protocols.QBFTv2ProtocolID),
 	}
 	c.mutable.instances = make(map[core.Duty]*utils.InstanceIO[Msg])
+	c.mutable.candidates = make(map[core.Duty]*candidateSet)
 
 	return c, nil
 }
@@ -159,24 +164,26 @@ func NewConsensus(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKe
 // Consensus implements core.Consensus & priority.coreConsensus.
 type Consensus struct {
 	// Immutable state
-	tcpNode     host.Host
-	sender      *p2p.Sender
-	peerLabels  []string
-	peers       []p2p.Peer
-	pubkeys     map[int64]*k1.PublicKey
-	privkey     *k1.PrivateKey
-	subs        []subscriber
-	deadliner   core.Deadliner
-	snifferFunc func(*pbv1.SniffedConsensusInstance)
-	gaterFunc   core.DutyGaterFunc
-	dropFilter  z.Field // Filter buffer overflow errors (possible DDoS)
-	timerFunc   utils.TimerFunc
-	metrics     metrics.ConsensusMetrics
+	tcpNode          host.Host
+	sender           *p2p.Sender
+	peerLabels       []string
+	peers            []p2p.Peer
+	pubkeys          map[int64]*k1.PublicKey
+	privkey          *k1.PrivateKey
+	subs             []subscriber
+	deadliner        core.Deadliner
+	snifferFunc      func(*pbv1.SniffedConsensusInstance)
+	gaterFunc        core.DutyGaterFunc
+	feeRecipientFunc func(core.PubKey) string
+	dropFilter       z.Field // Filter buffer overflow errors (possible DDoS)
+	timerFunc        utils.TimerFunc
+	metrics          metrics.ConsensusMetrics
 
 	// Mutable state
 	mutable struct {
 		sync.Mutex
-		instances map[core.Duty]*utils.InstanceIO[Msg]
+		instances  map[core.Duty]*utils.InstanceIO[Msg]
+		candidates map[core.Duty]*candidateSet
 	}
 }
 
@@ -227,6 +234,12 @@ func (c *Consensus) Start(ctx context.Context) {
 		func() proto.Message { return new(pbv1.QBFTConsensusMsg) },
 		c.handle)
 
+	if featureset.Enabled(featureset.ProposalCandidates) {
+		p2p.RegisterHandler("qbft", c.tcpNode, protocols.QBFTCandidateProtocolID,
+			func() proto.Message { return new(pbv1.QBFTCandidateMsg) },
+			c.handleCandidate)
+	}
+
 	go func() {
 		for {
 			select {
@@ -235,6 +248,7 @@ func (c *Consensus) Start(ctx context.Context) {
 				return
 			case duty := <-c.deadliner.C():
 				c.deleteInstanceIO(duty)
+				c.deleteCandidates(duty)
 			}
 		}
 	}()
@@ -245,6 +259,11 @@ func (c *Consensus) Start(ctx context.Context) {
 // waits until it completes, in both cases it returns the resulting error.
 // Note this errors if called multiple times for the same duty.
 func (c *Consensus) Propose(ctx context.Context, duty core.Duty, data core.UnsignedDataSet) error {
+	if duty.Type == core.DutyProposer && featureset.Enabled(featureset.ProposalCandidates) {
+		// Propose the most valuable block proposal of the cluster.
+		data = c.selectCandidate(ctx, duty, data)
+	}
+
 	// Hash the proposed data, since qbft only supports simple comparable values.
 	value, err := core.UnsignedDataSetToProto(data)
 	if err != nil {
@@ -120,11 +120,12 @@ func testQBFTConsensus(t *testing.T, threshold, nodes int) {
 		}
 
 		gaterFunc := func(core.Duty) bool { return true }
+		feeRecipientFunc := func(core.PubKey) string { return "" }
 
 		deadliner := coremocks.NewDeadliner(t)
 		deadliner.On("Add", mock.Anything).Return(true)
 		deadliner.On("C").Return(nil)
-		c, err := qbft.NewConsensus(hosts[i], new(p2p.Sender), peers, p2pkeys[i], deadliner, gaterFunc, sniffer)
+		c, err := qbft.NewConsensus(hosts[i], new(p2p.Sender), peers, p2pkeys[i], deadliner, gaterFunc, sniffer, feeRecipientFunc)
 		require.NoError(t, err)
 		c.Subscribe(func(_ context.Context, _ core.Duty, set core.UnsignedDataSet) error {
 			results <- set
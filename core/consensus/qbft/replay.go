@@ -0,0 +1,306 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package qbft
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/protocols"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+// ReplayedInstance is a consensus instance merged from the sniffed instances of one or more peers
+// and replayed through the qbft algorithm.
+type ReplayedInstance struct {
+	Duty  core.Duty
+	Nodes int
+	// Peers are the indexes of the peers the instance was sniffed by.
+	Peers []int64
+	// StartedAt is the earliest start of the sniffed instances.
+	StartedAt time.Time
+	Rounds    []ReplayedRound
+	// RoundChanges are the round changes triggered while replaying.
+	RoundChanges []ReplayedRoundChange
+	// Decided is true if replaying the messages decided a value in DecidedRound.
+	Decided      bool
+	DecidedRound int64
+	DecidedValue [32]byte
+}
+
+// ReplayedRound is a round of a replayed consensus instance.
+type ReplayedRound struct {
+	Round  int64
+	Leader int64
+	// Msgs are the round's messages ordered by the time they were first received by any of the sniffing peers.
+	Msgs []ReplayedMsg
+	// Steps summarises the peers present (*), missing (?) and not applicable (_) for each message type.
+	Steps string
+	// TimeoutReason explains why the round didn't decide, it is empty if it did.
+	TimeoutReason string
+}
+
+// ReplayedMsg is a message of a replayed consensus instance.
+type ReplayedMsg struct {
+	Type   qbft.MsgType
+	Source int64
+	Round  int64
+	Value  [32]byte
+	// Offset is the duration since StartedAt the message was first received by any of the sniffing peers.
+	Offset time.Duration
+}
+
+// ReplayedRoundChange is a round change triggered by an upon rule while replaying.
+type ReplayedRoundChange struct {
+	Round    int64
+	NewRound int64
+	Rule     qbft.UponRule
+}
+
+// replayKey identifies messages received by multiple peers.
+type replayKey struct {
+	Type          qbft.MsgType
+	Source        int64
+	Round         int64
+	Value         [32]byte
+	PreparedRound int64
+	PreparedValue [32]byte
+}
+
+// timedMsg is a message and the time it was first received.
+type timedMsg struct {
+	Msg       Msg
+	Timestamp time.Time
+}
+
+// ReplayInstances merges the sniffed QBFT instances of the same duty, typically dumped by different peers,
+// and replays each through the qbft algorithm. Round timers never expire while replaying, so instances
+// only progress by the sniffed messages and undecided instances are replayed until the timeout.
+// It returns the replayed instances ordered by duty.
+func ReplayInstances(ctx context.Context, instances []*pbv1.SniffedConsensusInstance, timeout time.Duration) ([]ReplayedInstance, error) {
+	byDuty := make(map[core.Duty][]*pbv1.SniffedConsensusInstance)
+	for _, instance := range instances {
+		if instance.GetProtocolId() != "" && instance.GetProtocolId() != protocols.QBFTv2ProtocolID {
+			continue // Only QBFT instances are supported.
+		}
+
+		if len(instance.GetMsgs()) == 0 {
+			continue
+		}
+
+		duty := core.DutyFromProto(instance.GetMsgs()[0].GetMsg().GetMsg().GetDuty())
+		byDuty[duty] = append(byDuty[duty], instance)
+	}
+
+	duties := make([]core.Duty, 0, len(byDuty))
+	for duty := range byDuty {
+		duties = append(duties, duty)
+	}
+	slices.SortFunc(duties, func(a, b core.Duty) int {
+		if a.Slot != b.Slot {
+			return cmp.Compare(a.Slot, b.Slot)
+		}
+
+		return cmp.Compare(a.Type, b.Type)
+	})
+
+	var resp []ReplayedInstance
+	for _, duty := range duties {
+		replayed, err := replayDuty(ctx, duty, byDuty[duty], timeout)
+		if err != nil {
+			return nil, errors.Wrap(err, "replay instance", z.Any("duty", duty))
+		}
+
+		resp = append(resp, replayed)
+	}
+
+	return resp, nil
+}
+
+// replayDuty merges the sniffed instances of the duty and replays them.
+func replayDuty(ctx context.Context, duty core.Duty, instances []*pbv1.SniffedConsensusInstance, timeout time.Duration) (ReplayedInstance, error) {
+	resp := ReplayedInstance{
+		Duty:  duty,
+		Nodes: int(instances[0].GetNodes()),
+	}
+
+	msgs := make(map[replayKey]timedMsg)
+	for _, instance := range instances {
+		if int(instance.GetNodes()) != resp.Nodes {
+			return ReplayedInstance{}, errors.New("mismatching instance nodes")
+		}
+
+		if !slices.Contains(resp.Peers, instance.GetPeerIdx()) {
+			resp.Peers = append(resp.Peers, instance.GetPeerIdx())
+		}
+
+		if startedAt := instance.GetStartedAt().AsTime(); resp.StartedAt.IsZero() || startedAt.Before(resp.StartedAt) {
+			resp.StartedAt = startedAt
+		}
+
+		for _, sniffed := range instance.GetMsgs() {
+			values, err := valuesByHash(sniffed.GetMsg().GetValues())
+			if err != nil {
+				return ReplayedInstance{}, err
+			}
+
+			msg, err := newMsg(sniffed.GetMsg().GetMsg(), sniffed.GetMsg().GetJustification(), values)
+			if err != nil {
+				return ReplayedInstance{}, err
+			}
+
+			if msg.Instance() != duty {
+				return ReplayedInstance{}, errors.New("mismatching message duty")
+			}
+
+			key := replayKey{
+				Type:          msg.Type(),
+				Source:        msg.Source(),
+				Round:         msg.Round(),
+				Value:         msg.Value(),
+				PreparedRound: msg.PreparedRound(),
+				PreparedValue: msg.PreparedValue(),
+			}
+
+			timestamp := sniffed.GetTimestamp().AsTime()
+			if existing, ok := msgs[key]; ok && !timestamp.Before(existing.Timestamp) {
+				continue
+			}
+
+			msgs[key] = timedMsg{Msg: msg, Timestamp: timestamp}
+		}
+	}
+
+	slices.Sort(resp.Peers)
+
+	ordered := make([]timedMsg, 0, len(msgs))
+	for _, msg := range msgs {
+		ordered = append(ordered, msg)
+	}
+	slices.SortStableFunc(ordered, func(a, b timedMsg) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	if err := replay(ctx, &resp, ordered, timeout); err != nil {
+		return ReplayedInstance{}, err
+	}
+
+	resp.Rounds = replayedRounds(resp, ordered)
+
+	return resp, nil
+}
+
+// replay runs the qbft algorithm as the first sniffing peer with the ordered messages,
+// populating the round changes and decided fields of the instance.
+func replay(ctx context.Context, instance *ReplayedInstance, ordered []timedMsg, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	def := qbft.Definition[core.Duty, [32]byte]{
+		IsLeader: func(duty core.Duty, round, process int64) bool {
+			return leader(duty, round, instance.Nodes) == process
+		},
+		NewTimer: func(int64) (<-chan time.Time, func()) {
+			return nil, func() {} // Never expire, progress by sniffed messages only.
+		},
+		Decide: func(_ context.Context, _ core.Duty, value [32]byte, qcommit []qbft.Msg[core.Duty, [32]byte]) {
+			instance.Decided = true
+			instance.DecidedRound = qcommit[0].Round()
+			instance.DecidedValue = value
+			cancel()
+		},
+		LogUponRule: func(context.Context, core.Duty, int64, int64, qbft.Msg[core.Duty, [32]byte], qbft.UponRule) {},
+		LogRoundChange: func(_ context.Context, _ core.Duty, _, round, newRound int64, rule qbft.UponRule, _ []qbft.Msg[core.Duty, [32]byte]) {
+			instance.RoundChanges = append(instance.RoundChanges, ReplayedRoundChange{
+				Round:    round,
+				NewRound: newRound,
+				Rule:     rule,
+			})
+		},
+		LogUnjust: func(context.Context, core.Duty, int64, qbft.Msg[core.Duty, [32]byte]) {},
+		Nodes:     instance.Nodes,
+		FIFOLimit: len(ordered) + 1,
+	}
+
+	recvBuffer := make(chan qbft.Msg[core.Duty, [32]byte], len(ordered))
+	for _, msg := range ordered {
+		recvBuffer <- msg.Msg
+	}
+
+	qt := qbft.Transport[core.Duty, [32]byte]{
+		Broadcast: func(context.Context, qbft.MsgType, core.Duty, int64, int64, [32]byte, int64, [32]byte,
+			[]qbft.Msg[core.Duty, [32]byte],
+		) error {
+			return nil // Messages broadcast by the replaying peer are already sniffed.
+		},
+		Receive: recvBuffer,
+	}
+
+	// The replaying peer never proposes, its sniffed pre-prepares are replayed instead.
+	err := qbft.Run[core.Duty, [32]byte](ctx, def, qt, instance.Duty, instance.Peers[0], nil)
+	if err != nil && !isContextErr(err) {
+		return err
+	}
+
+	return nil
+}
+
+// replayedRounds returns the rounds of the replayed instance up to the highest round of any message.
+func replayedRounds(instance ReplayedInstance, ordered []timedMsg) []ReplayedRound {
+	var (
+		maxRound int64 = 1
+		byRound        = make(map[int64][]qbft.Msg[core.Duty, [32]byte])
+		quorum         = qbft.Definition[int, int]{Nodes: instance.Nodes}.Quorum()
+	)
+
+	for _, msg := range ordered {
+		maxRound = max(maxRound, msg.Msg.Round())
+		byRound[msg.Msg.Round()] = append(byRound[msg.Msg.Round()], msg.Msg)
+	}
+
+	var resp []ReplayedRound
+	for round := int64(1); round <= maxRound; round++ {
+		roundLeader := leader(instance.Duty, round, instance.Nodes)
+		steps := groupRoundMessages(byRound[round], instance.Nodes, round, int(roundLeader))
+
+		var summary []string
+		for _, step := range steps {
+			summary = append(summary, step.Type.String()+"="+fmtStepPeers(step))
+		}
+
+		replayed := ReplayedRound{
+			Round:  round,
+			Leader: roundLeader,
+			Steps:  strings.Join(summary, " "),
+		}
+
+		if !instance.Decided || instance.DecidedRound != round {
+			replayed.TimeoutReason = timeoutReason(steps, round, quorum)
+		}
+
+		for _, msg := range ordered {
+			if msg.Msg.Round() != round {
+				continue
+			}
+
+			replayed.Msgs = append(replayed.Msgs, ReplayedMsg{
+				Type:   msg.Msg.Type(),
+				Source: msg.Msg.Source(),
+				Round:  round,
+				Value:  msg.Msg.Value(),
+				Offset: msg.Timestamp.Sub(instance.StartedAt),
+			})
+		}
+
+		resp = append(resp, replayed)
+	}
+
+	return resp
+}
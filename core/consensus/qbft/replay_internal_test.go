@@ -0,0 +1,124 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package qbft
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+func TestReplayInstances(t *testing.T) {
+	const nodes = 4
+
+	privkey, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	duty := core.NewAttesterDuty(1) // Leaders: round 1=0, round 2=1.
+	require.EqualValues(t, 0, leader(duty, 1, nodes))
+	require.EqualValues(t, 1, leader(duty, 2, nodes))
+
+	value := timestamppb.New(time.Unix(1, 0))
+	valueHash, err := hashProto(value)
+	require.NoError(t, err)
+	anyValue, err := anypb.New(value)
+	require.NoError(t, err)
+	values := map[[32]byte]*anypb.Any{valueHash: anyValue}
+
+	start := time.Unix(1700000000, 0)
+
+	var offset time.Duration
+	newSniffed := func(typ qbft.MsgType, source, round int64, justification []qbft.Msg[core.Duty, [32]byte]) (Msg, *pbv1.SniffedConsensusMsg) {
+		t.Helper()
+
+		var vHash [32]byte
+		if typ != qbft.MsgRoundChange {
+			vHash = valueHash
+		}
+
+		msg, err := createMsg(typ, duty, source, round, vHash, 0, [32]byte{}, values, justification, privkey)
+		require.NoError(t, err)
+
+		offset += time.Millisecond
+
+		return msg, &pbv1.SniffedConsensusMsg{
+			Timestamp: timestamppb.New(start.Add(offset)),
+			Msg:       msg.ToConsensusMsg(),
+		}
+	}
+
+	// Round 1 times out since the leader (peer 0) is offline, all other peers change round.
+	var (
+		roundChanges []qbft.Msg[core.Duty, [32]byte]
+		sniffed      []*pbv1.SniffedConsensusMsg
+	)
+	for source := int64(1); source < nodes; source++ {
+		msg, s := newSniffed(qbft.MsgRoundChange, source, 2, nil)
+		roundChanges = append(roundChanges, msg)
+		sniffed = append(sniffed, s)
+	}
+
+	// Round 2 decides without peer 0.
+	_, s := newSniffed(qbft.MsgPrePrepare, 1, 2, roundChanges)
+	sniffed = append(sniffed, s)
+	for _, typ := range []qbft.MsgType{qbft.MsgPrepare, qbft.MsgCommit} {
+		for source := int64(1); source < nodes; source++ {
+			_, s := newSniffed(typ, source, 2, nil)
+			sniffed = append(sniffed, s)
+		}
+	}
+
+	// Peer 1 and 2 both sniffed all messages, with peer 2 receiving later.
+	var later []*pbv1.SniffedConsensusMsg
+	for _, s := range sniffed {
+		later = append(later, &pbv1.SniffedConsensusMsg{
+			Timestamp: timestamppb.New(s.GetTimestamp().AsTime().Add(time.Second)),
+			Msg:       s.GetMsg(),
+		})
+	}
+
+	instances := []*pbv1.SniffedConsensusInstance{
+		{Nodes: nodes, PeerIdx: 2, StartedAt: timestamppb.New(start), Msgs: later},
+		{Nodes: nodes, PeerIdx: 1, StartedAt: timestamppb.New(start), Msgs: sniffed[:5]},
+		{Nodes: nodes, PeerIdx: 1, StartedAt: timestamppb.New(start), Msgs: sniffed[5:]},
+		{Nodes: nodes, PeerIdx: 3, ProtocolId: "/charon/consensus/other/1.0.0", Msgs: sniffed},
+	}
+
+	replayed, err := ReplayInstances(context.Background(), instances, time.Second)
+	require.NoError(t, err)
+	require.Len(t, replayed, 1)
+
+	instance := replayed[0]
+	require.Equal(t, duty, instance.Duty)
+	require.Equal(t, []int64{1, 2}, instance.Peers)
+	require.True(t, instance.Decided)
+	require.EqualValues(t, 2, instance.DecidedRound)
+	require.Equal(t, valueHash, instance.DecidedValue)
+	require.Equal(t, []ReplayedRoundChange{{Round: 1, NewRound: 2, Rule: qbft.UponFPlus1RoundChanges}}, instance.RoundChanges)
+
+	require.Len(t, instance.Rounds, 2)
+
+	round1 := instance.Rounds[0]
+	require.EqualValues(t, 0, round1.Leader)
+	require.Empty(t, round1.Msgs)
+	require.Equal(t, "no pre-prepare, missing leader=[0]", round1.TimeoutReason)
+
+	round2 := instance.Rounds[1]
+	require.EqualValues(t, 1, round2.Leader)
+	require.Empty(t, round2.TimeoutReason)
+	require.Equal(t, "pre_prepare=_*__ prepare=?*** commit=?*** round_change=?***", round2.Steps)
+	require.Len(t, round2.Msgs, len(sniffed))
+	for i, msg := range round2.Msgs {
+		// Merged messages are deduplicated with the earliest receive time.
+		require.Equal(t, time.Duration(i+1)*time.Millisecond, msg.Offset)
+	}
+}
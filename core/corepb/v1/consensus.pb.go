@@ -184,18 +184,362 @@ func (x *QBFTConsensusMsg) GetValues() []*anypb.Any {
 	return nil
 }
 
+// QBFTCandidateMsg is a candidate proposal shared with peers before proposing
+// so that the cluster converges on the most valuable candidate.
+type QBFTCandidateMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Duty        *Duty             `protobuf:"bytes,1,opt,name=duty,proto3" json:"duty,omitempty"`
+	Proposals   *UnsignedDataSet  `protobuf:"bytes,2,opt,name=proposals,proto3" json:"proposals,omitempty"`                                                                                                                // candidate proposals by validator pubkey
+	BlockValues map[string]string `protobuf:"bytes,3,rep,name=block_values,json=blockValues,proto3" json:"block_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // candidate block values in wei (decimal) by validator pubkey
+}
+
+func (x *QBFTCandidateMsg) Reset() {
+	*x = QBFTCandidateMsg{}
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QBFTCandidateMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QBFTCandidateMsg) ProtoMessage() {}
+
+func (x *QBFTCandidateMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QBFTCandidateMsg.ProtoReflect.Descriptor instead.
+func (*QBFTCandidateMsg) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QBFTCandidateMsg) GetDuty() *Duty {
+	if x != nil {
+		return x.Duty
+	}
+	return nil
+}
+
+func (x *QBFTCandidateMsg) GetProposals() *UnsignedDataSet {
+	if x != nil {
+		return x.Proposals
+	}
+	return nil
+}
+
+func (x *QBFTCandidateMsg) GetBlockValues() map[string]string {
+	if x != nil {
+		return x.BlockValues
+	}
+	return nil
+}
+
+// HotStuffMsg is a signed message of the HotStuff consensus protocol.
+type HotStuffMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type      int64       `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"` // new-view, proposal or vote
+	Duty      *Duty       `protobuf:"bytes,2,opt,name=duty,proto3" json:"duty,omitempty"`
+	PeerIdx   int64       `protobuf:"varint,3,opt,name=peer_idx,json=peerIdx,proto3" json:"peer_idx,omitempty"`
+	View      int64       `protobuf:"varint,4,opt,name=view,proto3" json:"view,omitempty"`
+	Phase     int64       `protobuf:"varint,5,opt,name=phase,proto3" json:"phase,omitempty"` // phase of proposals and votes
+	ValueHash []byte      `protobuf:"bytes,6,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	Justify   *HotStuffQC `protobuf:"bytes,7,opt,name=justify,proto3,oneof" json:"justify,omitempty"` // quorum certificate justifying the message
+	Signature []byte      `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *HotStuffMsg) Reset() {
+	*x = HotStuffMsg{}
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HotStuffMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotStuffMsg) ProtoMessage() {}
+
+func (x *HotStuffMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotStuffMsg.ProtoReflect.Descriptor instead.
+func (*HotStuffMsg) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HotStuffMsg) GetType() int64 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *HotStuffMsg) GetDuty() *Duty {
+	if x != nil {
+		return x.Duty
+	}
+	return nil
+}
+
+func (x *HotStuffMsg) GetPeerIdx() int64 {
+	if x != nil {
+		return x.PeerIdx
+	}
+	return 0
+}
+
+func (x *HotStuffMsg) GetView() int64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *HotStuffMsg) GetPhase() int64 {
+	if x != nil {
+		return x.Phase
+	}
+	return 0
+}
+
+func (x *HotStuffMsg) GetValueHash() []byte {
+	if x != nil {
+		return x.ValueHash
+	}
+	return nil
+}
+
+func (x *HotStuffMsg) GetJustify() *HotStuffQC {
+	if x != nil {
+		return x.Justify
+	}
+	return nil
+}
+
+func (x *HotStuffMsg) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// HotStuffQC is a quorum certificate of signed votes for the same phase, view and value.
+type HotStuffQC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phase     int64           `protobuf:"varint,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	View      int64           `protobuf:"varint,2,opt,name=view,proto3" json:"view,omitempty"`
+	ValueHash []byte          `protobuf:"bytes,3,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	Votes     []*HotStuffVote `protobuf:"bytes,4,rep,name=votes,proto3" json:"votes,omitempty"`
+}
+
+func (x *HotStuffQC) Reset() {
+	*x = HotStuffQC{}
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HotStuffQC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotStuffQC) ProtoMessage() {}
+
+func (x *HotStuffQC) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotStuffQC.ProtoReflect.Descriptor instead.
+func (*HotStuffQC) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HotStuffQC) GetPhase() int64 {
+	if x != nil {
+		return x.Phase
+	}
+	return 0
+}
+
+func (x *HotStuffQC) GetView() int64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *HotStuffQC) GetValueHash() []byte {
+	if x != nil {
+		return x.ValueHash
+	}
+	return nil
+}
+
+func (x *HotStuffQC) GetVotes() []*HotStuffVote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+// HotStuffVote is the signature of a vote in a quorum certificate.
+// The signed vote message is reconstructed from the quorum certificate and the message duty.
+type HotStuffVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerIdx   int64  `protobuf:"varint,1,opt,name=peer_idx,json=peerIdx,proto3" json:"peer_idx,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *HotStuffVote) Reset() {
+	*x = HotStuffVote{}
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HotStuffVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotStuffVote) ProtoMessage() {}
+
+func (x *HotStuffVote) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotStuffVote.ProtoReflect.Descriptor instead.
+func (*HotStuffVote) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HotStuffVote) GetPeerIdx() int64 {
+	if x != nil {
+		return x.PeerIdx
+	}
+	return 0
+}
+
+func (x *HotStuffVote) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type HotStuffConsensusMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg    *HotStuffMsg `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	Values []*anypb.Any `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"` // values of the hashes in the message
+}
+
+func (x *HotStuffConsensusMsg) Reset() {
+	*x = HotStuffConsensusMsg{}
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HotStuffConsensusMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotStuffConsensusMsg) ProtoMessage() {}
+
+func (x *HotStuffConsensusMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotStuffConsensusMsg.ProtoReflect.Descriptor instead.
+func (*HotStuffConsensusMsg) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HotStuffConsensusMsg) GetMsg() *HotStuffMsg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *HotStuffConsensusMsg) GetValues() []*anypb.Any {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 type SniffedConsensusMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Msg       *QBFTConsensusMsg      `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"` // Other consensus protocol messages can be added here
+	Timestamp   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Msg         *QBFTConsensusMsg      `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	HotstuffMsg *HotStuffConsensusMsg  `protobuf:"bytes,3,opt,name=hotstuff_msg,json=hotstuffMsg,proto3" json:"hotstuff_msg,omitempty"` // Other consensus protocol messages can be added here
 }
 
 func (x *SniffedConsensusMsg) Reset() {
 	*x = SniffedConsensusMsg{}
-	mi := &file_core_corepb_v1_consensus_proto_msgTypes[2]
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -207,7 +551,7 @@ func (x *SniffedConsensusMsg) String() string {
 func (*SniffedConsensusMsg) ProtoMessage() {}
 
 func (x *SniffedConsensusMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_core_corepb_v1_consensus_proto_msgTypes[2]
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -220,7 +564,7 @@ func (x *SniffedConsensusMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SniffedConsensusMsg.ProtoReflect.Descriptor instead.
 func (*SniffedConsensusMsg) Descriptor() ([]byte, []int) {
-	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{2}
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SniffedConsensusMsg) GetTimestamp() *timestamppb.Timestamp {
@@ -237,6 +581,13 @@ func (x *SniffedConsensusMsg) GetMsg() *QBFTConsensusMsg {
 	return nil
 }
 
+func (x *SniffedConsensusMsg) GetHotstuffMsg() *HotStuffConsensusMsg {
+	if x != nil {
+		return x.HotstuffMsg
+	}
+	return nil
+}
+
 type SniffedConsensusInstance struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -251,7 +602,7 @@ type SniffedConsensusInstance struct {
 
 func (x *SniffedConsensusInstance) Reset() {
 	*x = SniffedConsensusInstance{}
-	mi := &file_core_corepb_v1_consensus_proto_msgTypes[3]
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -263,7 +614,7 @@ func (x *SniffedConsensusInstance) String() string {
 func (*SniffedConsensusInstance) ProtoMessage() {}
 
 func (x *SniffedConsensusInstance) ProtoReflect() protoreflect.Message {
-	mi := &file_core_corepb_v1_consensus_proto_msgTypes[3]
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,7 +627,7 @@ func (x *SniffedConsensusInstance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SniffedConsensusInstance.ProtoReflect.Descriptor instead.
 func (*SniffedConsensusInstance) Descriptor() ([]byte, []int) {
-	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{3}
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SniffedConsensusInstance) GetStartedAt() *timestamppb.Timestamp {
@@ -325,7 +676,7 @@ type SniffedConsensusInstances struct {
 
 func (x *SniffedConsensusInstances) Reset() {
 	*x = SniffedConsensusInstances{}
-	mi := &file_core_corepb_v1_consensus_proto_msgTypes[4]
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +688,7 @@ func (x *SniffedConsensusInstances) String() string {
 func (*SniffedConsensusInstances) ProtoMessage() {}
 
 func (x *SniffedConsensusInstances) ProtoReflect() protoreflect.Message {
-	mi := &file_core_corepb_v1_consensus_proto_msgTypes[4]
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +701,7 @@ func (x *SniffedConsensusInstances) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SniffedConsensusInstances.ProtoReflect.Descriptor instead.
 func (*SniffedConsensusInstances) Descriptor() ([]byte, []int) {
-	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{4}
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SniffedConsensusInstances) GetInstances() []*SniffedConsensusInstance {
@@ -407,41 +758,101 @@ var file_core_corepb_v1_consensus_proto_rawDesc = []byte{
 	0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a,
 	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x41, 0x6e, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x13,
-	0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73,
-	0x4d, 0x73, 0x67, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x32, 0x0a,
-	0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x42, 0x46, 0x54,
-	0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73,
-	0x67, 0x22, 0xe0, 0x01, 0x0a, 0x18, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e,
-	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x39,
-	0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12,
-	0x19, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x78, 0x12, 0x37, 0x0a, 0x04, 0x6d, 0x73,
-	0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65,
-	0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6d,
-	0x73, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f,
-	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x49, 0x64, 0x22, 0x7e, 0x0a, 0x19, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43,
-	0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x73, 0x12, 0x46, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e,
-	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x69, 0x74,
-	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x69, 0x74,
-	0x48, 0x61, 0x73, 0x68, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63,
-	0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70,
-	0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x41, 0x6e, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x91, 0x02, 0x0a, 0x10,
+	0x51, 0x42, 0x46, 0x54, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x73, 0x67,
+	0x12, 0x28, 0x0a, 0x04, 0x64, 0x75, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x75, 0x74, 0x79, 0x52, 0x04, 0x64, 0x75, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x70, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x55,
+	0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x12, 0x54, 0x0a, 0x0c, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x31, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x51, 0x42, 0x46, 0x54, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x73,
+	0x67, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a,
+	0x3e, 0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x94, 0x02, 0x0a, 0x0b, 0x48, 0x6f, 0x74, 0x53, 0x74, 0x75, 0x66, 0x66, 0x4d, 0x73, 0x67, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x75, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x75, 0x74, 0x79, 0x52, 0x04, 0x64, 0x75, 0x74, 0x79, 0x12, 0x19, 0x0a,
+	0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x69, 0x65, 0x77,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x68, 0x61,
+	0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x39, 0x0a, 0x07, 0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x74, 0x53, 0x74, 0x75, 0x66, 0x66, 0x51, 0x43, 0x48, 0x00,
+	0x52, 0x07, 0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x79, 0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6a,
+	0x75, 0x73, 0x74, 0x69, 0x66, 0x79, 0x22, 0x89, 0x01, 0x0a, 0x0a, 0x48, 0x6f, 0x74, 0x53, 0x74,
+	0x75, 0x66, 0x66, 0x51, 0x43, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x76,
+	0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x12,
+	0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x32,
+	0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x6f, 0x74, 0x53, 0x74, 0x75, 0x66, 0x66, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x76, 0x6f, 0x74,
+	0x65, 0x73, 0x22, 0x47, 0x0a, 0x0c, 0x48, 0x6f, 0x74, 0x53, 0x74, 0x75, 0x66, 0x66, 0x56, 0x6f,
+	0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x78, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x73, 0x0a, 0x14, 0x48,
+	0x6f, 0x74, 0x53, 0x74, 0x75, 0x66, 0x66, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73,
+	0x4d, 0x73, 0x67, 0x12, 0x2d, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x6f, 0x74, 0x53, 0x74, 0x75, 0x66, 0x66, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d,
+	0x73, 0x67, 0x12, 0x2c, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x22, 0xcc, 0x01, 0x0a, 0x13, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73,
+	0x65, 0x6e, 0x73, 0x75, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x32, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x51, 0x42, 0x46, 0x54, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x4d, 0x73,
+	0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x47, 0x0a, 0x0c, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f,
+	0x74, 0x53, 0x74, 0x75, 0x66, 0x66, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x4d,
+	0x73, 0x67, 0x52, 0x0b, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x4d, 0x73, 0x67, 0x22,
+	0xe0, 0x01, 0x0a, 0x18, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x78, 0x12, 0x37, 0x0a, 0x04, 0x6d, 0x73, 0x67, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43,
+	0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6d, 0x73, 0x67,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x49, 0x64, 0x22, 0x7e, 0x0a, 0x19, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12,
+	0x46, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x69, 0x74, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x69, 0x74, 0x48, 0x61,
+	0x73, 0x68, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61,
+	0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -456,32 +867,48 @@ func file_core_corepb_v1_consensus_proto_rawDescGZIP() []byte {
 	return file_core_corepb_v1_consensus_proto_rawDescData
 }
 
-var file_core_corepb_v1_consensus_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_core_corepb_v1_consensus_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_core_corepb_v1_consensus_proto_goTypes = []any{
 	(*QBFTMsg)(nil),                   // 0: core.corepb.v1.QBFTMsg
 	(*QBFTConsensusMsg)(nil),          // 1: core.corepb.v1.QBFTConsensusMsg
-	(*SniffedConsensusMsg)(nil),       // 2: core.corepb.v1.SniffedConsensusMsg
-	(*SniffedConsensusInstance)(nil),  // 3: core.corepb.v1.SniffedConsensusInstance
-	(*SniffedConsensusInstances)(nil), // 4: core.corepb.v1.SniffedConsensusInstances
-	(*Duty)(nil),                      // 5: core.corepb.v1.Duty
-	(*anypb.Any)(nil),                 // 6: google.protobuf.Any
-	(*timestamppb.Timestamp)(nil),     // 7: google.protobuf.Timestamp
+	(*QBFTCandidateMsg)(nil),          // 2: core.corepb.v1.QBFTCandidateMsg
+	(*HotStuffMsg)(nil),               // 3: core.corepb.v1.HotStuffMsg
+	(*HotStuffQC)(nil),                // 4: core.corepb.v1.HotStuffQC
+	(*HotStuffVote)(nil),              // 5: core.corepb.v1.HotStuffVote
+	(*HotStuffConsensusMsg)(nil),      // 6: core.corepb.v1.HotStuffConsensusMsg
+	(*SniffedConsensusMsg)(nil),       // 7: core.corepb.v1.SniffedConsensusMsg
+	(*SniffedConsensusInstance)(nil),  // 8: core.corepb.v1.SniffedConsensusInstance
+	(*SniffedConsensusInstances)(nil), // 9: core.corepb.v1.SniffedConsensusInstances
+	nil,                               // 10: core.corepb.v1.QBFTCandidateMsg.BlockValuesEntry
+	(*Duty)(nil),                      // 11: core.corepb.v1.Duty
+	(*anypb.Any)(nil),                 // 12: google.protobuf.Any
+	(*UnsignedDataSet)(nil),           // 13: core.corepb.v1.UnsignedDataSet
+	(*timestamppb.Timestamp)(nil),     // 14: google.protobuf.Timestamp
 }
 var file_core_corepb_v1_consensus_proto_depIdxs = []int32{
-	5, // 0: core.corepb.v1.QBFTMsg.duty:type_name -> core.corepb.v1.Duty
-	0, // 1: core.corepb.v1.QBFTConsensusMsg.msg:type_name -> core.corepb.v1.QBFTMsg
-	0, // 2: core.corepb.v1.QBFTConsensusMsg.justification:type_name -> core.corepb.v1.QBFTMsg
-	6, // 3: core.corepb.v1.QBFTConsensusMsg.values:type_name -> google.protobuf.Any
-	7, // 4: core.corepb.v1.SniffedConsensusMsg.timestamp:type_name -> google.protobuf.Timestamp
-	1, // 5: core.corepb.v1.SniffedConsensusMsg.msg:type_name -> core.corepb.v1.QBFTConsensusMsg
-	7, // 6: core.corepb.v1.SniffedConsensusInstance.started_at:type_name -> google.protobuf.Timestamp
-	2, // 7: core.corepb.v1.SniffedConsensusInstance.msgs:type_name -> core.corepb.v1.SniffedConsensusMsg
-	3, // 8: core.corepb.v1.SniffedConsensusInstances.instances:type_name -> core.corepb.v1.SniffedConsensusInstance
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	11, // 0: core.corepb.v1.QBFTMsg.duty:type_name -> core.corepb.v1.Duty
+	0,  // 1: core.corepb.v1.QBFTConsensusMsg.msg:type_name -> core.corepb.v1.QBFTMsg
+	0,  // 2: core.corepb.v1.QBFTConsensusMsg.justification:type_name -> core.corepb.v1.QBFTMsg
+	12, // 3: core.corepb.v1.QBFTConsensusMsg.values:type_name -> google.protobuf.Any
+	11, // 4: core.corepb.v1.QBFTCandidateMsg.duty:type_name -> core.corepb.v1.Duty
+	13, // 5: core.corepb.v1.QBFTCandidateMsg.proposals:type_name -> core.corepb.v1.UnsignedDataSet
+	10, // 6: core.corepb.v1.QBFTCandidateMsg.block_values:type_name -> core.corepb.v1.QBFTCandidateMsg.BlockValuesEntry
+	11, // 7: core.corepb.v1.HotStuffMsg.duty:type_name -> core.corepb.v1.Duty
+	4,  // 8: core.corepb.v1.HotStuffMsg.justify:type_name -> core.corepb.v1.HotStuffQC
+	5,  // 9: core.corepb.v1.HotStuffQC.votes:type_name -> core.corepb.v1.HotStuffVote
+	3,  // 10: core.corepb.v1.HotStuffConsensusMsg.msg:type_name -> core.corepb.v1.HotStuffMsg
+	12, // 11: core.corepb.v1.HotStuffConsensusMsg.values:type_name -> google.protobuf.Any
+	14, // 12: core.corepb.v1.SniffedConsensusMsg.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 13: core.corepb.v1.SniffedConsensusMsg.msg:type_name -> core.corepb.v1.QBFTConsensusMsg
+	6,  // 14: core.corepb.v1.SniffedConsensusMsg.hotstuff_msg:type_name -> core.corepb.v1.HotStuffConsensusMsg
+	14, // 15: core.corepb.v1.SniffedConsensusInstance.started_at:type_name -> google.protobuf.Timestamp
+	7,  // 16: core.corepb.v1.SniffedConsensusInstance.msgs:type_name -> core.corepb.v1.SniffedConsensusMsg
+	8,  // 17: core.corepb.v1.SniffedConsensusInstances.instances:type_name -> core.corepb.v1.SniffedConsensusInstance
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_core_corepb_v1_consensus_proto_init() }
@@ -490,13 +917,14 @@ func file_core_corepb_v1_consensus_proto_init() {
 		return
 	}
 	file_core_corepb_v1_core_proto_init()
+	file_core_corepb_v1_consensus_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_core_corepb_v1_consensus_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
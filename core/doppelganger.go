@@ -0,0 +1,62 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+)
+
+// WithDoppelganger wraps the validator API subscription, refusing partial signatures submitted by the
+// validator client while the doppelganger check returns an error.
+func WithDoppelganger(check func() error) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+		w.VAPISubscribe = func(fn func(context.Context, Duty, ParSignedDataSet) error) {
+			clone.VAPISubscribe(func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+				if err := check(); err != nil {
+					return err
+				}
+
+				return fn(ctx, duty, set)
+			})
+		}
+	}
+}
+
+// WithParticipationCheck wraps the consensus, validator API and partial signature exchange inputs,
+// refusing to participate in duties while the check returns an error.
+func WithParticipationCheck(check func() error) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+		w.ConsensusParticipate = func(ctx context.Context, duty Duty) error {
+			if err := check(); err != nil {
+				return err
+			}
+
+			return clone.ConsensusParticipate(ctx, duty)
+		}
+		w.ConsensusPropose = func(ctx context.Context, duty Duty, set UnsignedDataSet) error {
+			if err := check(); err != nil {
+				return err
+			}
+
+			return clone.ConsensusPropose(ctx, duty, set)
+		}
+		w.ParSigExBroadcast = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			if err := check(); err != nil {
+				return err
+			}
+
+			return clone.ParSigExBroadcast(ctx, duty, set)
+		}
+		w.VAPISubscribe = func(fn func(context.Context, Duty, ParSignedDataSet) error) {
+			clone.VAPISubscribe(func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+				if err := check(); err != nil {
+					return err
+				}
+
+				return fn(ctx, duty, set)
+			})
+		}
+	}
+}
@@ -0,0 +1,407 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/atomicfile"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+const (
+	// diskVersion is the version of the persisted slashing protection file format.
+	diskVersion = "v1"
+	// compactFactor and compactMin define when the journal is compacted, i.e., when it contains
+	// more than compactFactor entries per DV in addition to compactMin entries.
+	compactFactor = 4
+	compactMin    = 1024
+)
+
+// NewDiskDB returns a new disk-backed dutyDB instance. It wraps an in-memory dutyDB
+// with slashing protection records persisted to the provided file, which is created if it doesn't exist.
+func NewDiskDB(filename string, deadliner core.Deadliner) (*DiskDB, error) {
+	records, err := loadRecords(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compact the journal on startup.
+	if err := writeRecords(filename, records); err != nil {
+		return nil, err
+	}
+
+	return &DiskDB{
+		MemDB:    NewMemDB(deadliner),
+		filename: filename,
+		records:  records,
+		entries:  len(records),
+	}, nil
+}
+
+// DiskDB is a dutyDB implementation that persists the highest proposed slot and the highest
+// attested source and target epochs per DV public key. It refuses to store unsigned data that
+// conflicts with previously stored data, even across restarts.
+//
+// Records are persisted incrementally by appending updated records to a journal file,
+// which is compacted when it grows too large.
+type DiskDB struct {
+	*MemDB
+
+	mu       sync.Mutex
+	filename string
+	records  map[core.PubKey]signingRecord
+	entries  int  // Number of entries in the journal file.
+	compact  bool // Compact the journal before appending, since a previous append failed.
+}
+
+// Store implements core.DutyDB, see its godoc.
+// Slashable duty data is checked against and persisted to disk before
+// being made available to the validator client. Data of DVs that conflicts with
+// previously stored data is refused, while data of the other DVs is still stored.
+func (db *DiskDB) Store(ctx context.Context, duty core.Duty, unsignedSet core.UnsignedDataSet) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var (
+		updates   = make(map[core.PubKey]signingRecord)
+		allowed   = make(core.UnsignedDataSet)
+		conflicts int
+		firstErr  error
+	)
+	for pubkey, unsignedData := range unsignedSet {
+		record := db.records[pubkey]
+
+		var (
+			ok  bool
+			err error
+		)
+		switch duty.Type {
+		case core.DutyProposer:
+			record, ok, err = checkProposal(record, unsignedData)
+		case core.DutyAttester:
+			record, ok, err = checkAttestation(record, unsignedData)
+		default:
+			allowed[pubkey] = unsignedData
+			continue // Not slashable.
+		}
+		if err != nil {
+			conflicts++
+			if firstErr == nil {
+				firstErr = errors.Wrap(err, "slashing protection", z.Any("pubkey", pubkey), z.Any("duty", duty))
+			}
+
+			continue
+		}
+
+		allowed[pubkey] = unsignedData
+		if ok {
+			updates[pubkey] = record
+		}
+	}
+
+	if err := db.persist(updates); err != nil {
+		return err
+	}
+
+	if len(allowed) > 0 {
+		if err := db.MemDB.Store(ctx, duty, allowed); err != nil {
+			return err
+		}
+	}
+
+	if conflicts > 1 {
+		return errors.Wrap(firstErr, "multiple slashing protection conflicts", z.Int("conflicts", conflicts))
+	}
+
+	return firstErr
+}
+
+// persist appends the updated records to the journal file and syncs it, or compacts
+// the journal if it grew too large. It must be called while holding the lock.
+func (db *DiskDB) persist(updates map[core.PubKey]signingRecord) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	records := make(map[core.PubKey]signingRecord, len(db.records))
+	for pubkey, record := range db.records {
+		records[pubkey] = record
+	}
+	for pubkey, record := range updates {
+		records[pubkey] = record
+	}
+
+	if db.compact || db.entries+len(updates) > compactFactor*len(records)+compactMin {
+		if err := writeRecords(db.filename, records); err != nil {
+			return err
+		}
+
+		db.records = records
+		db.entries = len(records)
+		db.compact = false
+
+		return nil
+	}
+
+	if err := appendRecords(db.filename, updates); err != nil {
+		// The journal may contain a partial entry if truncating it failed, so replace it with a compacted journal next time.
+		db.compact = true
+		return err
+	}
+
+	db.records = records
+	db.entries += len(updates)
+
+	return nil
+}
+
+// checkProposal returns the record updated with the unsigned proposal and true if it was updated.
+// It returns an error if the proposal conflicts with the record.
+func checkProposal(record signingRecord, unsignedData core.UnsignedData) (signingRecord, bool, error) {
+	proposal, ok := unsignedData.(core.VersionedProposal)
+	if !ok {
+		return signingRecord{}, false, errors.New("invalid versioned proposal")
+	}
+
+	slot, err := proposal.Slot()
+	if err != nil {
+		return signingRecord{}, false, err
+	}
+
+	root, err := proposal.Root()
+	if err != nil {
+		return signingRecord{}, false, errors.Wrap(err, "proposal root")
+	}
+
+	if prev := record.Proposal; prev != nil {
+		if uint64(slot) < prev.Slot {
+			return signingRecord{}, false, errors.New("proposal slot lower than previously stored",
+				z.U64("slot", uint64(slot)), z.U64("prev_slot", prev.Slot))
+		} else if uint64(slot) == prev.Slot && root.String() != prev.Root {
+			return signingRecord{}, false, errors.New("clashing blocks", z.U64("slot", uint64(slot)))
+		} else if uint64(slot) == prev.Slot {
+			return record, false, nil
+		}
+	}
+
+	record.Proposal = &proposalRecord{
+		Slot: uint64(slot),
+		Root: root.String(),
+	}
+
+	return record, true, nil
+}
+
+// checkAttestation returns the record updated with the unsigned attestation data and true if it was updated.
+// It returns an error if the attestation data is a double vote or surrounds a previously stored vote.
+func checkAttestation(record signingRecord, unsignedData core.UnsignedData) (signingRecord, bool, error) {
+	attData, ok := unsignedData.(core.AttestationData)
+	if !ok {
+		return signingRecord{}, false, errors.New("invalid unsigned attestation data")
+	}
+
+	source := uint64(attData.Data.Source.Epoch)
+	target := uint64(attData.Data.Target.Epoch)
+
+	root, err := attData.Data.HashTreeRoot()
+	if err != nil {
+		return signingRecord{}, false, errors.Wrap(err, "hash attestation data")
+	}
+
+	if prev := record.Attestation; prev != nil {
+		if target < prev.TargetEpoch {
+			return signingRecord{}, false, errors.New("attestation target epoch lower than previously stored",
+				z.U64("target", target), z.U64("prev_target", prev.TargetEpoch))
+		} else if source < prev.SourceEpoch {
+			return signingRecord{}, false, errors.New("attestation source epoch lower than previously stored",
+				z.U64("source", source), z.U64("prev_source", prev.SourceEpoch))
+		} else if target == prev.TargetEpoch && eth2p0.Root(root).String() != prev.Root {
+			return signingRecord{}, false, errors.New("clashing attestation data", z.U64("target", target))
+		} else if target == prev.TargetEpoch {
+			return record, false, nil
+		}
+	}
+
+	record.Attestation = &attestationRecord{
+		SourceEpoch: source,
+		TargetEpoch: target,
+		Root:        eth2p0.Root(root).String(),
+	}
+
+	return record, true, nil
+}
+
+// signingRecord is the persisted slashing protection record of a DV.
+type signingRecord struct {
+	Proposal    *proposalRecord    `json:"proposal,omitempty"`
+	Attestation *attestationRecord `json:"attestation,omitempty"`
+}
+
+// proposalRecord is the highest stored proposal of a DV.
+type proposalRecord struct {
+	Slot uint64 `json:"slot,string"`
+	Root string `json:"root"`
+}
+
+// attestationRecord is the highest stored attestation of a DV.
+type attestationRecord struct {
+	SourceEpoch uint64 `json:"source_epoch,string"`
+	TargetEpoch uint64 `json:"target_epoch,string"`
+	Root        string `json:"root"`
+}
+
+// diskHeader is the first line of the persisted slashing protection journal file.
+type diskHeader struct {
+	Version string `json:"version"`
+}
+
+// diskEntry is a line of the persisted slashing protection journal file,
+// containing the latest record of a DV. Later entries override earlier entries.
+type diskEntry struct {
+	PubKey core.PubKey `json:"pubkey"`
+	signingRecord
+}
+
+// loadRecords returns the slashing protection records from the journal file or empty records if the file doesn't exist.
+// A truncated last entry, as a result of a crash while appending, is ignored since it was never acknowledged.
+func loadRecords(filename string) (map[core.PubKey]signingRecord, error) {
+	records := make(map[core.PubKey]signingRecord)
+
+	b, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read dutydb file")
+	}
+
+	lines := bytes.Split(b, []byte("\n"))
+
+	var header diskHeader
+	if err := json.Unmarshal(lines[0], &header); err != nil {
+		return nil, errors.Wrap(err, "unmarshal dutydb file")
+	} else if header.Version != diskVersion {
+		return nil, errors.New("unsupported dutydb file version", z.Str("version", header.Version))
+	}
+
+	for i, line := range lines[1:] {
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry diskEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			if i == len(lines)-2 { // Last line without trailing newline.
+				break
+			}
+
+			return nil, errors.Wrap(err, "unmarshal dutydb file entry", z.Int("line", i+2))
+		}
+
+		records[entry.PubKey] = entry.signingRecord
+	}
+
+	return records, nil
+}
+
+// writeRecords atomically replaces the journal file with a compacted journal of the records.
+func writeRecords(filename string, records map[core.PubKey]signingRecord) error {
+	b, err := json.Marshal(diskHeader{Version: diskVersion})
+	if err != nil {
+		return errors.Wrap(err, "marshal dutydb file")
+	}
+	b = append(b, '\n')
+
+	entries, err := marshalEntries(records)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(filename, append(b, entries...), 0o600)
+}
+
+// appendRecords appends the records to the journal file and syncs it.
+func appendRecords(filename string, records map[core.PubKey]signingRecord) error {
+	b, err := marshalEntries(records)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "open dutydb file")
+	}
+
+	if err := appendSynced(f, b); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "close dutydb file")
+	}
+
+	return nil
+}
+
+// journalFile is the subset of *os.File used to append to the journal file.
+type journalFile interface {
+	io.WriteSeeker
+	Sync() error
+	Truncate(size int64) error
+}
+
+// appendSynced appends the entries to the journal file and syncs it. If appending fails, the file is
+// truncated to its previous size, so that a partially written entry doesn't corrupt subsequent entries.
+func appendSynced(f journalFile, b []byte) error {
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Wrap(err, "seek dutydb file")
+	}
+
+	_, err = f.Write(b)
+	if err != nil {
+		err = errors.Wrap(err, "append dutydb file")
+	} else if err = f.Sync(); err != nil {
+		err = errors.Wrap(err, "sync dutydb file")
+	}
+
+	if err != nil {
+		if truncErr := f.Truncate(offset); truncErr != nil {
+			return errors.Wrap(err, "truncate dutydb file failed", z.Str("truncate_err", truncErr.Error()))
+		}
+
+		_ = f.Sync() // Best effort, the journal is compacted before the next append regardless.
+
+		return err
+	}
+
+	return nil
+}
+
+// marshalEntries returns the records as newline terminated journal entries ordered by pubkey.
+func marshalEntries(records map[core.PubKey]signingRecord) ([]byte, error) {
+	var b []byte
+	for _, pubkey := range slices.Sorted(maps.Keys(records)) {
+		entry, err := json.Marshal(diskEntry{PubKey: pubkey, signingRecord: records[pubkey]})
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal dutydb file entry")
+		}
+
+		b = append(b, entry...)
+		b = append(b, '\n')
+	}
+
+	return b, nil
+}
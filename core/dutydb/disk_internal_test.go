@@ -0,0 +1,62 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestAppendSyncedTruncates(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "dutydb.json")
+	pubkey1 := testutil.RandomCorePubKey(t)
+	pubkey2 := testutil.RandomCorePubKey(t)
+
+	records1 := map[core.PubKey]signingRecord{pubkey1: {Proposal: &proposalRecord{Slot: 1, Root: "0x01"}}}
+	records2 := map[core.PubKey]signingRecord{pubkey2: {Proposal: &proposalRecord{Slot: 2, Root: "0x02"}}}
+
+	require.NoError(t, writeRecords(filename, records1))
+
+	b, err := marshalEntries(records2)
+	require.NoError(t, err)
+
+	// A failed write of a partial entry is truncated.
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	err = appendSynced(partialFile{File: f}, b)
+	require.ErrorContains(t, err, "append dutydb file")
+	require.NoError(t, f.Close())
+
+	loaded, err := loadRecords(filename)
+	require.NoError(t, err)
+	require.Equal(t, records1, loaded)
+
+	// Subsequent appends are not corrupted by the failed write.
+	require.NoError(t, appendRecords(filename, records2))
+
+	loaded, err = loadRecords(filename)
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	require.Equal(t, records2[pubkey2], loaded[pubkey2])
+}
+
+// partialFile is a journal file that writes half of the data before failing.
+type partialFile struct {
+	*os.File
+}
+
+func (f partialFile) Write(b []byte) (int, error) {
+	n, err := f.File.Write(b[:len(b)/2])
+	if err != nil {
+		return n, err
+	}
+
+	return n, errors.New("disk full")
+}
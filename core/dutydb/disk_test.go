@@ -0,0 +1,273 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestDiskDBProposerRestart(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "dutydb.json")
+	pubkey := testutil.RandomCorePubKey(t)
+
+	db, err := dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	const slot = 123
+	block := newProposal(t, slot)
+	err = db.Store(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{pubkey: block})
+	require.NoError(t, err)
+
+	proposal, err := db.AwaitProposal(ctx, slot)
+	require.NoError(t, err)
+	require.Equal(t, block.Bellatrix.Slot, proposal.Bellatrix.Slot)
+
+	db.Shutdown()
+
+	// Restart
+	db, err = dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	// Same block is allowed.
+	err = db.Store(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{pubkey: block})
+	require.NoError(t, err)
+
+	// Different block for the same slot is refused.
+	err = db.Store(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{pubkey: newProposal(t, slot)})
+	require.ErrorContains(t, err, "clashing blocks")
+
+	// Lower slot is refused.
+	err = db.Store(ctx, core.NewProposerDuty(slot-1), core.UnsignedDataSet{pubkey: newProposal(t, slot-1)})
+	require.ErrorContains(t, err, "proposal slot lower than previously stored")
+
+	// Higher slot is allowed.
+	err = db.Store(ctx, core.NewProposerDuty(slot+1), core.UnsignedDataSet{pubkey: newProposal(t, slot+1)})
+	require.NoError(t, err)
+
+	// Other validators are not affected.
+	err = db.Store(ctx, core.NewProposerDuty(slot-1), core.UnsignedDataSet{testutil.RandomCorePubKey(t): newProposal(t, slot-1)})
+	require.NoError(t, err)
+}
+
+func TestDiskDBAttesterRestart(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "dutydb.json")
+	pubkey := testutil.RandomCorePubKey(t)
+
+	db, err := dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	const (
+		slot   = 320
+		source = 8
+		target = 10
+	)
+
+	attData := newAttData(slot, source, target)
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{pubkey: attData})
+	require.NoError(t, err)
+
+	db.Shutdown()
+
+	// Restart
+	db, err = dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		data   core.AttestationData
+		errMsg string
+	}{
+		{
+			name: "same data",
+			data: attData,
+		},
+		{
+			name:   "double vote",
+			data:   newAttData(slot+1, source, target),
+			errMsg: "clashing attestation data",
+		},
+		{
+			name:   "lower target",
+			data:   newAttData(slot-32, source-1, target-1),
+			errMsg: "attestation target epoch lower than previously stored",
+		},
+		{
+			name:   "surround vote",
+			data:   newAttData(slot+32, source-1, target+1),
+			errMsg: "attestation source epoch lower than previously stored",
+		},
+		{
+			name: "next target",
+			data: newAttData(slot+32, source+1, target+1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slot := uint64(test.data.Data.Slot)
+			err := db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{pubkey: test.data})
+			if test.errMsg != "" {
+				require.ErrorContains(t, err, test.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			resp, err := db.AwaitAttestation(ctx, slot, uint64(test.data.Data.Index))
+			require.NoError(t, err)
+			require.Equal(t, test.data.Data, *resp)
+		})
+	}
+}
+
+func TestDiskDBInvalidFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "dutydb.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"version":"v0","records":{}}`), 0o644))
+
+	_, err := dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.ErrorContains(t, err, "unsupported dutydb file version")
+
+	require.NoError(t, os.WriteFile(filename, []byte(`invalid`), 0o644))
+
+	_, err = dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.ErrorContains(t, err, "unmarshal dutydb file")
+}
+
+func newProposal(t *testing.T, slot uint64) core.VersionedProposal {
+	t.Helper()
+
+	block := &eth2api.VersionedProposal{
+		Version:   eth2spec.DataVersionBellatrix,
+		Bellatrix: testutil.RandomBellatrixBeaconBlock(),
+	}
+	block.Bellatrix.Slot = eth2p0.Slot(slot)
+
+	proposal, err := core.NewVersionedProposal(block)
+	require.NoError(t, err)
+
+	return proposal
+}
+
+func newAttData(slot uint64, source, target eth2p0.Epoch) core.AttestationData {
+	data := testutil.RandomAttestationData()
+	data.Slot = eth2p0.Slot(slot)
+	data.Source.Epoch = source
+	data.Target.Epoch = target
+
+	return core.AttestationData{
+		Data: *data,
+		Duty: eth2v1.AttesterDuty{
+			Slot:             data.Slot,
+			CommitteeIndex:   data.Index,
+			CommitteeLength:  8,
+			CommitteesAtSlot: 1,
+		},
+	}
+}
+
+func TestDiskDBPartialConflict(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "dutydb.json")
+
+	db, err := dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	const slot = 320
+	pubkey1 := testutil.RandomCorePubKey(t)
+	pubkey2 := testutil.RandomCorePubKey(t)
+
+	attData1 := newAttData(slot, 8, 10)
+	attData1.Data.Index = 1
+	attData1.Duty.CommitteeIndex = 1
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{pubkey1: attData1})
+	require.NoError(t, err)
+
+	db.Shutdown()
+
+	// Restart
+	db, err = dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	// Double vote of pubkey1 is refused, but pubkey2 is stored.
+	attData2 := newAttData(slot+1, 8, 10)
+	attData2.Data.Index = 2
+	attData2.Duty.CommitteeIndex = 2
+	err = db.Store(ctx, core.NewAttesterDuty(slot+1), core.UnsignedDataSet{
+		pubkey1: newAttData(slot+1, 8, 10),
+		pubkey2: attData2,
+	})
+	require.ErrorContains(t, err, "clashing attestation data")
+
+	resp, err := db.AwaitAttestation(ctx, slot+1, 2)
+	require.NoError(t, err)
+	require.Equal(t, attData2.Data, *resp)
+
+	db.Shutdown()
+
+	// Restart, pubkey2 record was persisted.
+	db, err = dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	err = db.Store(ctx, core.NewAttesterDuty(slot+2), core.UnsignedDataSet{pubkey2: newAttData(slot+2, 8, 10)})
+	require.ErrorContains(t, err, "clashing attestation data")
+}
+
+func TestDiskDBJournal(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "dutydb.json")
+	pubkey := testutil.RandomCorePubKey(t)
+
+	db, err := dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	const n = 10
+	for i := range uint64(n) {
+		slot := 32 * (i + 1)
+		attData := newAttData(slot, eth2p0.Epoch(i), eth2p0.Epoch(i+1))
+		err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{pubkey: attData})
+		require.NoError(t, err)
+	}
+
+	countLines := func() int {
+		t.Helper()
+		b, err := os.ReadFile(filename)
+		require.NoError(t, err)
+
+		return strings.Count(string(b), "\n")
+	}
+
+	// Header and one entry per store.
+	require.Equal(t, 1+n, countLines())
+
+	// Append a truncated entry as if crashed while appending.
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"pubkey":"0x`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	db.Shutdown()
+
+	// Restart compacts the journal to the header and the latest entry.
+	db, err = dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+	require.Equal(t, 2, countLines())
+
+	err = db.Store(ctx, core.NewAttesterDuty(32*n), core.UnsignedDataSet{pubkey: newAttData(32*n, n-1, n)})
+	require.ErrorContains(t, err, "clashing attestation data")
+}
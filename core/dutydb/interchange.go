@@ -0,0 +1,113 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb
+
+import (
+	"strings"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/interchange"
+)
+
+// ImportInterchange merges the EIP-3076 slashing protection data of the provided DVs into the dutydb file.
+// Data of other validators is ignored. It returns the DVs that were imported.
+//
+// Imported records are merged by retaining the highest slots and epochs. Since interchange signing roots
+// are not comparable to the persisted data roots, any block or attestation at an imported slot or target epoch is refused.
+// Note that the dutydb file must not be in use by a running node.
+func ImportInterchange(filename string, data []interchange.Data, pubkeys []core.PubKey) ([]core.PubKey, error) {
+	records, err := loadRecords(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[core.PubKey]bool)
+	for _, pubkey := range pubkeys {
+		known[pubkey] = true
+	}
+
+	var imported []core.PubKey
+	for _, d := range data {
+		pubkey := core.PubKey(strings.ToLower(d.Pubkey))
+		if !known[pubkey] {
+			continue
+		}
+
+		records[pubkey] = mergeInterchange(records[pubkey], d)
+		imported = append(imported, pubkey)
+	}
+
+	if err := writeRecords(filename, records); err != nil {
+		return nil, err
+	}
+
+	return imported, nil
+}
+
+// ExportInterchange returns the EIP-3076 slashing protection data of the provided DVs from the dutydb file.
+// Only the highest block and attestation per DV is exported, which is sufficient for minimal slashing protection.
+func ExportInterchange(filename string, pubkeys []core.PubKey) ([]interchange.Data, error) {
+	records, err := loadRecords(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []interchange.Data
+	for _, pubkey := range pubkeys {
+		record := records[pubkey]
+
+		data := interchange.Data{
+			Pubkey:             string(pubkey),
+			SignedBlocks:       []interchange.SignedBlock{},
+			SignedAttestations: []interchange.SignedAttestation{},
+		}
+
+		if record.Proposal != nil {
+			data.SignedBlocks = append(data.SignedBlocks, interchange.SignedBlock{
+				Slot: record.Proposal.Slot,
+			})
+		}
+
+		if record.Attestation != nil {
+			data.SignedAttestations = append(data.SignedAttestations, interchange.SignedAttestation{
+				SourceEpoch: record.Attestation.SourceEpoch,
+				TargetEpoch: record.Attestation.TargetEpoch,
+			})
+		}
+
+		resp = append(resp, data)
+	}
+
+	return resp, nil
+}
+
+// mergeInterchange returns the record merged with the interchange data.
+func mergeInterchange(record signingRecord, data interchange.Data) signingRecord {
+	for _, block := range data.SignedBlocks {
+		if record.Proposal != nil && block.Slot < record.Proposal.Slot {
+			continue
+		}
+
+		record.Proposal = &proposalRecord{Slot: block.Slot} // Empty root refuses any block at this slot.
+	}
+
+	for _, att := range data.SignedAttestations {
+		merged := attestationRecord{
+			SourceEpoch: att.SourceEpoch,
+			TargetEpoch: att.TargetEpoch,
+		}
+
+		if prev := record.Attestation; prev != nil {
+			merged.SourceEpoch = max(prev.SourceEpoch, att.SourceEpoch)
+			merged.TargetEpoch = max(prev.TargetEpoch, att.TargetEpoch)
+
+			if prev.TargetEpoch > att.TargetEpoch {
+				merged.Root = prev.Root
+			}
+		}
+
+		record.Attestation = &merged // Empty root refuses any attestation at this target.
+	}
+
+	return record
+}
@@ -0,0 +1,96 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/eth2util/interchange"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestImportInterchange(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "dutydb.json")
+	pubkey := testutil.RandomCorePubKey(t)
+
+	const (
+		slot   = 320
+		source = 8
+		target = 10
+	)
+
+	imported, err := dutydb.ImportInterchange(filename, []interchange.Data{
+		{
+			Pubkey:             strings.ToUpper(string(pubkey)),
+			SignedBlocks:       []interchange.SignedBlock{{Slot: slot - 1}, {Slot: slot}},
+			SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: source, TargetEpoch: target}},
+		},
+		{
+			Pubkey:       string(testutil.RandomCorePubKey(t)),
+			SignedBlocks: []interchange.SignedBlock{{Slot: slot}},
+		},
+	}, []core.PubKey{pubkey})
+	require.NoError(t, err)
+	require.Equal(t, []core.PubKey{pubkey}, imported)
+
+	db, err := dutydb.NewDiskDB(filename, new(testDeadliner))
+	require.NoError(t, err)
+
+	// Any block at an imported slot is refused.
+	err = db.Store(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{pubkey: newProposal(t, slot)})
+	require.ErrorContains(t, err, "clashing blocks")
+
+	// Any attestation at an imported target is refused.
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{pubkey: newAttData(slot, source, target)})
+	require.ErrorContains(t, err, "clashing attestation data")
+
+	// Later duties are allowed.
+	err = db.Store(ctx, core.NewProposerDuty(slot+1), core.UnsignedDataSet{pubkey: newProposal(t, slot+1)})
+	require.NoError(t, err)
+	err = db.Store(ctx, core.NewAttesterDuty(slot+32), core.UnsignedDataSet{pubkey: newAttData(slot+32, target, target+1)})
+	require.NoError(t, err)
+	db.Shutdown()
+
+	// Importing older history doesn't lower the persisted records.
+	_, err = dutydb.ImportInterchange(filename, []interchange.Data{
+		{
+			Pubkey:             string(pubkey),
+			SignedBlocks:       []interchange.SignedBlock{{Slot: slot - 10}},
+			SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: source - 1, TargetEpoch: target - 1}},
+		},
+	}, []core.PubKey{pubkey})
+	require.NoError(t, err)
+
+	data, err := dutydb.ExportInterchange(filename, []core.PubKey{pubkey})
+	require.NoError(t, err)
+	require.Equal(t, []interchange.Data{
+		{
+			Pubkey:             string(pubkey),
+			SignedBlocks:       []interchange.SignedBlock{{Slot: slot + 1}},
+			SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: target, TargetEpoch: target + 1}},
+		},
+	}, data)
+}
+
+func TestExportInterchangeEmpty(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "dutydb.json")
+	pubkey := testutil.RandomCorePubKey(t)
+
+	data, err := dutydb.ExportInterchange(filename, []core.PubKey{pubkey})
+	require.NoError(t, err)
+	require.Equal(t, []interchange.Data{
+		{
+			Pubkey:             string(pubkey),
+			SignedBlocks:       []interchange.SignedBlock{},
+			SignedAttestations: []interchange.SignedAttestation{},
+		},
+	}, data)
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
@@ -22,7 +23,7 @@ func NewMemDB(deadliner core.Deadliner) *MemDB {
 		attPubKeys:        make(map[pkKey]core.PubKey),
 		attKeysBySlot:     make(map[uint64][]pkKey),
 		proDuties:         make(map[uint64]*eth2api.VersionedProposal),
-		aggDuties:         make(map[aggKey]core.AggregatedAttestation),
+		aggDuties:         make(map[aggKey]core.VersionedAggregatedAttestation),
 		aggKeysBySlot:     make(map[uint64][]aggKey),
 		contribDuties:     make(map[contribKey]*altair.SyncCommitteeContribution),
 		contribKeysBySlot: make(map[uint64][]contribKey),
@@ -47,7 +48,7 @@ type MemDB struct {
 	proQueries []proQuery
 
 	// DutyAggregator
-	aggDuties     map[aggKey]core.AggregatedAttestation
+	aggDuties     map[aggKey]core.VersionedAggregatedAttestation
 	aggKeysBySlot map[uint64][]aggKey
 	aggQueries    []aggQuery
 
@@ -192,19 +193,20 @@ func (db *MemDB) AwaitAttestation(ctx context.Context, slot uint64, commIdx uint
 	}
 }
 
-// AwaitAggAttestation blocks and returns the aggregated attestation for the slot
-// and attestation when available.
-func (db *MemDB) AwaitAggAttestation(ctx context.Context, slot uint64, attestationRoot eth2p0.Root,
-) (*eth2p0.Attestation, error) {
+// AwaitAggAttestation blocks and returns the aggregated attestation for the slot,
+// committee index and attestation when available.
+func (db *MemDB) AwaitAggAttestation(ctx context.Context, slot, commIdx uint64, attestationRoot eth2p0.Root,
+) (*eth2spec.VersionedAttestation, error) {
 	cancel := make(chan struct{})
 	defer close(cancel)
-	response := make(chan core.AggregatedAttestation, 1) // Instance of one so resolving never blocks
+	response := make(chan core.VersionedAggregatedAttestation, 1) // Instance of one so resolving never blocks
 
 	db.mu.Lock()
 	db.aggQueries = append(db.aggQueries, aggQuery{
 		Key: aggKey{
-			Slot: slot,
-			Root: attestationRoot,
+			Slot:    slot,
+			CommIdx: commIdx,
+			Root:    attestationRoot,
 		},
 		Response: response,
 		Cancel:   cancel,
@@ -223,12 +225,12 @@ func (db *MemDB) AwaitAggAttestation(ctx context.Context, slot uint64, attestati
 		if err != nil {
 			return nil, err
 		}
-		aggAtt, ok := clone.(core.AggregatedAttestation)
+		aggAtt, ok := clone.(core.VersionedAggregatedAttestation)
 		if !ok {
 			return nil, errors.New("invalid aggregated attestation")
 		}
 
-		return &aggAtt.Attestation, nil
+		return &aggAtt.VersionedAttestation, nil
 	}
 }
 
@@ -293,10 +295,11 @@ func (db *MemDB) storeAttestationUnsafe(pubkey core.PubKey, unsignedData core.Un
 		return errors.New("invalid unsigned attestation data")
 	}
 
-	// Store key and value for PubKeyByAttestation
+	// Store key and value for PubKeyByAttestation.
+	// Note the duty committee index is used since electra attestation data doesn't include it.
 	pKey := pkKey{
 		Slot:       uint64(attData.Data.Slot),
-		CommIdx:    uint64(attData.Data.Index),
+		CommIdx:    uint64(attData.Duty.CommitteeIndex),
 		ValCommIdx: attData.Duty.ValidatorCommitteeIndex,
 	}
 	if value, ok := db.attPubKeys[pKey]; ok {
@@ -311,7 +314,7 @@ func (db *MemDB) storeAttestationUnsafe(pubkey core.PubKey, unsignedData core.Un
 	// Store key and value for AwaitAttestation
 	aKey := attKey{
 		Slot:    uint64(attData.Data.Slot),
-		CommIdx: uint64(attData.Data.Index),
+		CommIdx: uint64(attData.Duty.CommitteeIndex),
 	}
 
 	if value, ok := db.attDuties[aKey]; ok {
@@ -332,22 +335,39 @@ func (db *MemDB) storeAggAttestationUnsafe(unsignedData core.UnsignedData) error
 		return err
 	}
 
-	aggAtt, ok := cloned.(core.AggregatedAttestation)
+	aggAtt, ok := cloned.(core.VersionedAggregatedAttestation)
 	if !ok {
 		return errors.New("invalid unsigned aggregated attestation")
 	}
 
-	aggRoot, err := aggAtt.Attestation.Data.HashTreeRoot()
+	attData, err := aggAtt.Data()
+	if err != nil {
+		return errors.Wrap(err, "aggregated attestation data")
+	}
+
+	aggRoot, err := attData.HashTreeRoot()
 	if err != nil {
 		return errors.Wrap(err, "hash aggregated attestation root")
 	}
 
-	slot := uint64(aggAtt.Attestation.Data.Slot)
+	// Electra attestation data doesn't include the committee index, so it is part of the key.
+	// Pre-electra data roots already commit to the committee index, so those are stored with zero.
+	var commIdx uint64
+	if aggAtt.Version >= eth2spec.DataVersionElectra {
+		idx, err := aggAtt.CommitteeIndex()
+		if err != nil {
+			return errors.Wrap(err, "aggregated attestation committee index")
+		}
+		commIdx = uint64(idx)
+	}
+
+	slot := uint64(attData.Slot)
 
 	// Store key and value for PubKeyByAttestation
 	key := aggKey{
-		Slot: slot,
-		Root: aggRoot,
+		Slot:    slot,
+		CommIdx: commIdx,
+		Root:    aggRoot,
 	}
 	if existing, ok := db.aggDuties[key]; ok {
 		existingRoot, err := existing.HashTreeRoot()
@@ -501,6 +521,12 @@ func (db *MemDB) resolveAggQueriesUnsafe() {
 		}
 
 		value, ok := db.aggDuties[query.Key]
+		if !ok {
+			// Pre-electra aggregates are stored without committee index, see storeAggAttestationUnsafe.
+			fallback := aggKey{Slot: query.Key.Slot, Root: query.Key.Root}
+			value, ok = db.aggDuties[fallback]
+			ok = ok && value.Version < eth2spec.DataVersionElectra
+		}
 		if !ok {
 			unresolved = append(unresolved, query)
 			continue
@@ -576,10 +602,11 @@ type pkKey struct {
 	ValCommIdx uint64
 }
 
-// aggKey is the key to lookup an aggregated attestation by root in the DB.
+// aggKey is the key to lookup an aggregated attestation by committee index and root in the DB.
 type aggKey struct {
-	Slot uint64
-	Root eth2p0.Root
+	Slot    uint64
+	CommIdx uint64
+	Root    eth2p0.Root
 }
 
 // contribKey is the key to look up sync contribution by root and subcommittee index in the DB.
@@ -606,7 +633,7 @@ type proQuery struct {
 // aggQuery is a waiting aggQuery with a response channel.
 type aggQuery struct {
 	Key      aggKey
-	Response chan<- core.AggregatedAttestation
+	Response chan<- core.VersionedAggregatedAttestation
 	Cancel   <-chan struct{}
 }
 
@@ -12,6 +12,7 @@ import (
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/core"
@@ -88,6 +89,7 @@ func TestMemDB(t *testing.T) {
 	unsignedA := core.AttestationData{
 		Data: attData,
 		Duty: eth2v1.AttesterDuty{
+			CommitteeIndex:          commIdx,
 			CommitteeLength:         commLen,
 			ValidatorCommitteeIndex: valCommIdxA,
 			CommitteesAtSlot:        notZero,
@@ -96,6 +98,7 @@ func TestMemDB(t *testing.T) {
 	unsignedB := core.AttestationData{
 		Data: attData,
 		Duty: eth2v1.AttesterDuty{
+			CommitteeIndex:          commIdx,
 			CommitteeLength:         commLen,
 			ValidatorCommitteeIndex: valCommIdxB,
 			CommitteesAtSlot:        notZero,
@@ -220,11 +223,13 @@ func TestMemDBAggregator(t *testing.T) {
 	const queries = 3
 
 	for range queries {
-		agg := testutil.RandomAttestation()
+		agg := testutil.RandomDenebVersionedAttestation()
+		unsigned, err := core.NewVersionedAggregatedAttestation(agg)
+		require.NoError(t, err)
 		set := core.UnsignedDataSet{
-			testutil.RandomCorePubKey(t): core.NewAggregatedAttestation(agg),
+			testutil.RandomCorePubKey(t): unsigned,
 		}
-		slot := uint64(agg.Data.Slot)
+		slot := uint64(agg.Deneb.Data.Slot)
 
 		errCh := make(chan error, 1)
 		go func() {
@@ -232,16 +237,64 @@ func TestMemDBAggregator(t *testing.T) {
 			errCh <- err
 		}()
 
-		root, err := agg.Data.HashTreeRoot()
+		root, err := agg.Deneb.Data.HashTreeRoot()
 		require.NoError(t, err)
 		err = <-errCh
 		require.NoError(t, err)
-		resp, err := db.AwaitAggAttestation(ctx, slot, root)
+
+		// Pre-electra aggregates are queried by any committee index.
+		resp, err := db.AwaitAggAttestation(ctx, slot, uint64(agg.Deneb.Data.Index), root)
 		require.NoError(t, err)
 		require.Equal(t, agg, resp)
 	}
 }
 
+func TestMemDBElectraAggregator(t *testing.T) {
+	ctx := context.Background()
+	db := dutydb.NewMemDB(new(testDeadliner))
+
+	// Electra aggregates of different committees share the same attestation data.
+	data := testutil.RandomAttestationData()
+	data.Index = 0
+	slot := uint64(data.Slot)
+
+	root, err := data.HashTreeRoot()
+	require.NoError(t, err)
+
+	aggs := make(map[uint64]*eth2spec.VersionedAttestation)
+	set := make(core.UnsignedDataSet)
+	for _, commIdx := range []uint64{1, 2} {
+		att := testutil.RandomElectraAttestation()
+		att.Data = data
+		att.CommitteeBits = bitfield.NewBitvector64()
+		att.CommitteeBits.SetBitAt(commIdx, true)
+
+		aggs[commIdx] = &eth2spec.VersionedAttestation{
+			Version: eth2spec.DataVersionElectra,
+			Electra: att,
+		}
+
+		unsigned, err := core.NewVersionedAggregatedAttestation(aggs[commIdx])
+		require.NoError(t, err)
+		set[testutil.RandomCorePubKey(t)] = unsigned
+	}
+
+	err = db.Store(ctx, core.NewAggregatorDuty(slot), set)
+	require.NoError(t, err)
+
+	for commIdx, agg := range aggs {
+		resp, err := db.AwaitAggAttestation(ctx, slot, commIdx, root)
+		require.NoError(t, err)
+		require.Equal(t, agg, resp)
+	}
+
+	// Electra aggregates are not returned for other committee indices.
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = db.AwaitAggAttestation(ctx, slot, 3, root)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestMemDBSyncContribution(t *testing.T) {
 	t.Run("await sync contribution", func(t *testing.T) {
 		ctx := context.Background()
@@ -432,7 +485,7 @@ func TestDutyExpiry(t *testing.T) {
 	require.NoError(t, err)
 
 	// Ensure it exists
-	pk, err := db.PubKeyByAttestation(ctx, uint64(att1.Data.Slot), uint64(att1.Data.Index), att1.Duty.ValidatorCommitteeIndex)
+	pk, err := db.PubKeyByAttestation(ctx, uint64(att1.Data.Slot), uint64(att1.Duty.CommitteeIndex), att1.Duty.ValidatorCommitteeIndex)
 	require.NoError(t, err)
 	require.NotEmpty(t, pk)
 
@@ -448,7 +501,7 @@ func TestDutyExpiry(t *testing.T) {
 	require.NoError(t, err)
 
 	// Pubkey not found.
-	_, err = db.PubKeyByAttestation(ctx, uint64(att1.Data.Slot), uint64(att1.Data.Index), att1.Duty.ValidatorCommitteeIndex)
+	_, err = db.PubKeyByAttestation(ctx, uint64(att1.Data.Slot), uint64(att1.Duty.CommitteeIndex), att1.Duty.ValidatorCommitteeIndex)
 	require.Error(t, err)
 }
 
@@ -38,6 +38,15 @@ type Fetcher struct {
 	aggSigDBFunc     func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
 	awaitAttDataFunc func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)
 	builderEnabled   bool
+	builderFunc      func(core.PubKey) bool
+	graffitiFunc     func(core.PubKey) string
+}
+
+// DefaultGraffiti returns the default block graffiti identifying the charon version.
+func DefaultGraffiti() string {
+	// TODO(dhruv): replace hardcoded graffiti with the one from cluster-lock.json
+	commitSHA, _ := version.GitCommit()
+	return fmt.Sprintf("charon/%v-%s", version.Version, commitSHA)
 }
 
 // Subscribe registers a callback for fetched duties.
@@ -110,6 +119,18 @@ func (f *Fetcher) RegisterAwaitAttData(fn func(ctx context.Context, slot uint64,
 	f.awaitAttDataFunc = fn
 }
 
+// RegisterBuilderEnabled registers a function overriding whether builder blocks are enabled by validator.
+// Note: This is not thread safe and should only be called *before* Fetch.
+func (f *Fetcher) RegisterBuilderEnabled(fn func(core.PubKey) bool) {
+	f.builderFunc = fn
+}
+
+// RegisterGraffiti registers a function overriding the block graffiti by validator.
+// Note: This is not thread safe and should only be called *before* Fetch.
+func (f *Fetcher) RegisterGraffiti(fn func(core.PubKey) string) {
+	f.graffitiFunc = fn
+}
+
 // fetchAttesterData returns the fetched attestation data set for committees and validators in the arg set.
 func (f *Fetcher) fetchAttesterData(ctx context.Context, slot uint64, defSet core.DutyDefinitionSet,
 ) (core.UnsignedDataSet, error) {
@@ -157,7 +178,7 @@ func (f *Fetcher) fetchAttesterData(ctx context.Context, slot uint64, defSet cor
 // fetchAggregatorData fetches the attestation aggregation data.
 func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot uint64, defSet core.DutyDefinitionSet) (core.UnsignedDataSet, error) {
 	// We may have multiple aggregators in the same committee, use the same aggregated attestation in that case.
-	aggAttByCommIdx := make(map[eth2p0.CommitteeIndex]*eth2p0.Attestation)
+	aggAttByCommIdx := make(map[eth2p0.CommitteeIndex]*eth2spec.VersionedAttestation)
 
 	resp := make(core.UnsignedDataSet)
 	for pubkey, dutyDef := range defSet {
@@ -188,8 +209,8 @@ func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot uint64, defSet c
 
 		aggAtt, ok := aggAttByCommIdx[attDef.CommitteeIndex]
 		if ok {
-			resp[pubkey] = core.AggregatedAttestation{
-				Attestation: *aggAtt,
+			resp[pubkey] = core.VersionedAggregatedAttestation{
+				VersionedAttestation: *aggAtt,
 			}
 
 			// Skips querying aggregate attestation for aggregators of same committee.
@@ -211,6 +232,7 @@ func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot uint64, defSet c
 		opts := &eth2api.AggregateAttestationOpts{
 			Slot:                eth2p0.Slot(slot),
 			AttestationDataRoot: dataRoot,
+			CommitteeIndex:      attDef.CommitteeIndex,
 		}
 		eth2Resp, err := f.eth2Cl.AggregateAttestation(ctx, opts)
 		if err != nil {
@@ -218,7 +240,7 @@ func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot uint64, defSet c
 		}
 
 		aggAtt = eth2Resp.Data
-		if aggAtt == nil {
+		if aggAtt == nil || aggAtt.IsEmpty() {
 			// Some beacon nodes return nil if the root is not found, return retryable error.
 			// This could happen if the beacon node didn't subscribe to the correct subnet.
 			return core.UnsignedDataSet{}, errors.New("aggregate attestation not found by root (retryable)", z.Hex("root", dataRoot[:]))
@@ -226,8 +248,9 @@ func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot uint64, defSet c
 
 		aggAttByCommIdx[attDef.CommitteeIndex] = aggAtt
 
-		resp[pubkey] = core.AggregatedAttestation{
-			Attestation: *aggAtt,
+		resp[pubkey], err = core.NewVersionedAggregatedAttestation(aggAtt)
+		if err != nil {
+			return core.UnsignedDataSet{}, err
 		}
 	}
 
@@ -246,13 +269,21 @@ func (f *Fetcher) fetchProposerData(ctx context.Context, slot uint64, defSet cor
 
 		randao := randaoData.Signature().ToETH2()
 
-		// TODO(dhruv): replace hardcoded graffiti with the one from cluster-lock.json
+		graffitiStr := DefaultGraffiti()
+		if f.graffitiFunc != nil {
+			graffitiStr = f.graffitiFunc(pubkey)
+		}
+
 		var graffiti [32]byte
-		commitSHA, _ := version.GitCommit()
-		copy(graffiti[:], fmt.Sprintf("charon/%v-%s", version.Version, commitSHA))
+		copy(graffiti[:], graffitiStr)
+
+		builderEnabled := f.builderEnabled
+		if f.builderFunc != nil {
+			builderEnabled = f.builderFunc(pubkey)
+		}
 
 		var bbf uint64
-		if f.builderEnabled {
+		if builderEnabled {
 			// This gives maximum priority to builder blocks:
 			// https://ethereum.github.io/beacon-APIs/#/Validator/produceBlockV3
 			bbf = math.MaxUint64
@@ -374,6 +405,12 @@ func verifyFeeRecipient(ctx context.Context, proposal *eth2api.VersionedProposal
 		} else {
 			actualAddr = fmt.Sprintf("%#x", proposal.Deneb.Block.Body.ExecutionPayload.FeeRecipient)
 		}
+	case eth2spec.DataVersionElectra:
+		if proposal.Blinded {
+			actualAddr = fmt.Sprintf("%#x", proposal.ElectraBlinded.Body.ExecutionPayloadHeader.FeeRecipient)
+		} else {
+			actualAddr = fmt.Sprintf("%#x", proposal.Electra.Block.Body.ExecutionPayload.FeeRecipient)
+		}
 	default:
 		return
 	}
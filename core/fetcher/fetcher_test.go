@@ -9,6 +9,7 @@ import (
 	"math"
 	"testing"
 
+	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -140,7 +141,7 @@ func TestFetchAggregator(t *testing.T) {
 	require.NoError(t, err)
 
 	var aggAttCallCount int
-	bmock.AggregateAttestationFunc = func(ctx context.Context, slot eth2p0.Slot, root eth2p0.Root) (*eth2p0.Attestation, error) {
+	bmock.AggregateAttestationFunc = func(ctx context.Context, opts *eth2api.AggregateAttestationOpts) (*eth2spec.VersionedAttestation, error) {
 		aggAttCallCount--
 		if nilAggregate {
 			return nil, nil //nolint:nilnil // This reproduces what go-eth2-client does
@@ -148,8 +149,9 @@ func TestFetchAggregator(t *testing.T) {
 		for _, att := range attByCommIdx {
 			dataRoot, err := att.Data.HashTreeRoot()
 			require.NoError(t, err)
-			if dataRoot == root {
-				return att, nil
+			if dataRoot == opts.AttestationDataRoot {
+				require.Equal(t, att.Data.Index, opts.CommitteeIndex)
+				return &eth2spec.VersionedAttestation{Version: eth2spec.DataVersionDeneb, Deneb: att}, nil
 			}
 		}
 
@@ -173,12 +175,13 @@ func TestFetchAggregator(t *testing.T) {
 		require.Len(t, resDataSet, 2)
 
 		for _, aggAtt := range resDataSet {
-			aggregated, ok := aggAtt.(core.AggregatedAttestation)
+			aggregated, ok := aggAtt.(core.VersionedAggregatedAttestation)
 			require.True(t, ok)
+			require.Equal(t, eth2spec.DataVersionDeneb, aggregated.Version)
 
-			att, ok := attByCommIdx[uint64(aggregated.Attestation.Data.Index)]
+			att, ok := attByCommIdx[uint64(aggregated.Deneb.Data.Index)]
 			require.True(t, ok)
-			require.Equal(t, aggregated.Attestation, *att)
+			require.Equal(t, att, aggregated.Deneb)
 		}
 
 		return done
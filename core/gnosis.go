@@ -0,0 +1,187 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	ssz "github.com/ferranbt/fastssz"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// gnosisMaxWithdrawalsPerPayload is the gnosis/chiado MAX_WITHDRAWALS_PER_PAYLOAD preset value,
+// which differs from the mainnet value of 16 used by go-eth2-client.
+const gnosisMaxWithdrawalsPerPayload = 8
+
+// gnosisBlockRoot returns the hash tree root of the deneb block using the gnosis/chiado preset.
+// It only differs from the mainnet root in the withdrawals list limit of the execution payload.
+func gnosisBlockRoot(block *deneb.BeaconBlock) ([32]byte, error) {
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+
+	indx := hh.Index()
+
+	hh.PutUint64(uint64(block.Slot))
+	hh.PutUint64(uint64(block.ProposerIndex))
+	hh.PutBytes(block.ParentRoot[:])
+	hh.PutBytes(block.StateRoot[:])
+
+	if err := hashGnosisBody(hh, block.Body); err != nil {
+		return [32]byte{}, err
+	}
+
+	hh.Merkleize(indx)
+
+	root, err := hh.HashRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "hash root")
+	}
+
+	return root, nil
+}
+
+// hashGnosisBody hashes the deneb block body using the gnosis/chiado preset.
+func hashGnosisBody(hh ssz.HashWalker, body *deneb.BeaconBlockBody) error {
+	if body == nil || body.ETH1Data == nil || body.SyncAggregate == nil || body.ExecutionPayload == nil {
+		return errors.New("incomplete gnosis block body")
+	}
+
+	indx := hh.Index()
+
+	hh.PutBytes(body.RANDAOReveal[:])
+
+	if err := body.ETH1Data.HashTreeRootWith(hh); err != nil {
+		return errors.Wrap(err, "hash eth1 data")
+	}
+
+	hh.PutBytes(body.Graffiti[:])
+
+	if err := hashList(hh, body.ProposerSlashings, 16); err != nil {
+		return err
+	}
+	if err := hashList(hh, body.AttesterSlashings, 2); err != nil {
+		return err
+	}
+	if err := hashList(hh, body.Attestations, 128); err != nil {
+		return err
+	}
+	if err := hashList(hh, body.Deposits, 16); err != nil {
+		return err
+	}
+	if err := hashList(hh, body.VoluntaryExits, 16); err != nil {
+		return err
+	}
+
+	if err := body.SyncAggregate.HashTreeRootWith(hh); err != nil {
+		return errors.Wrap(err, "hash sync aggregate")
+	}
+
+	if err := hashGnosisPayload(hh, body.ExecutionPayload); err != nil {
+		return err
+	}
+
+	if err := hashList(hh, body.BLSToExecutionChanges, 16); err != nil {
+		return err
+	}
+
+	const maxCommitments = 4096
+	if len(body.BlobKZGCommitments) > maxCommitments {
+		return errors.New("too many blob kzg commitments", z.Int("count", len(body.BlobKZGCommitments)))
+	}
+
+	subIndx := hh.Index()
+	for _, commitment := range body.BlobKZGCommitments {
+		hh.PutBytes(commitment[:])
+	}
+	hh.MerkleizeWithMixin(subIndx, uint64(len(body.BlobKZGCommitments)), maxCommitments)
+
+	hh.Merkleize(indx)
+
+	return nil
+}
+
+// hashGnosisPayload hashes the deneb execution payload using the gnosis/chiado withdrawals limit.
+func hashGnosisPayload(hh ssz.HashWalker, payload *deneb.ExecutionPayload) error {
+	if payload.BaseFeePerGas == nil {
+		return errors.New("missing base fee per gas")
+	}
+
+	const (
+		maxExtraData       = 32
+		maxTransactions    = 1048576
+		maxTransactionSize = 1073741824
+	)
+
+	indx := hh.Index()
+
+	hh.PutBytes(payload.ParentHash[:])
+	hh.PutBytes(payload.FeeRecipient[:])
+	hh.PutBytes(payload.StateRoot[:])
+	hh.PutBytes(payload.ReceiptsRoot[:])
+	hh.PutBytes(payload.LogsBloom[:])
+	hh.PutBytes(payload.PrevRandao[:])
+	hh.PutUint64(payload.BlockNumber)
+	hh.PutUint64(payload.GasLimit)
+	hh.PutUint64(payload.GasUsed)
+	hh.PutUint64(payload.Timestamp)
+
+	if len(payload.ExtraData) > maxExtraData {
+		return errors.New("extra data too long")
+	}
+	elemIndx := hh.Index()
+	hh.Append(payload.ExtraData)
+	hh.MerkleizeWithMixin(elemIndx, uint64(len(payload.ExtraData)), (maxExtraData+31)/32)
+
+	// Base fee per gas is a little-endian uint256.
+	baseFeeBE := payload.BaseFeePerGas.Bytes32()
+	baseFee := make([]byte, 32)
+	for i := range 32 {
+		baseFee[i] = baseFeeBE[31-i]
+	}
+	hh.PutBytes(baseFee)
+
+	hh.PutBytes(payload.BlockHash[:])
+
+	if len(payload.Transactions) > maxTransactions {
+		return errors.New("too many transactions")
+	}
+	subIndx := hh.Index()
+	for _, tx := range payload.Transactions {
+		if len(tx) > maxTransactionSize {
+			return errors.New("transaction too long")
+		}
+		elemIndx := hh.Index()
+		hh.AppendBytes32(tx)
+		hh.MerkleizeWithMixin(elemIndx, uint64(len(tx)), (maxTransactionSize+31)/32)
+	}
+	hh.MerkleizeWithMixin(subIndx, uint64(len(payload.Transactions)), maxTransactions)
+
+	if err := hashList(hh, payload.Withdrawals, gnosisMaxWithdrawalsPerPayload); err != nil {
+		return err
+	}
+
+	hh.PutUint64(payload.BlobGasUsed)
+	hh.PutUint64(payload.ExcessBlobGas)
+
+	hh.Merkleize(indx)
+
+	return nil
+}
+
+// hashList hashes the ssz list of containers with the provided limit.
+func hashList[T interface{ HashTreeRootWith(ssz.HashWalker) error }](hh ssz.HashWalker, elems []T, limit uint64) error {
+	if uint64(len(elems)) > limit {
+		return errors.New("ssz list too long", z.Int("len", len(elems)), z.U64("limit", limit))
+	}
+
+	subIndx := hh.Index()
+	for _, elem := range elems {
+		if err := elem.HashTreeRootWith(hh); err != nil {
+			return errors.Wrap(err, "hash list element")
+		}
+	}
+	hh.MerkleizeWithMixin(subIndx, uint64(len(elems)), limit)
+
+	return nil
+}
@@ -19,9 +19,10 @@ import (
 )
 
 const (
-	topicVersion  = "version"
-	topicProtocol = "protocol"
-	topicProposal = "proposal"
+	topicVersion   = "version"
+	topicProtocol  = "protocol"
+	topicProposal  = "proposal"
+	topicOverrides = "overrides"
 
 	// maxResults limits the number of results to keep.
 	maxResults = 100
@@ -30,8 +31,9 @@ const (
 )
 
 // New returns a new infosync component.
+// The overridesHash function returns the hash of the local proposer overrides that should be identical for all peers.
 func New(prioritiser *priority.Component, versions []version.SemVer, protocols []protocol.ID,
-	proposals []core.ProposalType,
+	proposals []core.ProposalType, overridesHash func() string,
 ) *Component {
 	// Add a mock alpha protocol if alpha features enabled in order to test infosync in prod.
 	// TODO(corver): Remove this once we have an actual use case.
@@ -40,10 +42,11 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 	}
 
 	c := &Component{
-		prioritiser: prioritiser,
-		versions:    versions,
-		protocols:   protocols,
-		proposals:   proposals,
+		prioritiser:   prioritiser,
+		versions:      versions,
+		protocols:     protocols,
+		proposals:     proposals,
+		overridesHash: overridesHash,
 	}
 
 	prioritiser.Subscribe(func(ctx context.Context, duty core.Duty, results []priority.TopicResult) error {
@@ -62,6 +65,10 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 					res.proposals = append(res.proposals, core.ProposalType(prio))
 				}
 			}
+
+			if result.Topic == topicOverrides {
+				c.checkOverrides(ctx, result.PrioritiesOnly())
+			}
 		}
 
 		log.Debug(ctx, "Infosync completed", fields...)
@@ -77,10 +84,11 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 }
 
 type Component struct {
-	prioritiser *priority.Component
-	versions    []version.SemVer
-	protocols   []protocol.ID
-	proposals   []core.ProposalType
+	prioritiser   *priority.Component
+	versions      []version.SemVer
+	protocols     []protocol.ID
+	proposals     []core.ProposalType
+	overridesHash func() string
 
 	mu      sync.Mutex
 	results []result
@@ -124,6 +132,20 @@ func (c *Component) Proposals(slot uint64) []core.ProposalType {
 	return resp
 }
 
+// checkOverrides warns if the local proposer overrides are not the only cluster wide agreed overrides.
+// Note that a minority of peers with different overrides only detect the mismatch locally.
+func (c *Component) checkOverrides(ctx context.Context, hashes []string) {
+	local := c.overridesHash()
+	if len(hashes) == 1 && hashes[0] == local {
+		overridesMismatchGauge.Set(0)
+		return
+	}
+
+	overridesMismatchGauge.Set(1)
+	log.Warn(ctx, "Proposer overrides mismatch cluster wide agreed overrides, ensure all peers use the same overrides file", nil,
+		z.Str("local", local), z.Any("cluster", hashes))
+}
+
 // addResult adds the result to the results if it is different from the last result.
 func (c *Component) addResult(result result) {
 	c.mu.Lock()
@@ -155,6 +177,10 @@ func (c *Component) Trigger(ctx context.Context, slot uint64) error {
 		priority.TopicProposal{
 			Topic:      topicProposal,
 			Priorities: proposalsToStrings(c.proposals),
+		},
+		priority.TopicProposal{
+			Topic:      topicOverrides,
+			Priorities: []string{c.overridesHash()},
 		})
 }
 
@@ -0,0 +1,16 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package infosync
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var overridesMismatchGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "core",
+	Subsystem: "infosync",
+	Name:      "overrides_mismatch",
+	Help:      "Set to 1 if the local proposer overrides differ from the cluster wide agreed overrides, else 0",
+})
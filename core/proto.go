@@ -62,11 +62,18 @@ func ParSignedDataFromProto(typ DutyType, data *pbv1.ParSignedData) (_ ParSigned
 	var signedData SignedData
 	switch typ {
 	case DutyAttester:
-		var a Attestation
-		if err := unmarshal(data.GetData(), &a); err != nil {
+		var a VersionedAttestation
+		if err := unmarshal(data.GetData(), &a); err == nil {
+			signedData = a
+			break
+		}
+
+		// Fallback to legacy attestations sent by peers that do not support versioned attestations.
+		var legacy Attestation
+		if err := unmarshal(data.GetData(), &legacy); err != nil {
 			return ParSignedData{}, errors.Wrap(err, "unmarshal attestation")
 		}
-		signedData = a
+		signedData = versionedAttestationFromLegacy(legacy)
 	case DutyProposer:
 		var b VersionedSignedProposal
 		if err := unmarshal(data.GetData(), &b); err != nil {
@@ -106,11 +113,18 @@ func ParSignedDataFromProto(typ DutyType, data *pbv1.ParSignedData) (_ ParSigned
 		}
 		signedData = s
 	case DutyAggregator:
-		var s SignedAggregateAndProof
-		if err := unmarshal(data.GetData(), &s); err != nil {
+		var s VersionedSignedAggregateAndProof
+		if err := unmarshal(data.GetData(), &s); err == nil {
+			signedData = s
+			break
+		}
+
+		// Fallback to legacy signed aggregate and proofs sent by peers that do not support versioned types.
+		var legacy SignedAggregateAndProof
+		if err := unmarshal(data.GetData(), &legacy); err != nil {
 			return ParSignedData{}, errors.Wrap(err, "unmarshal signed aggregate and proof")
 		}
-		signedData = s
+		signedData = versionedSignedAggregateAndProofFromLegacy(legacy)
 	case DutySyncMessage:
 		var s SignedSyncMessage
 		if err := unmarshal(data.GetData(), &s); err != nil {
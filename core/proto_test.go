@@ -7,7 +7,7 @@ import (
 	"math/rand"
 	"testing"
 
-	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
@@ -33,7 +33,11 @@ func TestParSignedDataSetProto(t *testing.T) {
 	}{
 		{
 			Type: core.DutyAttester,
-			Data: core.Attestation{Attestation: *testutil.RandomAttestation()},
+			Data: core.VersionedAttestation{VersionedAttestation: *testutil.RandomDenebVersionedAttestation()},
+		},
+		{
+			Type: core.DutyAttester,
+			Data: core.VersionedAttestation{VersionedAttestation: *testutil.RandomElectraVersionedAttestation()},
 		},
 		{
 			Type: core.DutyExit,
@@ -59,12 +63,17 @@ func TestParSignedDataSetProto(t *testing.T) {
 			Type: core.DutyPrepareAggregator,
 			Data: testutil.RandomCoreBeaconCommitteeSelection(),
 		},
+		{
+			Type: core.DutyProposer,
+			Data: testutil.RandomElectraCoreVersionedSignedProposal(),
+		},
 		{
 			Type: core.DutyAggregator,
-			Data: core.SignedAggregateAndProof{SignedAggregateAndProof: eth2p0.SignedAggregateAndProof{
-				Message:   testutil.RandomAggregateAndProof(),
-				Signature: testutil.RandomEth2Signature(),
-			}},
+			Data: core.VersionedSignedAggregateAndProof{VersionedSignedAggregateAndProof: *testutil.RandomDenebVersionedSignedAggregateAndProof()},
+		},
+		{
+			Type: core.DutyAggregator,
+			Data: core.VersionedSignedAggregateAndProof{VersionedSignedAggregateAndProof: *testutil.RandomElectraVersionedSignedAggregateAndProof()},
 		},
 		{
 			Type: core.DutySyncMessage,
@@ -122,7 +131,14 @@ func TestUnsignedDataToProto(t *testing.T) {
 		},
 		{
 			Type: core.DutyAggregator,
-			Data: core.NewAggregatedAttestation(testutil.RandomAttestation()),
+			Data: core.VersionedAggregatedAttestation{VersionedAttestation: *testutil.RandomDenebVersionedAttestation()},
+		},
+		{
+			Type: core.DutyAggregator,
+			Data: core.VersionedAggregatedAttestation{VersionedAttestation: eth2spec.VersionedAttestation{
+				Version: eth2spec.DataVersionElectra,
+				Electra: testutil.RandomElectraAttestation(),
+			}},
 		},
 		{
 			Type: core.DutySyncContribution,
@@ -231,16 +247,40 @@ func TestMarshalAttestation(t *testing.T) {
 	require.Equal(t, &att, a)
 }
 
+func TestLegacyParSignedDataFromProto(t *testing.T) {
+	att := testutil.RandomAttestation()
+	pb, err := core.ParSignedDataToProto(core.NewPartialAttestation(att, 1))
+	require.NoError(t, err)
+
+	parSig, err := core.ParSignedDataFromProto(core.DutyAttester, pb)
+	require.NoError(t, err)
+	require.Equal(t, core.VersionedAttestation{VersionedAttestation: eth2spec.VersionedAttestation{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   att,
+	}}, parSig.SignedData)
+
+	agg := testutil.RandomSignedAggregateAndProof()
+	pb, err = core.ParSignedDataToProto(core.NewPartialSignedAggregateAndProof(agg, 1))
+	require.NoError(t, err)
+
+	parSig, err = core.ParSignedDataFromProto(core.DutyAggregator, pb)
+	require.NoError(t, err)
+	require.Equal(t, core.VersionedSignedAggregateAndProof{VersionedSignedAggregateAndProof: eth2spec.VersionedSignedAggregateAndProof{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   agg,
+	}}, parSig.SignedData)
+}
+
 func randomSignedData(t *testing.T) map[core.DutyType]core.SignedData {
 	t.Helper()
 
 	return map[core.DutyType]core.SignedData{
-		core.DutyAttester:                core.NewAttestation(testutil.RandomAttestation()),
+		core.DutyAttester:                core.VersionedAttestation{VersionedAttestation: *testutil.RandomElectraVersionedAttestation()},
 		core.DutyExit:                    core.NewSignedVoluntaryExit(testutil.RandomExit()),
 		core.DutyRandao:                  core.SignedRandao{SignedEpoch: eth2util.SignedEpoch{Epoch: testutil.RandomEpoch(), Signature: testutil.RandomEth2Signature()}},
 		core.DutyProposer:                testutil.RandomBellatrixCoreVersionedSignedProposal(),
 		core.DutyPrepareAggregator:       testutil.RandomCoreBeaconCommitteeSelection(),
-		core.DutyAggregator:              core.NewSignedAggregateAndProof(testutil.RandomSignedAggregateAndProof()),
+		core.DutyAggregator:              core.VersionedSignedAggregateAndProof{VersionedSignedAggregateAndProof: *testutil.RandomElectraVersionedSignedAggregateAndProof()},
 		core.DutyPrepareSyncContribution: core.NewSyncCommitteeSelection(testutil.RandomSyncCommitteeSelection()),
 		core.DutySyncContribution:        core.NewSignedSyncContributionAndProof(testutil.RandomSignedSyncContributionAndProof()),
 	}
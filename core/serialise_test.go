@@ -23,17 +23,20 @@ var coreTypeFuncs = []func() any{
 		return ret
 	},
 	func() any { return new(core.Attestation) },
+	func() any { return new(core.VersionedAttestation) },
 	func() any { return new(core.Signature) },
 	func() any { return new(core.SignedVoluntaryExit) },
 	func() any { return new(core.SignedRandao) },
 	func() any { return new(core.BeaconCommitteeSelection) },
 	func() any { return new(core.SignedAggregateAndProof) },
+	func() any { return new(core.VersionedSignedAggregateAndProof) },
 	func() any { return new(core.SignedSyncMessage) },
 	func() any { return new(core.SyncContributionAndProof) },
 	func() any { return new(core.SignedSyncContributionAndProof) },
 	func() any { return new(core.SyncCommitteeSelection) },
 	func() any { return new(core.AttestationData) },
 	func() any { return new(core.AggregatedAttestation) },
+	func() any { return new(core.VersionedAggregatedAttestation) },
 	func() any { return new(core.VersionedProposal) },
 	func() any { return new(core.SyncContribution) },
 }
@@ -11,17 +11,19 @@ import (
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2electra "github.com/attestantio/go-eth2-client/api/v1/electra"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
-	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 	"github.com/obolnetwork/charon/eth2util/signing"
@@ -30,12 +32,14 @@ import (
 var (
 	_ SignedData = VersionedSignedProposal{}
 	_ SignedData = Attestation{}
+	_ SignedData = VersionedAttestation{}
 	_ SignedData = Signature{}
 	_ SignedData = SignedVoluntaryExit{}
 	_ SignedData = VersionedSignedValidatorRegistration{}
 	_ SignedData = SignedRandao{}
 	_ SignedData = BeaconCommitteeSelection{}
 	_ SignedData = SignedAggregateAndProof{}
+	_ SignedData = VersionedSignedAggregateAndProof{}
 	_ SignedData = SignedSyncMessage{}
 	_ SignedData = SyncContributionAndProof{}
 	_ SignedData = SignedSyncContributionAndProof{}
@@ -45,12 +49,16 @@ var (
 	_ ssz.Marshaler   = VersionedSignedProposal{}
 	_ ssz.Marshaler   = Attestation{}
 	_ ssz.Marshaler   = SignedAggregateAndProof{}
+	_ ssz.Marshaler   = VersionedAttestation{}
+	_ ssz.Marshaler   = VersionedSignedAggregateAndProof{}
 	_ ssz.Marshaler   = SignedSyncMessage{}
 	_ ssz.Marshaler   = SyncContributionAndProof{}
 	_ ssz.Marshaler   = SignedSyncContributionAndProof{}
 	_ ssz.Unmarshaler = new(VersionedSignedProposal)
 	_ ssz.Unmarshaler = new(Attestation)
 	_ ssz.Unmarshaler = new(SignedAggregateAndProof)
+	_ ssz.Unmarshaler = new(VersionedAttestation)
+	_ ssz.Unmarshaler = new(VersionedSignedAggregateAndProof)
 	_ ssz.Unmarshaler = new(SignedSyncMessage)
 	_ ssz.Unmarshaler = new(SyncContributionAndProof)
 	_ ssz.Unmarshaler = new(SignedSyncContributionAndProof)
@@ -160,6 +168,13 @@ func NewVersionedSignedProposal(proposal *eth2api.VersionedSignedProposal) (Vers
 		if proposal.DenebBlinded == nil && proposal.Blinded {
 			return VersionedSignedProposal{}, errors.New("no deneb blinded proposal")
 		}
+	case eth2spec.DataVersionElectra:
+		if proposal.Electra == nil && !proposal.Blinded {
+			return VersionedSignedProposal{}, errors.New("no electra proposal")
+		}
+		if proposal.ElectraBlinded == nil && proposal.Blinded {
+			return VersionedSignedProposal{}, errors.New("no electra blinded proposal")
+		}
 	default:
 		return VersionedSignedProposal{}, errors.New("unknown version")
 	}
@@ -187,6 +202,7 @@ func NewVersionedSignedProposalFromBlindedProposal(bp *eth2api.VersionedSignedBl
 		BellatrixBlinded: bp.Bellatrix,
 		CapellaBlinded:   bp.Capella,
 		DenebBlinded:     bp.Deneb,
+		ElectraBlinded:   bp.Electra,
 	})
 	if err != nil {
 		return VersionedSignedProposal{}, err
@@ -202,6 +218,7 @@ func NewPartialVersionedSignedBlindedProposal(bp *eth2api.VersionedSignedBlinded
 		BellatrixBlinded: bp.Bellatrix,
 		CapellaBlinded:   bp.Capella,
 		DenebBlinded:     bp.Deneb,
+		ElectraBlinded:   bp.Electra,
 	})
 	if err != nil {
 		return ParSignedData{}, err
@@ -228,6 +245,7 @@ func (p VersionedSignedProposal) ToBlinded() (eth2api.VersionedSignedBlindedProp
 		Bellatrix: p.BellatrixBlinded,
 		Capella:   p.CapellaBlinded,
 		Deneb:     p.DenebBlinded,
+		Electra:   p.ElectraBlinded,
 	}, nil
 }
 
@@ -256,6 +274,12 @@ func (p VersionedSignedProposal) Signature() Signature {
 		}
 
 		return SigFromETH2(p.Deneb.SignedBlock.Signature)
+	case eth2spec.DataVersionElectra:
+		if p.Blinded {
+			return SigFromETH2(p.ElectraBlinded.Signature)
+		}
+
+		return SigFromETH2(p.Electra.SignedBlock.Signature)
 	default:
 		panic("unknown version") // Note this is avoided by using `NewVersionedSignedProposal`.
 	}
@@ -291,6 +315,12 @@ func (p VersionedSignedProposal) SetSignature(sig Signature) (SignedData, error)
 		} else {
 			resp.Deneb.SignedBlock.Signature = sig.ToETH2()
 		}
+	case eth2spec.DataVersionElectra:
+		if resp.Blinded {
+			resp.ElectraBlinded.Signature = sig.ToETH2()
+		} else {
+			resp.Electra.SignedBlock.Signature = sig.ToETH2()
+		}
 	default:
 		return nil, errors.New("unknown type")
 	}
@@ -323,12 +353,16 @@ func (p VersionedSignedProposal) MessageRoot() ([32]byte, error) {
 		}
 
 		if featureset.Enabled(featureset.GnosisBlockHotfix) {
-			// translate p.Deneb.SignedBlock to its Gnosis associate and return its hash tree root
-			sbGnosis := deneb.BeaconBlockToGnosis(*p.Deneb.SignedBlock.Message)
-			return sbGnosis.HashTreeRoot()
+			return gnosisBlockRoot(p.Deneb.SignedBlock.Message)
 		}
 
 		return p.Deneb.SignedBlock.Message.HashTreeRoot()
+	case eth2spec.DataVersionElectra:
+		if p.Blinded {
+			return p.ElectraBlinded.Message.HashTreeRoot()
+		}
+
+		return p.Electra.SignedBlock.Message.HashTreeRoot()
 	default:
 		panic("unknown version") // Note this is avoided by using `NewVersionedSignedProposal`.
 	}
@@ -376,6 +410,12 @@ func (p VersionedSignedProposal) MarshalJSON() ([]byte, error) {
 		} else {
 			marshaller = p.VersionedSignedProposal.Deneb
 		}
+	case eth2spec.DataVersionElectra:
+		if p.Blinded {
+			marshaller = p.VersionedSignedProposal.ElectraBlinded
+		} else {
+			marshaller = p.VersionedSignedProposal.Electra
+		}
 	default:
 		return nil, errors.New("unknown version")
 	}
@@ -464,6 +504,20 @@ func (p *VersionedSignedProposal) UnmarshalJSON(input []byte) error {
 			}
 			resp.Deneb = block
 		}
+	case eth2spec.DataVersionElectra:
+		if raw.Blinded {
+			block := new(eth2electra.SignedBlindedBeaconBlock)
+			if err := json.Unmarshal(raw.Block, &block); err != nil {
+				return errors.Wrap(err, "unmarshal electra blinded")
+			}
+			resp.ElectraBlinded = block
+		} else {
+			block := new(eth2electra.SignedBlockContents)
+			if err := json.Unmarshal(raw.Block, &block); err != nil {
+				return errors.Wrap(err, "unmarshal electra")
+			}
+			resp.Electra = block
+		}
 	default:
 		return errors.New("unknown version")
 	}
@@ -560,6 +614,237 @@ func (a *Attestation) UnmarshalSSZ(b []byte) error {
 	return a.Attestation.UnmarshalSSZ(b)
 }
 
+// NewVersionedAttestation validates and returns a new wrapped VersionedAttestation.
+func NewVersionedAttestation(att *eth2spec.VersionedAttestation) (VersionedAttestation, error) {
+	if err := validateVersionedAttestation(att); err != nil {
+		return VersionedAttestation{}, err
+	}
+
+	return VersionedAttestation{VersionedAttestation: *att}, nil
+}
+
+// NewPartialVersionedAttestation validates and returns a new partially signed VersionedAttestation.
+func NewPartialVersionedAttestation(att *eth2spec.VersionedAttestation, shareIdx int) (ParSignedData, error) {
+	wrap, err := NewVersionedAttestation(att)
+	if err != nil {
+		return ParSignedData{}, err
+	}
+
+	return ParSignedData{
+		SignedData: wrap,
+		ShareIdx:   shareIdx,
+	}, nil
+}
+
+// versionedAttestationFromLegacy returns a VersionedAttestation from a legacy unversioned attestation.
+// Legacy attestations are only produced by peers that do not support versioned attestations yet,
+// these are only supported on pre-electra networks, so the attestation is assumed to be deneb.
+func versionedAttestationFromLegacy(att Attestation) VersionedAttestation {
+	return VersionedAttestation{VersionedAttestation: eth2spec.VersionedAttestation{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   &att.Attestation,
+	}}
+}
+
+// VersionedAttestation is a signed versioned attestation and implements SignedData.
+// The optional ValidatorIndex is required to submit electra attestations as single attestations.
+type VersionedAttestation struct {
+	eth2spec.VersionedAttestation
+}
+
+func (a VersionedAttestation) MessageRoot() ([32]byte, error) {
+	data, err := a.VersionedAttestation.Data()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "get attestation data")
+	}
+
+	return data.HashTreeRoot()
+}
+
+func (a VersionedAttestation) Clone() (SignedData, error) {
+	return a.clone()
+}
+
+// clone returns a copy of the VersionedAttestation.
+// It is similar to Clone that returns the SignedData interface.
+func (a VersionedAttestation) clone() (VersionedAttestation, error) {
+	var resp VersionedAttestation
+	err := cloneJSONMarshaler(a, &resp)
+	if err != nil {
+		return VersionedAttestation{}, errors.Wrap(err, "clone versioned attestation")
+	}
+
+	return resp, nil
+}
+
+func (a VersionedAttestation) Signature() Signature {
+	sig, err := a.VersionedAttestation.Signature()
+	if err != nil {
+		panic(err) // Note this is avoided by using `NewVersionedAttestation`.
+	}
+
+	return SigFromETH2(sig)
+}
+
+func (a VersionedAttestation) SetSignature(sig Signature) (SignedData, error) {
+	resp, err := a.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Version {
+	// No attestation nil checks since `NewVersionedAttestation` assumed.
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0.Signature = sig.ToETH2()
+	case eth2spec.DataVersionAltair:
+		resp.Altair.Signature = sig.ToETH2()
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix.Signature = sig.ToETH2()
+	case eth2spec.DataVersionCapella:
+		resp.Capella.Signature = sig.ToETH2()
+	case eth2spec.DataVersionDeneb:
+		resp.Deneb.Signature = sig.ToETH2()
+	case eth2spec.DataVersionElectra:
+		resp.Electra.Signature = sig.ToETH2()
+	default:
+		return nil, errors.New("unknown version")
+	}
+
+	return resp, nil
+}
+
+func (a VersionedAttestation) MarshalJSON() ([]byte, error) {
+	return marshalVersionedAttestationJSON(a.VersionedAttestation)
+}
+
+func (a *VersionedAttestation) UnmarshalJSON(input []byte) error {
+	att, err := unmarshalVersionedAttestationJSON(input)
+	if err != nil {
+		return err
+	}
+
+	a.VersionedAttestation = att
+
+	return nil
+}
+
+// validateVersionedAttestation returns an error if the versioned attestation doesn't contain its versioned value.
+func validateVersionedAttestation(att *eth2spec.VersionedAttestation) error {
+	var ok bool
+	switch att.Version {
+	case eth2spec.DataVersionPhase0:
+		ok = att.Phase0 != nil
+	case eth2spec.DataVersionAltair:
+		ok = att.Altair != nil
+	case eth2spec.DataVersionBellatrix:
+		ok = att.Bellatrix != nil
+	case eth2spec.DataVersionCapella:
+		ok = att.Capella != nil
+	case eth2spec.DataVersionDeneb:
+		ok = att.Deneb != nil
+	case eth2spec.DataVersionElectra:
+		ok = att.Electra != nil
+	default:
+		return errors.New("unknown version")
+	}
+
+	if !ok {
+		return errors.New("no attestation", z.Str("version", att.Version.String()))
+	}
+
+	return nil
+}
+
+// marshalVersionedAttestationJSON marshals the versioned attestation using a custom versioned serialiser.
+func marshalVersionedAttestationJSON(att eth2spec.VersionedAttestation) ([]byte, error) {
+	var marshaller json.Marshaler
+	switch att.Version {
+	case eth2spec.DataVersionPhase0:
+		marshaller = att.Phase0
+	case eth2spec.DataVersionAltair:
+		marshaller = att.Altair
+	case eth2spec.DataVersionBellatrix:
+		marshaller = att.Bellatrix
+	case eth2spec.DataVersionCapella:
+		marshaller = att.Capella
+	case eth2spec.DataVersionDeneb:
+		marshaller = att.Deneb
+	case eth2spec.DataVersionElectra:
+		marshaller = att.Electra
+	default:
+		return nil, errors.New("unknown version")
+	}
+
+	attestation, err := marshaller.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal attestation")
+	}
+
+	version, err := eth2util.DataVersionFromETH2(att.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert version")
+	}
+
+	resp, err := json.Marshal(versionedRawAttestationJSON{
+		Version:        version,
+		ValidatorIndex: att.ValidatorIndex,
+		Attestation:    attestation,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal wrapper")
+	}
+
+	return resp, nil
+}
+
+// unmarshalVersionedAttestationJSON unmarshals a versioned attestation using a custom versioned serialiser.
+// It returns an error if the version or attestation is missing, so legacy attestations are not accepted.
+func unmarshalVersionedAttestationJSON(input []byte) (eth2spec.VersionedAttestation, error) {
+	var raw versionedRawAttestationJSON
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return eth2spec.VersionedAttestation{}, errors.Wrap(err, "unmarshal attestation")
+	}
+
+	if len(raw.Attestation) == 0 {
+		return eth2spec.VersionedAttestation{}, errors.New("missing attestation")
+	}
+
+	var resp *eth2spec.VersionedAttestation
+	switch raw.Version {
+	case eth2util.DataVersionPhase0, eth2util.DataVersionAltair, eth2util.DataVersionBellatrix,
+		eth2util.DataVersionCapella, eth2util.DataVersionDeneb:
+		att := new(eth2p0.Attestation)
+		if err := json.Unmarshal(raw.Attestation, &att); err != nil {
+			return eth2spec.VersionedAttestation{}, errors.Wrap(err, "unmarshal attestation", z.Str("version", raw.Version.String()))
+		}
+
+		var err error
+		resp, err = eth2util.NewPhase0VersionedAttestation(raw.Version.ToETH2(), att)
+		if err != nil {
+			return eth2spec.VersionedAttestation{}, err
+		}
+	case eth2util.DataVersionElectra:
+		att := new(electra.Attestation)
+		if err := json.Unmarshal(raw.Attestation, &att); err != nil {
+			return eth2spec.VersionedAttestation{}, errors.Wrap(err, "unmarshal electra attestation")
+		}
+		resp = &eth2spec.VersionedAttestation{Version: eth2spec.DataVersionElectra, Electra: att}
+	default:
+		return eth2spec.VersionedAttestation{}, errors.New("unknown version")
+	}
+
+	resp.ValidatorIndex = raw.ValidatorIndex
+
+	return *resp, nil
+}
+
+// versionedRawAttestationJSON is a custom VersionedAttestation serialiser.
+type versionedRawAttestationJSON struct {
+	Version        eth2util.DataVersion   `json:"version"`
+	ValidatorIndex *eth2p0.ValidatorIndex `json:"validator_index,omitempty"`
+	Attestation    json.RawMessage        `json:"attestation"`
+}
+
 // NewSignedVoluntaryExit is a convenience function that returns a new signed voluntary exit.
 func NewSignedVoluntaryExit(exit *eth2p0.SignedVoluntaryExit) SignedVoluntaryExit {
 	return SignedVoluntaryExit{SignedVoluntaryExit: *exit}
@@ -1033,6 +1318,216 @@ func (s *SignedAggregateAndProof) UnmarshalSSZ(b []byte) error {
 	return s.SignedAggregateAndProof.UnmarshalSSZ(b)
 }
 
+// NewVersionedSignedAggregateAndProof validates and returns a new wrapped VersionedSignedAggregateAndProof.
+func NewVersionedSignedAggregateAndProof(data *eth2spec.VersionedSignedAggregateAndProof) (VersionedSignedAggregateAndProof, error) {
+	var ok bool
+	switch data.Version {
+	case eth2spec.DataVersionPhase0:
+		ok = data.Phase0 != nil
+	case eth2spec.DataVersionAltair:
+		ok = data.Altair != nil
+	case eth2spec.DataVersionBellatrix:
+		ok = data.Bellatrix != nil
+	case eth2spec.DataVersionCapella:
+		ok = data.Capella != nil
+	case eth2spec.DataVersionDeneb:
+		ok = data.Deneb != nil
+	case eth2spec.DataVersionElectra:
+		ok = data.Electra != nil
+	default:
+		return VersionedSignedAggregateAndProof{}, errors.New("unknown version")
+	}
+
+	if !ok {
+		return VersionedSignedAggregateAndProof{}, errors.New("no signed aggregate and proof", z.Str("version", data.Version.String()))
+	}
+
+	return VersionedSignedAggregateAndProof{VersionedSignedAggregateAndProof: *data}, nil
+}
+
+// NewPartialVersionedSignedAggregateAndProof validates and returns a new partially signed VersionedSignedAggregateAndProof.
+func NewPartialVersionedSignedAggregateAndProof(data *eth2spec.VersionedSignedAggregateAndProof, shareIdx int) (ParSignedData, error) {
+	wrap, err := NewVersionedSignedAggregateAndProof(data)
+	if err != nil {
+		return ParSignedData{}, err
+	}
+
+	return ParSignedData{
+		SignedData: wrap,
+		ShareIdx:   shareIdx,
+	}, nil
+}
+
+// versionedSignedAggregateAndProofFromLegacy returns a VersionedSignedAggregateAndProof from a legacy
+// unversioned signed aggregate and proof. See versionedAttestationFromLegacy for why it is assumed to be deneb.
+func versionedSignedAggregateAndProofFromLegacy(s SignedAggregateAndProof) VersionedSignedAggregateAndProof {
+	return VersionedSignedAggregateAndProof{VersionedSignedAggregateAndProof: eth2spec.VersionedSignedAggregateAndProof{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   &s.SignedAggregateAndProof,
+	}}
+}
+
+// VersionedSignedAggregateAndProof wraps eth2spec.VersionedSignedAggregateAndProof and implements SignedData.
+type VersionedSignedAggregateAndProof struct {
+	eth2spec.VersionedSignedAggregateAndProof
+}
+
+func (s VersionedSignedAggregateAndProof) MessageRoot() ([32]byte, error) {
+	switch s.Version {
+	// No nil checks since `NewVersionedSignedAggregateAndProof` assumed.
+	case eth2spec.DataVersionPhase0:
+		return s.Phase0.Message.HashTreeRoot()
+	case eth2spec.DataVersionAltair:
+		return s.Altair.Message.HashTreeRoot()
+	case eth2spec.DataVersionBellatrix:
+		return s.Bellatrix.Message.HashTreeRoot()
+	case eth2spec.DataVersionCapella:
+		return s.Capella.Message.HashTreeRoot()
+	case eth2spec.DataVersionDeneb:
+		return s.Deneb.Message.HashTreeRoot()
+	case eth2spec.DataVersionElectra:
+		return s.Electra.Message.HashTreeRoot()
+	default:
+		return [32]byte{}, errors.New("unknown version")
+	}
+}
+
+func (s VersionedSignedAggregateAndProof) Signature() Signature {
+	sig, err := s.VersionedSignedAggregateAndProof.Signature()
+	if err != nil {
+		panic(err) // Note this is avoided by using `NewVersionedSignedAggregateAndProof`.
+	}
+
+	return SigFromETH2(sig)
+}
+
+func (s VersionedSignedAggregateAndProof) SetSignature(sig Signature) (SignedData, error) {
+	resp, err := s.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Version {
+	// No nil checks since `NewVersionedSignedAggregateAndProof` assumed.
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0.Signature = sig.ToETH2()
+	case eth2spec.DataVersionAltair:
+		resp.Altair.Signature = sig.ToETH2()
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix.Signature = sig.ToETH2()
+	case eth2spec.DataVersionCapella:
+		resp.Capella.Signature = sig.ToETH2()
+	case eth2spec.DataVersionDeneb:
+		resp.Deneb.Signature = sig.ToETH2()
+	case eth2spec.DataVersionElectra:
+		resp.Electra.Signature = sig.ToETH2()
+	default:
+		return nil, errors.New("unknown version")
+	}
+
+	return resp, nil
+}
+
+func (s VersionedSignedAggregateAndProof) Clone() (SignedData, error) {
+	return s.clone()
+}
+
+func (s VersionedSignedAggregateAndProof) clone() (VersionedSignedAggregateAndProof, error) {
+	var resp VersionedSignedAggregateAndProof
+	err := cloneJSONMarshaler(s, &resp)
+	if err != nil {
+		return VersionedSignedAggregateAndProof{}, errors.Wrap(err, "clone versioned signed aggregate and proof")
+	}
+
+	return resp, nil
+}
+
+func (s VersionedSignedAggregateAndProof) MarshalJSON() ([]byte, error) {
+	var marshaller json.Marshaler
+	switch s.Version {
+	// No nil checks since `NewVersionedSignedAggregateAndProof` assumed.
+	case eth2spec.DataVersionPhase0:
+		marshaller = s.Phase0
+	case eth2spec.DataVersionAltair:
+		marshaller = s.Altair
+	case eth2spec.DataVersionBellatrix:
+		marshaller = s.Bellatrix
+	case eth2spec.DataVersionCapella:
+		marshaller = s.Capella
+	case eth2spec.DataVersionDeneb:
+		marshaller = s.Deneb
+	case eth2spec.DataVersionElectra:
+		marshaller = s.Electra
+	default:
+		return nil, errors.New("unknown version")
+	}
+
+	aggregateAndProof, err := marshaller.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal signed aggregate and proof")
+	}
+
+	version, err := eth2util.DataVersionFromETH2(s.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert version")
+	}
+
+	resp, err := json.Marshal(versionedRawSignedAggregateAndProofJSON{
+		Version:           version,
+		AggregateAndProof: aggregateAndProof,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal wrapper")
+	}
+
+	return resp, nil
+}
+
+func (s *VersionedSignedAggregateAndProof) UnmarshalJSON(input []byte) error {
+	var raw versionedRawSignedAggregateAndProofJSON
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return errors.Wrap(err, "unmarshal signed aggregate and proof")
+	}
+
+	if len(raw.AggregateAndProof) == 0 {
+		return errors.New("missing signed aggregate and proof")
+	}
+
+	var resp *eth2spec.VersionedSignedAggregateAndProof
+	switch raw.Version {
+	case eth2util.DataVersionPhase0, eth2util.DataVersionAltair, eth2util.DataVersionBellatrix,
+		eth2util.DataVersionCapella, eth2util.DataVersionDeneb:
+		aggregateAndProof := new(eth2p0.SignedAggregateAndProof)
+		if err := json.Unmarshal(raw.AggregateAndProof, &aggregateAndProof); err != nil {
+			return errors.Wrap(err, "unmarshal signed aggregate and proof", z.Str("version", raw.Version.String()))
+		}
+
+		var err error
+		resp, err = eth2util.NewPhase0VersionedSignedAggregateAndProof(raw.Version.ToETH2(), aggregateAndProof)
+		if err != nil {
+			return err
+		}
+	case eth2util.DataVersionElectra:
+		aggregateAndProof := new(electra.SignedAggregateAndProof)
+		if err := json.Unmarshal(raw.AggregateAndProof, &aggregateAndProof); err != nil {
+			return errors.Wrap(err, "unmarshal electra signed aggregate and proof")
+		}
+		resp = &eth2spec.VersionedSignedAggregateAndProof{Version: eth2spec.DataVersionElectra, Electra: aggregateAndProof}
+	default:
+		return errors.New("unknown version")
+	}
+
+	s.VersionedSignedAggregateAndProof = *resp
+
+	return nil
+}
+
+// versionedRawSignedAggregateAndProofJSON is a custom VersionedSignedAggregateAndProof serialiser.
+type versionedRawSignedAggregateAndProofJSON struct {
+	Version           eth2util.DataVersion `json:"version"`
+	AggregateAndProof json.RawMessage      `json:"aggregate_and_proof"`
+}
+
 // SyncCommitteeMessage: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/validator.md#synccommitteemessage.
 
 // NewSignedSyncMessage is a convenience function which returns new signed SignedSyncMessage.
@@ -9,9 +9,12 @@ import (
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2electra "github.com/attestantio/go-eth2-client/api/v1/electra"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/stretchr/testify/require"
@@ -133,6 +136,19 @@ func (p *VersionedSignedProposal) sszValFromVersion(version eth2util.DataVersion
 		}
 
 		return p.Deneb, nil
+	case eth2util.DataVersionElectra:
+		if p.Electra == nil && !blinded {
+			p.Electra = new(eth2electra.SignedBlockContents)
+		}
+		if p.ElectraBlinded == nil && blinded {
+			p.ElectraBlinded = new(eth2electra.SignedBlindedBeaconBlock)
+		}
+
+		if blinded {
+			return p.ElectraBlinded, nil
+		}
+
+		return p.Electra, nil
 	default:
 		return nil, errors.New("invalid version")
 	}
@@ -244,6 +260,19 @@ func (p *VersionedProposal) sszValFromVersion(version eth2util.DataVersion, blin
 		}
 
 		return p.Deneb, nil
+	case eth2util.DataVersionElectra:
+		if p.Electra == nil && !blinded {
+			p.Electra = new(eth2electra.BlockContents)
+		}
+		if p.ElectraBlinded == nil && blinded {
+			p.ElectraBlinded = new(eth2electra.BlindedBeaconBlock)
+		}
+
+		if blinded {
+			return p.ElectraBlinded, nil
+		}
+
+		return p.Electra, nil
 	default:
 		return nil, errors.New("invalid version")
 	}
@@ -475,3 +504,310 @@ func (a *attesterDutySSZ) UnmarshalSSZ(buf []byte) error {
 
 	return nil
 }
+
+// ======================= VersionedAttestation =======================
+
+// versionedAttestationOffset is the offset of a ssz encoded versioned attestation.
+const versionedAttestationOffset = 8 + 1 + 8 + 4 // version (uint64) + has validator index (uint8) + validator index (uint64) + offset (uint32)
+
+// MarshalSSZ ssz marshals the VersionedAttestation object.
+func (a VersionedAttestation) MarshalSSZ() ([]byte, error) {
+	resp, err := ssz.MarshalSSZ(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal VersionedAttestation")
+	}
+
+	return resp, nil
+}
+
+// MarshalSSZTo ssz marshals the VersionedAttestation object to a target array.
+func (a VersionedAttestation) MarshalSSZTo(dst []byte) ([]byte, error) {
+	version, err := eth2util.DataVersionFromETH2(a.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid version")
+	}
+
+	// Field (0) 'Version'
+	dst = ssz.MarshalUint64(dst, version.ToUint64())
+
+	// Field (1) 'HasValidatorIndex'
+	dst = ssz.MarshalBool(dst, a.ValidatorIndex != nil)
+
+	// Field (2) 'ValidatorIndex'
+	var valIdx uint64
+	if a.ValidatorIndex != nil {
+		valIdx = uint64(*a.ValidatorIndex)
+	}
+	dst = ssz.MarshalUint64(dst, valIdx)
+
+	// Offset (3) 'Value'
+	dst = ssz.WriteOffset(dst, versionedAttestationOffset)
+
+	val, err := attestationSSZValFromVersion(&a.VersionedAttestation, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Field (3) 'Value'
+	if dst, err = val.MarshalSSZTo(dst); err != nil {
+		return nil, errors.Wrap(err, "marshal attestation")
+	}
+
+	return dst, nil
+}
+
+// UnmarshalSSZ ssz unmarshals the VersionedAttestation object.
+func (a *VersionedAttestation) UnmarshalSSZ(buf []byte) error {
+	if len(buf) < versionedAttestationOffset {
+		return errors.Wrap(ssz.ErrSize, "versioned attestation too short")
+	}
+
+	// Field (0) 'Version'
+	version, err := eth2util.DataVersionFromUint64(ssz.UnmarshallUint64(buf[0:8]))
+	if err != nil {
+		return errors.Wrap(err, "unmarshal attestation version")
+	}
+
+	// Field (1) 'HasValidatorIndex'
+	hasValIdx := ssz.UnmarshalBool(buf[8:9])
+
+	// Field (2) 'ValidatorIndex'
+	valIdx := eth2p0.ValidatorIndex(ssz.UnmarshallUint64(buf[9:17]))
+
+	// Offset (3) 'Value'
+	o3 := ssz.ReadOffset(buf[17:21])
+	if versionedAttestationOffset != o3 {
+		return errors.Wrap(ssz.ErrOffset, "attestation offset", z.Any("version", version))
+	}
+
+	resp := eth2spec.VersionedAttestation{Version: version.ToETH2()}
+	if hasValIdx {
+		resp.ValidatorIndex = &valIdx
+	}
+
+	val, err := attestationSSZValFromVersion(&resp, version)
+	if err != nil {
+		return err
+	}
+
+	// Field (3) 'Value'
+	if err = val.UnmarshalSSZ(buf[o3:]); err != nil {
+		return errors.Wrap(err, "unmarshal attestation", z.Any("version", version))
+	}
+
+	a.VersionedAttestation = resp
+
+	return nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the VersionedAttestation object.
+func (a VersionedAttestation) SizeSSZ() int {
+	version, err := eth2util.DataVersionFromETH2(a.Version)
+	if err != nil {
+		// SSZMarshaller interface doesn't return an error, so we can't either.
+		return 0
+	}
+
+	val, err := attestationSSZValFromVersion(&a.VersionedAttestation, version)
+	if err != nil {
+		// SSZMarshaller interface doesn't return an error, so we can't either.
+		return 0
+	}
+
+	return versionedAttestationOffset + val.SizeSSZ()
+}
+
+// ================== VersionedAggregatedAttestation ===================
+
+// MarshalSSZ ssz marshals the VersionedAggregatedAttestation object.
+func (a VersionedAggregatedAttestation) MarshalSSZ() ([]byte, error) {
+	resp, err := ssz.MarshalSSZ(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal VersionedAggregatedAttestation")
+	}
+
+	return resp, nil
+}
+
+// MarshalSSZTo ssz marshals the VersionedAggregatedAttestation object to a target array.
+func (a VersionedAggregatedAttestation) MarshalSSZTo(buf []byte) ([]byte, error) {
+	version, err := eth2util.DataVersionFromETH2(a.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid version")
+	}
+
+	return marshalSSZVersionedTo(buf, version, false, a.sszValFromVersion)
+}
+
+// UnmarshalSSZ ssz unmarshalls the VersionedAggregatedAttestation object.
+func (a *VersionedAggregatedAttestation) UnmarshalSSZ(buf []byte) error {
+	version, _, err := unmarshalSSZVersioned(buf, a.sszValFromVersion)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal VersionedAggregatedAttestation")
+	}
+
+	a.Version = version.ToETH2()
+
+	return nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the VersionedAggregatedAttestation object.
+func (a VersionedAggregatedAttestation) SizeSSZ() int {
+	version, err := eth2util.DataVersionFromETH2(a.Version)
+	if err != nil {
+		// SSZMarshaller interface doesn't return an error, so we can't either.
+		return 0
+	}
+
+	val, err := a.sszValFromVersion(version, false)
+	if err != nil {
+		// SSZMarshaller interface doesn't return an error, so we can't either.
+		return 0
+	}
+
+	return sizeSSZVersioned(val)
+}
+
+// sszValFromVersion returns the internal value of the VersionedAggregatedAttestation object for a given version.
+func (a *VersionedAggregatedAttestation) sszValFromVersion(version eth2util.DataVersion, _ bool) (sszType, error) {
+	return attestationSSZValFromVersion(&a.VersionedAttestation, version)
+}
+
+// attestationSSZValFromVersion returns the internal value of the versioned attestation for a given version.
+func attestationSSZValFromVersion(att *eth2spec.VersionedAttestation, version eth2util.DataVersion) (sszType, error) {
+	switch version {
+	case eth2util.DataVersionPhase0:
+		if att.Phase0 == nil {
+			att.Phase0 = new(eth2p0.Attestation)
+		}
+
+		return att.Phase0, nil
+	case eth2util.DataVersionAltair:
+		if att.Altair == nil {
+			att.Altair = new(eth2p0.Attestation)
+		}
+
+		return att.Altair, nil
+	case eth2util.DataVersionBellatrix:
+		if att.Bellatrix == nil {
+			att.Bellatrix = new(eth2p0.Attestation)
+		}
+
+		return att.Bellatrix, nil
+	case eth2util.DataVersionCapella:
+		if att.Capella == nil {
+			att.Capella = new(eth2p0.Attestation)
+		}
+
+		return att.Capella, nil
+	case eth2util.DataVersionDeneb:
+		if att.Deneb == nil {
+			att.Deneb = new(eth2p0.Attestation)
+		}
+
+		return att.Deneb, nil
+	case eth2util.DataVersionElectra:
+		if att.Electra == nil {
+			att.Electra = new(electra.Attestation)
+		}
+
+		return att.Electra, nil
+	default:
+		return nil, errors.New("invalid version")
+	}
+}
+
+// ================== VersionedSignedAggregateAndProof ===================
+
+// MarshalSSZ ssz marshals the VersionedSignedAggregateAndProof object.
+func (s VersionedSignedAggregateAndProof) MarshalSSZ() ([]byte, error) {
+	resp, err := ssz.MarshalSSZ(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal VersionedSignedAggregateAndProof")
+	}
+
+	return resp, nil
+}
+
+// MarshalSSZTo ssz marshals the VersionedSignedAggregateAndProof object to a target array.
+func (s VersionedSignedAggregateAndProof) MarshalSSZTo(buf []byte) ([]byte, error) {
+	version, err := eth2util.DataVersionFromETH2(s.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid version")
+	}
+
+	return marshalSSZVersionedTo(buf, version, false, s.sszValFromVersion)
+}
+
+// UnmarshalSSZ ssz unmarshalls the VersionedSignedAggregateAndProof object.
+func (s *VersionedSignedAggregateAndProof) UnmarshalSSZ(buf []byte) error {
+	version, _, err := unmarshalSSZVersioned(buf, s.sszValFromVersion)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal VersionedSignedAggregateAndProof")
+	}
+
+	s.Version = version.ToETH2()
+
+	return nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the VersionedSignedAggregateAndProof object.
+func (s VersionedSignedAggregateAndProof) SizeSSZ() int {
+	version, err := eth2util.DataVersionFromETH2(s.Version)
+	if err != nil {
+		// SSZMarshaller interface doesn't return an error, so we can't either.
+		return 0
+	}
+
+	val, err := s.sszValFromVersion(version, false)
+	if err != nil {
+		// SSZMarshaller interface doesn't return an error, so we can't either.
+		return 0
+	}
+
+	return sizeSSZVersioned(val)
+}
+
+// sszValFromVersion returns the internal value of the VersionedSignedAggregateAndProof object for a given version.
+func (s *VersionedSignedAggregateAndProof) sszValFromVersion(version eth2util.DataVersion, _ bool) (sszType, error) {
+	switch version {
+	case eth2util.DataVersionPhase0:
+		if s.Phase0 == nil {
+			s.Phase0 = new(eth2p0.SignedAggregateAndProof)
+		}
+
+		return s.Phase0, nil
+	case eth2util.DataVersionAltair:
+		if s.Altair == nil {
+			s.Altair = new(eth2p0.SignedAggregateAndProof)
+		}
+
+		return s.Altair, nil
+	case eth2util.DataVersionBellatrix:
+		if s.Bellatrix == nil {
+			s.Bellatrix = new(eth2p0.SignedAggregateAndProof)
+		}
+
+		return s.Bellatrix, nil
+	case eth2util.DataVersionCapella:
+		if s.Capella == nil {
+			s.Capella = new(eth2p0.SignedAggregateAndProof)
+		}
+
+		return s.Capella, nil
+	case eth2util.DataVersionDeneb:
+		if s.Deneb == nil {
+			s.Deneb = new(eth2p0.SignedAggregateAndProof)
+		}
+
+		return s.Deneb, nil
+	case eth2util.DataVersionElectra:
+		if s.Electra == nil {
+			s.Electra = new(electra.SignedAggregateAndProof)
+		}
+
+		return s.Electra, nil
+	default:
+		return nil, errors.New("invalid version")
+	}
+}
@@ -17,7 +17,9 @@ import (
 
 // StartDutyTrace returns a context and span rooted to the duty traceID and wrapped in a duty span.
 // This creates a new trace root and should generally only be called when a new duty is scheduled
-// or when a duty is received from the VC or peer.
+// or when a duty is received from the VC or peer. Since the duty traceID is deterministic, the duty
+// spans of all nodes in the cluster join up in a single trace. A span context propagated
+// by the caller, e.g. the VC, is linked to the duty span.
 func StartDutyTrace(ctx context.Context, duty Duty, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	h := fnv.New128a()
 	_, _ = h.Write([]byte(duty.String()))
@@ -25,8 +27,13 @@ func StartDutyTrace(ctx context.Context, duty Duty, spanName string, opts ...tra
 	var traceID trace.TraceID
 	copy(traceID[:], h.Sum(nil))
 
+	var outerOpts []trace.SpanStartOption
+	if parent := trace.SpanContextFromContext(ctx); parent.IsValid() && parent.TraceID() != traceID {
+		outerOpts = append(outerOpts, trace.WithLinks(trace.Link{SpanContext: parent}))
+	}
+
 	var outerSpan, innerSpan trace.Span
-	ctx, outerSpan = tracer.Start(tracer.RootedCtx(ctx, traceID), "core/duty."+strings.Title(duty.Type.String()))
+	ctx, outerSpan = tracer.Start(tracer.RootedCtx(ctx, traceID), "core/duty."+strings.Title(duty.Type.String()), outerOpts...)
 	ctx, innerSpan = tracer.Start(ctx, spanName, opts...)
 
 	slotStr := strconv.FormatUint(duty.Slot, 10)
@@ -10,13 +10,16 @@ import (
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
 )
 
 const (
@@ -40,17 +43,24 @@ type subkey struct {
 
 // submission represents a duty submitted to the beacon node/chain.
 type submission struct {
-	Duty        core.Duty
-	Pubkey      core.PubKey
-	Data        core.SignedData
-	AttDataRoot eth2p0.Root
-	Delay       time.Duration
+	Duty   core.Duty
+	Pubkey core.PubKey
+	Data   core.SignedData
+	AttKey attKey
+	Delay  time.Duration
+}
+
+// attKey identifies the attestations of a committee by attestation data root.
+// The committee index is required since electra attestation data doesn't include it.
+type attKey struct {
+	DataRoot eth2p0.Root
+	CommIdx  eth2p0.CommitteeIndex
 }
 
-// block is a simplified block with its attestations.
+// block is a simplified block with its attestations split by committee.
 type block struct {
-	Slot                   uint64
-	AttestationsByDataRoot map[eth2p0.Root]*eth2p0.Attestation
+	Slot              uint64
+	AttestationsByKey map[attKey]*eth2p0.Attestation
 }
 
 // trackerInclFunc defines the tracker callback for the inclusion checker.
@@ -82,24 +92,28 @@ func (i *inclusionCore) Submitted(duty core.Duty, pubkey core.PubKey, data core.
 		return nil
 	}
 
-	var attRoot eth2p0.Root
+	var key attKey
 	if duty.Type == core.DutyAttester {
-		att, ok := data.(core.Attestation)
+		att, ok := data.(core.VersionedAttestation)
 		if !ok {
 			return errors.New("invalid attestation")
 		}
-		attRoot, err = att.Data.HashTreeRoot()
+		key, err = versionedAttKey(&att.VersionedAttestation)
 		if err != nil {
-			return errors.Wrap(err, "hash attestation")
+			return err
 		}
 	} else if duty.Type == core.DutyAggregator {
-		agg, ok := data.(core.SignedAggregateAndProof)
+		agg, ok := data.(core.VersionedSignedAggregateAndProof)
 		if !ok {
 			return errors.New("invalid aggregate and proof")
 		}
-		attRoot, err = agg.Message.Aggregate.Data.HashTreeRoot()
+		aggregate, err := aggregateAttestation(agg)
+		if err != nil {
+			return err
+		}
+		key, err = versionedAttKey(aggregate)
 		if err != nil {
-			return errors.Wrap(err, "hash aggregate")
+			return err
 		}
 	} else if duty.Type == core.DutyProposer {
 		var (
@@ -149,13 +163,12 @@ func (i *inclusionCore) Submitted(duty core.Duty, pubkey core.PubKey, data core.
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	key := subkey{Duty: duty, Pubkey: pubkey}
-	i.submissions[key] = submission{
-		Duty:        duty,
-		Pubkey:      pubkey,
-		Data:        data,
-		AttDataRoot: attRoot,
-		Delay:       delay,
+	i.submissions[subkey{Duty: duty, Pubkey: pubkey}] = submission{
+		Duty:   duty,
+		Pubkey: pubkey,
+		Data:   data,
+		AttKey: key,
+		Delay:  delay,
 	}
 
 	return err
@@ -244,32 +257,42 @@ func (i *inclusionCore) CheckBlock(ctx context.Context, block block) {
 
 // checkAggregationInclusion checks whether the aggregation is included in the block.
 func checkAggregationInclusion(sub submission, block block) (bool, error) {
-	att, ok := block.AttestationsByDataRoot[sub.AttDataRoot]
+	agg, ok := sub.Data.(core.VersionedSignedAggregateAndProof)
 	if !ok {
-		return false, nil
+		return false, errors.New("invalid aggregate and proof")
 	}
 
-	subBits := sub.Data.(core.SignedAggregateAndProof).Message.Aggregate.AggregationBits
-	ok, err := att.AggregationBits.Contains(subBits)
+	aggregate, err := aggregateAttestation(agg)
 	if err != nil {
-		return false, errors.Wrap(err, "check aggregation bits",
-			z.U64("block_bits", att.AggregationBits.Len()),
-			z.U64("sub_bits", subBits.Len()),
-		)
+		return false, err
 	}
 
-	return ok, nil
+	return checkBitsInclusion(sub, block, aggregate)
 }
 
 // checkAttestationInclusion checks whether the attestation is included in the block.
 func checkAttestationInclusion(sub submission, block block) (bool, error) {
-	att, ok := block.AttestationsByDataRoot[sub.AttDataRoot]
+	att, ok := sub.Data.(core.VersionedAttestation)
+	if !ok {
+		return false, errors.New("invalid attestation")
+	}
+
+	return checkBitsInclusion(sub, block, &att.VersionedAttestation)
+}
+
+// checkBitsInclusion checks whether the submitted attestation's aggregation bits are included in the block.
+func checkBitsInclusion(sub submission, block block, subAtt *eth2spec.VersionedAttestation) (bool, error) {
+	att, ok := block.AttestationsByKey[sub.AttKey]
 	if !ok {
 		return false, nil
 	}
 
-	subBits := sub.Data.(core.Attestation).AggregationBits
-	ok, err := att.AggregationBits.Contains(subBits)
+	subBits, err := subAtt.AggregationBits()
+	if err != nil {
+		return false, errors.Wrap(err, "get aggregation bits")
+	}
+
+	ok, err = att.AggregationBits.Contains(subBits)
 	if err != nil {
 		return false, errors.Wrap(err, "check aggregation bits",
 			z.U64("block_bits", att.AggregationBits.Len()),
@@ -280,6 +303,50 @@ func checkAttestationInclusion(sub submission, block block) (bool, error) {
 	return ok, nil
 }
 
+// versionedAttKey returns the attestation key of the versioned attestation.
+func versionedAttKey(att *eth2spec.VersionedAttestation) (attKey, error) {
+	data, err := att.Data()
+	if err != nil {
+		return attKey{}, errors.Wrap(err, "get attestation data")
+	}
+
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return attKey{}, errors.Wrap(err, "hash attestation")
+	}
+
+	commIdx, err := att.CommitteeIndex()
+	if err != nil {
+		return attKey{}, errors.Wrap(err, "get committee index")
+	}
+
+	return attKey{DataRoot: root, CommIdx: commIdx}, nil
+}
+
+// aggregateAttestation returns the versioned aggregate attestation of the signed aggregate and proof.
+func aggregateAttestation(agg core.VersionedSignedAggregateAndProof) (*eth2spec.VersionedAttestation, error) {
+	resp := &eth2spec.VersionedAttestation{Version: agg.Version}
+	switch agg.Version {
+	// No nil checks since `NewVersionedSignedAggregateAndProof` assumed.
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0 = agg.Phase0.Message.Aggregate
+	case eth2spec.DataVersionAltair:
+		resp.Altair = agg.Altair.Message.Aggregate
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix = agg.Bellatrix.Message.Aggregate
+	case eth2spec.DataVersionCapella:
+		resp.Capella = agg.Capella.Message.Aggregate
+	case eth2spec.DataVersionDeneb:
+		resp.Deneb = agg.Deneb.Message.Aggregate
+	case eth2spec.DataVersionElectra:
+		resp.Electra = agg.Electra.Message.Aggregate
+	default:
+		return nil, errors.New("unknown aggregate and proof version")
+	}
+
+	return resp, nil
+}
+
 // reportMissed reports duties that were broadcast but never included on chain.
 func reportMissed(ctx context.Context, sub submission) {
 	inclusionMisses.WithLabelValues(sub.Duty.Type.String()).Inc()
@@ -319,7 +386,7 @@ func reportMissed(ctx context.Context, sub submission) {
 
 // reportAttInclusion reports attestations that were included in a block.
 func reportAttInclusion(ctx context.Context, sub submission, block block) {
-	att := block.AttestationsByDataRoot[sub.AttDataRoot]
+	att := block.AttestationsByKey[sub.AttKey]
 	aggIndices := att.AggregationBits.BitIndices()
 	attSlot := uint64(att.Data.Slot)
 	blockSlot := block.Slot
@@ -435,37 +502,140 @@ func (a *InclusionChecker) checkBlock(ctx context.Context, slot uint64) error {
 		return nil // No block for this slot
 	}
 
-	// Map attestations by data root, merging duplicates (with identical attestation data).
-	attsMap := make(map[eth2p0.Root]*eth2p0.Attestation)
-	for _, att := range atts {
-		if att == nil || att.Data == nil {
-			return errors.New("invalid attestation")
-		}
+	committees := make(committeeLengths)
 
-		if att.Data.Target == nil || att.Data.Source == nil {
-			return errors.New("invalid attestation data checkpoint")
+	// Map attestations by data root and committee, merging duplicates (with identical attestation data).
+	attsMap := make(map[attKey]*eth2p0.Attestation)
+	for _, versioned := range atts {
+		if versioned == nil {
+			return errors.New("invalid attestation")
 		}
 
-		root, err := att.Data.HashTreeRoot()
+		split, err := a.splitByCommittee(ctx, slot, versioned, committees)
 		if err != nil {
-			return errors.Wrap(err, "hash attestation")
+			return err
 		}
 
-		// Zero signature since it isn't used and wouldn't be valid after merging anyway.
-		att.Signature = eth2p0.BLSSignature{}
+		for commIdx, att := range split {
+			if att.Data.Target == nil || att.Data.Source == nil {
+				return errors.New("invalid attestation data checkpoint")
+			}
 
-		if exist, ok := attsMap[root]; ok {
-			// Merge duplicate attestations (only aggregation bits)
-			att.AggregationBits, err = att.AggregationBits.Or(exist.AggregationBits)
+			root, err := att.Data.HashTreeRoot()
 			if err != nil {
-				return errors.Wrap(err, "merge attestation aggregation bits")
+				return errors.Wrap(err, "hash attestation")
 			}
-		}
 
-		attsMap[root] = att
+			key := attKey{DataRoot: root, CommIdx: commIdx}
+			if exist, ok := attsMap[key]; ok {
+				// Merge duplicate attestations (only aggregation bits)
+				att.AggregationBits, err = att.AggregationBits.Or(exist.AggregationBits)
+				if err != nil {
+					return errors.Wrap(err, "merge attestation aggregation bits")
+				}
+			}
+
+			attsMap[key] = att
+		}
 	}
 
-	a.checkBlockFunc(ctx, block{Slot: slot, AttestationsByDataRoot: attsMap})
+	a.checkBlockFunc(ctx, block{Slot: slot, AttestationsByKey: attsMap})
 
 	return nil
 }
+
+// committeeLengths caches beacon committee lengths by slot and committee index.
+type committeeLengths map[eth2p0.Slot]map[eth2p0.CommitteeIndex]uint64
+
+// splitByCommittee returns the attestation as simplified attestations by committee index.
+// Electra on-chain attestations contain the concatenated aggregation bits of all committees
+// set in its committee bits, so these are split using the beacon committee lengths.
+// The signatures of the returned attestations are zero since it isn't used and wouldn't be valid after merging anyway.
+func (a *InclusionChecker) splitByCommittee(ctx context.Context, blockSlot uint64, att *eth2spec.VersionedAttestation,
+	committees committeeLengths,
+) (map[eth2p0.CommitteeIndex]*eth2p0.Attestation, error) {
+	data, err := att.Data()
+	if err != nil || data == nil {
+		return nil, errors.New("invalid attestation")
+	}
+
+	bits, err := att.AggregationBits()
+	if err != nil {
+		return nil, errors.Wrap(err, "get aggregation bits")
+	}
+
+	if att.Version < eth2spec.DataVersionElectra {
+		return map[eth2p0.CommitteeIndex]*eth2p0.Attestation{
+			data.Index: {AggregationBits: bits, Data: data},
+		}, nil
+	}
+
+	commBits, err := att.CommitteeBits()
+	if err != nil {
+		return nil, errors.Wrap(err, "get committee bits")
+	}
+
+	lengths, err := a.committeeLengths(ctx, blockSlot, data.Slot, committees)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make(map[eth2p0.CommitteeIndex]*eth2p0.Attestation)
+	var offset uint64
+	for _, idx := range commBits.BitIndices() {
+		commIdx := eth2p0.CommitteeIndex(idx)
+		length, ok := lengths[commIdx]
+		if !ok {
+			return nil, errors.New("unknown attestation committee", z.U64("committee_index", uint64(commIdx)))
+		} else if offset+length > bits.Len() {
+			return nil, errors.New("attestation aggregation bits too short")
+		}
+
+		commAggBits := bitfield.NewBitlist(length)
+		for i := range length {
+			commAggBits.SetBitAt(i, bits.BitAt(offset+i))
+		}
+		offset += length
+
+		resp[commIdx] = &eth2p0.Attestation{AggregationBits: commAggBits, Data: data}
+	}
+
+	return resp, nil
+}
+
+// committeeLengths returns the beacon committee lengths by committee index of the attestation slot.
+// It queries the committees of the attestation slot's epoch from the block's state once.
+func (a *InclusionChecker) committeeLengths(ctx context.Context, blockSlot uint64, attSlot eth2p0.Slot,
+	committees committeeLengths,
+) (map[eth2p0.CommitteeIndex]uint64, error) {
+	if lengths, ok := committees[attSlot]; ok {
+		return lengths, nil
+	}
+
+	epoch, err := eth2util.EpochFromSlot(ctx, a.eth2Cl, attSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	eth2Resp, err := a.eth2Cl.BeaconCommittees(ctx, &eth2api.BeaconCommitteesOpts{
+		State: strconv.FormatUint(blockSlot, 10),
+		Epoch: &epoch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, committee := range eth2Resp.Data {
+		if committees[committee.Slot] == nil {
+			committees[committee.Slot] = make(map[eth2p0.CommitteeIndex]uint64)
+		}
+		committees[committee.Slot][committee.Index] = uint64(len(committee.Validators))
+	}
+
+	lengths, ok := committees[attSlot]
+	if !ok {
+		return nil, errors.New("no beacon committees for attestation slot", z.U64("slot", uint64(attSlot)))
+	}
+
+	return lengths, nil
+}
@@ -7,6 +7,10 @@ import (
 	"math/rand"
 	"testing"
 
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
@@ -29,11 +33,11 @@ func TestDuplicateAttData(t *testing.T) {
 	bits3 := testutil.RandomBitList(8)
 	attData := testutil.RandomAttestationData()
 
-	bmock.BlockAttestationsFunc = func(_ context.Context, _ string) ([]*eth2p0.Attestation, error) {
-		return []*eth2p0.Attestation{
-			{AggregationBits: bits1, Data: attData},
-			{AggregationBits: bits2, Data: attData},
-			{AggregationBits: bits3, Data: attData},
+	bmock.BlockAttestationsFunc = func(_ context.Context, _ string) ([]*eth2spec.VersionedAttestation, error) {
+		return []*eth2spec.VersionedAttestation{
+			{Version: eth2spec.DataVersionDeneb, Deneb: &eth2p0.Attestation{AggregationBits: bits1, Data: attData}},
+			{Version: eth2spec.DataVersionDeneb, Deneb: &eth2p0.Attestation{AggregationBits: bits2, Data: attData}},
+			{Version: eth2spec.DataVersionDeneb, Deneb: &eth2p0.Attestation{AggregationBits: bits3, Data: attData}},
 		}, nil
 	}
 
@@ -48,8 +52,8 @@ func TestDuplicateAttData(t *testing.T) {
 
 	// Assert that the block to check contains all bitlists above.
 	incl.checkBlockFunc = func(ctx context.Context, block block) {
-		require.Len(t, block.AttestationsByDataRoot, 1)
-		att, ok := block.AttestationsByDataRoot[attDataRoot]
+		require.Len(t, block.AttestationsByKey, 1)
+		att, ok := block.AttestationsByKey[attKey{DataRoot: attDataRoot, CommIdx: attData.Index}]
 		require.True(t, ok)
 
 		ok, err := att.AggregationBits.Contains(bits1)
@@ -73,6 +77,71 @@ func TestDuplicateAttData(t *testing.T) {
 	<-done
 }
 
+func TestElectraBlockAttestations(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	attData := testutil.RandomAttestationData()
+	attData.Index = 0
+
+	// Committee 1 has 3 validators and committee 3 has 2 validators.
+	bmock.BeaconCommitteesFunc = func(_ context.Context, opts *eth2api.BeaconCommitteesOpts) ([]*eth2v1.BeaconCommittee, error) {
+		require.NotNil(t, opts.Epoch)
+
+		return []*eth2v1.BeaconCommittee{
+			{Slot: attData.Slot, Index: 1, Validators: []eth2p0.ValidatorIndex{1, 2, 3}},
+			{Slot: attData.Slot, Index: 3, Validators: []eth2p0.ValidatorIndex{4, 5}},
+		}, nil
+	}
+
+	commBits := bitfield.NewBitvector64()
+	commBits.SetBitAt(1, true)
+	commBits.SetBitAt(3, true)
+
+	// Concatenated aggregation bits of committee 1 (0b010) and committee 3 (0b11).
+	aggBits := bitfield.NewBitlist(5)
+	aggBits.SetBitAt(1, true)
+	aggBits.SetBitAt(3, true)
+	aggBits.SetBitAt(4, true)
+
+	bmock.BlockAttestationsFunc = func(_ context.Context, _ string) ([]*eth2spec.VersionedAttestation, error) {
+		return []*eth2spec.VersionedAttestation{{
+			Version: eth2spec.DataVersionElectra,
+			Electra: &electra.Attestation{AggregationBits: aggBits, Data: attData, CommitteeBits: commBits},
+		}}, nil
+	}
+
+	incl, err := NewInclusion(ctx, bmock, func(core.Duty, core.PubKey, core.SignedData, error) {})
+	require.NoError(t, err)
+
+	attDataRoot, err := attData.HashTreeRoot()
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	incl.checkBlockFunc = func(ctx context.Context, block block) {
+		require.Len(t, block.AttestationsByKey, 2)
+
+		comm1, ok := block.AttestationsByKey[attKey{DataRoot: attDataRoot, CommIdx: 1}]
+		require.True(t, ok)
+		require.Equal(t, []int{1}, comm1.AggregationBits.BitIndices())
+		require.EqualValues(t, 3, comm1.AggregationBits.Len())
+
+		comm3, ok := block.AttestationsByKey[attKey{DataRoot: attDataRoot, CommIdx: 3}]
+		require.True(t, ok)
+		require.Equal(t, []int{0, 1}, comm3.AggregationBits.BitIndices())
+		require.EqualValues(t, 2, comm3.AggregationBits.Len())
+
+		close(done)
+	}
+
+	err = incl.checkBlock(ctx, uint64(attData.Slot))
+	require.NoError(t, err)
+
+	<-done
+}
+
 func TestInclusion(t *testing.T) {
 	//  Setup inclusion with a mock missedFunc and attIncludedFunc
 	var missed, included []core.Duty
@@ -88,14 +157,14 @@ func TestInclusion(t *testing.T) {
 	}
 
 	// Create some duties
-	att1 := testutil.RandomAttestation()
-	att1Duty := core.NewAttesterDuty(uint64(att1.Data.Slot))
+	att1 := testutil.RandomDenebVersionedAttestation()
+	att1Duty := core.NewAttesterDuty(uint64(att1.Deneb.Data.Slot))
 
-	agg2 := testutil.RandomSignedAggregateAndProof()
-	agg2Duty := core.NewAggregatorDuty(uint64(agg2.Message.Aggregate.Data.Slot))
+	agg2 := testutil.RandomElectraVersionedSignedAggregateAndProof()
+	agg2Duty := core.NewAggregatorDuty(uint64(agg2.Electra.Message.Aggregate.Data.Slot))
 
-	att3 := testutil.RandomAttestation()
-	att3Duty := core.NewAttesterDuty(uint64(att3.Data.Slot))
+	att3 := testutil.RandomElectraVersionedAttestation()
+	att3Duty := core.NewAttesterDuty(uint64(att3.Electra.Data.Slot))
 
 	block4 := testutil.RandomDenebVersionedSignedProposal()
 	block4Duty := core.NewProposerDuty(uint64(block4.Deneb.SignedBlock.Message.Slot))
@@ -108,11 +177,17 @@ func TestInclusion(t *testing.T) {
 	}
 
 	// Submit all duties
-	err := incl.Submitted(att1Duty, "", core.NewAttestation(att1), 0)
+	coreAtt1, err := core.NewVersionedAttestation(att1)
 	require.NoError(t, err)
-	err = incl.Submitted(agg2Duty, "", core.NewSignedAggregateAndProof(agg2), 0)
+	err = incl.Submitted(att1Duty, "", coreAtt1, 0)
 	require.NoError(t, err)
-	err = incl.Submitted(att3Duty, "", core.NewAttestation(att3), 0)
+	coreAgg2, err := core.NewVersionedSignedAggregateAndProof(agg2)
+	require.NoError(t, err)
+	err = incl.Submitted(agg2Duty, "", coreAgg2, 0)
+	require.NoError(t, err)
+	coreAtt3, err := core.NewVersionedAttestation(att3)
+	require.NoError(t, err)
+	err = incl.Submitted(att3Duty, "", coreAtt3, 0)
 	require.NoError(t, err)
 
 	coreBlock4, err := core.NewVersionedSignedProposal(block4)
@@ -123,19 +198,22 @@ func TestInclusion(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create a mock block with the 1st and 2nd attestations.
-	att1Root, err := att1.Data.HashTreeRoot()
+	att1Root, err := att1.Deneb.Data.HashTreeRoot()
+	require.NoError(t, err)
+	agg2Electra := agg2.Electra.Message.Aggregate
+	att2Root, err := agg2Electra.Data.HashTreeRoot()
 	require.NoError(t, err)
-	att2Root, err := agg2.Message.Aggregate.Data.HashTreeRoot()
+	agg2CommIdx, err := agg2Electra.CommitteeIndex()
 	require.NoError(t, err)
 	// Add some random aggregation bits to the attestation
-	addRandomBits(att1.AggregationBits)
-	addRandomBits(agg2.Message.Aggregate.AggregationBits)
+	addRandomBits(att1.Deneb.AggregationBits)
+	addRandomBits(agg2Electra.AggregationBits)
 
 	block := block{
 		Slot: block4Duty.Slot,
-		AttestationsByDataRoot: map[eth2p0.Root]*eth2p0.Attestation{
-			att1Root: att1,
-			att2Root: agg2.Message.Aggregate,
+		AttestationsByKey: map[attKey]*eth2p0.Attestation{
+			{DataRoot: att1Root, CommIdx: att1.Deneb.Data.Index}: att1.Deneb,
+			{DataRoot: att2Root, CommIdx: agg2CommIdx}:           {AggregationBits: agg2Electra.AggregationBits, Data: agg2Electra.Data},
 		},
 	}
 
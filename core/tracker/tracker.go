@@ -6,6 +6,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 
@@ -104,6 +106,40 @@ type Tracker struct {
 
 	// participationReporter instruments duty peer participation.
 	participationReporter func(ctx context.Context, duty core.Duty, failed bool, participatedShares map[int]int, unexpectedPeers map[int]int, expectedPerPeer int)
+
+	// subs are the subscribers of analysed duty outcomes.
+	subs []func(context.Context, Outcome)
+}
+
+// Outcome is the result of a duty analysed by the tracker.
+type Outcome struct {
+	// Duty is the analysed duty.
+	Duty core.Duty
+	// Failed is true if the duty failed.
+	Failed bool
+	// Step is the step the duty failed at, or the last step of successful duties.
+	Step string
+	// ReasonCode and Reason explain why a duty failed.
+	ReasonCode string
+	Reason     string
+	// Error is the last error of the failed step, if any.
+	Error string
+	// Participation is the number of partial signatures submitted by share index.
+	Participation map[int]int
+	// ExpectedPerPeer is the number of partial signatures expected from each peer.
+	ExpectedPerPeer int
+	// PubKeys are the validators the duty was tracked for.
+	PubKeys []core.PubKey
+	// Inclusions are the on-chain inclusion check results by validator, a nil error indicates inclusion.
+	// Validators not checked for inclusion are absent.
+	Inclusions map[core.PubKey]error
+}
+
+// Subscribe registers a subscriber of analysed duty outcomes. Duties not expected to be
+// performed by this node (like non-selected aggregations) are not reported.
+// It is not thread safe and must be called before Run.
+func (t *Tracker) Subscribe(fn func(context.Context, Outcome)) {
+	t.subs = append(t.subs, fn)
 }
 
 // New returns a new Tracker. The deleter deadliner must return well after analyser deadliner since duties of the same slot are often analysed together.
@@ -161,12 +197,62 @@ func (t *Tracker) Run(ctx context.Context) error {
 			// Analyse peer participation
 			participatedShares, unexpectedShares, expectedPerPeer := analyseParticipation(duty, t.events)
 			t.participationReporter(ctx, duty, failed, participatedShares, unexpectedShares, expectedPerPeer)
+
+			if !failed && failedStep == fetcher {
+				continue // Ignore duties not expected to be performed.
+			}
+
+			outcome := Outcome{
+				Duty:            duty,
+				Failed:          failed,
+				Step:            lastStep(duty.Type).String(),
+				Participation:   participatedShares,
+				ExpectedPerPeer: expectedPerPeer,
+				PubKeys:         dutyPubKeys(t.events[duty]),
+				Inclusions:      dutyInclusions(t.events[duty]),
+			}
+			if failed {
+				outcome.Step = failedStep.String()
+				outcome.ReasonCode = reason.Code
+				outcome.Reason = reason.Short
+				if failedErr != nil {
+					outcome.Error = failedErr.Error()
+				}
+			}
+
+			for _, sub := range t.subs {
+				sub(ctx, outcome)
+			}
 		case duty := <-t.deleter.C():
 			delete(t.events, duty)
 		}
 	}
 }
 
+// dutyPubKeys returns the sorted validators of the events.
+func dutyPubKeys(es []event) []core.PubKey {
+	unique := make(map[core.PubKey]bool)
+	for _, e := range es {
+		if e.pubkey != "" {
+			unique[e.pubkey] = true
+		}
+	}
+
+	return slices.Sorted(maps.Keys(unique))
+}
+
+// dutyInclusions returns the on-chain inclusion check results by validator of the events.
+func dutyInclusions(es []event) map[core.PubKey]error {
+	resp := make(map[core.PubKey]error)
+	for _, e := range es {
+		if e.step == chainInclusion {
+			resp[e.pubkey] = e.stepErr
+		}
+	}
+
+	return resp
+}
+
 // dutyFailedStep returns true if the duty failed. It also returns the step where the
 // duty got stuck and the last error that component returned.
 // If the duty didn't fail, it returns false and the zero step and a nil error.
@@ -59,6 +59,11 @@ func TestTrackerFailedDuty(t *testing.T) {
 			require.True(t, failed)
 		}
 
+		var outcomes []Outcome
+		tr.Subscribe(func(_ context.Context, outcome Outcome) {
+			outcomes = append(outcomes, outcome)
+		})
+
 		go func() {
 			for _, td := range testData {
 				tr.FetcherFetched(td.duty, td.defSet, nil)
@@ -73,6 +78,15 @@ func TestTrackerFailedDuty(t *testing.T) {
 		}()
 
 		require.ErrorIs(t, tr.Run(ctx), context.Canceled)
+
+		require.Len(t, outcomes, len(testData))
+		require.Equal(t, testData[0].duty, outcomes[0].Duty)
+		require.True(t, outcomes[0].Failed)
+		require.Equal(t, "consensus", outcomes[0].Step)
+		require.Equal(t, reasonNoConsensus.Code, outcomes[0].ReasonCode)
+		require.Equal(t, consensusErr.Error(), outcomes[0].Error)
+		require.Len(t, outcomes[0].PubKeys, len(testData[0].defSet))
+		require.Empty(t, outcomes[0].Inclusions)
 	})
 
 	t.Run("Success", func(t *testing.T) {
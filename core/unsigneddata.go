@@ -4,12 +4,14 @@ package core
 
 import (
 	"encoding/json"
+	"math/big"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2electra "github.com/attestantio/go-eth2-client/api/v1/electra"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -24,16 +26,19 @@ import (
 var (
 	_ UnsignedData = AttestationData{}
 	_ UnsignedData = AggregatedAttestation{}
+	_ UnsignedData = VersionedAggregatedAttestation{}
 	_ UnsignedData = VersionedProposal{}
 	_ UnsignedData = SyncContribution{}
 
 	// Some types also support SSZ marshalling and unmarshalling.
 	_ ssz.Marshaler   = AttestationData{}
 	_ ssz.Marshaler   = AggregatedAttestation{}
+	_ ssz.Marshaler   = VersionedAggregatedAttestation{}
 	_ ssz.Marshaler   = VersionedProposal{}
 	_ ssz.Marshaler   = SyncContribution{}
 	_ ssz.Unmarshaler = new(AttestationData)
 	_ ssz.Unmarshaler = new(AggregatedAttestation)
+	_ ssz.Unmarshaler = new(VersionedAggregatedAttestation)
 	_ ssz.Unmarshaler = new(VersionedProposal)
 	_ ssz.Unmarshaler = new(SyncContribution)
 )
@@ -136,6 +141,54 @@ func (a *AggregatedAttestation) UnmarshalSSZ(b []byte) error {
 	return a.Attestation.UnmarshalSSZ(b)
 }
 
+// NewVersionedAggregatedAttestation validates and returns a new versioned aggregated attestation.
+func NewVersionedAggregatedAttestation(att *eth2spec.VersionedAttestation) (VersionedAggregatedAttestation, error) {
+	if err := validateVersionedAttestation(att); err != nil {
+		return VersionedAggregatedAttestation{}, err
+	}
+
+	return VersionedAggregatedAttestation{VersionedAttestation: *att}, nil
+}
+
+// versionedAggregatedAttestationFromLegacy returns a VersionedAggregatedAttestation from a legacy
+// unversioned aggregated attestation. See versionedAttestationFromLegacy for why it is assumed to be deneb.
+func versionedAggregatedAttestationFromLegacy(a AggregatedAttestation) VersionedAggregatedAttestation {
+	return VersionedAggregatedAttestation{VersionedAttestation: eth2spec.VersionedAttestation{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   &a.Attestation,
+	}}
+}
+
+// VersionedAggregatedAttestation wraps an unsigned versioned aggregated attestation and implements the UnsignedData interface.
+type VersionedAggregatedAttestation struct {
+	eth2spec.VersionedAttestation
+}
+
+func (a VersionedAggregatedAttestation) Clone() (UnsignedData, error) {
+	var resp VersionedAggregatedAttestation
+	err := cloneJSONMarshaler(a, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "clone versioned aggregated attestation")
+	}
+
+	return resp, nil
+}
+
+func (a VersionedAggregatedAttestation) MarshalJSON() ([]byte, error) {
+	return marshalVersionedAttestationJSON(a.VersionedAttestation)
+}
+
+func (a *VersionedAggregatedAttestation) UnmarshalJSON(input []byte) error {
+	att, err := unmarshalVersionedAttestationJSON(input)
+	if err != nil {
+		return err
+	}
+
+	a.VersionedAttestation = att
+
+	return nil
+}
+
 // NewVersionedProposal validates and returns a new wrapped VersionedProposal.
 func NewVersionedProposal(proposal *eth2api.VersionedProposal) (VersionedProposal, error) {
 	switch proposal.Version {
@@ -168,6 +221,13 @@ func NewVersionedProposal(proposal *eth2api.VersionedProposal) (VersionedProposa
 		if proposal.DenebBlinded == nil && proposal.Blinded {
 			return VersionedProposal{}, errors.New("no deneb blinded block")
 		}
+	case eth2spec.DataVersionElectra:
+		if proposal.Electra == nil && !proposal.Blinded {
+			return VersionedProposal{}, errors.New("no electra block")
+		}
+		if proposal.ElectraBlinded == nil && proposal.Blinded {
+			return VersionedProposal{}, errors.New("no electra blinded block")
+		}
 	default:
 		return VersionedProposal{}, errors.New("unknown version")
 	}
@@ -187,6 +247,14 @@ func (p VersionedProposal) Clone() (UnsignedData, error) {
 		return nil, errors.Wrap(err, "clone block")
 	}
 
+	// Block values are not serialised, so copy them explicitly.
+	if p.ExecutionValue != nil {
+		resp.ExecutionValue = new(big.Int).Set(p.ExecutionValue)
+	}
+	if p.ConsensusValue != nil {
+		resp.ConsensusValue = new(big.Int).Set(p.ConsensusValue)
+	}
+
 	return resp, nil
 }
 
@@ -216,6 +284,12 @@ func (p VersionedProposal) MarshalJSON() ([]byte, error) {
 		} else {
 			marshaller = p.Deneb
 		}
+	case eth2spec.DataVersionElectra:
+		if p.Blinded {
+			marshaller = p.ElectraBlinded
+		} else {
+			marshaller = p.Electra
+		}
 	default:
 		return nil, errors.New("unknown version")
 	}
@@ -314,6 +388,20 @@ func (p *VersionedProposal) UnmarshalJSON(input []byte) error {
 			}
 			resp.Deneb = block
 		}
+	case eth2spec.DataVersionElectra:
+		if raw.Blinded {
+			block := new(eth2electra.BlindedBeaconBlock)
+			if err := json.Unmarshal(raw.Block, &block); err != nil {
+				return errors.Wrap(err, "unmarshal electra blinded")
+			}
+			resp.ElectraBlinded = block
+		} else {
+			block := new(eth2electra.BlockContents)
+			if err := json.Unmarshal(raw.Block, &block); err != nil {
+				return errors.Wrap(err, "unmarshal electra")
+			}
+			resp.Electra = block
+		}
 	default:
 		return errors.New("unknown version")
 	}
@@ -384,12 +472,18 @@ func unmarshalUnsignedData(typ DutyType, data []byte) (UnsignedData, error) {
 
 		return resp, nil
 	case DutyAggregator:
-		var resp AggregatedAttestation
-		if err := unmarshal(data, &resp); err != nil {
+		var resp VersionedAggregatedAttestation
+		if err := unmarshal(data, &resp); err == nil {
+			return resp, nil
+		}
+
+		// Fallback to legacy aggregated attestations sent by peers that do not support versioned attestations.
+		var legacy AggregatedAttestation
+		if err := unmarshal(data, &legacy); err != nil {
 			return nil, errors.Wrap(err, "unmarshal aggregated attestation")
 		}
 
-		return resp, nil
+		return versionedAggregatedAttestationFromLegacy(legacy), nil
 	case DutySyncContribution:
 		var resp SyncContribution
 		if err := unmarshal(data, &resp); err != nil {
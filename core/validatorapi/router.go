@@ -26,13 +26,16 @@ import (
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2electra "github.com/attestantio/go-eth2-client/api/v1/electra"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/go-bitfield"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -40,6 +43,7 @@ import (
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
 )
@@ -119,7 +123,13 @@ func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEn
 		{
 			Name:    "submit_attestations",
 			Path:    "/eth/v1/beacon/pool/attestations",
-			Handler: submitAttestations(h),
+			Handler: submitAttestations(h, eth2Cl),
+			Methods: []string{http.MethodPost},
+		},
+		{
+			Name:    "submit_attestations_v2",
+			Path:    "/eth/v2/beacon/pool/attestations",
+			Handler: submitAttestationsV2(h, eth2Cl),
 			Methods: []string{http.MethodPost},
 		},
 		{
@@ -209,13 +219,25 @@ func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEn
 		{
 			Name:    "aggregate_attestation",
 			Path:    "/eth/v1/validator/aggregate_attestation",
-			Handler: aggregateAttestation(h),
+			Handler: aggregateAttestation(h, eth2Cl),
+			Methods: []string{http.MethodGet},
+		},
+		{
+			Name:    "aggregate_attestation_v2",
+			Path:    "/eth/v2/validator/aggregate_attestation",
+			Handler: aggregateAttestationV2(h),
 			Methods: []string{http.MethodGet},
 		},
 		{
 			Name:    "submit_aggregate_and_proofs",
 			Path:    "/eth/v1/validator/aggregate_and_proofs",
-			Handler: submitAggregateAttestations(h),
+			Handler: submitAggregateAttestations(h, eth2Cl),
+			Methods: []string{http.MethodPost},
+		},
+		{
+			Name:    "submit_aggregate_and_proofs_v2",
+			Path:    "/eth/v2/validator/aggregate_and_proofs",
+			Handler: submitAggregateAttestationsV2(h, eth2Cl),
 			Methods: []string{http.MethodPost},
 		},
 		{
@@ -450,8 +472,8 @@ func attestationData(p eth2client.AttestationDataProvider) handlerFunc {
 	}
 }
 
-// submitAttestations returns a handler function for the attestation submitter endpoint.
-func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
+// submitAttestations returns a handler function for the v1 (pre-electra) attestation submitter endpoint.
+func submitAttestations(p eth2client.AttestationsSubmitter, eth2Cl eth2wrap.Client) handlerFunc {
 	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
 		var atts []*eth2p0.Attestation
 		err := unmarshal(typ, body, &atts)
@@ -459,8 +481,89 @@ func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
 			return nil, nil, errors.Wrap(err, "unmarshal attestations")
 		}
 
-		return nil, nil, p.SubmitAttestations(ctx, atts)
+		versioned, err := phase0VersionedAttestations(ctx, eth2Cl, atts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, p.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: versioned})
+	}
+}
+
+// submitAttestationsV2 returns a handler function for the v2 attestation submitter endpoint.
+// It accepts electra single attestations and pre-electra phase0 attestations.
+func submitAttestationsV2(p eth2client.AttestationsSubmitter, eth2Cl eth2wrap.Client) handlerFunc {
+	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
+		var singleAtts []*electra.SingleAttestation
+		if err := unmarshal(typ, body, &singleAtts); err == nil {
+			var versioned []*eth2spec.VersionedAttestation
+			for _, single := range singleAtts {
+				versioned = append(versioned, electraVersionedAttestation(single))
+			}
+
+			return nil, nil, p.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: versioned})
+		}
+
+		var atts []*eth2p0.Attestation
+		err := unmarshal(typ, body, &atts)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unmarshal attestations")
+		}
+
+		versioned, err := phase0VersionedAttestations(ctx, eth2Cl, atts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, p.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: versioned})
+	}
+}
+
+// electraVersionedAttestation returns a versioned electra attestation for the single attestation.
+// The aggregation bits are left empty since they are populated from the attester duty.
+func electraVersionedAttestation(single *electra.SingleAttestation) *eth2spec.VersionedAttestation {
+	commBits := bitfield.NewBitvector64()
+	commBits.SetBitAt(uint64(single.CommitteeIndex), true)
+	vIdx := single.AttesterIndex
+
+	return &eth2spec.VersionedAttestation{
+		Version:        eth2spec.DataVersionElectra,
+		ValidatorIndex: &vIdx,
+		Electra: &electra.Attestation{
+			Data:          single.Data,
+			Signature:     single.Signature,
+			CommitteeBits: commBits,
+		},
+	}
+}
+
+// phase0VersionedAttestations returns the phase0 attestations wrapped in the fork version of their slot.
+// It returns an error for attestations of electra or later slots.
+func phase0VersionedAttestations(ctx context.Context, eth2Cl eth2wrap.Client, atts []*eth2p0.Attestation) ([]*eth2spec.VersionedAttestation, error) {
+	var resp []*eth2spec.VersionedAttestation
+	for _, att := range atts {
+		if att.Data == nil {
+			return nil, errors.New("missing attestation data")
+		}
+
+		version, err := eth2util.DataVersionAtSlot(ctx, eth2Cl, att.Data.Slot)
+		if err != nil {
+			return nil, err
+		}
+
+		versioned, err := eth2util.NewPhase0VersionedAttestation(version, att)
+		if err != nil {
+			return nil, apiError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "phase0 attestation not supported for fork",
+				Err:        err,
+			}
+		}
+
+		resp = append(resp, versioned)
 	}
+
+	return resp, nil
 }
 
 // proposerDuties returns a handler function for the proposer duty endpoint.
@@ -772,6 +875,19 @@ func createProposeBlockResponse(proposal *eth2api.VersionedProposal) (*proposeBl
 			}
 			blockData = proposal.Deneb
 		}
+	case eth2spec.DataVersionElectra:
+		version = eth2spec.DataVersionElectra.String()
+		if proposal.Blinded {
+			if proposal.ElectraBlinded == nil {
+				return nil, errors.New("no electra blinded block")
+			}
+			blockData = proposal.ElectraBlinded
+		} else {
+			if proposal.Electra == nil {
+				return nil, errors.New("no electra block")
+			}
+			blockData = proposal.Electra
+		}
 	default:
 		if proposal.Blinded {
 			return nil, errors.New("invalid blinded block")
@@ -791,8 +907,21 @@ func createProposeBlockResponse(proposal *eth2api.VersionedProposal) (*proposeBl
 
 func submitProposal(p eth2client.ProposalSubmitter) handlerFunc {
 	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
+		electraBlock := new(eth2electra.SignedBlockContents)
+		err := unmarshal(typ, body, electraBlock)
+		if err == nil {
+			block := &eth2api.VersionedSignedProposal{
+				Version: eth2spec.DataVersionElectra,
+				Electra: electraBlock,
+			}
+
+			return nil, nil, p.SubmitProposal(ctx, &eth2api.SubmitProposalOpts{
+				Proposal: block,
+			})
+		}
+
 		denebBlock := new(eth2deneb.SignedBlockContents)
-		err := unmarshal(typ, body, denebBlock)
+		err = unmarshal(typ, body, denebBlock)
 		if err == nil {
 			block := &eth2api.VersionedSignedProposal{
 				Version: eth2spec.DataVersionDeneb,
@@ -862,9 +991,22 @@ func submitProposal(p eth2client.ProposalSubmitter) handlerFunc {
 
 func submitBlindedBlock(p eth2client.BlindedProposalSubmitter) handlerFunc {
 	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
-		// The blinded block maybe either bellatrix, capella or deneb.
+		// The blinded block maybe either bellatrix, capella, deneb or electra.
+		electraBlock := new(eth2electra.SignedBlindedBeaconBlock)
+		err := unmarshal(typ, body, electraBlock)
+		if err == nil {
+			block := &eth2api.VersionedSignedBlindedProposal{
+				Version: eth2spec.DataVersionElectra,
+				Electra: electraBlock,
+			}
+
+			return nil, nil, p.SubmitBlindedProposal(ctx, &eth2api.SubmitBlindedProposalOpts{
+				Proposal: block,
+			})
+		}
+
 		denebBlock := new(eth2deneb.SignedBlindedBeaconBlock)
-		err := unmarshal(typ, body, denebBlock)
+		err = unmarshal(typ, body, denebBlock)
 		if err == nil {
 			block := &eth2api.VersionedSignedBlindedProposal{
 				Version: eth2spec.DataVersionDeneb,
@@ -983,7 +1125,7 @@ func proposerConfig(p eth2exp.ProposerConfigProvider) handlerFunc {
 	}
 }
 
-func aggregateAttestation(p eth2client.AggregateAttestationProvider) handlerFunc {
+func aggregateAttestation(p eth2client.AggregateAttestationProvider, eth2Cl eth2wrap.Client) handlerFunc {
 	return func(ctx context.Context, _ map[string]string, query url.Values, _ contentType, _ []byte) (any, http.Header, error) {
 		slot, err := uintQuery(query, "slot")
 		if err != nil {
@@ -995,6 +1137,17 @@ func aggregateAttestation(p eth2client.AggregateAttestationProvider) handlerFunc
 			return nil, nil, err
 		}
 
+		version, err := eth2util.DataVersionAtSlot(ctx, eth2Cl, eth2p0.Slot(slot))
+		if err != nil {
+			return nil, nil, err
+		} else if version >= eth2spec.DataVersionElectra {
+			return nil, nil, apiError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "v1 aggregate attestation not supported for fork, use v2",
+				Err:        errors.New("v1 aggregate attestation not supported for fork", z.Str("version", version.String())),
+			}
+		}
+
 		opts := &eth2api.AggregateAttestationOpts{
 			Slot:                eth2p0.Slot(slot),
 			AttestationDataRoot: attDataRoot,
@@ -1003,7 +1156,11 @@ func aggregateAttestation(p eth2client.AggregateAttestationProvider) handlerFunc
 		if err != nil {
 			return nil, nil, err
 		}
-		data := eth2Resp.Data
+
+		data, err := phase0Attestation(eth2Resp.Data)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		return struct {
 			Data *eth2p0.Attestation `json:"data"`
@@ -1013,7 +1170,83 @@ func aggregateAttestation(p eth2client.AggregateAttestationProvider) handlerFunc
 	}
 }
 
-func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) handlerFunc {
+func aggregateAttestationV2(p eth2client.AggregateAttestationProvider) handlerFunc {
+	return func(ctx context.Context, _ map[string]string, query url.Values, _ contentType, _ []byte) (any, http.Header, error) {
+		slot, err := uintQuery(query, "slot")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var attDataRoot eth2p0.Root
+		if err := hexQueryFixed(query, "attestation_data_root", attDataRoot[:]); err != nil {
+			return nil, nil, err
+		}
+
+		commIdx, err := uintQuery(query, "committee_index")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		opts := &eth2api.AggregateAttestationOpts{
+			Slot:                eth2p0.Slot(slot),
+			AttestationDataRoot: attDataRoot,
+			CommitteeIndex:      eth2p0.CommitteeIndex(commIdx),
+		}
+		eth2Resp, err := p.AggregateAttestation(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var data any
+		switch eth2Resp.Data.Version {
+		case eth2spec.DataVersionElectra:
+			data = eth2Resp.Data.Electra
+		default:
+			data, err = phase0Attestation(eth2Resp.Data)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		resHeaders := make(http.Header)
+		resHeaders.Add(versionHeader, eth2Resp.Data.Version.String())
+
+		return struct {
+			Version string `json:"version"`
+			Data    any    `json:"data"`
+		}{
+			Version: eth2Resp.Data.Version.String(),
+			Data:    data,
+		}, resHeaders, nil
+	}
+}
+
+// phase0Attestation returns the phase0 attestation of the pre-electra versioned attestation.
+func phase0Attestation(att *eth2spec.VersionedAttestation) (*eth2p0.Attestation, error) {
+	var resp *eth2p0.Attestation
+	switch att.Version {
+	case eth2spec.DataVersionPhase0:
+		resp = att.Phase0
+	case eth2spec.DataVersionAltair:
+		resp = att.Altair
+	case eth2spec.DataVersionBellatrix:
+		resp = att.Bellatrix
+	case eth2spec.DataVersionCapella:
+		resp = att.Capella
+	case eth2spec.DataVersionDeneb:
+		resp = att.Deneb
+	default:
+		return nil, errors.New("not a phase0 attestation version", z.Str("version", att.Version.String()))
+	}
+
+	if resp == nil {
+		return nil, errors.New("missing attestation", z.Str("version", att.Version.String()))
+	}
+
+	return resp, nil
+}
+
+func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter, eth2Cl eth2wrap.Client) handlerFunc {
 	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
 		var aggs []*eth2p0.SignedAggregateAndProof
 		err := unmarshal(typ, body, &aggs)
@@ -1021,7 +1254,12 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 			return nil, nil, errors.Wrap(err, "unmarshal signed aggregate and proofs")
 		}
 
-		err = s.SubmitAggregateAttestations(ctx, aggs)
+		versioned, err := phase0VersionedAggregateAndProofs(ctx, eth2Cl, aggs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = s.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{SignedAggregateAndProofs: versioned})
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1030,6 +1268,70 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 	}
 }
 
+func submitAggregateAttestationsV2(s eth2client.AggregateAttestationsSubmitter, eth2Cl eth2wrap.Client) handlerFunc {
+	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
+		var versioned []*eth2spec.VersionedSignedAggregateAndProof
+
+		var electraAggs []*electra.SignedAggregateAndProof
+		if err := unmarshal(typ, body, &electraAggs); err == nil {
+			for _, agg := range electraAggs {
+				versioned = append(versioned, &eth2spec.VersionedSignedAggregateAndProof{
+					Version: eth2spec.DataVersionElectra,
+					Electra: agg,
+				})
+			}
+		} else {
+			var aggs []*eth2p0.SignedAggregateAndProof
+			err := unmarshal(typ, body, &aggs)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "unmarshal signed aggregate and proofs")
+			}
+
+			versioned, err = phase0VersionedAggregateAndProofs(ctx, eth2Cl, aggs)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		err := s.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{SignedAggregateAndProofs: versioned})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, nil, nil
+	}
+}
+
+// phase0VersionedAggregateAndProofs returns the phase0 signed aggregate and proofs wrapped in the fork version of their slot.
+// It returns an error for aggregates of electra or later slots.
+func phase0VersionedAggregateAndProofs(ctx context.Context, eth2Cl eth2wrap.Client, aggs []*eth2p0.SignedAggregateAndProof,
+) ([]*eth2spec.VersionedSignedAggregateAndProof, error) {
+	var resp []*eth2spec.VersionedSignedAggregateAndProof
+	for _, agg := range aggs {
+		if agg.Message == nil || agg.Message.Aggregate == nil || agg.Message.Aggregate.Data == nil {
+			return nil, errors.New("invalid signed aggregate and proof")
+		}
+
+		version, err := eth2util.DataVersionAtSlot(ctx, eth2Cl, agg.Message.Aggregate.Data.Slot)
+		if err != nil {
+			return nil, err
+		}
+
+		versioned, err := eth2util.NewPhase0VersionedSignedAggregateAndProof(version, agg)
+		if err != nil {
+			return nil, apiError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "phase0 aggregate and proof not supported for fork",
+				Err:        err,
+			}
+		}
+
+		resp = append(resp, versioned)
+	}
+
+	return resp, nil
+}
+
 func submitSyncCommitteeMessages(s eth2client.SyncCommitteeMessagesSubmitter) handlerFunc {
 	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
 		var msgs []*altair.SyncCommitteeMessage
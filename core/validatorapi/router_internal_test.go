@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
@@ -1423,44 +1424,174 @@ func TestBeaconCommitteeSelections(t *testing.T) {
 func TestSubmitAggregateAttestations(t *testing.T) {
 	ctx := context.Background()
 
-	const vIdx = 1
-
-	agg := &eth2p0.SignedAggregateAndProof{
-		Message: &eth2p0.AggregateAndProof{
-			AggregatorIndex: vIdx,
-			Aggregate:       testutil.RandomAttestation(),
-			SelectionProof:  testutil.RandomEth2Signature(),
+	tests := []struct {
+		name    string
+		agg     *eth2spec.VersionedSignedAggregateAndProof
+		electra bool
+	}{
+		{
+			name: "deneb",
+			agg:  testutil.RandomDenebVersionedSignedAggregateAndProof(),
+		},
+		{
+			name:    "electra",
+			agg:     testutil.RandomElectraVersionedSignedAggregateAndProof(),
+			electra: true,
 		},
-		Signature: testutil.RandomEth2Signature(),
 	}
 
-	handler := testHandler{
-		SubmitAggregateAttestationsFunc: func(_ context.Context, aggregateAndProofs []*eth2p0.SignedAggregateAndProof) error {
-			require.Equal(t, agg, aggregateAndProofs[0])
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := testHandler{
+				SubmitAggregateAttestationsFunc: func(_ context.Context, opts *eth2api.SubmitAggregateAttestationsOpts) error {
+					require.Equal(t, test.agg, opts.SignedAggregateAndProofs[0])
 
-			return nil
-		},
+					return nil
+				},
+			}
+
+			proxy := httptest.NewServer(handler.newBeaconHandler(t))
+			defer proxy.Close()
+
+			r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL, electra: test.electra}, true)
+			require.NoError(t, err)
+
+			server := httptest.NewServer(r)
+			defer server.Close()
+
+			var eth2Svc eth2client.Service
+			eth2Svc, err = eth2http.New(ctx,
+				eth2http.WithLogLevel(1),
+				eth2http.WithAddress(server.URL),
+			)
+			require.NoError(t, err)
+
+			eth2Cl := eth2wrap.AdaptEth2HTTP(eth2Svc.(*eth2http.Service), time.Second)
+			err = eth2Cl.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{
+				SignedAggregateAndProofs: []*eth2spec.VersionedSignedAggregateAndProof{test.agg},
+			})
+			require.NoError(t, err)
+		})
 	}
+}
 
-	proxy := httptest.NewServer(handler.newBeaconHandler(t))
-	defer proxy.Close()
+func TestSubmitAttestations(t *testing.T) {
+	ctx := context.Background()
 
-	r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL}, true)
-	require.NoError(t, err)
+	t.Run("deneb", func(t *testing.T) {
+		att := testutil.RandomDenebVersionedAttestation()
 
-	server := httptest.NewServer(r)
-	defer server.Close()
+		handler := testHandler{
+			SubmitAttestationsFunc: func(_ context.Context, opts *eth2api.SubmitAttestationsOpts) error {
+				require.Equal(t, att, opts.Attestations[0])
 
-	var eth2Svc eth2client.Service
-	eth2Svc, err = eth2http.New(ctx,
-		eth2http.WithLogLevel(1),
-		eth2http.WithAddress(server.URL),
-	)
-	require.NoError(t, err)
+				return nil
+			},
+		}
 
-	eth2Cl := eth2wrap.AdaptEth2HTTP(eth2Svc.(*eth2http.Service), time.Second)
-	err = eth2Cl.SubmitAggregateAttestations(ctx, []*eth2p0.SignedAggregateAndProof{agg})
-	require.NoError(t, err)
+		testRouter(t, handler, func(ctx context.Context, cl *eth2http.Service) {
+			err := cl.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{
+				Attestations: []*eth2spec.VersionedAttestation{att},
+			})
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("electra", func(t *testing.T) {
+		att := testutil.RandomElectraVersionedAttestation()
+
+		handler := testHandler{
+			SubmitAttestationsFunc: func(_ context.Context, opts *eth2api.SubmitAttestationsOpts) error {
+				// Single attestations do not include aggregation bits, these are populated from the duty.
+				expect := *att.Electra
+				expect.AggregationBits = nil
+				require.Equal(t, &eth2spec.VersionedAttestation{
+					Version:        eth2spec.DataVersionElectra,
+					ValidatorIndex: att.ValidatorIndex,
+					Electra:        &expect,
+				}, opts.Attestations[0])
+
+				return nil
+			},
+		}
+
+		testRouter(t, handler, func(ctx context.Context, cl *eth2http.Service) {
+			err := cl.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{
+				Attestations: []*eth2spec.VersionedAttestation{att},
+			})
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("v1 electra", func(t *testing.T) {
+		handler := testHandler{}
+
+		proxy := httptest.NewServer(handler.newBeaconHandler(t))
+		defer proxy.Close()
+
+		r, err := NewRouter(ctx, handler, testBeaconAddr{addr: proxy.URL, electra: true}, true)
+		require.NoError(t, err)
+
+		server := httptest.NewServer(r)
+		defer server.Close()
+
+		b, err := json.Marshal([]*eth2p0.Attestation{testutil.RandomAttestation()})
+		require.NoError(t, err)
+
+		resp, err := http.Post(server.URL+"/eth/v1/beacon/pool/attestations", "application/json", bytes.NewReader(b))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestAggregateAttestation(t *testing.T) {
+	tests := []struct {
+		name string
+		att  *eth2spec.VersionedAttestation
+	}{
+		{
+			name: "deneb",
+			att:  testutil.RandomDenebVersionedAttestation(),
+		},
+		{
+			name: "electra",
+			att: &eth2spec.VersionedAttestation{
+				Version: eth2spec.DataVersionElectra,
+				Electra: testutil.RandomElectraAttestation(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := test.att.Data()
+			require.NoError(t, err)
+			root, err := data.HashTreeRoot()
+			require.NoError(t, err)
+			commIdx, err := test.att.CommitteeIndex()
+			require.NoError(t, err)
+
+			handler := testHandler{
+				AggregateAttestationFunc: func(_ context.Context, opts *eth2api.AggregateAttestationOpts) (*eth2api.Response[*eth2spec.VersionedAttestation], error) {
+					require.Equal(t, data.Slot, opts.Slot)
+					require.Equal(t, eth2p0.Root(root), opts.AttestationDataRoot)
+					require.Equal(t, commIdx, opts.CommitteeIndex)
+
+					return wrapResponse(test.att), nil
+				},
+			}
+
+			testRouter(t, handler, func(ctx context.Context, cl *eth2http.Service) {
+				resp, err := cl.AggregateAttestation(ctx, &eth2api.AggregateAttestationOpts{
+					Slot:                data.Slot,
+					AttestationDataRoot: root,
+					CommitteeIndex:      commIdx,
+				})
+				require.NoError(t, err)
+				require.Equal(t, test.att, resp.Data)
+			})
+		})
+	}
 }
 
 func TestGetExecutionOptimisticFromMetadata(t *testing.T) {
@@ -1609,6 +1740,30 @@ func TestCreateProposeBlindedBlockResponse(t *testing.T) {
 		})
 		require.ErrorContains(t, err, "no deneb blinded block")
 	})
+
+	t.Run("electra", func(t *testing.T) {
+		p := &eth2api.VersionedProposal{
+			Version:        eth2spec.DataVersionElectra,
+			ElectraBlinded: testutil.RandomElectraBlindedBeaconBlock(),
+			Blinded:        true,
+			ConsensusValue: big.NewInt(123),
+			ExecutionValue: big.NewInt(456),
+		}
+
+		pp, err := createProposeBlockResponse(p)
+		require.NoError(t, err)
+		require.NotNil(t, pp)
+		require.Equal(t, p.Version.String(), pp.Version)
+		require.Equal(t, p.ElectraBlinded, pp.Data)
+		require.Equal(t, p.ConsensusValue.String(), pp.ConsensusBlockValue)
+		require.Equal(t, p.ExecutionValue.String(), pp.ExecutionPayloadValue)
+
+		_, err = createProposeBlockResponse(&eth2api.VersionedProposal{
+			Version: eth2spec.DataVersionElectra,
+			Blinded: true,
+		})
+		require.ErrorContains(t, err, "no electra blinded block")
+	})
 }
 
 func TestCreateProposeBlockResponse(t *testing.T) {
@@ -1726,6 +1881,26 @@ func TestCreateProposeBlockResponse(t *testing.T) {
 		})
 		require.ErrorContains(t, err, "no deneb block")
 	})
+
+	t.Run("electra", func(t *testing.T) {
+		p := &eth2api.VersionedProposal{
+			Version:        eth2spec.DataVersionElectra,
+			Electra:        testutil.RandomElectraVersionedProposal().Electra,
+			ConsensusValue: big.NewInt(123),
+			ExecutionValue: big.NewInt(456),
+		}
+
+		pp, err := createProposeBlockResponse(p)
+		require.NoError(t, err)
+		require.NotNil(t, pp)
+		require.Equal(t, p.Version.String(), pp.Version)
+		require.Equal(t, p.Electra, pp.Data)
+
+		_, err = createProposeBlockResponse(&eth2api.VersionedProposal{
+			Version: eth2spec.DataVersionElectra,
+		})
+		require.ErrorContains(t, err, "no electra block")
+	})
 }
 
 // testRouter is a helper function to test router endpoints with an eth2http client. The outer test
@@ -1795,7 +1970,9 @@ type testHandler struct {
 	SubmitVoluntaryExitFunc                func(ctx context.Context, exit *eth2p0.SignedVoluntaryExit) error
 	SubmitValidatorRegistrationsFunc       func(ctx context.Context, registrations []*eth2api.VersionedSignedValidatorRegistration) error
 	AggregateBeaconCommitteeSelectionsFunc func(ctx context.Context, selections []*eth2exp.BeaconCommitteeSelection) ([]*eth2exp.BeaconCommitteeSelection, error)
-	SubmitAggregateAttestationsFunc        func(ctx context.Context, aggregateAndProofs []*eth2p0.SignedAggregateAndProof) error
+	SubmitAggregateAttestationsFunc        func(ctx context.Context, opts *eth2api.SubmitAggregateAttestationsOpts) error
+	SubmitAttestationsFunc                 func(ctx context.Context, opts *eth2api.SubmitAttestationsOpts) error
+	AggregateAttestationFunc               func(ctx context.Context, opts *eth2api.AggregateAttestationOpts) (*eth2api.Response[*eth2spec.VersionedAttestation], error)
 	SubmitSyncCommitteeMessagesFunc        func(ctx context.Context, messages []*altair.SyncCommitteeMessage) error
 	SyncCommitteeDutiesFunc                func(ctx context.Context, opts *eth2api.SyncCommitteeDutiesOpts) (*eth2api.Response[[]*eth2v1.SyncCommitteeDuty], error)
 	SyncCommitteeContributionFunc          func(ctx context.Context, opts *eth2api.SyncCommitteeContributionOpts) (*eth2api.Response[*altair.SyncCommitteeContribution], error)
@@ -1857,8 +2034,16 @@ func (h testHandler) AggregateBeaconCommitteeSelections(ctx context.Context, sel
 	return h.AggregateBeaconCommitteeSelectionsFunc(ctx, selections)
 }
 
-func (h testHandler) SubmitAggregateAttestations(ctx context.Context, aggregateAndProofs []*eth2p0.SignedAggregateAndProof) error {
-	return h.SubmitAggregateAttestationsFunc(ctx, aggregateAndProofs)
+func (h testHandler) SubmitAggregateAttestations(ctx context.Context, opts *eth2api.SubmitAggregateAttestationsOpts) error {
+	return h.SubmitAggregateAttestationsFunc(ctx, opts)
+}
+
+func (h testHandler) SubmitAttestations(ctx context.Context, opts *eth2api.SubmitAttestationsOpts) error {
+	return h.SubmitAttestationsFunc(ctx, opts)
+}
+
+func (h testHandler) AggregateAttestation(ctx context.Context, opts *eth2api.AggregateAttestationOpts) (*eth2api.Response[*eth2spec.VersionedAttestation], error) {
+	return h.AggregateAttestationFunc(ctx, opts)
 }
 
 func (h testHandler) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
@@ -1893,7 +2078,8 @@ func (h testHandler) newBeaconHandler(t *testing.T) http.Handler {
 	})
 	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
 		res := map[string]any{
-			"SLOTS_PER_EPOCH": strconv.Itoa(slotsPerEpoch),
+			"SLOTS_PER_EPOCH":    strconv.Itoa(slotsPerEpoch),
+			"ELECTRA_FORK_EPOCH": strconv.FormatUint(math.MaxUint64, 10),
 		}
 		writeResponse(ctx, w, "", nest(res, "data"), nil)
 	})
@@ -1937,12 +2123,33 @@ func nest(data any, nests ...string) any {
 	return res
 }
 
-// testBeaconAddr implements eth2client.Service only returning an address.
+// testBeaconAddr implements eth2client.Service only returning an address and a spec
+// with all forks up to deneb, or electra if enabled, activated at genesis.
 type testBeaconAddr struct {
 	eth2wrap.Client
-	addr string
+	addr    string
+	electra bool
 }
 
 func (t testBeaconAddr) Address() string {
 	return t.addr
 }
+
+func (testBeaconAddr) SlotsPerEpoch(context.Context) (uint64, error) {
+	return slotsPerEpoch, nil
+}
+
+func (t testBeaconAddr) Spec(context.Context, *eth2api.SpecOpts) (*eth2api.Response[map[string]any], error) {
+	spec := map[string]any{
+		"SLOTS_PER_EPOCH":      uint64(slotsPerEpoch),
+		"ALTAIR_FORK_EPOCH":    uint64(0),
+		"BELLATRIX_FORK_EPOCH": uint64(0),
+		"CAPELLA_FORK_EPOCH":   uint64(0),
+		"DENEB_FORK_EPOCH":     uint64(0),
+	}
+	if t.electra {
+		spec["ELECTRA_FORK_EPOCH"] = uint64(0)
+	}
+
+	return wrapResponse(spec), nil
+}
@@ -3,11 +3,13 @@
 package validatorapi
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
 	"runtime"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
+	"github.com/prysmaticlabs/go-bitfield"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -33,8 +36,9 @@ import (
 )
 
 const (
-	gasLimit    = 30000000
-	zeroAddress = "0x0000000000000000000000000000000000000000"
+	// DefaultGasLimit is the default gas limit of builder registrations returned by the proposer config.
+	DefaultGasLimit = 30000000
+	zeroAddress     = "0x0000000000000000000000000000000000000000"
 )
 
 // SlotFromTimestamp returns the Ethereum slot associated to a timestamp, given the genesis configuration fetched
@@ -196,9 +200,12 @@ type Component struct {
 	awaitAttFunc              func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)
 	awaitProposalFunc         func(ctx context.Context, slot uint64) (*eth2api.VersionedProposal, error)
 	awaitSyncContributionFunc func(ctx context.Context, slot, subcommIdx uint64, beaconBlockRoot eth2p0.Root) (*altair.SyncCommitteeContribution, error)
-	awaitAggAttFunc           func(ctx context.Context, slot uint64, attestationRoot eth2p0.Root) (*eth2p0.Attestation, error)
+	awaitAggAttFunc           func(ctx context.Context, slot, commIdx uint64, attestationRoot eth2p0.Root) (*eth2spec.VersionedAttestation, error)
 	awaitAggSigDBFunc         func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
 	dutyDefFunc               func(ctx context.Context, duty core.Duty) (core.DutyDefinitionSet, error)
+	gasLimitFunc              func(core.PubKey) uint64
+	builderFunc               func(core.PubKey) bool
+	regTimestampFunc          func(core.PubKey) (time.Time, bool)
 	subs                      []func(context.Context, core.Duty, core.ParSignedDataSet) error
 }
 
@@ -234,7 +241,7 @@ func (c *Component) RegisterGetDutyDefinition(fn func(ctx context.Context, duty
 
 // RegisterAwaitAggAttestation registers a function to query an aggregated attestation.
 // It supports a single function, since it is an input of the component.
-func (c *Component) RegisterAwaitAggAttestation(fn func(ctx context.Context, slot uint64, attestationRoot eth2p0.Root) (*eth2p0.Attestation, error)) {
+func (c *Component) RegisterAwaitAggAttestation(fn func(ctx context.Context, slot, commIdx uint64, attestationRoot eth2p0.Root) (*eth2spec.VersionedAttestation, error)) {
 	c.awaitAggAttFunc = fn
 }
 
@@ -243,6 +250,17 @@ func (c *Component) RegisterAwaitAggSigDB(fn func(context.Context, core.Duty, co
 	c.awaitAggSigDBFunc = fn
 }
 
+// RegisterProposerOverrides registers functions overriding the gas limit, builder enablement and
+// builder registration timestamp by validator returned by ProposerConfig.
+// It only supports a single set of functions, since it is an input of the component.
+func (c *Component) RegisterProposerOverrides(gasLimitFunc func(core.PubKey) uint64, builderFunc func(core.PubKey) bool,
+	regTimestampFunc func(core.PubKey) (time.Time, bool),
+) {
+	c.gasLimitFunc = gasLimitFunc
+	c.builderFunc = builderFunc
+	c.regTimestampFunc = regTimestampFunc
+}
+
 // Subscribe registers a partial signed data set store function.
 // It supports multiple functions since it is the output of the component.
 func (c *Component) Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error) {
@@ -271,34 +289,45 @@ func (c Component) AttestationData(parent context.Context, opts *eth2api.Attesta
 }
 
 // SubmitAttestations implements the eth2client.AttestationsSubmitter for the router.
-func (c Component) SubmitAttestations(ctx context.Context, attestations []*eth2p0.Attestation) error {
-	duty := core.NewAttesterDuty(uint64(attestations[0].Data.Slot))
-	if len(attestations) > 0 {
+func (c Component) SubmitAttestations(ctx context.Context, opts *eth2api.SubmitAttestationsOpts) error {
+	if len(opts.Attestations) > 0 {
 		// Pick the first attestation slot to use as trace root.
+		data, err := opts.Attestations[0].Data()
+		if err != nil {
+			return errors.Wrap(err, "get attestation data")
+		}
+
 		var span trace.Span
-		ctx, span = core.StartDutyTrace(ctx, duty, "core/validatorapi.SubmitAttestations")
+		ctx, span = core.StartDutyTrace(ctx, core.NewAttesterDuty(uint64(data.Slot)), "core/validatorapi.SubmitAttestations")
 		defer span.End()
 	}
 
 	setsBySlot := make(map[uint64]core.ParSignedDataSet)
-	for _, att := range attestations {
-		slot := uint64(att.Data.Slot)
+	for _, att := range opts.Attestations {
+		data, err := att.Data()
+		if err != nil {
+			return errors.Wrap(err, "get attestation data")
+		}
+		slot := uint64(data.Slot)
 
-		// Determine the validator that sent this by mapping values from original AttestationDuty via the dutyDB
-		indices := att.AggregationBits.BitIndices()
-		if len(indices) != 1 {
-			return errors.New("unexpected number of aggregation bits",
-				z.Str("aggbits", fmt.Sprintf("%#x", []byte(att.AggregationBits))))
+		var pubkey core.PubKey
+		if att.Version >= eth2spec.DataVersionElectra {
+			pubkey, att, err = c.electraAttestation(ctx, att)
+			if err != nil {
+				return err
+			}
+		} else {
+			pubkey, err = c.attestationPubKey(ctx, att)
+			if err != nil {
+				return err
+			}
 		}
 
-		pubkey, err := c.pubKeyByAttFunc(ctx, slot, uint64(att.Data.Index), uint64(indices[0]))
+		parSigData, err := core.NewPartialVersionedAttestation(att, c.shareIdx)
 		if err != nil {
-			return errors.Wrap(err, "failed to find pubkey", z.U64("slot", slot),
-				z.Int("commIdx", int(att.Data.Index)), z.Int("valCommIdx", indices[0]))
+			return err
 		}
 
-		parSigData := core.NewPartialAttestation(att, c.shareIdx)
-
 		// Verify attestation signature
 		err = c.verifyPartialSig(ctx, parSigData, pubkey)
 		if err != nil {
@@ -332,6 +361,104 @@ func (c Component) SubmitAttestations(ctx context.Context, attestations []*eth2p
 	return nil
 }
 
+// attestationPubKey returns the validator public key of the pre-electra attestation by mapping
+// values from original AttestationDuty via the dutyDB.
+func (c Component) attestationPubKey(ctx context.Context, att *eth2spec.VersionedAttestation) (core.PubKey, error) {
+	data, err := att.Data()
+	if err != nil {
+		return "", errors.Wrap(err, "get attestation data")
+	}
+
+	aggBits, err := att.AggregationBits()
+	if err != nil {
+		return "", errors.Wrap(err, "get aggregation bits")
+	}
+
+	indices := aggBits.BitIndices()
+	if len(indices) != 1 {
+		return "", errors.New("unexpected number of aggregation bits",
+			z.Str("aggbits", fmt.Sprintf("%#x", []byte(aggBits))))
+	}
+
+	slot := uint64(data.Slot)
+	pubkey, err := c.pubKeyByAttFunc(ctx, slot, uint64(data.Index), uint64(indices[0]))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to find pubkey", z.U64("slot", slot),
+			z.Int("commIdx", int(data.Index)), z.Int("valCommIdx", indices[0]))
+	}
+
+	return pubkey, nil
+}
+
+// electraAttestation returns the validator public key of the electra attestation and the attestation
+// including its aggregation bits. Electra attestations are submitted by VCs as single attestations
+// identified by validator index, so the aggregation bits are populated from the attester duty.
+func (c Component) electraAttestation(ctx context.Context, att *eth2spec.VersionedAttestation,
+) (core.PubKey, *eth2spec.VersionedAttestation, error) {
+	if att.Electra == nil || att.Electra.Data == nil {
+		return "", nil, errors.New("invalid electra attestation")
+	} else if att.ValidatorIndex == nil {
+		return "", nil, errors.New("missing electra attestation validator index")
+	}
+
+	commIdx, err := att.Electra.CommitteeIndex()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "electra attestation committee index")
+	}
+
+	vals, err := c.eth2Cl.ActiveValidators(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	eth2Pubkey, ok := vals[*att.ValidatorIndex]
+	if !ok {
+		return "", nil, errors.New("validator not found", z.U64("vidx", uint64(*att.ValidatorIndex)))
+	}
+
+	pubkey, err := core.PubKeyFromBytes(eth2Pubkey[:])
+	if err != nil {
+		return "", nil, err
+	}
+
+	slot := uint64(att.Electra.Data.Slot)
+	defSet, err := c.dutyDefFunc(ctx, core.NewAttesterDuty(slot))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "get attester duty", z.U64("slot", slot))
+	}
+
+	def, ok := defSet[pubkey]
+	if !ok {
+		return "", nil, errors.New("attester duty not found", z.U64("slot", slot), z.Any("pubkey", pubkey))
+	}
+
+	attDef, ok := def.(core.AttesterDefinition)
+	if !ok {
+		return "", nil, errors.New("invalid attester definition")
+	} else if attDef.CommitteeIndex != commIdx {
+		return "", nil, errors.New("attestation committee index mismatches duty",
+			z.U64("commIdx", uint64(commIdx)), z.U64("duty_commIdx", uint64(attDef.CommitteeIndex)))
+	}
+
+	aggBits := bitfield.NewBitlist(attDef.CommitteeLength)
+	aggBits.SetBitAt(attDef.ValidatorCommitteeIndex, true)
+
+	if att.Electra.AggregationBits.Len() > 0 && !bytes.Equal(att.Electra.AggregationBits, aggBits) {
+		return "", nil, errors.New("attestation aggregation bits mismatches duty",
+			z.Str("aggbits", fmt.Sprintf("%#x", []byte(att.Electra.AggregationBits))))
+	}
+
+	electraAtt := *att.Electra
+	electraAtt.AggregationBits = aggBits
+	vIdx := *att.ValidatorIndex
+
+	return pubkey, &eth2spec.VersionedAttestation{
+		Version:        eth2spec.DataVersionElectra,
+		ValidatorIndex: &vIdx,
+		Electra:        &electraAtt,
+	}, nil
+}
+
 func (c Component) Proposal(ctx context.Context, opts *eth2api.ProposalOpts) (*eth2api.Response[*eth2api.VersionedProposal], error) {
 	// Get proposer pubkey (this is a blocking query).
 	pubkey, err := c.getProposerPubkey(ctx, core.NewProposerDuty(uint64(opts.Slot)))
@@ -628,6 +755,13 @@ func (c Component) submitRegistration(ctx context.Context, registration *eth2api
 	duty := core.NewBuilderRegistrationDuty(uint64(slot))
 	ctx = log.WithCtx(ctx, z.Any("duty", duty))
 
+	if feeRecipient, err := registration.FeeRecipient(); err == nil && c.feeRecipientFunc != nil &&
+		!strings.EqualFold(feeRecipient.String(), c.feeRecipientFunc(pubkey)) {
+		log.Warn(ctx, "Validator registration fee recipient mismatches proposer config, "+
+			"ensure the validator client uses the proposer config API", nil, z.Any("pubkey", pubkey),
+			z.Str("registration", feeRecipient.String()), z.Str("expected", c.feeRecipientFunc(pubkey)))
+	}
+
 	signedData, err := core.NewPartialVersionedSignedValidatorRegistration(registration, c.shareIdx)
 	if err != nil {
 		return err
@@ -768,10 +902,10 @@ func (c Component) AggregateBeaconCommitteeSelections(ctx context.Context, selec
 	return c.getAggregateBeaconCommSelection(ctx, psigsBySlot)
 }
 
-// AggregateAttestation returns the aggregate attestation for the given attestation root.
+// AggregateAttestation returns the aggregate attestation for the given attestation root and committee index.
 // It does a blocking query to DutyAggregator unsigned data from dutyDB.
-func (c Component) AggregateAttestation(ctx context.Context, opts *eth2api.AggregateAttestationOpts) (*eth2api.Response[*eth2p0.Attestation], error) {
-	aggAtt, err := c.awaitAggAttFunc(ctx, uint64(opts.Slot), opts.AttestationDataRoot)
+func (c Component) AggregateAttestation(ctx context.Context, opts *eth2api.AggregateAttestationOpts) (*eth2api.Response[*eth2spec.VersionedAttestation], error) {
+	aggAtt, err := c.awaitAggAttFunc(ctx, uint64(opts.Slot), uint64(opts.CommitteeIndex), opts.AttestationDataRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -782,16 +916,25 @@ func (c Component) AggregateAttestation(ctx context.Context, opts *eth2api.Aggre
 // SubmitAggregateAttestations receives partially signed aggregateAndProofs.
 // - It verifies partial signature on AggregateAndProof.
 // - It then calls all the subscribers for further steps on partially signed aggregate and proof.
-func (c Component) SubmitAggregateAttestations(ctx context.Context, aggregateAndProofs []*eth2p0.SignedAggregateAndProof) error {
+func (c Component) SubmitAggregateAttestations(ctx context.Context, opts *eth2api.SubmitAggregateAttestationsOpts) error {
 	vals, err := c.eth2Cl.ActiveValidators(ctx)
 	if err != nil {
 		return err
 	}
 
 	psigsBySlot := make(map[eth2p0.Slot]core.ParSignedDataSet)
-	for _, agg := range aggregateAndProofs {
-		slot := agg.Message.Aggregate.Data.Slot
-		eth2Pubkey, ok := vals[agg.Message.AggregatorIndex]
+	for _, agg := range opts.SignedAggregateAndProofs {
+		slot, err := agg.Slot()
+		if err != nil {
+			return errors.Wrap(err, "get aggregate slot")
+		}
+
+		aggIdx, err := agg.AggregatorIndex()
+		if err != nil {
+			return errors.Wrap(err, "get aggregator index")
+		}
+
+		eth2Pubkey, ok := vals[aggIdx]
 		if !ok {
 			return errors.New("validator not found")
 		}
@@ -803,13 +946,16 @@ func (c Component) SubmitAggregateAttestations(ctx context.Context, aggregateAnd
 
 		// Verify inner selection proof (outcome of DutyPrepareAggregator).
 		if !c.insecureTest {
-			err = signing.VerifyAggregateAndProofSelection(ctx, c.eth2Cl, tbls.PublicKey(eth2Pubkey), agg.Message)
+			err = signing.VerifyAggregateAndProofSelection(ctx, c.eth2Cl, tbls.PublicKey(eth2Pubkey), agg)
 			if err != nil {
 				return err
 			}
 		}
 
-		parSigData := core.NewPartialSignedAggregateAndProof(agg, c.shareIdx)
+		parSigData, err := core.NewPartialVersionedSignedAggregateAndProof(agg, c.shareIdx)
+		if err != nil {
+			return err
+		}
 
 		// Verify outer partial signature.
 		err = c.verifyPartialSig(ctx, parSigData, pk)
@@ -1282,7 +1428,7 @@ func (c Component) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigR
 			FeeRecipient: zeroAddress,
 			Builder: eth2exp.Builder{
 				Enabled:  false,
-				GasLimit: gasLimit,
+				GasLimit: DefaultGasLimit,
 			},
 		},
 	}
@@ -1309,13 +1455,31 @@ func (c Component) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigR
 			return nil, err
 		}
 
+		var (
+			valGasLimit  uint64 = DefaultGasLimit
+			valBuilder          = c.builderEnabled
+			valTimestamp        = timestamp
+		)
+		if c.gasLimitFunc != nil {
+			valGasLimit = c.gasLimitFunc(pubkey)
+		}
+		if c.builderFunc != nil {
+			valBuilder = c.builderFunc(pubkey)
+		}
+		if c.regTimestampFunc != nil {
+			// Overridden registrations must override pre-generated registrations and previously overridden registrations.
+			if ts, ok := c.regTimestampFunc(pubkey); ok && ts.After(valTimestamp) {
+				valTimestamp = ts
+			}
+		}
+
 		resp.Proposers[eth2Share] = eth2exp.ProposerConfig{
 			FeeRecipient: c.feeRecipientFunc(pubkey),
 			Builder: eth2exp.Builder{
-				Enabled:  c.builderEnabled,
-				GasLimit: gasLimit,
+				Enabled:  valBuilder,
+				GasLimit: uint(valGasLimit),
 				Overrides: map[string]string{
-					"timestamp":  strconv.FormatInt(timestamp.Unix(), 10),
+					"timestamp":  strconv.FormatInt(valTimestamp.Unix(), 10),
 					"public_key": string(pubkey),
 				},
 			},
@@ -21,6 +21,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
@@ -92,7 +93,10 @@ func TestComponent_ValidSubmitAttestations(t *testing.T) {
 		Signature: eth2p0.BLSSignature{},
 	}
 
-	atts := []*eth2p0.Attestation{attA, attB}
+	atts := []*eth2spec.VersionedAttestation{
+		{Version: eth2spec.DataVersionDeneb, Deneb: attA},
+		{Version: eth2spec.DataVersionDeneb, Deneb: attB},
+	}
 
 	component.RegisterPubKeyByAttestation(func(ctx context.Context, slot, commIdx, valCommIdx uint64) (core.PubKey, error) {
 		return pubkeysByIdx[eth2p0.ValidatorIndex(valCommIdx)], nil
@@ -103,22 +107,103 @@ func TestComponent_ValidSubmitAttestations(t *testing.T) {
 		require.Equal(t, uint64(slot), duty.Slot)
 
 		parSignedDataA := set[pubkeysByIdx[vIdxA]]
-		actAttA, ok := parSignedDataA.SignedData.(core.Attestation)
+		actAttA, ok := parSignedDataA.SignedData.(core.VersionedAttestation)
 		require.True(t, ok)
-		require.Equal(t, *attA, actAttA.Attestation)
+		require.Equal(t, attA, actAttA.Deneb)
 
 		parSignedDataB := set[pubkeysByIdx[vIdxB]]
-		actAttB, ok := parSignedDataB.SignedData.(core.Attestation)
+		actAttB, ok := parSignedDataB.SignedData.(core.VersionedAttestation)
 		require.True(t, ok)
-		require.Equal(t, *attB, actAttB.Attestation)
+		require.Equal(t, attB, actAttB.Deneb)
 
 		return nil
 	})
 
-	err = component.SubmitAttestations(ctx, atts)
+	err = component.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: atts})
 	require.NoError(t, err)
 }
 
+func TestComponent_ValidSubmitElectraAttestations(t *testing.T) {
+	ctx := context.Background()
+	eth2Cl, err := beaconmock.New(beaconmock.WithValidatorSet(beaconmock.ValidatorSetA))
+	require.NoError(t, err)
+
+	const (
+		slot       = 123
+		commIdx    = 3
+		vIdx       = 1
+		valCommIdx = 5
+		commLen    = 8
+	)
+
+	eth2Pubkey := beaconmock.ValidatorSetA[vIdx].Validator.PublicKey
+	pubkey, err := core.PubKeyFromBytes(eth2Pubkey[:])
+	require.NoError(t, err)
+
+	component, err := validatorapi.NewComponentInsecure(t, eth2Cl, 0)
+	require.NoError(t, err)
+
+	component.RegisterGetDutyDefinition(func(_ context.Context, duty core.Duty) (core.DutyDefinitionSet, error) {
+		require.Equal(t, core.NewAttesterDuty(slot), duty)
+
+		return core.DutyDefinitionSet{
+			pubkey: core.NewAttesterDefinition(&eth2v1.AttesterDuty{
+				PubKey:                  eth2Pubkey,
+				Slot:                    slot,
+				ValidatorIndex:          vIdx,
+				CommitteeIndex:          commIdx,
+				CommitteeLength:         commLen,
+				ValidatorCommitteeIndex: valCommIdx,
+			}),
+		}, nil
+	})
+
+	newAtt := func(commIdx uint64) *eth2spec.VersionedAttestation {
+		commBits := bitfield.NewBitvector64()
+		commBits.SetBitAt(commIdx, true)
+		vIdx := eth2p0.ValidatorIndex(vIdx)
+
+		return &eth2spec.VersionedAttestation{
+			Version:        eth2spec.DataVersionElectra,
+			ValidatorIndex: &vIdx,
+			Electra: &electra.Attestation{
+				Data: &eth2p0.AttestationData{
+					Slot:   slot,
+					Source: &eth2p0.Checkpoint{},
+					Target: &eth2p0.Checkpoint{},
+				},
+				CommitteeBits: commBits,
+			},
+		}
+	}
+
+	component.Subscribe(func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+		require.Equal(t, core.NewAttesterDuty(slot), duty)
+
+		att, ok := set[pubkey].SignedData.(core.VersionedAttestation)
+		require.True(t, ok)
+
+		// Aggregation bits are populated from the attester duty.
+		aggBits, err := att.AggregationBits()
+		require.NoError(t, err)
+		require.EqualValues(t, commLen, aggBits.Len())
+		require.Equal(t, []int{valCommIdx}, aggBits.BitIndices())
+
+		return nil
+	})
+
+	err = component.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{
+		Attestations: []*eth2spec.VersionedAttestation{newAtt(commIdx)},
+	})
+	require.NoError(t, err)
+
+	// Attestations for a different committee than the duty are rejected.
+	err = component.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{
+		Attestations: []*eth2spec.VersionedAttestation{newAtt(commIdx + 1)},
+	})
+	require.ErrorContains(t, err, "attestation committee index mismatches duty")
+}
+
 func TestComponent_InvalidSubmitAttestations(t *testing.T) {
 	ctx := context.Background()
 	eth2Cl, err := beaconmock.New()
@@ -150,9 +235,9 @@ func TestComponent_InvalidSubmitAttestations(t *testing.T) {
 		Signature: eth2p0.BLSSignature{},
 	}
 
-	atts := []*eth2p0.Attestation{att}
+	atts := []*eth2spec.VersionedAttestation{{Version: eth2spec.DataVersionDeneb, Deneb: att}}
 
-	err = component.SubmitAttestations(ctx, atts)
+	err = component.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: atts})
 	require.Error(t, err)
 }
 
@@ -325,7 +410,9 @@ func TestSignAndVerify(t *testing.T) {
 		Data:            &attData,
 		Signature:       sig,
 	}
-	err = vapi.SubmitAttestations(ctx, []*eth2p0.Attestation{&att})
+	err = vapi.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{
+		Attestations: []*eth2spec.VersionedAttestation{{Version: eth2spec.DataVersionDeneb, Deneb: &att}},
+	})
 	require.NoError(t, err)
 	wg.Wait()
 }
@@ -649,11 +736,25 @@ func TestComponent_SubmitProposal_Gnosis(t *testing.T) {
 		return core.DutyDefinitionSet{corePubKey: nil}, nil
 	})
 
-	gnosisBlock := deneb.BeaconBlockToGnosis(*unsignedBlock.Deneb)
-	// Sign beacon block
-	sigRoot, err := gnosisBlock.HashTreeRoot()
+	signedBlock := &eth2api.VersionedSignedProposal{
+		Version: unsignedBlock.Version,
+		Deneb: &eth2deneb.SignedBlockContents{
+			SignedBlock: &deneb.SignedBeaconBlock{
+				Message: unsignedBlock.Deneb,
+			},
+		},
+	}
+
+	// Sign beacon block using the gnosis block root.
+	coreBlock, err := core.NewVersionedSignedProposal(signedBlock)
+	require.NoError(t, err)
+	sigRoot, err := coreBlock.MessageRoot()
 	require.NoError(t, err)
 
+	stdRoot, err := unsignedBlock.Deneb.HashTreeRoot()
+	require.NoError(t, err)
+	require.NotEqual(t, stdRoot, sigRoot)
+
 	domain, err := signing.GetDomain(ctx, bmock, signing.DomainBeaconProposer, epoch)
 	require.NoError(t, err)
 
@@ -663,15 +764,7 @@ func TestComponent_SubmitProposal_Gnosis(t *testing.T) {
 	s, err := tbls.Sign(secret, sigData[:])
 	require.NoError(t, err)
 
-	signedBlock := &eth2api.VersionedSignedProposal{
-		Version: unsignedBlock.Version,
-		Deneb: &eth2deneb.SignedBlockContents{
-			SignedBlock: &deneb.SignedBeaconBlock{
-				Message:   unsignedBlock.Deneb,
-				Signature: eth2p0.BLSSignature(s),
-			},
-		},
-	}
+	signedBlock.Deneb.SignedBlock.Signature = eth2p0.BLSSignature(s)
 
 	// Register subscriber
 	vapi.Subscribe(func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
@@ -825,7 +918,7 @@ func TestComponent_SubmitProposalInvalidBlock(t *testing.T) {
 		},
 		{
 			name:   "none",
-			block:  &eth2api.VersionedSignedProposal{Version: eth2spec.DataVersion(6)},
+			block:  &eth2api.VersionedSignedProposal{Version: eth2spec.DataVersion(7)},
 			errMsg: "unsupported version",
 		},
 		{
@@ -1108,7 +1201,7 @@ func TestComponent_SubmitBlindedProposalInvalidBlock(t *testing.T) {
 		},
 		{
 			name:   "none",
-			block:  &eth2api.VersionedSignedBlindedProposal{Version: eth2spec.DataVersion(6)},
+			block:  &eth2api.VersionedSignedBlindedProposal{Version: eth2spec.DataVersion(7)},
 			errMsg: "unsupported version",
 		},
 		{
@@ -1597,6 +1690,27 @@ func TestComponent_TekuProposerConfig(t *testing.T) {
 			},
 		},
 	}, resp)
+
+	// Overridden gas limit, builder enablement and registration timestamp.
+	overrideTimestamp := genesis.Add(time.Hour)
+	vapi.RegisterProposerOverrides(func(core.PubKey) uint64 {
+		return 36000000
+	}, func(core.PubKey) bool {
+		return false
+	}, func(core.PubKey) (time.Time, bool) {
+		return overrideTimestamp, true
+	})
+
+	resp, err = vapi.ProposerConfig(ctx)
+	require.NoError(t, err)
+	require.Equal(t, eth2exp.Builder{
+		Enabled:  false,
+		GasLimit: 36000000,
+		Overrides: map[string]string{
+			"timestamp":  strconv.FormatInt(overrideTimestamp.Unix(), 10),
+			"public_key": string(pk),
+		},
+	}, resp.Proposers[eth2pk].Builder)
 }
 
 func TestComponent_AggregateBeaconCommitteeSelections(t *testing.T) {
@@ -1664,6 +1778,7 @@ func TestComponent_SubmitAggregateAttestations(t *testing.T) {
 		},
 		Signature: testutil.RandomEth2Signature(),
 	}
+	versioned := &eth2spec.VersionedSignedAggregateAndProof{Version: eth2spec.DataVersionDeneb, Deneb: agg}
 
 	slot := agg.Message.Aggregate.Data.Slot
 	pubkey := beaconmock.ValidatorSetA[vIdx].Validator.PublicKey
@@ -1682,12 +1797,16 @@ func TestComponent_SubmitAggregateAttestations(t *testing.T) {
 
 		data, ok := set[pk]
 		require.True(t, ok)
-		require.Equal(t, core.NewPartialSignedAggregateAndProof(agg, 0), data)
+		expect, err := core.NewPartialVersionedSignedAggregateAndProof(versioned, 0)
+		require.NoError(t, err)
+		require.Equal(t, expect, data)
 
 		return nil
 	})
 
-	require.NoError(t, vapi.SubmitAggregateAttestations(ctx, []*eth2p0.SignedAggregateAndProof{agg}))
+	require.NoError(t, vapi.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{
+		SignedAggregateAndProofs: []*eth2spec.VersionedSignedAggregateAndProof{versioned},
+	}))
 }
 
 func TestComponent_SubmitAggregateAttestationVerify(t *testing.T) {
@@ -1741,7 +1860,11 @@ func TestComponent_SubmitAggregateAttestationVerify(t *testing.T) {
 		return nil
 	})
 
-	err = vapi.SubmitAggregateAttestations(ctx, []*eth2p0.SignedAggregateAndProof{signedAggProof})
+	err = vapi.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{
+		SignedAggregateAndProofs: []*eth2spec.VersionedSignedAggregateAndProof{
+			{Version: eth2spec.DataVersionDeneb, Deneb: signedAggProof},
+		},
+	})
 	require.NoError(t, err)
 	<-done
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/obolnetwork/charon/eth2util/deposit"
 	"github.com/obolnetwork/charon/eth2util/keymanager"
 	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/eth2util/sharesigner"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
@@ -50,6 +51,7 @@ type Config struct {
 
 	KeymanagerAddr      string
 	KeymanagerAuthToken string
+	RemoteSignerAddr    string
 
 	PublishAddr    string
 	PublishTimeout time.Duration
@@ -119,6 +121,14 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
+	if conf.RemoteSignerAddr != "" {
+		if conf.KeymanagerAddr == "" {
+			return errors.New("--remote-signer-address requires --keymanager-address to import keyshares to the remote signer. Please fix configuration flags")
+		} else if _, err := sharesigner.NewRemote(conf.RemoteSignerAddr); err != nil {
+			return err
+		}
+	}
+
 	// Check if keymanager address is reachable.
 	if conf.KeymanagerAddr != "" {
 		cl := keymanager.New(conf.KeymanagerAddr, conf.KeymanagerAuthToken)
@@ -205,10 +215,28 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	caster := bcast.New(tcpNode, peerIDs, key)
 
-	// register bcast callbacks for frostp2p
-	tp, err := newFrostP2P(tcpNode, peerMap, caster, def.Threshold, def.NumValidators)
-	if err != nil {
-		return errors.Wrap(err, "frost error")
+	// register bcast callbacks for the DKG algorithm transport
+	var runDKG func(context.Context) ([]share, error)
+	switch def.DKGAlgorithm {
+	case "default", "frost":
+		tp, err := newFrostP2P(tcpNode, peerMap, caster, def.Threshold, def.NumValidators)
+		if err != nil {
+			return errors.Wrap(err, "frost error")
+		}
+
+		runDKG = func(ctx context.Context) ([]share, error) {
+			return runFrostParallel(ctx, tp, uint32(def.NumValidators), uint32(len(peerMap)),
+				uint32(def.Threshold), uint32(nodeIdx.ShareIdx), defHash)
+		}
+	case "pedersen":
+		tp := newPedersenP2P(tcpNode, peerMap, caster, def.Threshold, def.NumValidators)
+
+		runDKG = func(ctx context.Context) ([]share, error) {
+			return runPedersenParallel(ctx, tp, uint32(def.NumValidators), uint32(len(peerMap)),
+				uint32(def.Threshold), uint32(nodeIdx.ShareIdx))
+		}
+	default:
+		return errors.New("unsupported dkg algorithm", z.Str("algorithm", def.DKGAlgorithm))
 	}
 
 	// register bcast callbacks for lock hash k1 signature handler
@@ -226,16 +254,16 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	log.Info(ctx, "All peers connected, starting DKG ceremony")
 
-	var shares []share
-	switch def.DKGAlgorithm {
-	case "default", "frost":
-		shares, err = runFrostParallel(ctx, tp, uint32(def.NumValidators), uint32(len(peerMap)),
-			uint32(def.Threshold), uint32(nodeIdx.ShareIdx), defHash)
-		if err != nil {
-			return err
-		}
-	default:
-		return errors.New("unsupported dkg algorithm")
+	shares, err := runDKG(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Partial signatures are signed with the keyshares in memory, since keyshares are only imported
+	// to the remote signer after the cluster lock is signed.
+	signer, err := newLocalShareSigner(shares)
+	if err != nil {
+		return err
 	}
 
 	// DKG was step 1, advance to step 2
@@ -250,7 +278,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	} else {
 		depositAmounts = deposit.DedupAmounts(depositAmounts)
 	}
-	depositDatas, err := signAndAggDepositData(ctx, ex, shares, def.WithdrawalAddresses(), network, nodeIdx, depositAmounts)
+	depositDatas, err := signAndAggDepositData(ctx, ex, signer, shares, def.WithdrawalAddresses(), network, nodeIdx, depositAmounts)
 	if err != nil {
 		return err
 	}
@@ -265,6 +293,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	valRegs, err := signAndAggValidatorRegistrations(
 		ctx,
 		ex,
+		signer,
 		shares,
 		def.FeeRecipientAddresses(),
 		registration.DefaultGasLimit,
@@ -282,7 +311,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	}
 
 	// Sign, exchange and aggregate Lock Hash signatures
-	lock, err := signAndAggLockHash(ctx, shares, def, nodeIdx, ex, depositDatas, valRegs)
+	lock, err := signAndAggLockHash(ctx, signer, shares, def, nodeIdx, ex, depositDatas, valRegs)
 	if err != nil {
 		return err
 	}
@@ -318,7 +347,11 @@ func Run(ctx context.Context, conf Config) (err error) {
 	// Write keystores, deposit data and cluster lock files after exchange of partial signatures in order
 	// to prevent partial data writes in case of peer connection lost
 
-	if conf.KeymanagerAddr != "" { // Save to keymanager
+	if conf.RemoteSignerAddr != "" { // Import to remote signer
+		if err = importToRemoteSigner(ctx, conf, shares, lock.LockHash); err != nil {
+			return err
+		}
+	} else if conf.KeymanagerAddr != "" { // Save to keymanager
 		if err = writeKeysToKeymanager(ctx, conf.KeymanagerAddr, conf.KeymanagerAuthToken, shares); err != nil {
 			return err
 		}
@@ -399,7 +432,7 @@ func setupP2P(ctx context.Context, key *k1.PrivateKey, conf Config, peers []p2p.
 		return nil, nil, err
 	}
 
-	tcpNode, err := p2p.NewTCPNode(ctx, conf.P2P, key, connGater, false)
+	tcpNode, err := p2p.NewTCPNode(ctx, conf.P2P, key, connGater, false, p2p.WithHolePunching(ctx, peerIDs))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -544,7 +577,7 @@ func startSyncProtocol(ctx context.Context, tcpNode host.Host, key *k1.PrivateKe
 }
 
 // signAndAggLockHash returns cluster lock file with aggregated signature after signing, exchange and aggregation of partial signatures.
-func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definition,
+func signAndAggLockHash(ctx context.Context, signer sharesigner.Signer, shares []share, def cluster.Definition,
 	nodeIdx cluster.NodeIdx, ex *exchanger, depositDatas [][]eth2p0.DepositData, valRegs []core.VersionedSignedValidatorRegistration,
 ) (cluster.Lock, error) {
 	vals, err := createDistValidators(shares, depositDatas, valRegs)
@@ -558,16 +591,17 @@ func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definit
 		}
 	}
 
-	lock := cluster.Lock{
-		Definition: def,
-		Validators: vals,
-	}
-	lock, err = lock.SetLockHash()
+	return signAndAggLock(ctx, signer, shares, cluster.Lock{Definition: def, Validators: vals}, nodeIdx, ex)
+}
+
+// signAndAggLock returns the cluster lock with lock hash and aggregated signature after signing, exchange and aggregation of partial signatures.
+func signAndAggLock(ctx context.Context, signer sharesigner.Signer, shares []share, lock cluster.Lock, nodeIdx cluster.NodeIdx, ex *exchanger) (cluster.Lock, error) {
+	lock, err := lock.SetLockHash()
 	if err != nil {
 		return cluster.Lock{}, err
 	}
 
-	lockHashSig, err := signLockHash(nodeIdx.ShareIdx, shares, lock.LockHash)
+	lockHashSig, err := signLockHash(ctx, signer, nodeIdx.ShareIdx, shares, lock.LockHash)
 	if err != nil {
 		return cluster.Lock{}, err
 	}
@@ -603,14 +637,14 @@ func signAndAggLockHash(ctx context.Context, shares []share, def cluster.Definit
 }
 
 // signAndAggDepositData returns the deposit datas for each DV after signing, exchange and aggregation of partial signatures.
-func signAndAggDepositData(ctx context.Context, ex *exchanger, shares []share,
+func signAndAggDepositData(ctx context.Context, ex *exchanger, signer sharesigner.Signer, shares []share,
 	withdrawalAddresses []string, network string,
 	nodeIdx cluster.NodeIdx, depositAmounts []eth2p0.Gwei,
 ) ([][]eth2p0.DepositData, error) {
 	var depositDataForAmounts [][]eth2p0.DepositData
 
 	for i, amount := range depositAmounts {
-		parSig, despositMsgs, err := signDepositMsgs(shares, nodeIdx.ShareIdx, withdrawalAddresses, network, amount)
+		parSig, despositMsgs, err := signDepositMsgs(ctx, signer, shares, nodeIdx.ShareIdx, withdrawalAddresses, network, amount)
 		if err != nil {
 			return nil, err
 		}
@@ -635,13 +669,14 @@ func signAndAggDepositData(ctx context.Context, ex *exchanger, shares []share,
 func signAndAggValidatorRegistrations(
 	ctx context.Context,
 	ex *exchanger,
+	signer sharesigner.Signer,
 	shares []share,
 	feeRecipients []string,
 	gasLimit uint64,
 	nodeIdx cluster.NodeIdx,
 	forkVersion []byte,
 ) ([]core.VersionedSignedValidatorRegistration, error) {
-	parSig, valRegs, err := signValidatorRegistrations(shares, nodeIdx.ShareIdx, feeRecipients, gasLimit, forkVersion)
+	parSig, valRegs, err := signValidatorRegistrations(ctx, signer, shares, nodeIdx.ShareIdx, feeRecipients, gasLimit, forkVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -701,8 +736,56 @@ func aggLockHashSig(data map[core.PubKey][]core.ParSignedData, shares map[core.P
 	return aggSig, pubkeys, nil
 }
 
+// importToRemoteSigner imports the keyshares to the remote signer via the keymanager API and verifies that
+// the remote signer signs with all keyshares by requesting signatures of the lock hash.
+// It must only be called after the cluster lock is signed, so aborted ceremonies don't leave keyshares behind.
+func importToRemoteSigner(ctx context.Context, conf Config, shares []share, lockHash []byte) error {
+	if err := writeKeysToKeymanager(ctx, conf.KeymanagerAddr, conf.KeymanagerAuthToken, shares); err != nil {
+		return errors.Wrap(err, "import keyshares to remote signer")
+	}
+	log.Info(ctx, "Imported keyshares to remote signer", z.Str("keymanager_address", conf.KeymanagerAddr))
+
+	signer, err := sharesigner.NewRemote(conf.RemoteSignerAddr)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range shares {
+		pubshare, err := tbls.SecretToPublicKey(s.SecretShare)
+		if err != nil {
+			return errors.Wrap(err, "get public share")
+		}
+
+		if _, err := signer.Sign(ctx, pubshare, lockHash); err != nil {
+			return errors.Wrap(err, "verify remote signer keyshare")
+		}
+	}
+
+	return nil
+}
+
+// newLocalShareSigner returns a signer of partial signatures using the secret shares in memory.
+func newLocalShareSigner(shares []share) (sharesigner.Signer, error) {
+	var secrets []tbls.PrivateKey
+	for _, s := range shares {
+		secrets = append(secrets, s.SecretShare)
+	}
+
+	return sharesigner.NewLocal(secrets...)
+}
+
+// signShare returns the signature of the message by this node's share of the distributed validator.
+func signShare(ctx context.Context, signer sharesigner.Signer, share share, shareIdx int, msg []byte) (tbls.Signature, error) {
+	pubshare, ok := share.PublicShares[shareIdx]
+	if !ok {
+		return tbls.Signature{}, errors.New("missing public share", z.Int("share_idx", shareIdx))
+	}
+
+	return signer.Sign(ctx, pubshare, msg)
+}
+
 // signLockHash returns a partially signed dataset containing signatures of the lock hash.
-func signLockHash(shareIdx int, shares []share, hash []byte) (core.ParSignedDataSet, error) {
+func signLockHash(ctx context.Context, signer sharesigner.Signer, shareIdx int, shares []share, hash []byte) (core.ParSignedDataSet, error) {
 	set := make(core.ParSignedDataSet)
 	for _, share := range shares {
 		pk, err := core.PubKeyFromBytes(share.PubKey[:])
@@ -710,7 +793,7 @@ func signLockHash(shareIdx int, shares []share, hash []byte) (core.ParSignedData
 			return nil, err
 		}
 
-		sig, err := tbls.Sign(share.SecretShare, hash)
+		sig, err := signShare(ctx, signer, share, shareIdx, hash)
 		if err != nil {
 			return nil, err
 		}
@@ -722,7 +805,7 @@ func signLockHash(shareIdx int, shares []share, hash []byte) (core.ParSignedData
 }
 
 // signDepositMsgs returns a partially signed dataset containing signatures of the deposit message signing root.
-func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string, network string, amount eth2p0.Gwei) (core.ParSignedDataSet, map[core.PubKey]eth2p0.DepositMessage, error) {
+func signDepositMsgs(ctx context.Context, signer sharesigner.Signer, shares []share, shareIdx int, withdrawalAddresses []string, network string, amount eth2p0.Gwei) (core.ParSignedDataSet, map[core.PubKey]eth2p0.DepositMessage, error) {
 	msgs := make(map[core.PubKey]eth2p0.DepositMessage)
 	set := make(core.ParSignedDataSet)
 	for i, share := range shares {
@@ -750,7 +833,7 @@ func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string,
 			return nil, nil, err
 		}
 
-		sig, err := tbls.Sign(share.SecretShare, sigRoot[:])
+		sig, err := signShare(ctx, signer, share, shareIdx, sigRoot[:])
 		if err != nil {
 			return nil, nil, err
 		}
@@ -767,7 +850,7 @@ func signDepositMsgs(shares []share, shareIdx int, withdrawalAddresses []string,
 }
 
 // signValidatorRegistrations returns a partially signed dataset containing signatures of the validator registrations signing root.
-func signValidatorRegistrations(shares []share, shareIdx int, feeRecipients []string, gasLimit uint64, forkVersion []byte) (core.ParSignedDataSet, map[core.PubKey]core.VersionedSignedValidatorRegistration, error) {
+func signValidatorRegistrations(ctx context.Context, signer sharesigner.Signer, shares []share, shareIdx int, feeRecipients []string, gasLimit uint64, forkVersion []byte) (core.ParSignedDataSet, map[core.PubKey]core.VersionedSignedValidatorRegistration, error) {
 	msgs := make(map[core.PubKey]core.VersionedSignedValidatorRegistration)
 	set := make(core.ParSignedDataSet)
 	for idx, share := range shares {
@@ -791,7 +874,7 @@ func signValidatorRegistrations(shares []share, shareIdx int, feeRecipients []st
 			return nil, nil, err
 		}
 
-		sig, err := tbls.Sign(share.SecretShare, sigRoot[:])
+		sig, err := signShare(ctx, signer, share, shareIdx, sigRoot[:])
 		if err != nil {
 			return nil, nil, err
 		}
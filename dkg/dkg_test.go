@@ -40,6 +40,7 @@ import (
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
 	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/remotesignermock"
 )
 
 func TestDKG(t *testing.T) {
@@ -66,6 +67,7 @@ func TestDKG(t *testing.T) {
 		version        string // Defaults to latest if empty
 		depositAmounts []eth2p0.Gwei
 		keymanager     bool
+		remoteSigner   bool
 		publish        bool
 	}{
 		{
@@ -77,6 +79,10 @@ func TestDKG(t *testing.T) {
 			name:    "frost_latest",
 			dkgAlgo: "frost",
 		},
+		{
+			name:    "pedersen_latest",
+			dkgAlgo: "pedersen",
+		},
 		{
 			name:    "with_partial_deposits",
 			version: "v1.8.0",
@@ -92,6 +98,11 @@ func TestDKG(t *testing.T) {
 			dkgAlgo:    "frost",
 			keymanager: true,
 		},
+		{
+			name:         "dkg with remote signer",
+			dkgAlgo:      "frost",
+			remoteSigner: true,
+		},
 		{
 			name:    "dkg with lockfile publish",
 			dkgAlgo: "frost",
@@ -114,15 +125,15 @@ func TestDKG(t *testing.T) {
 			lock, keys, _ := cluster.NewForT(t, vals, nodes, nodes, seed, random, opts...)
 			dir := t.TempDir()
 
-			testDKG(t, lock.Definition, dir, keys, test.keymanager, test.publish)
-			if !test.keymanager {
+			testDKG(t, lock.Definition, dir, keys, test.keymanager, test.remoteSigner, test.publish)
+			if !test.keymanager && !test.remoteSigner {
 				verifyDKGResults(t, lock.Definition, dir)
 			}
 		})
 	}
 }
 
-func testDKG(t *testing.T, def cluster.Definition, dir string, p2pKeys []*k1.PrivateKey, keymanager bool, publish bool) {
+func testDKG(t *testing.T, def cluster.Definition, dir string, p2pKeys []*k1.PrivateKey, keymanager bool, remoteSigner bool, publish bool) {
 	t.Helper()
 
 	require.NoError(t, def.VerifySignatures())
@@ -170,6 +181,19 @@ func testDKG(t *testing.T, def cluster.Definition, dir string, p2pKeys []*k1.Pri
 		conf.KeymanagerAuthToken = testAuthToken
 	}
 
+	var remoteSignerShares func() []tbls.PublicKey
+	if remoteSigner {
+		handler, pubshares, err := remotesignermock.MockServer()
+		require.NoError(t, err)
+		srv := httptest.NewServer(handler)
+		defer srv.Close()
+
+		remoteSignerShares = pubshares
+		conf.KeymanagerAddr = srv.URL
+		conf.KeymanagerAuthToken = "test-auth-token"
+		conf.RemoteSignerAddr = srv.URL
+	}
+
 	receivedLockfile := make(chan struct{}) // Receives string for lockfile intercepted by the obol-api server
 	if publish {
 		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -232,6 +256,15 @@ func testDKG(t *testing.T, def cluster.Definition, dir string, p2pKeys []*k1.Pri
 		t.Log("All keystores received 🎉")
 	}
 
+	if remoteSigner {
+		// All keyshares are held by the remote signer and none are stored on disk.
+		require.Len(t, remoteSignerShares(), len(def.Operators)*def.NumValidators)
+		for i := range len(def.Operators) {
+			_, err := os.Stat(path.Join(dir, fmt.Sprintf("node%d", i), "validator_keys"))
+			require.ErrorIs(t, err, os.ErrNotExist)
+		}
+	}
+
 	if publish {
 		expectedReceives := 1
 		for expectedReceives > 0 {
@@ -0,0 +1,500 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        (unknown)
+// source: dkg/dkgpb/v1/pedersen.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PedersenMsgKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValIdx   uint32 `protobuf:"varint,1,opt,name=val_idx,json=valIdx,proto3" json:"val_idx,omitempty"`
+	SourceId uint32 `protobuf:"varint,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId uint32 `protobuf:"varint,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+}
+
+func (x *PedersenMsgKey) Reset() {
+	*x = PedersenMsgKey{}
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PedersenMsgKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedersenMsgKey) ProtoMessage() {}
+
+func (x *PedersenMsgKey) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedersenMsgKey.ProtoReflect.Descriptor instead.
+func (*PedersenMsgKey) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_pedersen_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PedersenMsgKey) GetValIdx() uint32 {
+	if x != nil {
+		return x.ValIdx
+	}
+	return 0
+}
+
+func (x *PedersenMsgKey) GetSourceId() uint32 {
+	if x != nil {
+		return x.SourceId
+	}
+	return 0
+}
+
+func (x *PedersenMsgKey) GetTargetId() uint32 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+type PedersenDealCasts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Casts []*PedersenDealCast `protobuf:"bytes,1,rep,name=casts,proto3" json:"casts,omitempty"` // One per validator
+}
+
+func (x *PedersenDealCasts) Reset() {
+	*x = PedersenDealCasts{}
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PedersenDealCasts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedersenDealCasts) ProtoMessage() {}
+
+func (x *PedersenDealCasts) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedersenDealCasts.ProtoReflect.Descriptor instead.
+func (*PedersenDealCasts) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_pedersen_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PedersenDealCasts) GetCasts() []*PedersenDealCast {
+	if x != nil {
+		return x.Casts
+	}
+	return nil
+}
+
+type PedersenDealCast struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key         *PedersenMsgKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Commitments [][]byte        `protobuf:"bytes,2,rep,name=commitments,proto3" json:"commitments,omitempty"`
+}
+
+func (x *PedersenDealCast) Reset() {
+	*x = PedersenDealCast{}
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PedersenDealCast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedersenDealCast) ProtoMessage() {}
+
+func (x *PedersenDealCast) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedersenDealCast.ProtoReflect.Descriptor instead.
+func (*PedersenDealCast) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_pedersen_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PedersenDealCast) GetKey() *PedersenMsgKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *PedersenDealCast) GetCommitments() [][]byte {
+	if x != nil {
+		return x.Commitments
+	}
+	return nil
+}
+
+type PedersenDealP2P struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shares []*PedersenShamirShare `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"` // One per validator
+}
+
+func (x *PedersenDealP2P) Reset() {
+	*x = PedersenDealP2P{}
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PedersenDealP2P) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedersenDealP2P) ProtoMessage() {}
+
+func (x *PedersenDealP2P) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedersenDealP2P.ProtoReflect.Descriptor instead.
+func (*PedersenDealP2P) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_pedersen_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PedersenDealP2P) GetShares() []*PedersenShamirShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type PedersenShamirShare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   *PedersenMsgKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Id    uint32          `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Value []byte          `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *PedersenShamirShare) Reset() {
+	*x = PedersenShamirShare{}
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PedersenShamirShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedersenShamirShare) ProtoMessage() {}
+
+func (x *PedersenShamirShare) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedersenShamirShare.ProtoReflect.Descriptor instead.
+func (*PedersenShamirShare) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_pedersen_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PedersenShamirShare) GetKey() *PedersenMsgKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *PedersenShamirShare) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PedersenShamirShare) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type PedersenComplaints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Complaints     []*PedersenMsgKey `protobuf:"bytes,1,rep,name=complaints,proto3" json:"complaints,omitempty"`                                       // Source is the complainer, target is the accused dealer.
+	InvalidDealers []uint32          `protobuf:"varint,2,rep,packed,name=invalid_dealers,json=invalidDealers,proto3" json:"invalid_dealers,omitempty"` // Dealers with missing or invalid commitments.
+}
+
+func (x *PedersenComplaints) Reset() {
+	*x = PedersenComplaints{}
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PedersenComplaints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedersenComplaints) ProtoMessage() {}
+
+func (x *PedersenComplaints) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedersenComplaints.ProtoReflect.Descriptor instead.
+func (*PedersenComplaints) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_pedersen_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PedersenComplaints) GetComplaints() []*PedersenMsgKey {
+	if x != nil {
+		return x.Complaints
+	}
+	return nil
+}
+
+func (x *PedersenComplaints) GetInvalidDealers() []uint32 {
+	if x != nil {
+		return x.InvalidDealers
+	}
+	return nil
+}
+
+type PedersenJustifications struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Justifications []*PedersenShamirShare `protobuf:"bytes,1,rep,name=justifications,proto3" json:"justifications,omitempty"` // Source is the accused dealer, target is the complainer.
+}
+
+func (x *PedersenJustifications) Reset() {
+	*x = PedersenJustifications{}
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PedersenJustifications) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PedersenJustifications) ProtoMessage() {}
+
+func (x *PedersenJustifications) ProtoReflect() protoreflect.Message {
+	mi := &file_dkg_dkgpb_v1_pedersen_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PedersenJustifications.ProtoReflect.Descriptor instead.
+func (*PedersenJustifications) Descriptor() ([]byte, []int) {
+	return file_dkg_dkgpb_v1_pedersen_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PedersenJustifications) GetJustifications() []*PedersenShamirShare {
+	if x != nil {
+		return x.Justifications
+	}
+	return nil
+}
+
+var File_dkg_dkgpb_v1_pedersen_proto protoreflect.FileDescriptor
+
+var file_dkg_dkgpb_v1_pedersen_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x64, 0x6b, 0x67, 0x2f, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x70,
+	0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x64,
+	0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x22, 0x63, 0x0a, 0x0e, 0x50,
+	0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x4d, 0x73, 0x67, 0x4b, 0x65, 0x79, 0x12, 0x17, 0x0a,
+	0x07, 0x76, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x49, 0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64,
+	0x22, 0x49, 0x0a, 0x11, 0x50, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x44, 0x65, 0x61, 0x6c,
+	0x43, 0x61, 0x73, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x05, 0x63, 0x61, 0x73, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x44, 0x65, 0x61, 0x6c,
+	0x43, 0x61, 0x73, 0x74, 0x52, 0x05, 0x63, 0x61, 0x73, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x10, 0x50,
+	0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x44, 0x65, 0x61, 0x6c, 0x43, 0x61, 0x73, 0x74, 0x12,
+	0x2e, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64,
+	0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x65,
+	0x72, 0x73, 0x65, 0x6e, 0x4d, 0x73, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0x4c, 0x0a, 0x0f, 0x50, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x44, 0x65, 0x61,
+	0x6c, 0x50, 0x32, 0x50, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x53, 0x68, 0x61, 0x6d,
+	0x69, 0x72, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x22,
+	0x6b, 0x0a, 0x13, 0x50, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x53, 0x68, 0x61, 0x6d, 0x69,
+	0x72, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x2e, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x4d, 0x73, 0x67, 0x4b, 0x65,
+	0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x7b, 0x0a, 0x12,
+	0x50, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x74, 0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x64, 0x6b, 0x67,
+	0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x4d, 0x73,
+	0x67, 0x4b, 0x65, 0x79, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x74, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x64, 0x65, 0x61, 0x6c,
+	0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0e, 0x69, 0x6e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x44, 0x65, 0x61, 0x6c, 0x65, 0x72, 0x73, 0x22, 0x63, 0x0a, 0x16, 0x50, 0x65, 0x64,
+	0x65, 0x72, 0x73, 0x65, 0x6e, 0x4a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a, 0x0e, 0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x6b,
+	0x67, 0x2e, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x64, 0x65, 0x72,
+	0x73, 0x65, 0x6e, 0x53, 0x68, 0x61, 0x6d, 0x69, 0x72, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x0e,
+	0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x2c,
+	0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f,
+	0x6c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f,
+	0x64, 0x6b, 0x67, 0x2f, 0x64, 0x6b, 0x67, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_dkg_dkgpb_v1_pedersen_proto_rawDescOnce sync.Once
+	file_dkg_dkgpb_v1_pedersen_proto_rawDescData = file_dkg_dkgpb_v1_pedersen_proto_rawDesc
+)
+
+func file_dkg_dkgpb_v1_pedersen_proto_rawDescGZIP() []byte {
+	file_dkg_dkgpb_v1_pedersen_proto_rawDescOnce.Do(func() {
+		file_dkg_dkgpb_v1_pedersen_proto_rawDescData = protoimpl.X.CompressGZIP(file_dkg_dkgpb_v1_pedersen_proto_rawDescData)
+	})
+	return file_dkg_dkgpb_v1_pedersen_proto_rawDescData
+}
+
+var file_dkg_dkgpb_v1_pedersen_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_dkg_dkgpb_v1_pedersen_proto_goTypes = []any{
+	(*PedersenMsgKey)(nil),         // 0: dkg.dkgpb.v1.PedersenMsgKey
+	(*PedersenDealCasts)(nil),      // 1: dkg.dkgpb.v1.PedersenDealCasts
+	(*PedersenDealCast)(nil),       // 2: dkg.dkgpb.v1.PedersenDealCast
+	(*PedersenDealP2P)(nil),        // 3: dkg.dkgpb.v1.PedersenDealP2P
+	(*PedersenShamirShare)(nil),    // 4: dkg.dkgpb.v1.PedersenShamirShare
+	(*PedersenComplaints)(nil),     // 5: dkg.dkgpb.v1.PedersenComplaints
+	(*PedersenJustifications)(nil), // 6: dkg.dkgpb.v1.PedersenJustifications
+}
+var file_dkg_dkgpb_v1_pedersen_proto_depIdxs = []int32{
+	2, // 0: dkg.dkgpb.v1.PedersenDealCasts.casts:type_name -> dkg.dkgpb.v1.PedersenDealCast
+	0, // 1: dkg.dkgpb.v1.PedersenDealCast.key:type_name -> dkg.dkgpb.v1.PedersenMsgKey
+	4, // 2: dkg.dkgpb.v1.PedersenDealP2P.shares:type_name -> dkg.dkgpb.v1.PedersenShamirShare
+	0, // 3: dkg.dkgpb.v1.PedersenShamirShare.key:type_name -> dkg.dkgpb.v1.PedersenMsgKey
+	0, // 4: dkg.dkgpb.v1.PedersenComplaints.complaints:type_name -> dkg.dkgpb.v1.PedersenMsgKey
+	4, // 5: dkg.dkgpb.v1.PedersenJustifications.justifications:type_name -> dkg.dkgpb.v1.PedersenShamirShare
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_dkg_dkgpb_v1_pedersen_proto_init() }
+func file_dkg_dkgpb_v1_pedersen_proto_init() {
+	if File_dkg_dkgpb_v1_pedersen_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_dkg_dkgpb_v1_pedersen_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_dkg_dkgpb_v1_pedersen_proto_goTypes,
+		DependencyIndexes: file_dkg_dkgpb_v1_pedersen_proto_depIdxs,
+		MessageInfos:      file_dkg_dkgpb_v1_pedersen_proto_msgTypes,
+	}.Build()
+	File_dkg_dkgpb_v1_pedersen_proto = out.File
+	file_dkg_dkgpb_v1_pedersen_proto_rawDesc = nil
+	file_dkg_dkgpb_v1_pedersen_proto_goTypes = nil
+	file_dkg_dkgpb_v1_pedersen_proto_depIdxs = nil
+}
@@ -185,6 +185,23 @@ func (n *nodeSigBcast) exchange(
 
 	n.setSig(localSig, n.nodeIdx.PeerIdx)
 
+	return n.waitSigs(ctx)
+}
+
+// await returns the K1 signatures over the lock hash of all peers without contributing a signature.
+// It is used by nodes that participate in the bcast protocol but aren't one of the peers.
+func (n *nodeSigBcast) await(ctx context.Context, lockHash []byte) ([][]byte, error) {
+	go func() {
+		n.lockHashCh <- lockHash
+	}()
+
+	log.Debug(ctx, "Awaiting node signatures")
+
+	return n.waitSigs(ctx)
+}
+
+// waitSigs blocks until the signatures of all peers have been received and returns them.
+func (n *nodeSigBcast) waitSigs(ctx context.Context) ([][]byte, error) {
 	tick := time.NewTicker(100 * time.Millisecond)
 	defer tick.Stop()
 
@@ -0,0 +1,484 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"crypto/rand"
+	"slices"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/coinbase/kryptology/pkg/sharing"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// pTransport abstracts the transport of pedersen DKG messages.
+// All broadcast rounds must be reliable, i.e., all nodes must receive identical broadcast messages.
+type pTransport interface {
+	// Deal returns results of the deal round; the received commitments broadcast by all nodes
+	// and the shares sent directly to this node. Missing shares are omitted.
+	Deal(context.Context, map[msgKey][]curves.Point, map[msgKey]sharing.ShamirShare) (
+		map[msgKey][]curves.Point, map[msgKey]sharing.ShamirShare, error)
+
+	// Complain returns the complaints and the dealers with missing or invalid commitments reported by each node
+	// (by share index) that broadcast its complaints. Nodes that didn't reply before timing out are omitted.
+	Complain(context.Context, []msgKey, []uint32) ([]msgKey, map[uint32][]uint32, error)
+
+	// Justify returns the justifications (publicly revealed shares) broadcast by all nodes that replied.
+	Justify(context.Context, map[msgKey]sharing.ShamirShare) (map[msgKey]sharing.ShamirShare, error)
+}
+
+// runPedersenParallel runs numValidators Pedersen (Joint-Feldman) DKG processes in parallel (sharing transport rounds)
+// and returns a list of shares (one for each distributed validator).
+//
+// Unlike FROST, dealers that send invalid shares are identified via a complaint round and
+// excluded from the resulting keys if they fail to publicly justify the disputed shares.
+// Dealers with provably invalid commitments, dealers reported by more than the faulty number of nodes
+// and dealers that didn't take part in the complaint round are also excluded.
+func runPedersenParallel(ctx context.Context, tp pTransport, numValidators, numNodes, threshold, shareIdx uint32) ([]share, error) {
+	var secrets []curves.Scalar
+	for range numValidators {
+		secrets = append(secrets, curve.Scalar.Random(rand.Reader))
+	}
+
+	castDeal, p2pDeal, ownShares, err := dealSecrets(secrets, numNodes, threshold, shareIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	dealers := allDealers(numNodes)
+
+	log.Debug(ctx, "Sending pedersen deal messages")
+
+	commitments, shares, err := tp.Deal(ctx, castDeal, p2pDeal)
+	if err != nil {
+		return nil, errors.Wrap(err, "transport deal round")
+	}
+
+	for key, share := range ownShares {
+		shares[key] = share
+	}
+
+	var invalid []uint32
+	for _, dealer := range dealers {
+		if !validCommitments(commitments, numValidators, dealer, threshold) {
+			invalid = append(invalid, dealer)
+		}
+	}
+
+	qual, err := resolveComplaints(ctx, tp, commitments, shares, p2pDeal, dealers, invalid, numValidators, numNodes, threshold, shareIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(qual) < int(threshold) {
+		return nil, errors.New("insufficient qualified pedersen dealers",
+			z.Int("qualified", len(qual)), z.U64("threshold", uint64(threshold)))
+	}
+
+	for dealer := range qual {
+		if !validCommitments(commitments, numValidators, dealer, threshold) {
+			return nil, errors.New("missing commitments of qualified pedersen dealer", z.U64("dealer", uint64(dealer)))
+		}
+	}
+
+	weights := make(map[uint32]curves.Scalar)
+	for dealer := range qual {
+		weights[dealer] = curve.Scalar.One()
+	}
+
+	return combineShares(commitments, shares, weights, numValidators, numNodes, shareIdx)
+}
+
+// resolveComplaints runs the complaint and justification rounds and returns the qualified dealers.
+// Disputed shares are replaced by the publicly justified shares.
+//
+// Dealers are excluded by all nodes if they didn't broadcast complaints, if their received commitments are invalid
+// or if more than the faulty number of nodes report their commitments as missing or invalid, see excludedDealers.
+//
+// Only the numNodes receivers of shares (share indexes 1 to numNodes) complain, complaints by other nodes are ignored.
+func resolveComplaints(ctx context.Context, tp pTransport, commitments map[msgKey][]curves.Point,
+	shares, dealt map[msgKey]sharing.ShamirShare, dealers, invalid []uint32, numValidators, numNodes, threshold, shareIdx uint32,
+) (map[uint32]bool, error) {
+	if len(invalid) > 0 {
+		log.Warn(ctx, "Reporting DKG dealers with missing or invalid commitments", nil, z.Any("dealers", invalid))
+	}
+
+	var complaints []msgKey
+	if shareIdx <= numNodes {
+		complaints = pedersenComplaints(commitments, shares, numValidators, excludeDealers(dealers, invalid), shareIdx)
+	}
+	if len(complaints) > 0 {
+		log.Warn(ctx, "Complaining about invalid or missing DKG shares", nil, z.Int("complaints", len(complaints)))
+	}
+
+	allComplaints, reports, err := tp.Complain(ctx, complaints, invalid)
+	if err != nil {
+		return nil, errors.Wrap(err, "transport complaint round")
+	}
+
+	allComplaints = slices.DeleteFunc(allComplaints, func(complaint msgKey) bool {
+		return complaint.SourceID > numNodes
+	})
+
+	if excluded := excludedDealers(commitments, reports, dealers, numValidators, numNodes, threshold); len(excluded) > 0 {
+		log.Warn(ctx, "Excluded DKG dealers with missing or invalid commitments or complaints", nil, z.Any("dealers", excluded))
+		dealers = excludeDealers(dealers, excluded)
+	}
+
+	justifications := pedersenJustifications(allComplaints, dealt, shareIdx)
+
+	allJustifications, err := tp.Justify(ctx, justifications)
+	if err != nil {
+		return nil, errors.Wrap(err, "transport justification round")
+	}
+
+	qual := qualifiedDealers(commitments, allComplaints, allJustifications, dealers, threshold)
+	if len(qual) < len(dealers) {
+		log.Warn(ctx, "Excluded misbehaving DKG dealers", nil, z.Any("dealers", disqualified(qual, dealers)))
+	}
+
+	for key, share := range allJustifications {
+		if key.TargetID != shareIdx {
+			continue
+		}
+
+		shares[msgKey{ValIdx: key.ValIdx, SourceID: key.SourceID, TargetID: shareIdx}] = share
+	}
+
+	return qual, nil
+}
+
+// dealSecrets returns the commitments to broadcast, the shares to send to other nodes
+// and this node's own shares of the provided secrets (one for each validator).
+func dealSecrets(secrets []curves.Scalar, numNodes, threshold, shareIdx uint32) (
+	map[msgKey][]curves.Point, map[msgKey]sharing.ShamirShare, map[msgKey]sharing.ShamirShare, error,
+) {
+	feldman, err := sharing.NewFeldman(threshold, numNodes, curve)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "new feldman")
+	}
+
+	var (
+		castResults = make(map[msgKey][]curves.Point)
+		p2pResults  = make(map[msgKey]sharing.ShamirShare)
+		ownResults  = make(map[msgKey]sharing.ShamirShare)
+	)
+	for i, secret := range secrets {
+		vIdx := uint32(i)
+		verifier, shares, err := feldman.Split(secret, rand.Reader)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "split secret")
+		}
+
+		castResults[msgKey{
+			ValIdx:   vIdx,
+			SourceID: shareIdx,
+			TargetID: 0, // Broadcast
+		}] = verifier.Commitments
+
+		for _, share := range shares {
+			key := msgKey{
+				ValIdx:   vIdx,
+				SourceID: shareIdx,
+				TargetID: share.Id,
+			}
+
+			if share.Id == shareIdx {
+				ownResults[key] = *share
+			} else {
+				p2pResults[key] = *share
+			}
+		}
+	}
+
+	return castResults, p2pResults, ownResults, nil
+}
+
+// validCommitments returns true if the dealer broadcast the expected amount of commitments for all validators.
+func validCommitments(commitments map[msgKey][]curves.Point, numValidators, dealer, threshold uint32) bool {
+	for vIdx := range numValidators {
+		if len(commitments[msgKey{ValIdx: vIdx, SourceID: dealer}]) != int(threshold) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// excludedDealers returns the dealers to exclude before the justification round:
+//   - Dealers that didn't broadcast complaints, i.e., that are offline.
+//   - Dealers with received but invalid commitments, since all nodes receive identical reliably broadcast commitments.
+//   - Dealers reported with missing or invalid commitments by more than the faulty number of nodes,
+//     so at least one honest node reported them. Single malicious nodes cannot exclude honest dealers.
+func excludedDealers(commitments map[msgKey][]curves.Point, reports map[uint32][]uint32, dealers []uint32,
+	numValidators, numNodes, threshold uint32,
+) []uint32 {
+	faulty := (int(numNodes) - 1) / 3
+
+	var resp []uint32
+	for _, dealer := range dealers {
+		if _, ok := reports[dealer]; !ok {
+			resp = append(resp, dealer)
+			continue
+		}
+
+		if receivedCommitments(commitments, numValidators, dealer) && !validCommitments(commitments, numValidators, dealer, threshold) {
+			resp = append(resp, dealer)
+			continue
+		}
+
+		var reported int
+		for _, invalid := range reports {
+			if slices.Contains(invalid, dealer) {
+				reported++
+			}
+		}
+
+		if reported > faulty {
+			resp = append(resp, dealer)
+		}
+	}
+
+	return resp
+}
+
+// receivedCommitments returns true if any commitments of the dealer were received.
+func receivedCommitments(commitments map[msgKey][]curves.Point, numValidators, dealer uint32) bool {
+	for vIdx := range numValidators {
+		if _, ok := commitments[msgKey{ValIdx: vIdx, SourceID: dealer}]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pedersenComplaints returns complaints against all dealers that sent this node missing or invalid shares.
+func pedersenComplaints(commitments map[msgKey][]curves.Point, shares map[msgKey]sharing.ShamirShare,
+	numValidators uint32, dealers []uint32, shareIdx uint32,
+) []msgKey {
+	var complaints []msgKey
+	for vIdx := range numValidators {
+		for _, dealer := range dealers {
+			share, ok := shares[msgKey{ValIdx: vIdx, SourceID: dealer, TargetID: shareIdx}]
+			if ok && verifyShare(commitments[msgKey{ValIdx: vIdx, SourceID: dealer}], share, shareIdx) {
+				continue
+			}
+
+			complaints = append(complaints, msgKey{
+				ValIdx:   vIdx,
+				SourceID: shareIdx,
+				TargetID: dealer,
+			})
+		}
+	}
+
+	return complaints
+}
+
+// pedersenJustifications returns the shares this node dealt to nodes that complained about it.
+func pedersenJustifications(complaints []msgKey, dealt map[msgKey]sharing.ShamirShare, shareIdx uint32) map[msgKey]sharing.ShamirShare {
+	resp := make(map[msgKey]sharing.ShamirShare)
+	for _, complaint := range complaints {
+		if complaint.TargetID != shareIdx {
+			continue
+		}
+
+		key := msgKey{
+			ValIdx:   complaint.ValIdx,
+			SourceID: shareIdx,
+			TargetID: complaint.SourceID,
+		}
+
+		share, ok := dealt[key]
+		if !ok {
+			continue // Ignore invalid complaints.
+		}
+
+		resp[key] = share
+	}
+
+	return resp
+}
+
+// qualifiedDealers returns the set of dealers that either received no complaints
+// or publicly justified all disputed shares. Dealers with threshold or more complaints for a validator are
+// disqualified since justifying them would reveal their secret.
+// All honest nodes calculate the same set since it only depends on reliably broadcast messages.
+func qualifiedDealers(commitments map[msgKey][]curves.Point, complaints []msgKey,
+	justifications map[msgKey]sharing.ShamirShare, dealers []uint32, threshold uint32,
+) map[uint32]bool {
+	qual := make(map[uint32]bool)
+	for _, dealer := range dealers {
+		qual[dealer] = true
+	}
+
+	var (
+		counts = make(map[msgKey]int) // Number of complaints per validator and dealer.
+		dedup  = make(map[msgKey]bool)
+	)
+	for _, complaint := range complaints {
+		if dedup[complaint] {
+			continue
+		}
+		dedup[complaint] = true
+
+		if !qual[complaint.TargetID] {
+			continue // Not a dealer or already disqualified.
+		}
+
+		dealerKey := msgKey{ValIdx: complaint.ValIdx, SourceID: complaint.TargetID}
+		counts[dealerKey]++
+
+		if counts[dealerKey] >= int(threshold) {
+			qual[complaint.TargetID] = false
+			continue
+		}
+
+		share, ok := justifications[msgKey{
+			ValIdx:   complaint.ValIdx,
+			SourceID: complaint.TargetID,
+			TargetID: complaint.SourceID,
+		}]
+		if !ok || !verifyShare(commitments[dealerKey], share, complaint.SourceID) {
+			qual[complaint.TargetID] = false
+		}
+	}
+
+	for dealer, ok := range qual {
+		if !ok {
+			delete(qual, dealer)
+		}
+	}
+
+	return qual
+}
+
+// combineShares returns a slice of shares (one for each validator) by combining the shares and commitments
+// of the dealers weighted by the provided scalars. Nodes that don't receive shares (share index greater
+// than numNodes) only combine the commitments, so the returned shares have empty secret shares.
+func combineShares(commitments map[msgKey][]curves.Point, shares map[msgKey]sharing.ShamirShare,
+	weights map[uint32]curves.Scalar, numValidators, numNodes, shareIdx uint32,
+) ([]share, error) {
+	var resp []share
+	for vIdx := range numValidators {
+		var (
+			secret    = curve.Scalar.Zero()
+			combComms []curves.Point
+		)
+		for dealer, weight := range weights {
+			if shareIdx <= numNodes {
+				share, ok := shares[msgKey{ValIdx: vIdx, SourceID: dealer, TargetID: shareIdx}]
+				if !ok {
+					return nil, errors.New("missing qualified dealer share", z.U64("val_idx", uint64(vIdx)), z.U64("dealer", uint64(dealer)))
+				}
+
+				scalar, err := curve.Scalar.SetBytes(share.Value)
+				if err != nil {
+					return nil, errors.Wrap(err, "decode share scalar")
+				}
+				secret = secret.Add(scalar.Mul(weight))
+			}
+
+			for i, comm := range commitments[msgKey{ValIdx: vIdx, SourceID: dealer}] {
+				comm = comm.Mul(weight)
+				if i < len(combComms) {
+					combComms[i] = combComms[i].Add(comm)
+				} else {
+					combComms = append(combComms, comm)
+				}
+			}
+		}
+
+		pubkey, err := pointToPubKey(combComms[0])
+		if err != nil {
+			return nil, err
+		}
+
+		var secretShare tbls.PrivateKey
+		if shareIdx <= numNodes {
+			secretShare, err = scalarToSecretShare(secret)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		pubShares := make(map[int]tbls.PublicKey)
+		for id := uint32(1); id <= numNodes; id++ {
+			pubShare, err := pointToPubKey(evalCommitments(combComms, id))
+			if err != nil {
+				return nil, err
+			}
+			pubShares[int(id)] = pubShare
+		}
+
+		resp = append(resp, share{
+			PubKey:       pubkey,
+			SecretShare:  secretShare,
+			PublicShares: pubShares,
+		})
+	}
+
+	return resp, nil
+}
+
+// verifyShare returns true if the share with the expected ID is valid for the dealer's commitments.
+func verifyShare(commitments []curves.Point, share sharing.ShamirShare, id uint32) bool {
+	if len(commitments) == 0 || share.Id != id {
+		return false
+	}
+
+	return sharing.FeldmanVerifier{Commitments: commitments}.Verify(&share) == nil
+}
+
+// evalCommitments returns the public share of the ID by evaluating the commitment polynomial.
+func evalCommitments(commitments []curves.Point, id uint32) curves.Point {
+	x := curve.Scalar.New(int(id))
+	xi := curve.Scalar.One()
+	resp := commitments[0]
+	for _, comm := range commitments[1:] {
+		xi = xi.Mul(x)
+		resp = resp.Add(comm.Mul(xi))
+	}
+
+	return resp
+}
+
+// excludeDealers returns the dealers without the excluded dealers.
+func excludeDealers(dealers, excluded []uint32) []uint32 {
+	var resp []uint32
+	for _, dealer := range dealers {
+		if !slices.Contains(excluded, dealer) {
+			resp = append(resp, dealer)
+		}
+	}
+
+	return resp
+}
+
+// allDealers returns all node share indexes as dealers.
+func allDealers(numNodes uint32) []uint32 {
+	var resp []uint32
+	for dealer := uint32(1); dealer <= numNodes; dealer++ {
+		resp = append(resp, dealer)
+	}
+
+	return resp
+}
+
+// disqualified returns the dealers not in the qualified set.
+func disqualified(qual map[uint32]bool, dealers []uint32) []uint32 {
+	var resp []uint32
+	for _, dealer := range dealers {
+		if !qual[dealer] {
+			resp = append(resp, dealer)
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,488 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"crypto/rand"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/coinbase/kryptology/pkg/sharing"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	pb "github.com/obolnetwork/charon/dkg/dkgpb/v1"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestPedersenDKG(t *testing.T) {
+	tests := []struct {
+		name string
+		// corrupt returns the possibly corrupted shares sent by the dealer.
+		corrupt func(dealer uint32, shares map[msgKey]sharing.ShamirShare) map[msgKey]sharing.ShamirShare
+		// dropCommitments returns true if the receiver doesn't receive the dealer's commitments.
+		dropCommitments func(receiver, dealer uint32) bool
+		// report returns the dealers falsely reported by the reporter as having invalid commitments.
+		report func(reporter uint32) []uint32
+		// silent is the dealer that doesn't take part in the complaint and justification rounds.
+		silent uint32
+		// excluded is the expected excluded dealer which also corrupts its justifications.
+		excluded uint32
+	}{
+		{
+			name: "honest",
+		},
+		{
+			name: "dealer omits share then justifies",
+			corrupt: func(dealer uint32, shares map[msgKey]sharing.ShamirShare) map[msgKey]sharing.ShamirShare {
+				resp := make(map[msgKey]sharing.ShamirShare)
+				for key, share := range shares {
+					if dealer == 1 && key.ValIdx == 0 && key.TargetID == 2 {
+						continue
+					}
+					resp[key] = share
+				}
+
+				return resp
+			},
+		},
+		{
+			name: "dealer sends invalid shares",
+			corrupt: func(dealer uint32, shares map[msgKey]sharing.ShamirShare) map[msgKey]sharing.ShamirShare {
+				if dealer != 2 {
+					return shares
+				}
+
+				resp := make(map[msgKey]sharing.ShamirShare)
+				for key, share := range shares {
+					if key.ValIdx == 1 && key.TargetID == 3 {
+						share.Value = curve.Scalar.One().Bytes()
+					}
+					resp[key] = share
+				}
+
+				return resp
+			},
+			excluded: 2,
+		},
+		{
+			name: "dealer commitments missing",
+			dropCommitments: func(receiver, dealer uint32) bool {
+				return (receiver == 1 || receiver == 2) && dealer == 3
+			},
+			excluded: 3,
+		},
+		{
+			name: "single false invalid dealer report",
+			report: func(reporter uint32) []uint32 {
+				if reporter == 1 {
+					return []uint32{2}
+				}
+
+				return nil
+			},
+		},
+		{
+			name:     "dealer silent after deal",
+			silent:   4,
+			excluded: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			const (
+				nodes     = 4
+				threshold = 3
+				vals      = 2
+			)
+
+			tp := &pedersenMemTransport{nodes: nodes}
+			if test.silent != 0 {
+				tp.silent = 1
+			}
+
+			var (
+				eg      errgroup.Group
+				results = make([][]share, nodes)
+			)
+			for i := range nodes {
+				ntp := corruptTransport{
+					pedersenMemTransport: tp,
+					dealer:               uint32(i + 1),
+					corrupt:              test.corrupt,
+					justify:              test.corrupt != nil && test.excluded != 0,
+					dropCommitments:      test.dropCommitments,
+					report:               test.report,
+					silent:               test.silent == uint32(i+1),
+				}
+
+				eg.Go(func() error {
+					shares, err := runPedersenParallel(ctx, ntp, vals, nodes, threshold, uint32(i+1))
+					if ntp.silent && errors.Is(err, errSilent) {
+						return nil
+					} else if err != nil {
+						cancel()
+						return err
+					}
+					results[i] = shares
+
+					return nil
+				})
+			}
+
+			require.NoError(t, eg.Wait())
+
+			for vIdx := range vals {
+				secrets := make(map[int]tbls.PrivateKey)
+				for i := range nodes {
+					if uint32(i+1) == test.silent {
+						continue
+					}
+
+					share := results[i][vIdx]
+					require.Equal(t, results[0][vIdx].PubKey, share.PubKey)
+					require.Equal(t, results[0][vIdx].PublicShares, share.PublicShares)
+
+					pubShare, err := tbls.SecretToPublicKey(share.SecretShare)
+					require.NoError(t, err)
+					require.Equal(t, share.PublicShares[i+1], pubShare)
+
+					secrets[i+1] = share.SecretShare
+				}
+
+				secret, err := tbls.RecoverSecret(secrets, nodes, threshold)
+				require.NoError(t, err)
+				pubkey, err := tbls.SecretToPublicKey(secret)
+				require.NoError(t, err)
+				require.Equal(t, results[0][vIdx].PubKey, pubkey)
+			}
+
+			// Only the excluded dealer's commitment isn't included in the resulting public key.
+			var pubkey curves.Point
+			for dealer := uint32(1); dealer <= nodes; dealer++ {
+				if dealer == test.excluded {
+					continue
+				}
+
+				comm := tp.commitments[msgKey{ValIdx: 0, SourceID: dealer}][0]
+				if pubkey == nil {
+					pubkey = comm
+				} else {
+					pubkey = pubkey.Add(comm)
+				}
+			}
+			expect, err := pointToPubKey(pubkey)
+			require.NoError(t, err)
+			require.Equal(t, expect, results[0][0].PubKey)
+		})
+	}
+}
+
+func TestPedersenP2PRounds(t *testing.T) {
+	ctx := context.Background()
+
+	newTransport := func() *pedersenP2P {
+		return &pedersenP2P{
+			peers:              map[uint32]peer.ID{1: "1", 2: "2", 3: "3", 4: "4"},
+			shareIdx:           1,
+			quorum:             3,
+			bcastFunc:          func(context.Context, string, proto.Message) error { return nil },
+			roundTimeout:       10 * time.Millisecond,
+			complaintsRecv:     make(chan pedersenComplaintsMsg, 4),
+			justificationsRecv: make(chan *pb.PedersenJustifications, 4),
+		}
+	}
+
+	t.Run("complaints quorum", func(t *testing.T) {
+		tp := newTransport()
+		tp.complaintsRecv <- pedersenComplaintsMsg{Source: 2, Msg: &pb.PedersenComplaints{InvalidDealers: []uint32{3}}}
+		tp.complaintsRecv <- pedersenComplaintsMsg{Source: 3, Msg: new(pb.PedersenComplaints)}
+
+		_, reports, err := tp.Complain(ctx, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[uint32][]uint32{1: nil, 2: {3}, 3: nil}, reports)
+	})
+
+	t.Run("insufficient complaints", func(t *testing.T) {
+		tp := newTransport()
+		tp.complaintsRecv <- pedersenComplaintsMsg{Source: 2, Msg: new(pb.PedersenComplaints)}
+
+		_, _, err := tp.Complain(ctx, nil, nil)
+		require.ErrorContains(t, err, "insufficient pedersen complaints")
+	})
+
+	t.Run("justifications quorum", func(t *testing.T) {
+		tp := newTransport()
+		tp.justificationsRecv <- new(pb.PedersenJustifications)
+		tp.justificationsRecv <- new(pb.PedersenJustifications)
+
+		_, err := tp.Justify(ctx, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("insufficient justifications", func(t *testing.T) {
+		tp := newTransport()
+
+		_, err := tp.Justify(ctx, nil)
+		require.ErrorContains(t, err, "insufficient pedersen justifications")
+	})
+}
+
+func TestExcludedDealers(t *testing.T) {
+	const (
+		nodes     = 4
+		threshold = 3
+	)
+
+	castDeal, _, _, err := dealSecrets([]curves.Scalar{curve.Scalar.Random(rand.Reader)}, nodes, threshold, 1)
+	require.NoError(t, err)
+
+	dealers := allDealers(nodes)
+	commitments := make(map[msgKey][]curves.Point)
+	for _, dealer := range dealers {
+		commitments[msgKey{SourceID: dealer}] = castDeal[msgKey{SourceID: 1}]
+	}
+
+	// Honest.
+	reports := map[uint32][]uint32{1: nil, 2: nil, 3: nil, 4: nil}
+	require.Empty(t, excludedDealers(commitments, reports, dealers, 1, nodes, threshold))
+
+	// Single report is ignored.
+	reports = map[uint32][]uint32{1: {2}, 2: nil, 3: nil, 4: nil}
+	require.Empty(t, excludedDealers(commitments, reports, dealers, 1, nodes, threshold))
+
+	// More than faulty reports.
+	reports = map[uint32][]uint32{1: {2}, 2: nil, 3: {2}, 4: nil}
+	require.Equal(t, []uint32{2}, excludedDealers(commitments, reports, dealers, 1, nodes, threshold))
+
+	// Missing complaints.
+	reports = map[uint32][]uint32{1: nil, 2: nil, 3: nil}
+	require.Equal(t, []uint32{4}, excludedDealers(commitments, reports, dealers, 1, nodes, threshold))
+
+	// Invalid commitments.
+	commitments[msgKey{SourceID: 3}] = commitments[msgKey{SourceID: 3}][:1]
+	reports = map[uint32][]uint32{1: nil, 2: nil, 3: nil, 4: nil}
+	require.Equal(t, []uint32{3}, excludedDealers(commitments, reports, dealers, 1, nodes, threshold))
+}
+
+func TestQualifiedDealers(t *testing.T) {
+	const (
+		nodes     = 4
+		threshold = 3
+	)
+
+	castDeal, p2pDeal, _, err := dealSecrets([]curves.Scalar{curve.Scalar.Random(rand.Reader)}, nodes, threshold, 1)
+	require.NoError(t, err)
+
+	dealers := allDealers(nodes)
+	commitments := make(map[msgKey][]curves.Point)
+	for _, dealer := range dealers {
+		commitments[msgKey{SourceID: dealer}] = castDeal[msgKey{SourceID: 1}]
+	}
+
+	// Justified complaint.
+	complaints := []msgKey{{SourceID: 2, TargetID: 1}}
+	qual := qualifiedDealers(commitments, complaints, pedersenJustifications(complaints, p2pDeal, 1), dealers, threshold)
+	require.Len(t, qual, nodes)
+
+	// Unjustified complaint.
+	qual = qualifiedDealers(commitments, complaints, nil, dealers, threshold)
+	require.Len(t, qual, nodes-1)
+	require.False(t, qual[1])
+	require.Equal(t, []uint32{1}, disqualified(qual, dealers))
+
+	// Too many complaints.
+	complaints = []msgKey{{SourceID: 2, TargetID: 1}, {SourceID: 3, TargetID: 1}, {SourceID: 4, TargetID: 1}}
+	qual = qualifiedDealers(commitments, complaints, pedersenJustifications(complaints, p2pDeal, 1), dealers, threshold)
+	require.False(t, qual[1])
+
+	// Complaints against non-dealers are ignored.
+	complaints = []msgKey{{SourceID: 1, TargetID: 4}}
+	qual = qualifiedDealers(commitments, complaints, nil, dealers[:3], threshold)
+	require.Len(t, qual, 3)
+}
+
+var errSilent = errors.New("silent dealer")
+
+// corruptTransport wraps a pedersenMemTransport and corrupts the shares sent by the dealer if corrupt is set.
+// It also corrupts the justifications if justify is true, drops received commitments if dropCommitments returns true,
+// falsely reports the dealers returned by report and doesn't take part in rounds after the deal if silent is true.
+type corruptTransport struct {
+	*pedersenMemTransport
+	dealer          uint32
+	corrupt         func(dealer uint32, shares map[msgKey]sharing.ShamirShare) map[msgKey]sharing.ShamirShare
+	justify         bool
+	dropCommitments func(receiver, dealer uint32) bool
+	report          func(reporter uint32) []uint32
+	silent          bool
+}
+
+func (t corruptTransport) Complain(ctx context.Context, complaints []msgKey, invalidDealers []uint32) ([]msgKey, map[uint32][]uint32, error) {
+	if t.silent {
+		return nil, nil, errSilent
+	}
+
+	if t.report != nil {
+		invalidDealers = append(invalidDealers, t.report(t.dealer)...)
+	}
+
+	return t.complain(ctx, t.dealer, complaints, invalidDealers)
+}
+
+func (t corruptTransport) Justify(ctx context.Context, justifications map[msgKey]sharing.ShamirShare) (map[msgKey]sharing.ShamirShare, error) {
+	if t.silent {
+		return nil, errSilent
+	}
+
+	if t.justify {
+		justifications = t.corrupt(t.dealer, justifications)
+	}
+
+	return t.pedersenMemTransport.Justify(ctx, justifications)
+}
+
+func (t corruptTransport) Deal(ctx context.Context, commitments map[msgKey][]curves.Point, shares map[msgKey]sharing.ShamirShare,
+) (map[msgKey][]curves.Point, map[msgKey]sharing.ShamirShare, error) {
+	if t.corrupt != nil {
+		shares = t.corrupt(t.dealer, shares)
+	}
+
+	allCommitments, received, err := t.pedersenMemTransport.Deal(ctx, commitments, shares)
+	if err != nil || t.dropCommitments == nil {
+		return allCommitments, received, err
+	}
+
+	resp := make(map[msgKey][]curves.Point)
+	for key, comms := range allCommitments {
+		if !t.dropCommitments(t.dealer, key.SourceID) {
+			resp[key] = comms
+		}
+	}
+
+	return resp, received, nil
+}
+
+// pedersenMemTransport is an in-memory reliable pedersen transport.
+// The silent nodes only take part in the deal round.
+type pedersenMemTransport struct {
+	mu     sync.Mutex
+	nodes  int
+	silent int
+
+	deals       int
+	commitments map[msgKey][]curves.Point
+	shares      map[msgKey]sharing.ShamirShare
+
+	complains  int
+	complaints []msgKey
+	reports    map[uint32][]uint32
+
+	justifies      int
+	justifications map[msgKey]sharing.ShamirShare
+}
+
+func (t *pedersenMemTransport) Deal(ctx context.Context, commitments map[msgKey][]curves.Point, shares map[msgKey]sharing.ShamirShare,
+) (map[msgKey][]curves.Point, map[msgKey]sharing.ShamirShare, error) {
+	var sourceID uint32
+
+	t.mu.Lock()
+	if t.deals == 0 {
+		t.commitments = make(map[msgKey][]curves.Point)
+		t.shares = make(map[msgKey]sharing.ShamirShare)
+	}
+	for key, comms := range commitments {
+		sourceID = key.SourceID
+		t.commitments[key] = comms
+	}
+	for key, share := range shares {
+		t.shares[key] = share
+	}
+	t.deals++
+	t.mu.Unlock()
+
+	if err := t.await(ctx, &t.deals, t.nodes); err != nil {
+		return nil, nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	resp := make(map[msgKey]sharing.ShamirShare)
+	for key, share := range t.shares {
+		if key.TargetID == sourceID {
+			resp[key] = share
+		}
+	}
+
+	return t.commitments, resp, nil
+}
+
+// complain implements the complaint round for the reporter, since the in-memory transport is shared by all nodes.
+func (t *pedersenMemTransport) complain(ctx context.Context, reporter uint32, complaints []msgKey, invalidDealers []uint32,
+) ([]msgKey, map[uint32][]uint32, error) {
+	t.mu.Lock()
+	if t.complains == 0 {
+		t.reports = make(map[uint32][]uint32)
+	}
+	t.complaints = append(t.complaints, complaints...)
+	t.reports[reporter] = invalidDealers
+	t.complains++
+	t.mu.Unlock()
+
+	if err := t.await(ctx, &t.complains, t.nodes-t.silent); err != nil {
+		return nil, nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]msgKey(nil), t.complaints...), maps.Clone(t.reports), nil
+}
+
+func (t *pedersenMemTransport) Justify(ctx context.Context, justifications map[msgKey]sharing.ShamirShare) (map[msgKey]sharing.ShamirShare, error) {
+	t.mu.Lock()
+	if t.justifies == 0 {
+		t.justifications = make(map[msgKey]sharing.ShamirShare)
+	}
+	for key, share := range justifications {
+		t.justifications[key] = share
+	}
+	t.justifies++
+	t.mu.Unlock()
+
+	if err := t.await(ctx, &t.justifies, t.nodes-t.silent); err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.justifications, nil
+}
+
+// await blocks until the number of nodes incremented the round counter.
+func (t *pedersenMemTransport) await(ctx context.Context, round *int, nodes int) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		t.mu.Lock()
+		if *round == nodes {
+			t.mu.Unlock()
+			return nil
+		}
+		t.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,538 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/coinbase/kryptology/pkg/sharing"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	pb "github.com/obolnetwork/charon/dkg/dkgpb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	// pedersenCommitmentTimeout is the duration to wait for the deal commitments of all nodes.
+	// Dealers with commitments still missing afterward are excluded.
+	pedersenCommitmentTimeout = 30 * time.Second
+
+	// pedersenShareTimeout is the duration to wait for missing deal shares after all commitments were received.
+	// Shares still missing afterward result in complaints.
+	pedersenShareTimeout = 5 * time.Second
+
+	// pedersenRoundTimeout is the duration to wait for the complaints or justifications of all nodes.
+	// The round proceeds afterward if at least threshold nodes replied, nodes that didn't reply
+	// to the complaint round are excluded as dealers.
+	pedersenRoundTimeout = 30 * time.Second
+)
+
+var (
+	pedersenDealCastID          = string(pedersenProtocol("deal/cast"))
+	pedersenDealP2PID           = pedersenProtocol("deal/p2p")
+	pedersenComplaintCastID     = string(pedersenProtocol("complaint/cast"))
+	pedersenJustificationCastID = string(pedersenProtocol("justification/cast"))
+)
+
+// pedersenMessageIDs returns the bcast message IDs pedersenp2p uses.
+func pedersenMessageIDs() []string {
+	return []string{pedersenDealCastID, pedersenComplaintCastID, pedersenJustificationCastID}
+}
+
+// newPedersenP2P returns a p2p pedersen transport implementation.
+// It registers bcast handlers on bcastComp.
+func newPedersenP2P(tcpNode host.Host, peers map[peer.ID]cluster.NodeIdx, bcastComp *bcast.Component, threshold, numVals int) *pedersenP2P {
+	var (
+		dealCastsRecv      = make(chan *pb.PedersenDealCasts, len(peers))
+		dealP2PRecv        = make(chan *pb.PedersenDealP2P, len(peers))
+		complaintsRecv     = make(chan pedersenComplaintsMsg, len(peers))
+		justificationsRecv = make(chan *pb.PedersenJustifications, len(peers))
+		peersByShareIdx    = make(map[uint32]peer.ID)
+		numNodes           = len(peers)
+		selfShareIdx       = peers[tcpNode.ID()].ShareIdx
+		validKey           = newPedersenKeyValidator(numNodes, numVals)
+		mu                 sync.Mutex
+		dedupDealP2P       = make(map[peer.ID]bool)
+		dedupCasts         = make(map[string]map[peer.ID]bool)
+	)
+	for pID, nodeIdx := range peers {
+		peersByShareIdx[uint32(nodeIdx.ShareIdx)] = pID
+	}
+
+	// validDealCasts returns an error if the deal casts of the source node are invalid.
+	// It is used before signing deal casts, so invalid commitments are never reliably broadcast.
+	validDealCasts := func(msg *pb.PedersenDealCasts, sourceIdx int) error {
+		for _, cast := range msg.GetCasts() {
+			if err := validKey(cast.GetKey(), sourceIdx); err != nil {
+				return err
+			} else if cast.GetKey().GetTargetId() != 0 {
+				return errors.New("invalid pedersen deal cast target ID")
+			} else if len(cast.GetCommitments()) != threshold {
+				return errors.New("invalid amount of pedersen commitments",
+					z.Int("received", len(cast.GetCommitments())),
+					z.Int("expected", threshold),
+				)
+			} else if _, _, err := dealCastFromProto(cast); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Register deal p2p protocol handler.
+	p2p.RegisterHandler("pedersen", tcpNode, pedersenDealP2PID,
+		func() proto.Message { return new(pb.PedersenDealP2P) },
+		func(ctx context.Context, pID peer.ID, req proto.Message) (proto.Message, bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			msg, ok := req.(*pb.PedersenDealP2P)
+			if !ok {
+				return nil, false, errors.New("invalid pedersen deal p2p message")
+			}
+
+			for _, share := range msg.GetShares() {
+				if err := validKey(share.GetKey(), peers[pID].ShareIdx); err != nil {
+					return nil, false, err
+				} else if int(share.GetKey().GetTargetId()) != selfShareIdx {
+					return nil, false, errors.New("invalid pedersen deal p2p target ID")
+				}
+			}
+
+			if dedupDealP2P[pID] {
+				log.Debug(ctx, "Ignoring duplicate pedersen deal p2p message", z.Any("peer", p2p.PeerName(pID)))
+				return nil, false, nil
+			}
+			dedupDealP2P[pID] = true
+
+			dealP2PRecv <- msg
+
+			return nil, false, nil
+		},
+	)
+
+	bcastCallback := func(ctx context.Context, pID peer.ID, msgID string, m proto.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if dedupCasts[msgID] == nil {
+			dedupCasts[msgID] = make(map[peer.ID]bool)
+		}
+		if dedupCasts[msgID][pID] {
+			log.Debug(ctx, "Ignoring duplicate pedersen message", z.Any("peer", p2p.PeerName(pID)), z.Str("message_id", msgID))
+			return nil
+		}
+
+		sourceIdx := peers[pID].ShareIdx
+
+		switch msg := m.(type) {
+		case *pb.PedersenDealCasts:
+			if msgID != pedersenDealCastID {
+				return errors.New("invalid pedersen message type", z.Str("message_id", msgID))
+			}
+
+			if err := validDealCasts(msg, sourceIdx); err != nil {
+				return err
+			}
+
+			dealCastsRecv <- msg
+		case *pb.PedersenComplaints:
+			if msgID != pedersenComplaintCastID {
+				return errors.New("invalid pedersen message type", z.Str("message_id", msgID))
+			}
+
+			for _, key := range msg.GetComplaints() {
+				if err := validKey(key, sourceIdx); err != nil {
+					return err
+				} else if key.GetTargetId() == 0 {
+					return errors.New("invalid pedersen complaint target ID")
+				}
+			}
+
+			for _, dealer := range msg.GetInvalidDealers() {
+				if dealer == 0 || int(dealer) > numNodes {
+					return errors.New("invalid pedersen complaint dealer")
+				}
+			}
+
+			complaintsRecv <- pedersenComplaintsMsg{Source: uint32(sourceIdx), Msg: msg}
+		case *pb.PedersenJustifications:
+			if msgID != pedersenJustificationCastID {
+				return errors.New("invalid pedersen message type", z.Str("message_id", msgID))
+			}
+
+			for _, share := range msg.GetJustifications() {
+				if err := validKey(share.GetKey(), sourceIdx); err != nil {
+					return err
+				} else if share.GetKey().GetTargetId() == 0 || share.GetId() != share.GetKey().GetTargetId() {
+					return errors.New("invalid pedersen justification target ID")
+				}
+			}
+
+			justificationsRecv <- msg
+		default:
+			return errors.New("invalid pedersen message type", z.Str("message_id", msgID))
+		}
+
+		dedupCasts[msgID][pID] = true
+
+		return nil
+	}
+
+	checkDeal := func(pID peer.ID, msg *pb.PedersenDealCasts) error {
+		return validDealCasts(msg, peers[pID].ShareIdx)
+	}
+
+	for _, msgID := range pedersenMessageIDs() {
+		bcastComp.RegisterMessageIDFuncs(msgID, bcastCallback, newPedersenCheckMsg(msgID, checkDeal))
+	}
+
+	return &pedersenP2P{
+		tcpNode:            tcpNode,
+		peers:              peersByShareIdx,
+		shareIdx:           uint32(selfShareIdx),
+		quorum:             threshold,
+		bcastFunc:          bcastComp.Broadcast,
+		commitmentTimeout:  pedersenCommitmentTimeout,
+		shareTimeout:       pedersenShareTimeout,
+		roundTimeout:       pedersenRoundTimeout,
+		dealCastsRecv:      dealCastsRecv,
+		dealP2PRecv:        dealP2PRecv,
+		complaintsRecv:     complaintsRecv,
+		justificationsRecv: justificationsRecv,
+	}
+}
+
+// newPedersenKeyValidator returns a function that validates the message key of a message received from the source node.
+// The target ID must be zero for broadcasts or a different node otherwise.
+func newPedersenKeyValidator(numNodes, numVals int) func(*pb.PedersenMsgKey, int) error {
+	return func(key *pb.PedersenMsgKey, sourceIdx int) error {
+		if key == nil {
+			return errors.New("pedersen msg key cannot be nil")
+		} else if int(key.GetSourceId()) != sourceIdx {
+			return errors.New("invalid pedersen source ID")
+		} else if int(key.GetTargetId()) > numNodes || int(key.GetTargetId()) == sourceIdx {
+			return errors.New("invalid pedersen target ID")
+		} else if int(key.GetValIdx()) >= numVals {
+			return errors.New("invalid pedersen validator index")
+		}
+
+		return nil
+	}
+}
+
+// newPedersenCheckMsg returns a bcast.CheckMessage function for the pedersen message ID.
+// Deal casts are also checked by checkDeal.
+func newPedersenCheckMsg(messageID string, checkDeal func(peer.ID, *pb.PedersenDealCasts) error) bcast.CheckMessage {
+	return func(_ context.Context, pID peer.ID, msgAny *anypb.Any) error {
+		var target proto.Message
+		switch messageID {
+		case pedersenDealCastID:
+			target = new(pb.PedersenDealCasts)
+		case pedersenComplaintCastID:
+			target = new(pb.PedersenComplaints)
+		case pedersenJustificationCastID:
+			target = new(pb.PedersenJustifications)
+		default:
+			return errors.New("pedersen message id unsupported", z.Str("message_id", messageID))
+		}
+
+		if err := msgAny.UnmarshalTo(target); err != nil {
+			return errors.Wrap(err, "pedersen check message fail")
+		}
+
+		if casts, ok := target.(*pb.PedersenDealCasts); ok {
+			return checkDeal(pID, casts)
+		}
+
+		return nil
+	}
+}
+
+// pedersenComplaintsMsg is a complaints message and the share index of the node that broadcast it.
+type pedersenComplaintsMsg struct {
+	Source uint32
+	Msg    *pb.PedersenComplaints
+}
+
+// pedersenP2P implements pedersen transport.
+type pedersenP2P struct {
+	tcpNode            host.Host
+	peers              map[uint32]peer.ID // map[shareIdx]peerID
+	shareIdx           uint32
+	quorum             int // Minimum number of nodes replying to a round before it times out.
+	bcastFunc          bcast.BroadcastFunc
+	commitmentTimeout  time.Duration
+	shareTimeout       time.Duration
+	roundTimeout       time.Duration
+	dealCastsRecv      chan *pb.PedersenDealCasts
+	dealP2PRecv        chan *pb.PedersenDealP2P
+	complaintsRecv     chan pedersenComplaintsMsg
+	justificationsRecv chan *pb.PedersenJustifications
+}
+
+// Deal returns results of the deal round; the received commitments broadcast by all nodes
+// and the shares sent directly to this node. Commitments not received within commitmentTimeout
+// and shares not received within shareTimeout after all commitments were received are omitted.
+// Failing to send shares to a peer doesn't fail the round, the peer complains about the missing shares instead.
+func (p *pedersenP2P) Deal(ctx context.Context, commitments map[msgKey][]curves.Point, shares map[msgKey]sharing.ShamirShare,
+) (map[msgKey][]curves.Point, map[msgKey]sharing.ShamirShare, error) {
+	// Send shares directly to peers before broadcasting commitments,
+	// so they usually arrive before the commitments.
+	// All peers receive a message, even if empty, so they don't wait for missing shares.
+	p2pMsgs := make(map[peer.ID]*pb.PedersenDealP2P)
+	for _, pID := range p.peers {
+		if pID != p.tcpNode.ID() {
+			p2pMsgs[pID] = new(pb.PedersenDealP2P)
+		}
+	}
+
+	for key, share := range shares {
+		pID, ok := p.peers[key.TargetID]
+		if !ok {
+			return nil, nil, errors.New("unknown target")
+		} else if pID == p.tcpNode.ID() {
+			return nil, nil, errors.New("bug: unexpected p2p message to self")
+		}
+
+		p2pMsgs[pID].Shares = append(p2pMsgs[pID].Shares, pedersenShareToProto(key, share))
+	}
+
+	for pID, p2pMsg := range p2pMsgs {
+		if err := p2p.Send(ctx, p.tcpNode, pedersenDealP2PID, pID, p2pMsg); err != nil {
+			log.Warn(ctx, "Failed sending pedersen deal shares", err, z.Any("peer", p2p.PeerName(pID)))
+		}
+	}
+
+	casts := new(pb.PedersenDealCasts)
+	for key, comms := range commitments {
+		casts.Casts = append(casts.Casts, dealCastToProto(key, comms))
+	}
+
+	if err := p.bcastFunc(ctx, pedersenDealCastID, casts); err != nil {
+		return nil, nil, err
+	}
+	p.dealCastsRecv <- casts // Send to self
+
+	// Wait for all commitments until timeout, then for remaining shares until timeout.
+	var (
+		castsRecvs    []*pb.PedersenDealCasts
+		p2pRecvs      []*pb.PedersenDealP2P
+		commitTimeout = time.After(p.commitmentTimeout)
+		timeout       <-chan time.Time
+	)
+	for len(castsRecvs) < len(p.peers) || len(p2pRecvs) < len(p.peers)-1 {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-commitTimeout:
+			log.Warn(ctx, "Timeout waiting for pedersen deal commitments", nil, z.Int("missing", len(p.peers)-len(castsRecvs)))
+			if timeout == nil {
+				timeout = time.After(p.shareTimeout)
+			}
+		case <-timeout:
+			log.Warn(ctx, "Timeout waiting for pedersen deal shares", nil, z.Int("missing", len(p.peers)-1-len(p2pRecvs)))
+			castMap, p2pMap := makeDealResponse(ctx, castsRecvs, p2pRecvs)
+
+			return castMap, p2pMap, nil
+		case msg := <-p.dealCastsRecv:
+			castsRecvs = append(castsRecvs, msg)
+			if len(castsRecvs) == len(p.peers) {
+				timeout = time.After(p.shareTimeout)
+			}
+		case msg := <-p.dealP2PRecv:
+			p2pRecvs = append(p2pRecvs, msg)
+		}
+	}
+
+	castMap, p2pMap := makeDealResponse(ctx, castsRecvs, p2pRecvs)
+
+	return castMap, p2pMap, nil
+}
+
+// Complain returns the complaints and the dealers with missing or invalid commitments reported by each node
+// that broadcast its complaints within roundTimeout. It returns an error if fewer than quorum nodes did.
+func (p *pedersenP2P) Complain(ctx context.Context, complaints []msgKey, invalidDealers []uint32) ([]msgKey, map[uint32][]uint32, error) {
+	msg := &pb.PedersenComplaints{InvalidDealers: invalidDealers}
+	for _, key := range complaints {
+		msg.Complaints = append(msg.Complaints, pedersenKeyToProto(key))
+	}
+
+	if err := p.bcastFunc(ctx, pedersenComplaintCastID, msg); err != nil {
+		return nil, nil, err
+	}
+	p.complaintsRecv <- pedersenComplaintsMsg{Source: p.shareIdx, Msg: msg} // Send to self
+
+	var (
+		resp    []msgKey
+		reports = make(map[uint32][]uint32)
+		timeout = time.After(p.roundTimeout)
+	)
+	for len(reports) < len(p.peers) {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-timeout:
+			log.Warn(ctx, "Timeout waiting for pedersen complaints", nil, z.Int("missing", len(p.peers)-len(reports)))
+			if len(reports) < p.quorum {
+				return nil, nil, errors.New("insufficient pedersen complaints", z.Int("received", len(reports)), z.Int("quorum", p.quorum))
+			}
+
+			return resp, reports, nil
+		case msg := <-p.complaintsRecv:
+			for _, key := range msg.Msg.GetComplaints() {
+				resp = append(resp, pedersenKeyFromProto(key))
+			}
+			reports[msg.Source] = msg.Msg.GetInvalidDealers()
+		}
+	}
+
+	return resp, reports, nil
+}
+
+// Justify returns the justifications (publicly revealed shares) broadcast by all nodes within roundTimeout.
+// It returns an error if fewer than quorum nodes broadcast their justifications.
+func (p *pedersenP2P) Justify(ctx context.Context, justifications map[msgKey]sharing.ShamirShare) (map[msgKey]sharing.ShamirShare, error) {
+	msg := new(pb.PedersenJustifications)
+	for key, share := range justifications {
+		msg.Justifications = append(msg.Justifications, pedersenShareToProto(key, share))
+	}
+
+	if err := p.bcastFunc(ctx, pedersenJustificationCastID, msg); err != nil {
+		return nil, err
+	}
+	p.justificationsRecv <- msg // Send to self
+
+	var (
+		resp     = make(map[msgKey]sharing.ShamirShare)
+		received int
+		timeout  = time.After(p.roundTimeout)
+	)
+	for received < len(p.peers) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			log.Warn(ctx, "Timeout waiting for pedersen justifications", nil, z.Int("missing", len(p.peers)-received))
+			if received < p.quorum {
+				return nil, errors.New("insufficient pedersen justifications", z.Int("received", received), z.Int("quorum", p.quorum))
+			}
+
+			return resp, nil
+		case msg := <-p.justificationsRecv:
+			received++
+			for _, sharePB := range msg.GetJustifications() {
+				key, share := pedersenShareFromProto(sharePB)
+				resp[key] = share
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// makeDealResponse returns the deal round response from the list of received messages.
+// Commitments that cannot be decoded are omitted, resulting in the dealer being excluded.
+func makeDealResponse(ctx context.Context, casts []*pb.PedersenDealCasts, p2ps []*pb.PedersenDealP2P) (map[msgKey][]curves.Point, map[msgKey]sharing.ShamirShare) {
+	var (
+		castMap = make(map[msgKey][]curves.Point)
+		p2pMap  = make(map[msgKey]sharing.ShamirShare)
+	)
+	for _, msg := range casts {
+		for _, castPB := range msg.GetCasts() {
+			key, comms, err := dealCastFromProto(castPB)
+			if err != nil {
+				log.Warn(ctx, "Ignoring invalid pedersen deal commitments", err, z.U64("dealer", uint64(castPB.GetKey().GetSourceId())))
+				continue
+			}
+
+			castMap[key] = comms
+		}
+	}
+
+	for _, msg := range p2ps {
+		for _, sharePB := range msg.GetShares() {
+			key, share := pedersenShareFromProto(sharePB)
+			p2pMap[key] = share
+		}
+	}
+
+	return castMap, p2pMap
+}
+
+func dealCastToProto(key msgKey, commitments []curves.Point) *pb.PedersenDealCast {
+	var commBytes [][]byte
+	for _, comm := range commitments {
+		commBytes = append(commBytes, comm.ToAffineCompressed())
+	}
+
+	return &pb.PedersenDealCast{
+		Key:         pedersenKeyToProto(key),
+		Commitments: commBytes,
+	}
+}
+
+func dealCastFromProto(cast *pb.PedersenDealCast) (msgKey, []curves.Point, error) {
+	var comms []curves.Point
+	for _, comm := range cast.GetCommitments() {
+		c, err := curve.Point.FromAffineCompressed(comm)
+		if err != nil {
+			return msgKey{}, nil, errors.Wrap(err, "decode commitment")
+		}
+
+		comms = append(comms, c)
+	}
+
+	return pedersenKeyFromProto(cast.GetKey()), comms, nil
+}
+
+func pedersenShareToProto(key msgKey, share sharing.ShamirShare) *pb.PedersenShamirShare {
+	return &pb.PedersenShamirShare{
+		Key:   pedersenKeyToProto(key),
+		Id:    share.Id,
+		Value: share.Value,
+	}
+}
+
+func pedersenShareFromProto(share *pb.PedersenShamirShare) (msgKey, sharing.ShamirShare) {
+	return pedersenKeyFromProto(share.GetKey()), sharing.ShamirShare{
+		Id:    share.GetId(),
+		Value: share.GetValue(),
+	}
+}
+
+func pedersenKeyToProto(key msgKey) *pb.PedersenMsgKey {
+	return &pb.PedersenMsgKey{
+		ValIdx:   key.ValIdx,
+		SourceId: key.SourceID,
+		TargetId: key.TargetID,
+	}
+}
+
+// pedersenKeyFromProto returns the message key. Note that keys are validated when received.
+func pedersenKeyFromProto(key *pb.PedersenMsgKey) msgKey {
+	return msgKey{
+		ValIdx:   key.GetValIdx(),
+		SourceID: key.GetSourceId(),
+		TargetID: key.GetTargetId(),
+	}
+}
+
+// pedersenProtocol returns the pedersen protocol ID including the provided suffixes.
+func pedersenProtocol(suffix string) protocol.ID {
+	return protocol.ID(path.Join("/charon/dkg/pedersen/1.0.0/", suffix))
+}
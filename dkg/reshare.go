@@ -0,0 +1,760 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/coinbase/kryptology/pkg/core/curves"
+	"github.com/coinbase/kryptology/pkg/sharing"
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/privkeylock"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/sharesigner"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// ReshareConfig defines the config of a key resharing ceremony.
+type ReshareConfig struct {
+	// LockFile is the path to the existing cluster lock file.
+	LockFile string
+	// ManifestFile is the path to the existing cluster manifest file, it is optional.
+	// The reshare mutation is appended to it, otherwise a new cluster manifest is created from the cluster lock.
+	ManifestFile string
+	// ValidatorKeysDir is the directory containing the existing validator key shares.
+	// It is only required for existing operators.
+	ValidatorKeysDir string
+	// NewOperatorENRs are the ENRs of the new operator set.
+	NewOperatorENRs []string
+	// NewThreshold is the new threshold, it defaults to the safe threshold of the new operator set if zero.
+	NewThreshold int
+	// DataDir is the directory containing the charon-enr-private-key.
+	DataDir string
+	// OutputDir is the directory where the new validator key shares, cluster lock and cluster manifest are written to.
+	OutputDir string
+
+	NoVerify      bool
+	P2P           p2p.Config
+	Log           log.Config
+	ShutdownDelay time.Duration
+	Timeout       time.Duration
+
+	TestConfig TestConfig
+}
+
+// Reshare executes a key resharing ceremony between the existing operators and the new operator set.
+// It writes new secret share keystores, a new cluster lock file and a cluster manifest file for the same
+// distributed validator public keys to disk, without reconstructing the full validator private keys.
+//
+// All existing operators, including those not part of the new operator set, act as dealers by resharing their
+// existing secret shares to the new operator set. They also approve the resulting reshare cluster manifest mutation,
+// which is appended to the existing cluster manifest. Only the new operators receive shares and write outputs to disk.
+func Reshare(ctx context.Context, conf ReshareConfig) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ctx = log.WithTopic(ctx, "reshare")
+
+	{
+		// Setup private key locking.
+		lockSvc, err := privkeylock.New(p2p.KeyPath(conf.DataDir)+".lock", "charon alpha reshare")
+		if err != nil {
+			return err
+		}
+
+		// Start it async
+		go func() {
+			if err := lockSvc.Run(); err != nil {
+				log.Error(ctx, "Error locking private key file", err)
+			}
+		}()
+
+		// Stop it on exit.
+		defer lockSvc.Close()
+	}
+
+	version.LogInfo(ctx, "Charon reshare starting")
+
+	oldLock, err := loadLock(conf.LockFile, conf.NoVerify)
+	if err != nil {
+		return err
+	}
+
+	dag, oldCluster, err := loadReshareDAG(conf.ManifestFile, conf.LockFile, oldLock)
+	if err != nil {
+		return err
+	}
+
+	def, err := reshareDefinition(oldLock.Definition, conf.NewOperatorENRs, conf.NewThreshold)
+	if err != nil {
+		return err
+	}
+
+	if err := checkClearOutputDir(conf.OutputDir); err != nil {
+		return err
+	}
+
+	if err := checkWrites(conf.OutputDir); err != nil {
+		return err
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(def.ForkVersion)
+	if err != nil {
+		return err
+	}
+
+	if network == eth2util.Mainnet.Name && (Config{TestConfig: conf.TestConfig}).HasTestConfig() {
+		return errors.New("cannot use test flags on mainnet")
+	}
+
+	newPeers, err := def.Peers()
+	if err != nil {
+		return err
+	}
+
+	// All existing and new operators participate in the ceremony.
+	peers, operators, err := reshareParticipants(oldLock.Definition, def)
+	if err != nil {
+		return err
+	}
+
+	key := conf.TestConfig.P2PKey
+	if key == nil {
+		key, err = p2p.LoadPrivKey(conf.DataDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	pID, err := p2p.PeerIDFromKey(key.PubKey())
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Starting local P2P networking peer")
+
+	logPeerSummary(ctx, pID, peers, operators)
+
+	tcpNode, shutdown, err := setupP2P(ctx, key, Config{P2P: conf.P2P, TestConfig: conf.TestConfig}, peers, def.DefinitionHash)
+	if err != nil {
+		return err
+	}
+	defer shutdown()
+
+	var (
+		nodeIdx  cluster.NodeIdx
+		peerIDs  []peer.ID
+		peerMap  = make(map[peer.ID]cluster.NodeIdx)
+		numNodes = len(newPeers)
+	)
+	for _, p := range peers {
+		idx := cluster.NodeIdx{PeerIdx: p.Index, ShareIdx: p.ShareIdx()}
+		if p.ID == tcpNode.ID() {
+			nodeIdx = idx
+		}
+		peerMap[p.ID] = idx
+		peerIDs = append(peerIDs, p.ID)
+	}
+
+	// Departing operators only deal and approve, they are not part of the new operator set.
+	departing := nodeIdx.PeerIdx >= numNodes
+
+	newPeerIDs, err := def.PeerIDs()
+	if err != nil {
+		return errors.Wrap(err, "get peer IDs")
+	}
+
+	oldPeerIDs, err := oldLock.PeerIDs()
+	if err != nil {
+		return errors.Wrap(err, "get existing peer IDs")
+	}
+
+	dealers, err := reshareDealers(oldLock.Definition, peers)
+	if err != nil {
+		return err
+	}
+
+	var secrets []tbls.PrivateKey
+	if oldShareIdx, ok := dealers[uint32(nodeIdx.ShareIdx)]; ok {
+		secrets, err = loadReshareSecrets(conf.ValidatorKeysDir, oldLock, oldShareIdx)
+		if err != nil {
+			return err
+		}
+	}
+
+	var ex *exchanger
+	if !departing {
+		ex = newExchanger(tcpNode, nodeIdx.PeerIdx, newPeerIDs, def.NumValidators, []sigType{sigLock}, conf.Timeout)
+	}
+
+	caster := bcast.New(tcpNode, peerIDs, key)
+
+	// register bcast callbacks for the reshare transport, reshare approvals and lock hash k1 signature handler
+	tp := newPedersenP2P(tcpNode, peerMap, caster, def.Threshold, def.NumValidators)
+	approvalCaster := newReshareApprovalBcast(oldPeerIDs, caster)
+	nodeSigIdx := nodeIdx
+	if departing {
+		nodeSigIdx = cluster.NodeIdx{PeerIdx: -1} // Departing operators don't sign the new lock.
+	}
+	nodeSigCaster := newNodeSigBcast(newPeers, nodeSigIdx, caster)
+
+	log.Info(ctx, "Waiting to connect to all peers...")
+
+	// Improve UX of "context cancelled" errors when sync fails.
+	ctx = errors.WithCtxErr(ctx, "p2p connection failed, please retry reshare")
+
+	nextStepSync, stopSync, err := startSyncProtocol(ctx, tcpNode, key, def.DefinitionHash, peerIDs, cancel, conf.TestConfig)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "All peers connected, starting reshare ceremony", z.Int("dealers", len(dealers)), z.Bool("departing", departing))
+
+	shares, err := runReshareParallel(ctx, tp, oldLock, dealers, secrets, uint32(numNodes), uint32(def.Threshold), uint32(nodeIdx.ShareIdx))
+	if err != nil {
+		return err
+	}
+
+	// Reshare was step 1, advance to step 2
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	vals, err := reshareDistValidators(oldLock.Validators, shares)
+	if err != nil {
+		return err
+	}
+
+	// Exchange existing operators' approvals of the reshare mutation.
+	reshareMutation, err := approveReshare(ctx, approvalCaster, key, tcpNode.ID(), oldCluster, def, vals)
+	if err != nil {
+		return err
+	}
+	dag.Mutations = append(dag.Mutations, reshareMutation)
+
+	log.Debug(ctx, "Exchanged reshare approvals")
+	// Reshare approval was step 2, advance to step 3
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	lock := cluster.Lock{Definition: def, Validators: vals}
+	if departing {
+		lock, err = lock.SetLockHash()
+	} else {
+		var signer sharesigner.Signer
+		signer, err = newLocalShareSigner(shares)
+		if err != nil {
+			return err
+		}
+
+		// Sign, exchange and aggregate Lock Hash signatures
+		lock, err = signAndAggLock(ctx, signer, shares, lock, nodeIdx, ex)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Debug(ctx, "Aggregated lock hash signatures")
+	// Lock hash aggregate was step 3, advance to step 4
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	// Sign, exchange K1 signatures over Lock Hash
+	if departing {
+		_, err = nodeSigCaster.await(ctx, lock.LockHash)
+	} else {
+		lock.NodeSignatures, err = nodeSigCaster.exchange(ctx, key, lock.LockHash)
+	}
+	if err != nil {
+		return errors.Wrap(err, "k1 lock hash signature exchange")
+	}
+
+	if !cluster.SupportNodeSignatures(lock.Version) {
+		lock.NodeSignatures = nil
+	}
+
+	log.Debug(ctx, "Exchanged node signatures")
+	// Node signatures was step 4, advance to step 5
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	if !departing {
+		if !conf.NoVerify {
+			if err := lock.VerifySignatures(); err != nil {
+				return errors.Wrap(err, "invalid lock file")
+			}
+		}
+
+		if err := writeKeysToDisk(Config{DataDir: conf.OutputDir, TestConfig: conf.TestConfig}, shares); err != nil {
+			return err
+		}
+		log.Debug(ctx, "Saved keyshares to disk")
+
+		if err := writeLock(conf.OutputDir, lock); err != nil {
+			return err
+		}
+		log.Debug(ctx, "Saved lock file to disk")
+
+		if err := writeManifest(conf.OutputDir, dag); err != nil {
+			return err
+		}
+		log.Debug(ctx, "Saved cluster manifest file to disk")
+	}
+
+	// Signature verification and disk key write was step 5, advance to step 6
+	if err := nextStepSync(ctx); err != nil {
+		return err
+	}
+
+	if err := stopSync(ctx); err != nil {
+		return errors.Wrap(err, "sync shutdown") // Consider increasing --shutdown-delay if this occurs often.
+	}
+
+	if conf.TestConfig.ShutdownCallback != nil {
+		conf.TestConfig.ShutdownCallback()
+	}
+	log.Debug(ctx, "Graceful shutdown delay", z.Int("seconds", int(conf.ShutdownDelay.Seconds())))
+	time.Sleep(conf.ShutdownDelay)
+
+	if departing {
+		log.Info(ctx, "Successfully completed reshare ceremony as departing operator 🎉")
+	} else {
+		log.Info(ctx, "Successfully completed reshare ceremony 🎉", z.Str("output_dir", conf.OutputDir))
+	}
+
+	return nil
+}
+
+// approveReshare returns the reshare mutation approved by all existing operators after verifying that
+// it is a valid transformation of the existing cluster.
+func approveReshare(ctx context.Context, approvalCaster *reshareApprovalBcast, key *k1.PrivateKey, selfID peer.ID,
+	oldCluster *manifestpb.Cluster, def cluster.Definition, vals []cluster.DistValidator,
+) (*manifestpb.SignedMutation, error) {
+	var enrs []string
+	for _, operator := range def.Operators {
+		enrs = append(enrs, operator.ENR)
+	}
+
+	var pubShares [][][]byte
+	for _, val := range vals {
+		pubShares = append(pubShares, val.PubShares)
+	}
+
+	proposal, err := manifest.NewReshare(oldCluster.GetLatestMutationHash(), enrs, def.Threshold, pubShares)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := manifest.Hash(proposal)
+	if err != nil {
+		return nil, errors.Wrap(err, "hash reshare proposal")
+	}
+
+	approvals, err := approvalCaster.exchange(ctx, key, selfID, hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "reshare approval exchange")
+	}
+
+	approved, err := manifest.NewApprovedMutation(proposal, approvals)
+	if err != nil {
+		return nil, errors.Wrap(err, "new approved reshare mutation")
+	}
+
+	if _, err := manifest.Transform(proto.Clone(oldCluster).(*manifestpb.Cluster), approved); err != nil {
+		return nil, errors.Wrap(err, "invalid reshare mutation")
+	}
+
+	return approved, nil
+}
+
+// runReshareParallel reshares the existing secret shares of all validators (sharing transport rounds)
+// and returns a list of new shares (one for each distributed validator).
+//
+// Dealers maps the ceremony share index of each dealer to its existing share index. Each dealer deals its existing
+// secret share (provided via secrets if this node is a dealer) to the numNodes new operators (share indexes 1 to numNodes).
+// Dealers must commit to their existing public shares. The new shares are the lagrange interpolation of the qualified
+// dealers' shares, which results in the same group public keys. Departing operators (share index greater than numNodes)
+// only deal, so their returned shares have empty secret shares.
+func runReshareParallel(ctx context.Context, tp pTransport, oldLock cluster.Lock, dealers map[uint32]uint32,
+	secrets []tbls.PrivateKey, numNodes, threshold, shareIdx uint32,
+) ([]share, error) {
+	oldThreshold := oldLock.Threshold
+	if len(dealers) < oldThreshold {
+		return nil, errors.New("insufficient existing operators",
+			z.Int("existing", len(dealers)), z.Int("threshold", oldThreshold))
+	}
+
+	numValidators := uint32(len(oldLock.Validators))
+
+	var (
+		castDeal = make(map[msgKey][]curves.Point)
+		p2pDeal  = make(map[msgKey]sharing.ShamirShare)
+		ownDeal  = make(map[msgKey]sharing.ShamirShare)
+	)
+	if len(secrets) > 0 {
+		var scalars []curves.Scalar
+		for _, secret := range secrets {
+			scalar, err := curve.Scalar.SetBytes(secret[:])
+			if err != nil {
+				return nil, errors.Wrap(err, "secret share to scalar")
+			}
+			scalars = append(scalars, scalar)
+		}
+
+		var err error
+		castDeal, p2pDeal, ownDeal, err = dealSecrets(scalars, numNodes, threshold, shareIdx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	log.Debug(ctx, "Sending reshare deal messages", z.Bool("dealer", len(secrets) > 0))
+
+	commitments, shares, err := tp.Deal(ctx, castDeal, p2pDeal)
+	if err != nil {
+		return nil, errors.Wrap(err, "transport deal round")
+	}
+
+	for key, share := range ownDeal {
+		shares[key] = share
+	}
+
+	// Only consider dealers that committed to their existing public shares.
+	var invalid []uint32
+	for _, dealer := range sortedDealers(dealers) {
+		if !validReshareCommitments(commitments, oldLock.Validators, dealer, dealers[dealer], threshold) {
+			invalid = append(invalid, dealer)
+		}
+	}
+
+	qual, err := resolveComplaints(ctx, tp, commitments, shares, p2pDeal, sortedDealers(dealers), invalid, numValidators, numNodes, threshold, shareIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(qual) < oldThreshold {
+		return nil, errors.New("insufficient qualified reshare dealers",
+			z.Int("qualified", len(qual)), z.Int("threshold", oldThreshold))
+	}
+
+	shamir, err := sharing.NewShamir(uint32(oldThreshold), uint32(len(oldLock.Operators)), curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "new shamir")
+	}
+
+	var oldShareIdxs []uint32
+	for dealer := range qual {
+		oldShareIdxs = append(oldShareIdxs, dealers[dealer])
+	}
+
+	coeffs, err := shamir.LagrangeCoeffs(oldShareIdxs)
+	if err != nil {
+		return nil, errors.Wrap(err, "lagrange coefficients")
+	}
+
+	weights := make(map[uint32]curves.Scalar)
+	for dealer := range qual {
+		weights[dealer] = coeffs[dealers[dealer]]
+	}
+
+	resp, err := combineShares(commitments, shares, weights, numValidators, numNodes, shareIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, s := range resp {
+		if !bytes.Equal(s.PubKey[:], oldLock.Validators[i].PubKey) {
+			return nil, errors.New("bug: reshared validator public key mismatch", z.Int("val_idx", i))
+		}
+	}
+
+	return resp, nil
+}
+
+// validReshareCommitments returns true if the dealer's commitments are valid and commit to its existing public shares.
+func validReshareCommitments(commitments map[msgKey][]curves.Point, vals []cluster.DistValidator, dealer, oldShareIdx, threshold uint32) bool {
+	for vIdx, val := range vals {
+		comms := commitments[msgKey{ValIdx: uint32(vIdx), SourceID: dealer}]
+		if len(comms) != int(threshold) || int(oldShareIdx) > len(val.PubShares) {
+			return false
+		}
+
+		if !bytes.Equal(comms[0].ToAffineCompressed(), val.PubShares[oldShareIdx-1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// reshareDefinition returns the new cluster definition with the new operator set and threshold.
+// The operators are unsigned since the new definition isn't created via the launchpad.
+func reshareDefinition(def cluster.Definition, enrs []string, threshold int) (cluster.Definition, error) {
+	if len(enrs) == 0 {
+		return cluster.Definition{}, errors.New("no new operator ENRs provided")
+	}
+
+	if threshold == 0 {
+		threshold = cluster.Threshold(len(enrs))
+	} else if threshold < 2 {
+		return cluster.Definition{}, errors.New("threshold must be greater than 1", z.Int("threshold", threshold))
+	} else if threshold > len(enrs) {
+		return cluster.Definition{}, errors.New("threshold cannot be greater than number of operators",
+			z.Int("threshold", threshold), z.Int("operators", len(enrs)))
+	}
+
+	var operators []cluster.Operator
+	for _, enr := range enrs {
+		operators = append(operators, cluster.Operator{ENR: enr})
+	}
+
+	def.Operators = operators
+	def.Threshold = threshold
+	def.Creator = cluster.Creator{}
+
+	return def.SetDefinitionHashes()
+}
+
+// reshareParticipants returns the peers participating in the reshare ceremony and their operators;
+// the new operators followed by the existing operators not part of the new operator set.
+func reshareParticipants(oldDef, newDef cluster.Definition) ([]p2p.Peer, []cluster.Operator, error) {
+	peers, err := newDef.Peers()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldPeers, err := oldDef.Peers()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operators := append([]cluster.Operator(nil), newDef.Operators...)
+	for i, oldPeer := range oldPeers {
+		if slices.ContainsFunc(peers, func(p p2p.Peer) bool { return p.ID == oldPeer.ID }) {
+			continue
+		}
+
+		record, err := enr.Parse(oldDef.Operators[i].ENR)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "decode enr", z.Str("enr", oldDef.Operators[i].ENR))
+		}
+
+		p, err := p2p.NewPeerFromENR(record, len(peers))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		peers = append(peers, p)
+		operators = append(operators, oldDef.Operators[i])
+	}
+
+	return peers, operators, nil
+}
+
+// reshareDealers returns the ceremony share index of all existing operators (dealers)
+// mapped to their existing share index.
+func reshareDealers(oldDef cluster.Definition, peers []p2p.Peer) (map[uint32]uint32, error) {
+	oldPeers, err := oldDef.Peers()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make(map[uint32]uint32)
+	for _, oldPeer := range oldPeers {
+		for _, p := range peers {
+			if p.ID == oldPeer.ID {
+				resp[uint32(p.ShareIdx())] = uint32(oldPeer.ShareIdx())
+			}
+		}
+	}
+
+	if len(resp) != len(oldPeers) {
+		return nil, errors.New("bug: existing operators missing from reshare participants")
+	}
+
+	return resp, nil
+}
+
+// loadReshareDAG returns the existing cluster manifest DAG and the materialised cluster after verifying that
+// it matches the cluster lock. A new DAG is created from the cluster lock if the manifest file doesn't exist.
+func loadReshareDAG(manifestFile, lockFile string, lock cluster.Lock) (*manifestpb.SignedMutationList, *manifestpb.Cluster, error) {
+	dag, err := manifest.LoadDAG(manifestFile, lockFile, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "load cluster manifest")
+	}
+
+	c, err := manifest.Materialise(dag)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "materialise cluster manifest")
+	}
+
+	if int(c.GetThreshold()) != lock.Threshold || len(c.GetOperators()) != len(lock.Operators) ||
+		len(c.GetValidators()) != len(lock.Validators) {
+		return nil, nil, errors.New("cluster manifest doesn't match cluster lock")
+	}
+
+	for i, operator := range c.GetOperators() {
+		if operator.GetEnr() != lock.Operators[i].ENR {
+			return nil, nil, errors.New("cluster manifest operators don't match cluster lock", z.Int("index", i))
+		}
+	}
+
+	for i, val := range c.GetValidators() {
+		if !bytes.Equal(val.GetPublicKey(), lock.Validators[i].PubKey) ||
+			!slices.EqualFunc(val.GetPubShares(), lock.Validators[i].PubShares, bytes.Equal) {
+			return nil, nil, errors.New("cluster manifest validators don't match cluster lock", z.Int("val_idx", i))
+		}
+	}
+
+	return dag, c, nil
+}
+
+// writeManifest writes the cluster manifest DAG to the cluster-manifest.pb file in the directory.
+func writeManifest(dir string, dag *manifestpb.SignedMutationList) error {
+	b, err := proto.Marshal(dag)
+	if err != nil {
+		return errors.Wrap(err, "marshal cluster manifest")
+	}
+
+	//nolint:gosec // File needs to be read-write since the cluster manifest is modified by mutations.
+	if err := os.WriteFile(filepath.Join(dir, "cluster-manifest.pb"), b, 0o644); err != nil {
+		return errors.Wrap(err, "write cluster manifest")
+	}
+
+	return nil
+}
+
+// loadReshareSecrets returns the existing secret shares of all validators after verifying them against the lock.
+func loadReshareSecrets(dir string, lock cluster.Lock, shareIdx uint32) ([]tbls.PrivateKey, error) {
+	keyFiles, err := keystore.LoadFilesUnordered(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := keyFiles.SequencedKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secrets) != len(lock.Validators) {
+		return nil, errors.New("validator key shares count doesn't match cluster lock",
+			z.Int("key_shares", len(secrets)), z.Int("validators", len(lock.Validators)))
+	}
+
+	for i, secret := range secrets {
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if int(shareIdx) > len(lock.Validators[i].PubShares) {
+			return nil, errors.New("invalid cluster lock public shares", z.Int("val_idx", i))
+		}
+
+		expect, err := lock.Validators[i].PublicShare(int(shareIdx) - 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if pubshare != expect {
+			return nil, errors.New("validator key share doesn't match cluster lock", z.Int("val_idx", i))
+		}
+	}
+
+	return secrets, nil
+}
+
+// reshareDistValidators returns the existing distributed validators with the new public shares.
+// The deposit data and builder registrations remain valid since the group public keys are unchanged.
+func reshareDistValidators(vals []cluster.DistValidator, shares []share) ([]cluster.DistValidator, error) {
+	if len(vals) != len(shares) {
+		return nil, errors.New("bug: mismatching reshare validators")
+	}
+
+	var resp []cluster.DistValidator
+	for i, val := range vals {
+		val.PubShares = msgFromShare(shares[i]).PubShares
+		resp = append(resp, val)
+	}
+
+	return resp, nil
+}
+
+// loadLock returns the cluster lock from disk after verifying its hashes and signatures unless noVerify is true.
+func loadLock(filename string, noVerify bool) (cluster.Lock, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "read cluster lock", z.Str("path", filename))
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(b, &lock); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "unmarshal cluster lock", z.Str("path", filename))
+	}
+
+	if noVerify {
+		return lock, nil
+	}
+
+	if err := lock.VerifyHashes(); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "cluster lock hash verification failed")
+	}
+
+	if err := lock.VerifySignatures(); err != nil {
+		return cluster.Lock{}, errors.Wrap(err, "cluster lock signature verification failed")
+	}
+
+	return lock, nil
+}
+
+// checkClearOutputDir creates the output directory if it doesn't exist and returns an error if it
+// already contains validator keys, a cluster lock or a cluster manifest.
+func checkClearOutputDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "create output directory", z.Str("output_dir", dir))
+	}
+
+	for _, name := range []string{"validator_keys", "cluster-lock.json", "cluster-manifest.pb"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return errors.New("output directory not clean, cannot continue", z.Str("disallowed_entity", name), z.Str("output_dir", dir))
+		}
+	}
+
+	return nil
+}
+
+// sortedDealers returns the dealers' new share indexes in ascending order.
+func sortedDealers(dealers map[uint32]uint32) []uint32 {
+	var resp []uint32
+	for dealer := range dealers {
+		resp = append(resp, dealer)
+	}
+
+	sort.Slice(resp, func(i, j int) bool { return resp[i] < resp[j] })
+
+	return resp
+}
@@ -0,0 +1,194 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/dkg"
+	dkgsync "github.com/obolnetwork/charon/dkg/sync"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestReshare(t *testing.T) {
+	const (
+		nodes     = 4
+		threshold = 3
+		vals      = 2
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	oldLock, oldKeys, secretShares := cluster.NewForT(t, vals, threshold, nodes, seed, random)
+
+	dir := t.TempDir()
+	lockFile := path.Join(dir, "cluster-lock.json")
+	b, err := json.Marshal(oldLock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockFile, b, 0o644))
+
+	// Replace the first operator with a new operator. The departing operator still deals.
+	newKey, newRecord := testutil.RandomENR(t, 100)
+	p2pKeys := append(append([]*k1.PrivateKey(nil), oldKeys[1:]...), newKey)
+
+	var enrs []string
+	for _, key := range p2pKeys {
+		record, err := enr.New(key)
+		require.NoError(t, err)
+		enrs = append(enrs, record.String())
+	}
+	require.Equal(t, newRecord.String(), enrs[nodes-1])
+
+	relayAddr := startRelay(ctx, t)
+
+	// All participants; the new operators followed by the departing operator.
+	participants := append(append([]*k1.PrivateKey(nil), p2pKeys...), oldKeys[0])
+
+	var eg errgroup.Group
+	for i, key := range participants {
+		dataDir := path.Join(dir, fmt.Sprintf("node%d", i))
+		require.NoError(t, os.MkdirAll(dataDir, 0o755))
+		require.NoError(t, k1util.Save(key, p2p.KeyPath(dataDir)))
+
+		keysDir := path.Join(dataDir, "validator_keys")
+		if i != nodes-1 { // Existing operators
+			oldShareIdx := i + 2
+			if i == nodes {
+				oldShareIdx = 1 // Departing operator
+			}
+
+			require.NoError(t, os.MkdirAll(keysDir, 0o755))
+
+			var secrets []tbls.PrivateKey
+			for _, shares := range secretShares {
+				secrets = append(secrets, shares[oldShareIdx-1])
+			}
+			require.NoError(t, keystore.StoreKeysInsecure(secrets, keysDir, keystore.ConfirmInsecureKeys))
+		}
+
+		conf := dkg.ReshareConfig{
+			LockFile:         lockFile,
+			ManifestFile:     path.Join(dir, "cluster-manifest.pb"),
+			ValidatorKeysDir: keysDir,
+			NewOperatorENRs:  enrs,
+			DataDir:          dataDir,
+			OutputDir:        path.Join(dataDir, "reshare"),
+			P2P: p2p.Config{
+				Relays:   []string{relayAddr},
+				TCPAddrs: []string{testutil.AvailableAddr(t).String()},
+			},
+			Log:           log.DefaultConfig(),
+			ShutdownDelay: time.Second,
+			Timeout:       8 * time.Second,
+			TestConfig: dkg.TestConfig{
+				StoreKeysFunc: func(secrets []tbls.PrivateKey, dir string) error {
+					return keystore.StoreKeysInsecure(secrets, dir, keystore.ConfirmInsecureKeys)
+				},
+				SyncOpts: []func(*dkgsync.Client){dkgsync.WithPeriod(time.Millisecond * 50)},
+			},
+		}
+
+		eg.Go(func() error {
+			err := dkg.Reshare(peerCtx(ctx, i), conf)
+			if err != nil {
+				cancel()
+			}
+
+			return err
+		})
+	}
+
+	err = eg.Wait()
+	testutil.SkipIfBindErr(t, err)
+	testutil.RequireNoError(t, err)
+
+	var (
+		locks   []cluster.Lock
+		secrets = make([]map[int]tbls.PrivateKey, vals)
+	)
+	for i := range p2pKeys {
+		outputDir := path.Join(dir, fmt.Sprintf("node%d", i), "reshare")
+
+		b, err := os.ReadFile(path.Join(outputDir, "cluster-lock.json"))
+		require.NoError(t, err)
+
+		var lock cluster.Lock
+		require.NoError(t, json.Unmarshal(b, &lock))
+		require.NoError(t, lock.VerifyHashes())
+		require.NoError(t, lock.VerifySignatures())
+		locks = append(locks, lock)
+
+		// The cluster manifest contains the reshare mutation approved by the existing operators.
+		c, err := manifest.LoadCluster(path.Join(outputDir, "cluster-manifest.pb"), "", nil)
+		require.NoError(t, err)
+		require.EqualValues(t, threshold, c.GetThreshold())
+		require.Len(t, c.GetOperators(), nodes)
+		for j, operator := range c.GetOperators() {
+			require.Equal(t, enrs[j], operator.GetEnr())
+		}
+		for vIdx, val := range c.GetValidators() {
+			require.Equal(t, lock.Validators[vIdx].PubShares, val.GetPubShares())
+		}
+
+		keyFiles, err := keystore.LoadFilesUnordered(path.Join(outputDir, "validator_keys"))
+		require.NoError(t, err)
+		keys, err := keyFiles.SequencedKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, vals)
+
+		for vIdx, key := range keys {
+			if secrets[vIdx] == nil {
+				secrets[vIdx] = make(map[int]tbls.PrivateKey)
+			}
+			secrets[vIdx][i+1] = key
+		}
+	}
+
+	// The departing operator doesn't write any outputs.
+	_, err = os.Stat(path.Join(dir, fmt.Sprintf("node%d", nodes), "reshare", "cluster-lock.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	for _, lock := range locks {
+		require.Equal(t, locks[0].LockHash, lock.LockHash)
+		require.Equal(t, enrs[0], lock.Operators[0].ENR)
+		require.Equal(t, threshold, lock.Threshold)
+		require.Equal(t, oldLock.UUID, lock.UUID)
+	}
+
+	for vIdx, val := range locks[0].Validators {
+		require.Equal(t, oldLock.Validators[vIdx].PubKey, val.PubKey)
+		require.NotEqual(t, oldLock.Validators[vIdx].PubShares, val.PubShares)
+		require.Equal(t, oldLock.Validators[vIdx].BuilderRegistration, val.BuilderRegistration)
+
+		// Any threshold of new shares recovers the same validator key.
+		delete(secrets[vIdx], 1)
+		secret, err := tbls.RecoverSecret(secrets[vIdx], nodes, threshold)
+		require.NoError(t, err)
+
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+		require.Equal(t, val.PubKey, pubkey[:])
+	}
+}
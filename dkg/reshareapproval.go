@@ -0,0 +1,167 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/dkg/bcast"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const reshareApprovalMsgID = "/charon/dkg/reshare_approval"
+
+// reshareApprovalBcast handles broadcasting of the existing operators' node approvals
+// of the reshare mutation proposal via the bcast protocol.
+type reshareApprovalBcast struct {
+	approvals map[peer.ID]*manifestpb.SignedMutation
+	mu        sync.Mutex
+
+	bcastFunc bcast.BroadcastFunc
+	approvers []peer.ID
+}
+
+// newReshareApprovalBcast returns a new instance of reshareApprovalBcast expecting node approvals from the approvers.
+// It registers bcast handlers on bcastComp.
+func newReshareApprovalBcast(approvers []peer.ID, bcastComp *bcast.Component) *reshareApprovalBcast {
+	ret := &reshareApprovalBcast{
+		approvals: make(map[peer.ID]*manifestpb.SignedMutation),
+		bcastFunc: bcastComp.Broadcast,
+		approvers: approvers,
+	}
+
+	bcastComp.RegisterMessageIDFuncs(reshareApprovalMsgID, ret.broadcastCallback, ret.checkMessage)
+
+	return ret
+}
+
+// isApprover returns true if the peer is one of the approvers.
+func (r *reshareApprovalBcast) isApprover(pID peer.ID) bool {
+	for _, approver := range r.approvers {
+		if approver == pID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setApproval stores the peer's approval, ignoring duplicates.
+// It is safe to use concurrently.
+func (r *reshareApprovalBcast) setApproval(pID peer.ID, approval *manifestpb.SignedMutation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.approvals[pID]; !ok {
+		r.approvals[pID] = approval
+	}
+}
+
+// allApprovals returns the approvals ordered by approver and true if the approvals of all approvers have been received.
+// It is safe to use concurrently.
+func (r *reshareApprovalBcast) allApprovals() ([]*manifestpb.SignedMutation, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var resp []*manifestpb.SignedMutation
+	for _, approver := range r.approvers {
+		approval, ok := r.approvals[approver]
+		if !ok {
+			return nil, false
+		}
+		resp = append(resp, approval)
+	}
+
+	return resp, true
+}
+
+// broadcastCallback is the bcast.Callback for reshareApprovalBcast.
+func (r *reshareApprovalBcast) broadcastCallback(_ context.Context, pID peer.ID, _ string, msg proto.Message) error {
+	approval, ok := msg.(*manifestpb.SignedMutation)
+	if !ok {
+		return errors.New("invalid reshare approval type")
+	}
+
+	if !r.isApprover(pID) {
+		return errors.New("reshare approval from non-existing operator", z.Str("peer", p2p.PeerName(pID)))
+	}
+
+	pubkey, err := p2p.PeerIDToKey(pID)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(pubkey.SerializeCompressed(), approval.GetSigner()) {
+		return errors.New("reshare approval signer mismatch", z.Str("peer", p2p.PeerName(pID)))
+	}
+
+	r.setApproval(pID, approval)
+
+	return nil
+}
+
+// checkMessage is the bcast.CheckMessage for reshareApprovalBcast.
+func (*reshareApprovalBcast) checkMessage(_ context.Context, peerID peer.ID, msgAny *anypb.Any) error {
+	var msg manifestpb.SignedMutation
+	if err := msgAny.UnmarshalTo(&msg); err != nil {
+		return errors.Wrap(err, "reshare approval request malformed", z.Str("peer_id", peerID.String()))
+	}
+
+	return nil
+}
+
+// exchange returns the node approvals of the proposal hash by all approvers.
+// The local node only signs and broadcasts its approval if it is one of the approvers.
+// Note the returned approvals must still be verified, e.g., via manifest.NewApprovedMutation.
+func (r *reshareApprovalBcast) exchange(ctx context.Context, key *k1.PrivateKey, selfID peer.ID, hash []byte) ([]*manifestpb.SignedMutation, error) {
+	if r.isApprover(selfID) {
+		approval, err := manifest.SignNodeApproval(hash, key)
+		if err != nil {
+			return nil, errors.Wrap(err, "sign reshare approval")
+		}
+
+		log.Debug(ctx, "Exchanging reshare approvals")
+
+		if err := r.bcastFunc(ctx, reshareApprovalMsgID, approval); err != nil {
+			return nil, errors.Wrap(err, "reshare approval broadcast")
+		}
+
+		r.setApproval(selfID, approval)
+	}
+
+	tick := time.NewTicker(100 * time.Millisecond)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-tick.C:
+			approvals, ok := r.allApprovals()
+			if !ok {
+				continue
+			}
+
+			for i, approval := range approvals {
+				if !bytes.Equal(hash, approval.GetMutation().GetParent()) {
+					return nil, errors.New("reshare approval of different proposal", z.Str("peer", p2p.PeerName(r.approvers[i])))
+				}
+			}
+
+			return approvals, nil
+		}
+	}
+}
@@ -32,6 +32,8 @@ const (
 	keyTCP = "tcp"
 	// keyUDP is the key used to store the UDP port in the record.
 	keyUDP = "udp"
+	// keyQUIC is the key used to store the QUIC (UDP) port in the record.
+	keyQUIC = "quic"
 )
 
 // Parse parses the given base64 encoded string into a record.
@@ -123,6 +125,13 @@ func WithUDP(port int) Option {
 	}
 }
 
+// WithQUIC returns an option that sets the QUIC port of the record.
+func WithQUIC(port int) Option {
+	return func(kvs map[string][]byte) {
+		kvs[keyQUIC] = toBigEndian(port)
+	}
+}
+
 // New returns a new enr record for the given private key and provided options.
 func New(privkey *k1.PrivateKey, opts ...Option) (Record, error) {
 	kvs := map[string][]byte{
@@ -174,6 +183,12 @@ func (r Record) UDP() (int, bool) {
 	return fromBigEndian(b), ok
 }
 
+// QUIC returns the QUIC port of the record or false if not present.
+func (r Record) QUIC() (int, bool) {
+	b, ok := r.kvs[keyQUIC]
+	return fromBigEndian(b), ok
+}
+
 // String returns the base64 encoded string representation of the record.
 func (r Record) String() string {
 	return "enr:" + base64.RawURLEncoding.EncodeToString(encodeElements(r.Signature, r.kvs))
@@ -63,8 +63,9 @@ func TestIPTCP(t *testing.T) {
 	expectIP := net.IPv4(1, 2, 3, 4)
 	expectTCP := 8000
 	expectUDP := 9000
+	expectQUIC := 9001
 
-	r1, err := enr.New(privkey, enr.WithIP(expectIP), enr.WithTCP(expectTCP), enr.WithUDP(expectUDP))
+	r1, err := enr.New(privkey, enr.WithIP(expectIP), enr.WithTCP(expectTCP), enr.WithUDP(expectUDP), enr.WithQUIC(expectQUIC))
 	require.NoError(t, err)
 
 	ip, ok := r1.IP()
@@ -93,6 +94,10 @@ func TestIPTCP(t *testing.T) {
 	udp, ok = r2.UDP()
 	require.True(t, ok)
 	require.Equal(t, expectUDP, udp)
+
+	quic, ok := r2.QUIC()
+	require.True(t, ok)
+	require.Equal(t, expectQUIC, quic)
 }
 
 func TestNew(t *testing.T) {
@@ -9,6 +9,8 @@ import (
 	"unicode"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"golang.org/x/crypto/sha3"
@@ -27,6 +29,48 @@ func EpochFromSlot(ctx context.Context, eth2Cl eth2client.SlotsPerEpochProvider,
 	return eth2p0.Epoch(uint64(slot) / slotsPerEpoch), nil
 }
 
+// forkSchedule is the list of forks after phase0 and their spec epoch keys in activation order.
+var forkSchedule = []struct {
+	Version eth2spec.DataVersion
+	Key     string
+}{
+	{Version: eth2spec.DataVersionAltair, Key: "ALTAIR_FORK_EPOCH"},
+	{Version: eth2spec.DataVersionBellatrix, Key: "BELLATRIX_FORK_EPOCH"},
+	{Version: eth2spec.DataVersionCapella, Key: "CAPELLA_FORK_EPOCH"},
+	{Version: eth2spec.DataVersionDeneb, Key: "DENEB_FORK_EPOCH"},
+	{Version: eth2spec.DataVersionElectra, Key: "ELECTRA_FORK_EPOCH"},
+}
+
+// DataVersionAtSlot returns the data version of the fork active at the given slot
+// according to the beacon node spec. Forks without a configured epoch are not scheduled.
+func DataVersionAtSlot(ctx context.Context, eth2Cl interface {
+	eth2client.SpecProvider
+	eth2client.SlotsPerEpochProvider
+}, slot eth2p0.Slot,
+) (eth2spec.DataVersion, error) {
+	epoch, err := EpochFromSlot(ctx, eth2Cl, slot)
+	if err != nil {
+		return eth2spec.DataVersionUnknown, err
+	}
+
+	resp, err := eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
+	if err != nil {
+		return eth2spec.DataVersionUnknown, errors.Wrap(err, "fetch spec")
+	}
+
+	version := eth2spec.DataVersionPhase0
+	for _, fork := range forkSchedule {
+		forkEpoch, ok := resp.Data[fork.Key].(uint64)
+		if !ok || eth2p0.Epoch(forkEpoch) > epoch {
+			break
+		}
+
+		version = fork.Version
+	}
+
+	return version, nil
+}
+
 // ChecksumAddress returns an EIP55-compliant 0xhex representation of the 0xhex ethereum address.
 func ChecksumAddress(address string) (string, error) {
 	if !strings.HasPrefix(address, "0x") || len(address) != 2+20*2 {
@@ -66,3 +110,47 @@ func PublicKeyToAddress(pubkey *k1.PublicKey) string {
 
 	return checksumAddressBytes(h.Sum(nil)[12:])
 }
+
+// NewPhase0VersionedAttestation returns a versioned attestation wrapping the phase0 attestation
+// for the given pre-electra version.
+func NewPhase0VersionedAttestation(version eth2spec.DataVersion, att *eth2p0.Attestation) (*eth2spec.VersionedAttestation, error) {
+	resp := &eth2spec.VersionedAttestation{Version: version}
+	switch version {
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0 = att
+	case eth2spec.DataVersionAltair:
+		resp.Altair = att
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix = att
+	case eth2spec.DataVersionCapella:
+		resp.Capella = att
+	case eth2spec.DataVersionDeneb:
+		resp.Deneb = att
+	default:
+		return nil, errors.New("not a phase0 attestation version", z.Str("version", version.String()))
+	}
+
+	return resp, nil
+}
+
+// NewPhase0VersionedSignedAggregateAndProof returns a versioned signed aggregate and proof wrapping
+// the phase0 signed aggregate and proof for the given pre-electra version.
+func NewPhase0VersionedSignedAggregateAndProof(version eth2spec.DataVersion, agg *eth2p0.SignedAggregateAndProof) (*eth2spec.VersionedSignedAggregateAndProof, error) {
+	resp := &eth2spec.VersionedSignedAggregateAndProof{Version: version}
+	switch version {
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0 = agg
+	case eth2spec.DataVersionAltair:
+		resp.Altair = agg
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix = agg
+	case eth2spec.DataVersionCapella:
+		resp.Capella = agg
+	case eth2spec.DataVersionDeneb:
+		resp.Deneb = agg
+	default:
+		return nil, errors.New("not a phase0 signed aggregate and proof version", z.Str("version", version.String()))
+	}
+
+	return resp, nil
+}
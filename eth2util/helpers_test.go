@@ -3,14 +3,18 @@
 package eth2util_test
 
 import (
+	"context"
 	"encoding/hex"
 	"strings"
 	"testing"
 
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
 )
 
 func TestChecksummedAddress(t *testing.T) {
@@ -66,3 +70,37 @@ func TestPublicKeyToAddress(t *testing.T) {
 	actual := eth2util.PublicKeyToAddress(privKey.PubKey())
 	require.Equal(t, testAddrHex, actual)
 }
+
+func TestDataVersionAtSlot(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New(beaconmock.WithElectraForkEpoch(10))
+	require.NoError(t, err)
+
+	slotsPerEpoch, err := bmock.SlotsPerEpoch(ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		Epoch   eth2p0.Epoch
+		Version eth2spec.DataVersion
+	}{
+		{Epoch: 0, Version: eth2spec.DataVersionBellatrix},
+		{Epoch: 9, Version: eth2spec.DataVersionBellatrix},
+		{Epoch: 10, Version: eth2spec.DataVersionElectra},
+		{Epoch: 100, Version: eth2spec.DataVersionElectra},
+	}
+	for _, test := range tests {
+		slot := eth2p0.Slot(uint64(test.Epoch) * slotsPerEpoch)
+		version, err := eth2util.DataVersionAtSlot(ctx, bmock, slot)
+		require.NoError(t, err)
+		require.Equal(t, test.Version, version, "epoch %d", test.Epoch)
+	}
+
+	// Electra isn't scheduled by default.
+	bmock, err = beaconmock.New()
+	require.NoError(t, err)
+
+	version, err := eth2util.DataVersionAtSlot(ctx, bmock, 1<<40)
+	require.NoError(t, err)
+	require.Equal(t, eth2spec.DataVersionDeneb, version)
+}
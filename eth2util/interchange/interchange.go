@@ -0,0 +1,110 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package interchange provides the EIP-3076 slashing protection interchange format.
+// See https://eips.ethereum.org/EIPS/eip-3076.
+package interchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// formatVersion is the only supported EIP-3076 interchange format version.
+const formatVersion = "5"
+
+// File is the EIP-3076 slashing protection interchange file.
+type File struct {
+	Metadata Metadata `json:"metadata"`
+	Data     []Data   `json:"data"`
+}
+
+// Metadata is the EIP-3076 interchange metadata.
+type Metadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// Data is the signing history of a single validator.
+type Data struct {
+	Pubkey             string              `json:"pubkey"`
+	SignedBlocks       []SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []SignedAttestation `json:"signed_attestations"`
+}
+
+// SignedBlock is a signed block of a validator.
+type SignedBlock struct {
+	Slot        uint64 `json:"slot,string"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// SignedAttestation is a signed attestation of a validator.
+type SignedAttestation struct {
+	SourceEpoch uint64 `json:"source_epoch,string"`
+	TargetEpoch uint64 `json:"target_epoch,string"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// New returns a new interchange file for the genesis validators root and data.
+func New(genesisValidatorsRoot [32]byte, data []Data) File {
+	if data == nil {
+		data = []Data{}
+	}
+
+	return File{
+		Metadata: Metadata{
+			InterchangeFormatVersion: formatVersion,
+			GenesisValidatorsRoot:    rootToHex(genesisValidatorsRoot),
+		},
+		Data: data,
+	}
+}
+
+// Load returns the interchange file at the path after verifying it matches the genesis validators root.
+func Load(path string, genesisValidatorsRoot [32]byte) (File, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, errors.Wrap(err, "read interchange file")
+	}
+
+	var file File
+	if err := json.Unmarshal(b, &file); err != nil {
+		return File{}, errors.Wrap(err, "unmarshal interchange file")
+	}
+
+	if file.Metadata.InterchangeFormatVersion != formatVersion {
+		return File{}, errors.New("unsupported interchange format version",
+			z.Str("version", file.Metadata.InterchangeFormatVersion))
+	}
+
+	if !strings.EqualFold(file.Metadata.GenesisValidatorsRoot, rootToHex(genesisValidatorsRoot)) {
+		return File{}, errors.New("mismatching genesis validators root",
+			z.Str("expected", rootToHex(genesisValidatorsRoot)),
+			z.Str("actual", file.Metadata.GenesisValidatorsRoot))
+	}
+
+	return file, nil
+}
+
+// Write writes the interchange file to the path.
+func Write(path string, file File) error {
+	b, err := json.MarshalIndent(file, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal interchange file")
+	}
+
+	if err := os.WriteFile(path, b, 0o644); err != nil { //nolint:gosec // Interchange files are not sensitive.
+		return errors.Wrap(err, "write interchange file")
+	}
+
+	return nil
+}
+
+// rootToHex returns the 0x-prefixed lower case hex representation of the root.
+func rootToHex(root [32]byte) string {
+	return fmt.Sprintf("%#x", root)
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package interchange_test
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/interchange"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestLoadExample(t *testing.T) {
+	root := mustRoot(t, "04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673")
+
+	file, err := interchange.Load("testdata/eip3076_example.json", root)
+	require.NoError(t, err)
+	require.Len(t, file.Data, 1)
+	require.Len(t, file.Data[0].SignedBlocks, 2)
+	require.Len(t, file.Data[0].SignedAttestations, 2)
+	require.EqualValues(t, 81952, file.Data[0].SignedBlocks[0].Slot)
+	require.Empty(t, file.Data[0].SignedBlocks[1].SigningRoot)
+	require.EqualValues(t, 2290, file.Data[0].SignedAttestations[1].SourceEpoch)
+	require.EqualValues(t, 3008, file.Data[0].SignedAttestations[1].TargetEpoch)
+
+	_, err = interchange.Load("testdata/eip3076_example.json", testutil.RandomRoot())
+	require.ErrorContains(t, err, "mismatching genesis validators root")
+}
+
+func TestWriteLoad(t *testing.T) {
+	root := testutil.RandomRoot()
+	path := filepath.Join(t.TempDir(), "interchange.json")
+
+	file := interchange.New(root, []interchange.Data{
+		{
+			Pubkey:             string(testutil.RandomCorePubKey(t)),
+			SignedBlocks:       []interchange.SignedBlock{{Slot: 1}},
+			SignedAttestations: []interchange.SignedAttestation{{SourceEpoch: 2, TargetEpoch: 3}},
+		},
+	})
+
+	require.NoError(t, interchange.Write(path, file))
+
+	loaded, err := interchange.Load(path, root)
+	require.NoError(t, err)
+	require.Equal(t, file, loaded)
+}
+
+func TestUnsupportedVersion(t *testing.T) {
+	root := testutil.RandomRoot()
+	path := filepath.Join(t.TempDir(), "interchange.json")
+
+	file := interchange.New(root, nil)
+	file.Metadata.InterchangeFormatVersion = "4"
+	require.NoError(t, interchange.Write(path, file))
+
+	_, err := interchange.Load(path, root)
+	require.ErrorContains(t, err, "unsupported interchange format version")
+}
+
+func mustRoot(t *testing.T, hexStr string) [32]byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(hexStr)
+	require.NoError(t, err)
+
+	return [32]byte(b)
+}
@@ -169,8 +169,8 @@ func Encrypt(secret tbls.PrivateKey, password string, random io.Reader,
 	}, nil
 }
 
-// decrypt returns the secret from the encrypted (empty password) Keystore.
-func decrypt(store Keystore, password string) (tbls.PrivateKey, error) {
+// Decrypt returns the secret from the encrypted Keystore.
+func Decrypt(store Keystore, password string) (tbls.PrivateKey, error) {
 	decryptor := keystorev4.New()
 	secretBytes, err := decryptor.Decrypt(store.Crypto, password)
 	if err != nil {
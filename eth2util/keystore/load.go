@@ -97,7 +97,7 @@ func LoadFilesUnordered(dir string) (KeyFiles, error) {
 			return KeyFile{}, errors.Wrap(err, "load password", z.Str("filename", filename))
 		}
 
-		secret, err := decrypt(store, password)
+		secret, err := Decrypt(store, password)
 		if err != nil {
 			return KeyFile{}, errors.Wrap(err, "keystore decryption", z.Str("filename", filename))
 		}
@@ -0,0 +1,162 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package sharesigner provides signing of messages with validator key shares,
+// either locally with decrypted secret shares or via a remote signer signing bare signing roots.
+package sharesigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	remoteTimeout = 10 * time.Second
+	rootLen       = 32
+)
+
+// Signer signs messages with the secret key share of a public key share.
+type Signer interface {
+	// Sign returns the signature of the message signed by the secret share of the provided public share.
+	Sign(ctx context.Context, pubshare tbls.PublicKey, msg []byte) (tbls.Signature, error)
+}
+
+// NewLocal returns a signer signing with the provided secret shares in memory.
+func NewLocal(secrets ...tbls.PrivateKey) (Signer, error) {
+	secretsByPubshare := make(map[tbls.PublicKey]tbls.PrivateKey)
+	for _, secret := range secrets {
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return nil, errors.Wrap(err, "get public share")
+		}
+
+		secretsByPubshare[pubshare] = secret
+	}
+
+	return localSigner(secretsByPubshare), nil
+}
+
+// localSigner signs with secret shares in memory.
+type localSigner map[tbls.PublicKey]tbls.PrivateKey
+
+func (s localSigner) Sign(_ context.Context, pubshare tbls.PublicKey, msg []byte) (tbls.Signature, error) {
+	secret, ok := s[pubshare]
+	if !ok {
+		return tbls.Signature{}, errors.New("secret share not found", z.Hex("pubshare", pubshare[:]))
+	}
+
+	return tbls.Sign(secret, msg)
+}
+
+// NewRemote returns a signer that requests signatures of signing roots from the remote signer at the provided
+// base URL. The remote signer holds the secret shares, they are never loaded into memory.
+//
+// The remote signer must implement the Web3Signer sign endpoint for bare signing roots without typed signing data:
+//
+//	POST {baseURL}/api/v1/eth2/sign/{0x-prefixed hex public share}
+//	Content-Type: application/json
+//	Accept: application/json
+//
+//	{"signingRoot":"{0x-prefixed hex 32 byte signing root}"}
+//
+// It must respond with either a JSON {"signature":"{0x-prefixed hex signature}"} body or a plain text
+// 0x-prefixed hex signature. Typed signing requests aren't used since charon signs messages unknown to Web3Signer,
+// like cluster lock hashes, so signers requiring typed requests (like Consensys Web3Signer) aren't supported.
+func NewRemote(baseURL string) (Signer, error) {
+	u, err := url.ParseRequestURI(baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse remote signer address", z.Str("addr", baseURL))
+	}
+
+	return remoteSigner{baseURL: u}, nil
+}
+
+// remoteSigner signs via a remote Web3Signer-style signer.
+type remoteSigner struct {
+	baseURL *url.URL
+}
+
+// signRequest is the remote signer sign request body. Only the signing root is provided
+// since the remote signer isn't aware of charon specific messages (like the cluster lock hash).
+// See NewRemote for the exact API.
+type signRequest struct {
+	SigningRoot string `json:"signingRoot"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+// Sign requests the signature of the message from the remote signer and verifies it against the public share.
+// The message must be a 32 byte signing root.
+func (s remoteSigner) Sign(ctx context.Context, pubshare tbls.PublicKey, msg []byte) (tbls.Signature, error) {
+	if len(msg) != rootLen {
+		return tbls.Signature{}, errors.New("remote signer only signs signing roots", z.Int("length", len(msg)))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+
+	reqBody, err := json.Marshal(signRequest{SigningRoot: fmt.Sprintf("%#x", msg)})
+	if err != nil {
+		return tbls.Signature{}, errors.Wrap(err, "marshal sign request")
+	}
+
+	addr := s.baseURL.JoinPath("/api/v1/eth2/sign", fmt.Sprintf("%#x", pubshare[:])).String()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr, bytes.NewReader(reqBody))
+	if err != nil {
+		return tbls.Signature{}, errors.Wrap(err, "new sign request", z.Str("url", addr))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return tbls.Signature{}, errors.Wrap(err, "remote signer request")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return tbls.Signature{}, errors.Wrap(err, "read response")
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return tbls.Signature{}, errors.New("remote signer request failed",
+			z.Int("status", resp.StatusCode), z.Str("body", string(data)), z.Hex("pubshare", pubshare[:]))
+	}
+
+	sigHex := strings.TrimSpace(string(data))
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		var sigResp signResponse
+		if err := json.Unmarshal(data, &sigResp); err != nil {
+			return tbls.Signature{}, errors.Wrap(err, "unmarshal sign response")
+		}
+		sigHex = sigResp.Signature
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+	if err != nil {
+		return tbls.Signature{}, errors.Wrap(err, "decode signature")
+	} else if len(b) != len(tbls.Signature{}) {
+		return tbls.Signature{}, errors.New("invalid signature length", z.Int("length", len(b)))
+	}
+
+	sig := tbls.Signature(b)
+	if err := tbls.Verify(pubshare, msg, sig); err != nil {
+		return tbls.Signature{}, errors.Wrap(err, "invalid remote signer signature", z.Hex("pubshare", pubshare[:]))
+	}
+
+	return sig, nil
+}
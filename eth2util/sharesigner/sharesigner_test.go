@@ -0,0 +1,102 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package sharesigner_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/sharesigner"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/remotesignermock"
+)
+
+func TestSigners(t *testing.T) {
+	ctx := context.Background()
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubshare, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	unknownSecret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	unknown, err := tbls.SecretToPublicKey(unknownSecret)
+	require.NoError(t, err)
+
+	handler, _, err := remotesignermock.MockServer(secret)
+	require.NoError(t, err)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	local, err := sharesigner.NewLocal(secret)
+	require.NoError(t, err)
+	remote, err := sharesigner.NewRemote(srv.URL)
+	require.NoError(t, err)
+
+	root := testutil.RandomRoot()
+
+	for name, signer := range map[string]sharesigner.Signer{"local": local, "remote": remote} {
+		t.Run(name, func(t *testing.T) {
+			sig, err := signer.Sign(ctx, pubshare, root[:])
+			require.NoError(t, err)
+			require.NoError(t, tbls.Verify(pubshare, root[:], sig))
+
+			_, err = signer.Sign(ctx, unknown, root[:])
+			require.Error(t, err)
+		})
+	}
+
+	_, err = remote.Sign(ctx, pubshare, []byte("not a signing root"))
+	require.ErrorContains(t, err, "remote signer only signs signing roots")
+}
+
+func TestRemoteAPI(t *testing.T) {
+	ctx := context.Background()
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubshare, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	root := testutil.RandomRoot()
+	sig, err := tbls.Sign(secret, root[:])
+	require.NoError(t, err)
+
+	for _, contentType := range []string{"application/json", "text/plain"} {
+		t.Run(contentType, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, fmt.Sprintf("/base/api/v1/eth2/sign/%#x", pubshare[:]), r.URL.Path)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.Equal(t, "application/json", r.Header.Get("Accept"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf(`{"signingRoot":"%#x"}`, root[:]), string(body))
+
+				w.Header().Set("Content-Type", contentType)
+				if contentType == "application/json" {
+					_, _ = fmt.Fprintf(w, `{"signature":"%#x"}`, sig[:])
+				} else {
+					_, _ = fmt.Fprintf(w, "%#x\n", sig[:])
+				}
+			}))
+			defer srv.Close()
+
+			remote, err := sharesigner.NewRemote(srv.URL + "/base")
+			require.NoError(t, err)
+
+			resp, err := remote.Sign(ctx, pubshare, root[:])
+			require.NoError(t, err)
+			require.Equal(t, sig, resp)
+		})
+	}
+}
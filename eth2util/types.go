@@ -26,6 +26,7 @@ const (
 	DataVersionBellatrix DataVersion = "bellatrix"
 	DataVersionCapella   DataVersion = "capella"
 	DataVersionDeneb     DataVersion = "deneb"
+	DataVersionElectra   DataVersion = "electra"
 )
 
 // dataVersionValues maps DataVersion to the integer value used by go-eth2-client pre-v0.18.
@@ -35,6 +36,7 @@ var dataVersionValues = map[DataVersion]int{
 	DataVersionBellatrix: 2,
 	DataVersionCapella:   3,
 	DataVersionDeneb:     4,
+	DataVersionElectra:   5,
 }
 
 // MarshalJSON marshals the DataVersion as a number equaled to the go-eth2-client
@@ -89,6 +91,8 @@ func (v DataVersion) ToETH2() eth2spec.DataVersion {
 		return eth2spec.DataVersionCapella
 	case DataVersionDeneb:
 		return eth2spec.DataVersionDeneb
+	case DataVersionElectra:
+		return eth2spec.DataVersionElectra
 	default:
 		return eth2spec.DataVersion(0)
 	}
@@ -128,6 +132,8 @@ func DataVersionFromETH2(version eth2spec.DataVersion) (DataVersion, error) {
 		return DataVersionCapella, nil
 	case eth2spec.DataVersionDeneb:
 		return DataVersionDeneb, nil
+	case eth2spec.DataVersionElectra:
+		return DataVersionElectra, nil
 	default:
 		return DataVersionUnknown, errors.New("unknown data version")
 	}
@@ -166,13 +166,13 @@ func queryRelayAddrs(ctx context.Context, relayURL string, backoff func(), lockH
 		}
 
 		if strings.HasPrefix(string(b), "enr:") {
-			addr, err := multiAddrFromENRStr(string(b))
+			addrs, err := multiAddrsFromENRStr(string(b))
 			if err != nil {
 				log.Warn(ctx, "Failure parsing relay address from ENR (will try again)", err)
 				continue
 			}
 
-			return []ma.Multiaddr{addr}, nil
+			return addrs, nil
 		}
 
 		var addrs []string
@@ -197,8 +197,9 @@ func queryRelayAddrs(ctx context.Context, relayURL string, backoff func(), lockH
 	return nil, errors.Wrap(ctx.Err(), "timeout querying relay addresses")
 }
 
-// multiAddrFromENRStr returns the multiaddr from the ENR string.
-func multiAddrFromENRStr(enrStr string) (ma.Multiaddr, error) {
+// multiAddrsFromENRStr returns the TCP and QUIC multiaddrs from the ENR string.
+// The TCP address is returned first, followed by the QUIC address.
+func multiAddrsFromENRStr(enrStr string) ([]ma.Multiaddr, error) {
 	r, err := enr.Parse(enrStr)
 	if err != nil {
 		return nil, errors.Wrap(err, "parse ENR")
@@ -209,20 +210,32 @@ func multiAddrFromENRStr(enrStr string) (ma.Multiaddr, error) {
 		return nil, errors.New("enr does not have an IP")
 	}
 
-	port, ok := r.TCP()
-	if !ok {
-		return nil, errors.New("enr does not have a TCP port")
-	}
-
 	id, err := PeerIDFromKey(r.PubKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "get peer ID from ENR key")
 	}
 
-	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", ip.String(), port, id))
-	if err != nil {
-		return nil, errors.Wrap(err, "create multiaddr")
+	var addrs []ma.Multiaddr
+
+	if port, ok := r.TCP(); ok {
+		addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", ip.String(), port, id))
+		if err != nil {
+			return nil, errors.Wrap(err, "create multiaddr")
+		}
+		addrs = append(addrs, addr)
+	}
+
+	if port, ok := r.QUIC(); ok {
+		addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/udp/%d/quic-v1/p2p/%s", ip.String(), port, id))
+		if err != nil {
+			return nil, errors.Wrap(err, "create quic multiaddr")
+		}
+		addrs = append(addrs, addr)
+	}
+
+	if len(addrs) == 0 {
+		return nil, errors.New("enr does not have a TCP or QUIC port")
 	}
 
-	return addr, nil
+	return addrs, nil
 }
@@ -0,0 +1,77 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestMultiAddrsFromENRStr(t *testing.T) {
+	key := testutil.GenerateInsecureK1Key(t, 1)
+	id, err := PeerIDFromKey(key.PubKey())
+	require.NoError(t, err)
+
+	ip := net.IPv4(10, 0, 0, 1)
+
+	tests := []struct {
+		name   string
+		opts   []enr.Option
+		expect []string
+		errMsg string
+	}{
+		{
+			name:   "tcp",
+			opts:   []enr.Option{enr.WithIP(ip), enr.WithTCP(3610)},
+			expect: []string{"/ip4/10.0.0.1/tcp/3610/p2p/" + id.String()},
+		},
+		{
+			name: "tcp and quic",
+			opts: []enr.Option{enr.WithIP(ip), enr.WithTCP(3610), enr.WithQUIC(3630)},
+			expect: []string{
+				"/ip4/10.0.0.1/tcp/3610/p2p/" + id.String(),
+				"/ip4/10.0.0.1/udp/3630/quic-v1/p2p/" + id.String(),
+			},
+		},
+		{
+			name:   "quic",
+			opts:   []enr.Option{enr.WithIP(ip), enr.WithQUIC(3630)},
+			expect: []string{"/ip4/10.0.0.1/udp/3630/quic-v1/p2p/" + id.String()},
+		},
+		{
+			name:   "no ports",
+			opts:   []enr.Option{enr.WithIP(ip)},
+			errMsg: "enr does not have a TCP or QUIC port",
+		},
+		{
+			name:   "no ip",
+			opts:   []enr.Option{enr.WithTCP(3610)},
+			errMsg: "enr does not have an IP",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record, err := enr.New(key, test.opts...)
+			require.NoError(t, err)
+
+			addrs, err := multiAddrsFromENRStr(record.String())
+			if test.errMsg != "" {
+				require.ErrorContains(t, err, test.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			var actual []string
+			for _, addr := range addrs {
+				actual = append(actual, addr.String())
+			}
+			require.Equal(t, test.expect, actual)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/eth2util/enr"
 )
 
 type Config struct {
@@ -20,6 +21,8 @@ type Config struct {
 	ExternalHost string
 	// TCPAddrs defines the lib-p2p tcp listen addresses.
 	TCPAddrs []string
+	// UDPAddrs defines the lib-p2p udp (QUIC) listen addresses.
+	UDPAddrs []string
 	// DisableReuseport disables TCP port reuse for libp2p.
 	DisableReuseport bool
 }
@@ -39,14 +42,35 @@ func (c Config) ParseTCPAddrs() ([]*net.TCPAddr, error) {
 	return res, nil
 }
 
+// ParseUDPAddrs returns the configured udp addresses as typed net udp addresses.
+func (c Config) ParseUDPAddrs() ([]*net.UDPAddr, error) {
+	res := make([]*net.UDPAddr, 0, len(c.UDPAddrs))
+
+	for _, addr := range c.UDPAddrs {
+		udpAddr, err := resolveUDPListenAddr(addr)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, udpAddr)
+	}
+
+	return res, nil
+}
+
 // Multiaddrs returns the configured addresses as libp2p multiaddrs.
+// TCP addresses are returned first, followed by QUIC addresses.
 func (c Config) Multiaddrs() ([]ma.Multiaddr, error) {
 	tcpAddrs, err := c.ParseTCPAddrs()
 	if err != nil {
 		return nil, err
 	}
 
-	res := make([]ma.Multiaddr, 0, len(tcpAddrs))
+	udpAddrs, err := c.ParseUDPAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]ma.Multiaddr, 0, len(tcpAddrs)+len(udpAddrs))
 
 	for _, addr := range tcpAddrs {
 		maddr, err := multiAddrFromIPPort(addr.IP, addr.Port)
@@ -57,9 +81,68 @@ func (c Config) Multiaddrs() ([]ma.Multiaddr, error) {
 		res = append(res, maddr)
 	}
 
+	for _, addr := range udpAddrs {
+		maddr, err := quicMultiAddrFromIPPort(addr.IP, addr.Port)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, maddr)
+	}
+
 	return res, nil
 }
 
+// ENROptions returns the ENR options advertising the node's IPv4 address, TCP port and QUIC (UDP) port.
+// The IP is the external IP if configured, else the first specified IPv4 listen address.
+func (c Config) ENROptions() ([]enr.Option, error) {
+	tcpAddrs, err := c.ParseTCPAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	udpAddrs, err := c.ParseUDPAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	var ip net.IP
+	if c.ExternalIP != "" {
+		ip = net.ParseIP(c.ExternalIP).To4()
+		if ip == nil {
+			return nil, errors.New("invalid external IPv4 address")
+		}
+	} else {
+		var listenIPs []net.IP
+		for _, addr := range tcpAddrs {
+			listenIPs = append(listenIPs, addr.IP)
+		}
+		for _, addr := range udpAddrs {
+			listenIPs = append(listenIPs, addr.IP)
+		}
+
+		for _, listenIP := range listenIPs {
+			if listenIP.To4() != nil && !listenIP.IsUnspecified() {
+				ip = listenIP
+				break
+			}
+		}
+	}
+
+	var opts []enr.Option
+	if ip != nil {
+		opts = append(opts, enr.WithIP(ip))
+	}
+	if len(tcpAddrs) > 0 {
+		opts = append(opts, enr.WithTCP(tcpAddrs[0].Port))
+	}
+	if len(udpAddrs) > 0 {
+		opts = append(opts, enr.WithQUIC(udpAddrs[0].Port))
+	}
+
+	return opts, nil
+}
+
 func resolveListenAddr(addr string) (*net.TCPAddr, error) {
 	tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
 	if err != nil {
@@ -73,23 +156,56 @@ func resolveListenAddr(addr string) (*net.TCPAddr, error) {
 	return tcpAddr, nil
 }
 
+func resolveUDPListenAddr(addr string) (*net.UDPAddr, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve P2P UDP bind addr")
+	}
+
+	if udpAddr.IP == nil {
+		return nil, errors.New("p2p UDP bind IP not specified")
+	}
+
+	return udpAddr, nil
+}
+
 // multiAddrFromIPPort returns a multiaddr composed of the provided ip (v4 or v6) and tcp port.
 func multiAddrFromIPPort(ip net.IP, port int) (ma.Multiaddr, error) {
-	if ip.To4() == nil && ip.To16() == nil {
-		return nil, errors.New("invalid ip address")
+	ipAddr, err := ipMultiAddr(ip)
+	if err != nil {
+		return nil, err
 	}
 
-	var typ string
-	if ip.To4() != nil {
-		typ = "ip4"
-	} else if ip.To16() != nil {
-		typ = "ip6"
+	maddr, err := ma.NewMultiaddr(fmt.Sprintf("%s/tcp/%d", ipAddr, port))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid multiaddr")
 	}
 
-	maddr, err := ma.NewMultiaddr(fmt.Sprintf("/%s/%s/tcp/%d", typ, ip.String(), port))
+	return maddr, nil
+}
+
+// quicMultiAddrFromIPPort returns a QUIC multiaddr composed of the provided ip (v4 or v6) and udp port.
+func quicMultiAddrFromIPPort(ip net.IP, port int) (ma.Multiaddr, error) {
+	ipAddr, err := ipMultiAddr(ip)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid multiaddr")
+		return nil, err
+	}
+
+	maddr, err := ma.NewMultiaddr(fmt.Sprintf("%s/udp/%d/quic-v1", ipAddr, port))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid quic multiaddr")
 	}
 
 	return maddr, nil
 }
+
+// ipMultiAddr returns the multiaddr string of the provided ip (v4 or v6).
+func ipMultiAddr(ip net.IP) (string, error) {
+	if ip.To4() != nil {
+		return "/ip4/" + ip.String(), nil
+	} else if ip.To16() != nil {
+		return "/ip6/" + ip.String(), nil
+	}
+
+	return "", errors.New("invalid ip address")
+}
@@ -6,7 +6,10 @@ import (
 	"net"
 	"testing"
 
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/enr"
 )
 
 func TestResolveListenAddr(t *testing.T) {
@@ -50,6 +53,9 @@ func TestConfig_Multiaddrs(t *testing.T) {
 			"10.0.0.2:0",
 			"[" + net.IPv6linklocalallnodes.String() + "]:0",
 		},
+		UDPAddrs: []string{
+			"10.0.0.2:0",
+		},
 	}
 
 	maddrs, err := c.Multiaddrs()
@@ -63,5 +69,54 @@ func TestConfig_Multiaddrs(t *testing.T) {
 	require.Equal(t, []string{
 		"/ip4/10.0.0.2/tcp/0",
 		"/ip6/ff02::1/tcp/0",
+		"/ip4/10.0.0.2/udp/0/quic-v1",
 	}, maddrStrs)
 }
+
+func TestConfig_ENROptions(t *testing.T) {
+	key, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	c := Config{
+		TCPAddrs: []string{"0.0.0.0:3610", "10.0.0.2:3611"},
+		UDPAddrs: []string{"0.0.0.0:3630"},
+	}
+
+	opts, err := c.ENROptions()
+	require.NoError(t, err)
+
+	r, err := enr.New(key, opts...)
+	require.NoError(t, err)
+
+	ip, ok := r.IP()
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.2", ip.String())
+	tcp, ok := r.TCP()
+	require.True(t, ok)
+	require.Equal(t, 3610, tcp)
+	quic, ok := r.QUIC()
+	require.True(t, ok)
+	require.Equal(t, 3630, quic)
+
+	// The external IP is advertised if configured.
+	c.ExternalIP = "1.2.3.4"
+	opts, err = c.ENROptions()
+	require.NoError(t, err)
+
+	r, err = enr.New(key, opts...)
+	require.NoError(t, err)
+
+	ip, ok = r.IP()
+	require.True(t, ok)
+	require.Equal(t, "1.2.3.4", ip.String())
+
+	// QUIC isn't advertised without UDP addresses.
+	opts, err = Config{TCPAddrs: []string{"10.0.0.2:3610"}}.ENROptions()
+	require.NoError(t, err)
+
+	r, err = enr.New(key, opts...)
+	require.NoError(t, err)
+
+	_, ok = r.QUIC()
+	require.False(t, ok)
+}
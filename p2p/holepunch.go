@@ -0,0 +1,74 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/holepunch"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	holePunchResultDirectDial    = "direct_dial"
+	holePunchResultSuccess       = "success"
+	holePunchResultFailure       = "failure"
+	holePunchResultProtocolError = "protocol_error"
+)
+
+// WithHolePunching returns a libp2p option that enables direct connection upgrades via
+// DCUtR (Direct Connection Upgrade through Relay) hole punching for relay-connected peers.
+// Upgrade attempts to the provided peers are logged and instrumented.
+func WithHolePunching(ctx context.Context, peers []peer.ID) libp2p.Option {
+	peerNames := make(map[peer.ID]string)
+	for _, p := range peers {
+		peerNames[p] = PeerName(p)
+	}
+
+	return libp2p.EnableHolePunching(holepunch.WithTracer(holePunchTracer{
+		ctx:       log.WithTopic(ctx, "p2p"),
+		peerNames: peerNames,
+	}))
+}
+
+// holePunchTracer implements holepunch.EventTracer by logging and instrumenting hole punch events.
+type holePunchTracer struct {
+	ctx       context.Context
+	peerNames map[peer.ID]string
+}
+
+func (t holePunchTracer) Trace(evt *holepunch.Event) {
+	name, ok := t.peerNames[evt.Remote]
+	if !ok {
+		return // Do not instrument relays
+	}
+
+	switch e := evt.Evt.(type) {
+	case *holepunch.DirectDialEvt:
+		if !e.Success {
+			return // Hole punching is attempted next.
+		}
+
+		log.Debug(t.ctx, "Relay connection upgraded via direct dial", z.Str("peer", name), z.Any("duration", e.EllapsedTime))
+		holePunchCounter.WithLabelValues(name, holePunchResultDirectDial).Inc()
+	case *holepunch.EndHolePunchEvt:
+		if !e.Success {
+			log.Debug(t.ctx, "Hole punch failed", z.Str("peer", name), z.Str("error", e.Error), z.Any("duration", e.EllapsedTime))
+			holePunchCounter.WithLabelValues(name, holePunchResultFailure).Inc()
+
+			return
+		}
+
+		log.Debug(t.ctx, "Relay connection upgraded via hole punch", z.Str("peer", name), z.Any("duration", e.EllapsedTime))
+		holePunchCounter.WithLabelValues(name, holePunchResultSuccess).Inc()
+		holePunchLatency.WithLabelValues(name).Observe(e.EllapsedTime.Seconds())
+	case *holepunch.ProtocolErrorEvt:
+		log.Debug(t.ctx, "Hole punch protocol error", z.Str("peer", name), z.Str("error", e.Error))
+		holePunchCounter.WithLabelValues(name, holePunchResultProtocolError).Inc()
+	default:
+	}
+}
@@ -56,6 +56,18 @@ var (
 		Help:      "Current number of libp2p connections by peer and type ('direct' or 'relay'). Note that peers may have multiple connections.",
 	}, []string{"peer", "type"})
 
+	holePunchCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "p2p",
+		Name:      "peer_hole_punch_total",
+		Help:      "Total number of DCUtR relay connection upgrade attempts by peer and result ('direct_dial', 'success', 'failure' or 'protocol_error').",
+	}, []string{"peer", "result"})
+
+	holePunchLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "p2p",
+		Name:      "peer_hole_punch_duration_secs",
+		Help:      "Duration in seconds of successful hole punches per peer",
+	}, []string{"peer"})
+
 	peerStreamGauge = promauto.NewResetGaugeVec(prometheus.GaugeOpts{
 		Namespace: "p2p",
 		Name:      "peer_streams",
@@ -17,8 +17,9 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/routing"
+	"github.com/libp2p/go-libp2p/p2p/host/observedaddrs"
 	"github.com/libp2p/go-libp2p/p2p/net/swarm"
-	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
+	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
@@ -30,6 +31,9 @@ import (
 	"github.com/obolnetwork/charon/app/z"
 )
 
+// tcpFallbackDelay is the delay after which TCP addresses are dialled if QUIC addresses are available.
+const tcpFallbackDelay = 250 * time.Millisecond
+
 var activationThreshOnce = sync.Once{}
 
 // NewTCPNode returns a started tcp-based libp2p host.
@@ -39,7 +43,7 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 	activationThreshOnce.Do(func() {
 		// Use own observed addresses as soon as a single relay reports it.
 		// Since there are probably no other directly connected peers to do so.
-		identify.ActivationThresh = 1
+		observedaddrs.ActivationThresh = 1
 	})
 
 	addrs, err := cfg.Multiaddrs()
@@ -48,7 +52,7 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 	}
 
 	if len(addrs) == 0 {
-		log.Info(ctx, "LibP2P not accepting incoming connections since --p2p-tcp-addresses empty")
+		log.Info(ctx, "LibP2P not accepting incoming connections since --p2p-tcp-addresses and --p2p-udp-addresses empty")
 	}
 
 	externalAddrs, err := externalMultiAddrs(cfg)
@@ -76,8 +80,10 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 		libp2p.AddrsFactory(func(internalAddrs []ma.Multiaddr) []ma.Multiaddr {
 			return filterAdvertisedAddrs(externalAddrs, internalAddrs, filterPrivateAddrs)
 		}),
+		// Enable QUIC and TCP transports, preferring QUIC when dialing.
 		libp2p.Transport(tcp.NewTCPTransport, tcpOpts...),
-		libp2p.SwarmOpts(swarm.WithDialRanker(swarm.NoDelayDialRanker)),
+		libp2p.Transport(quic.NewTransport),
+		libp2p.SwarmOpts(swarm.WithDialRanker(quicFirstDialRanker)),
 	}
 
 	defaultOpts = append(defaultOpts, opts...)
@@ -117,23 +123,31 @@ func filterAdvertisedAddrs(externalAddrs, internalAddrs []ma.Multiaddr, excludeI
 	return resp
 }
 
-// externalMultiAddrs returns the external IP and Hostname fields as multiaddrs using the listen TCP address ports.
+// externalMultiAddrs returns the external IP and Hostname fields as multiaddrs using the listen TCP and UDP (QUIC) address ports.
 func externalMultiAddrs(cfg Config) ([]ma.Multiaddr, error) {
 	tcpAddrs, err := cfg.ParseTCPAddrs()
 	if err != nil {
 		return nil, err
 	}
 
-	var ports []int
+	udpAddrs, err := cfg.ParseUDPAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	var tcpPorts, udpPorts []int
 	for _, addr := range tcpAddrs {
-		ports = append(ports, addr.Port)
+		tcpPorts = append(tcpPorts, addr.Port)
+	}
+	for _, addr := range udpAddrs {
+		udpPorts = append(udpPorts, addr.Port)
 	}
 
 	var resp []ma.Multiaddr
 
 	if cfg.ExternalIP != "" {
 		ip := net.ParseIP(cfg.ExternalIP)
-		for _, port := range ports {
+		for _, port := range tcpPorts {
 			maddr, err := multiAddrFromIPPort(ip, port)
 			if err != nil {
 				return nil, err
@@ -141,10 +155,19 @@ func externalMultiAddrs(cfg Config) ([]ma.Multiaddr, error) {
 
 			resp = append(resp, maddr)
 		}
+
+		for _, port := range udpPorts {
+			maddr, err := quicMultiAddrFromIPPort(ip, port)
+			if err != nil {
+				return nil, err
+			}
+
+			resp = append(resp, maddr)
+		}
 	}
 
 	if cfg.ExternalHost != "" {
-		for _, port := range ports {
+		for _, port := range tcpPorts {
 			maddr, err := ma.NewMultiaddr(fmt.Sprintf("/dns/%s/tcp/%d", cfg.ExternalHost, port))
 			if err != nil {
 				return nil, errors.Wrap(err, "invalid dns multiaddr")
@@ -152,11 +175,60 @@ func externalMultiAddrs(cfg Config) ([]ma.Multiaddr, error) {
 
 			resp = append(resp, maddr)
 		}
+
+		for _, port := range udpPorts {
+			maddr, err := ma.NewMultiaddr(fmt.Sprintf("/dns/%s/udp/%d/quic-v1", cfg.ExternalHost, port))
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid dns quic multiaddr")
+			}
+
+			resp = append(resp, maddr)
+		}
 	}
 
 	return resp, nil
 }
 
+// quicFirstDialRanker is a libp2p dial ranker that dials QUIC addresses immediately
+// and falls back to all other addresses (TCP and relay) after tcpFallbackDelay.
+// All addresses are dialled immediately if no QUIC addresses are known.
+func quicFirstDialRanker(addrs []ma.Multiaddr) []network.AddrDelay {
+	var hasQUIC bool
+	for _, addr := range addrs {
+		if isQUICAddr(addr) {
+			hasQUIC = true
+			break
+		}
+	}
+
+	if !hasQUIC {
+		return swarm.NoDelayDialRanker(addrs)
+	}
+
+	resp := make([]network.AddrDelay, 0, len(addrs))
+	for _, addr := range addrs {
+		var delay time.Duration
+		if !isQUICAddr(addr) {
+			delay = tcpFallbackDelay
+		}
+
+		resp = append(resp, network.AddrDelay{Addr: addr, Delay: delay})
+	}
+
+	return resp
+}
+
+// isQUICAddr returns true if the address is a direct QUIC address.
+func isQUICAddr(addr ma.Multiaddr) bool {
+	if _, err := addr.ValueForProtocol(ma.P_CIRCUIT); err == nil {
+		return false
+	}
+
+	_, err := addr.ValueForProtocol(ma.P_QUIC_V1)
+
+	return err == nil
+}
+
 // multiAddrsViaRelay returns multiaddrs to the peer via the relay.
 // See https://github.com/libp2p/go-libp2p/blob/master/examples/relay/main.go.
 func multiAddrsViaRelay(relayPeer Peer, peerID peer.ID) ([]ma.Multiaddr, error) {
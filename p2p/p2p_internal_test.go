@@ -3,9 +3,14 @@
 package p2p
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/holepunch"
 	ma "github.com/multiformats/go-multiaddr"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -80,3 +85,65 @@ func TestFilterAdvertisedAddrs(t *testing.T) {
 		})
 	}
 }
+
+func TestQUICFirstDialRanker(t *testing.T) {
+	tcpAddr := ma.StringCast("/ip4/1.1.1.1/tcp/3610")
+	quicAddr := ma.StringCast("/ip4/1.1.1.1/udp/3630/quic-v1")
+	relayAddr := ma.StringCast("/ip4/2.2.2.2/udp/3640/quic-v1/p2p/16Uiu2HAmRpbmF2x7Xdmb4ujjLdvUDyArrs3JMnrWGDWqXQPGqm1R/p2p-circuit")
+
+	// No QUIC addresses, dial all immediately.
+	delays := quicFirstDialRanker([]ma.Multiaddr{tcpAddr, relayAddr})
+	require.Len(t, delays, 2)
+	for _, delay := range delays {
+		require.Zero(t, delay.Delay)
+	}
+
+	// QUIC addresses first, fallback to others.
+	delays = quicFirstDialRanker([]ma.Multiaddr{tcpAddr, quicAddr, relayAddr})
+	require.Len(t, delays, 3)
+	require.Equal(t, tcpAddr, delays[0].Addr)
+	require.Equal(t, tcpFallbackDelay, delays[0].Delay)
+	require.Equal(t, quicAddr, delays[1].Addr)
+	require.Zero(t, delays[1].Delay)
+	require.Equal(t, relayAddr, delays[2].Addr)
+	require.Equal(t, tcpFallbackDelay, delays[2].Delay)
+}
+
+func TestHolePunchTracer(t *testing.T) {
+	peerID := peer.ID("peer")
+	relayID := peer.ID("relay")
+	name := PeerName(peerID)
+
+	tracer := holePunchTracer{
+		ctx:       context.Background(),
+		peerNames: map[peer.ID]string{peerID: name},
+	}
+
+	count := func(result string) int {
+		t.Helper()
+		return int(promtestutil.ToFloat64(holePunchCounter.WithLabelValues(name, result)))
+	}
+
+	trace := func(p peer.ID, evt any) {
+		t.Helper()
+		tracer.Trace(&holepunch.Event{Remote: p, Evt: evt})
+	}
+
+	// Failed direct dials are followed by hole punching, so are not instrumented.
+	trace(peerID, &holepunch.DirectDialEvt{Success: false, Error: "dial failed"})
+	trace(peerID, &holepunch.DirectDialEvt{Success: true, EllapsedTime: time.Millisecond})
+	trace(peerID, &holepunch.EndHolePunchEvt{Success: false, Error: "timeout"})
+	trace(peerID, &holepunch.EndHolePunchEvt{Success: true, EllapsedTime: time.Second})
+	trace(peerID, &holepunch.EndHolePunchEvt{Success: true, EllapsedTime: time.Second})
+	trace(peerID, &holepunch.ProtocolErrorEvt{Error: "unexpected message"})
+	trace(peerID, &holepunch.HolePunchAttemptEvt{Attempt: 1})
+
+	// Relays are not instrumented.
+	trace(relayID, &holepunch.EndHolePunchEvt{Success: true})
+
+	require.Equal(t, 1, count(holePunchResultDirectDial))
+	require.Equal(t, 1, count(holePunchResultFailure))
+	require.Equal(t, 2, count(holePunchResultSuccess))
+	require.Equal(t, 1, count(holePunchResultProtocolError))
+	require.Equal(t, 1, promtestutil.CollectAndCount(holePunchLatency))
+}
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/cluster"
@@ -36,6 +39,34 @@ func TestNewHost(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestQUICConnection(t *testing.T) {
+	ctx := context.Background()
+
+	newNode := func(udpAddrs ...string) host.Host {
+		t.Helper()
+
+		privKey, err := k1.GeneratePrivateKey()
+		require.NoError(t, err)
+
+		node, err := p2p.NewTCPNode(ctx, p2p.Config{UDPAddrs: udpAddrs}, privKey, p2p.NewOpenGater(), false)
+		testutil.SkipIfBindErr(t, err)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = node.Close() })
+
+		return node
+	}
+
+	server := newNode("127.0.0.1:0")
+	client := newNode() // Dial only
+
+	require.NoError(t, client.Connect(ctx, peer.AddrInfo{ID: server.ID(), Addrs: server.Addrs()}))
+
+	conns := client.Network().ConnsToPeer(server.ID())
+	require.Len(t, conns, 1)
+	_, err := conns[0].RemoteMultiaddr().ValueForProtocol(ma.P_QUIC_V1)
+	require.NoError(t, err)
+}
+
 func TestVerifyP2PKey(t *testing.T) {
 	seed := 0
 	random := rand.New(rand.NewSource(int64(seed)))
@@ -6,7 +6,11 @@ import (
 	"context"
 	"sync"
 
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/eth2util"
@@ -63,6 +67,38 @@ func (s *attestationStore) AttestationDataByRoot(dataRoot eth2p0.Root) (*eth2p0.
 	return data, nil
 }
 
+// AggregateAttestation returns an empty versioned aggregate attestation for a previously generated attestation data.
+func (s *attestationStore) AggregateAttestation(ctx context.Context, opts *eth2api.AggregateAttestationOpts) (*eth2spec.VersionedAttestation, error) {
+	data, err := s.AttestationDataByRoot(opts.AttestationDataRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := eth2util.DataVersionAtSlot(ctx, s.httpMock, data.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != eth2spec.DataVersionElectra {
+		return eth2util.NewPhase0VersionedAttestation(version, &eth2p0.Attestation{
+			AggregationBits: bitfield.NewBitlist(0),
+			Data:            data,
+		})
+	}
+
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(uint64(opts.CommitteeIndex), true)
+
+	return &eth2spec.VersionedAttestation{
+		Version: version,
+		Electra: &electra.Attestation{
+			AggregationBits: bitfield.NewBitlist(0),
+			Data:            data,
+			CommitteeBits:   committeeBits,
+		},
+	}, nil
+}
+
 // NewAttestationData generates and returns an attestation data.
 func (s *attestationStore) NewAttestationData(ctx context.Context, slot eth2p0.Slot, index eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
 	epoch, err := eth2util.EpochFromSlot(ctx, s.httpMock, slot)
@@ -70,6 +106,15 @@ func (s *attestationStore) NewAttestationData(ctx context.Context, slot eth2p0.S
 		return nil, err
 	}
 
+	version, err := eth2util.DataVersionAtSlot(ctx, s.httpMock, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == eth2spec.DataVersionElectra {
+		index = 0 // Electra attestation data doesn't include the committee index.
+	}
+
 	data := newAttestationData(epoch, slot, index)
 
 	root, err := data.HashTreeRoot()
@@ -73,7 +73,9 @@ func TestAttestationStore(t *testing.T) {
 	bmockResp, err := bmock.AggregateAttestation(ctx, aggAttOpts) // Slot is ignored.
 	require.NoError(t, err)
 	att := bmockResp.Data
-	require.Equal(t, attData, att.Data)
+	aggData, err := att.Data()
+	require.NoError(t, err)
+	require.Equal(t, attData, aggData)
 
 	aggAttopts2 := &eth2api.AggregateAttestationOpts{
 		Slot:                attData.Slot,
@@ -283,6 +283,27 @@ func WithSlotsPerEpoch(slotsPerEpoch int) Option {
 	}
 }
 
+// WithElectraForkEpoch configures the http mock with the provided electra fork epoch.
+// Earlier forks scheduled after the provided epoch are moved to it, since forks are activated in order.
+func WithElectraForkEpoch(epoch eth2p0.Epoch) Option {
+	return func(mock *Mock) {
+		for _, fork := range []struct {
+			Key   string
+			Epoch eth2p0.Epoch
+		}{
+			{Key: "CAPELLA_FORK_EPOCH", Epoch: 256},
+			{Key: "DENEB_FORK_EPOCH", Epoch: 29696},
+			{Key: "ELECTRA_FORK_EPOCH", Epoch: epoch},
+		} {
+			mock.overrides = append(mock.overrides, staticOverride{
+				Endpoint: "/eth/v1/config/spec",
+				Key:      fork.Key,
+				Value:    strconv.FormatUint(uint64(min(fork.Epoch, epoch)), 10),
+			})
+		}
+	}
+}
+
 // WithDeterministicAttesterDuties configures the mock to provide deterministic
 // duties based on provided arguments and config.
 // Note it depends on ValidatorsFunc being populated, e.g. via WithValidatorSet.
@@ -536,26 +557,27 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		AttesterDutiesFunc: func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2v1.AttesterDuty, error) {
 			return []*eth2v1.AttesterDuty{}, nil
 		},
-		BlockAttestationsFunc: func(context.Context, string) ([]*eth2p0.Attestation, error) {
-			return []*eth2p0.Attestation{}, nil
+		BlockAttestationsFunc: func(context.Context, string) ([]*eth2spec.VersionedAttestation, error) {
+			return []*eth2spec.VersionedAttestation{}, nil
+		},
+		BeaconCommitteesFunc: func(context.Context, *eth2api.BeaconCommitteesOpts) ([]*eth2v1.BeaconCommittee, error) {
+			return []*eth2v1.BeaconCommittee{}, nil
 		},
 		NodePeerCountFunc: func(context.Context) (int, error) {
 			return 80, nil
 		},
-		AttestationDataFunc: func(ctx context.Context, slot eth2p0.Slot, index eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
-			return attStore.NewAttestationData(ctx, slot, index)
-		},
-		AggregateAttestationFunc: func(_ context.Context, _ eth2p0.Slot, root eth2p0.Root) (*eth2p0.Attestation, error) {
-			attData, err := attStore.AttestationDataByRoot(root)
-			if err != nil {
-				return nil, err
+		ValidatorLivenessFunc: func(_ context.Context, _ eth2p0.Epoch, indices []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]bool, error) {
+			liveness := make(map[eth2p0.ValidatorIndex]bool)
+			for _, index := range indices {
+				liveness[index] = false
 			}
 
-			return &eth2p0.Attestation{
-				AggregationBits: bitfield.NewBitlist(0),
-				Data:            attData,
-			}, nil
+			return liveness, nil
+		},
+		AttestationDataFunc: func(ctx context.Context, slot eth2p0.Slot, index eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+			return attStore.NewAttestationData(ctx, slot, index)
 		},
+		AggregateAttestationFunc: attStore.AggregateAttestation,
 		CachedValidatorsFunc: func(context.Context) (eth2wrap.ActiveValidators, eth2wrap.CompleteValidators, error) {
 			return nil, nil, nil
 		},
@@ -565,7 +587,7 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		ValidatorsByPubKeyFunc: func(context.Context, string, []eth2p0.BLSPubKey) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
 			return nil, nil
 		},
-		SubmitAttestationsFunc: func(context.Context, []*eth2p0.Attestation) error {
+		SubmitAttestationsFunc: func(context.Context, *eth2api.SubmitAttestationsOpts) error {
 			return nil
 		},
 		SubmitProposalFunc: func(context.Context, *eth2api.SubmitProposalOpts) error {
@@ -594,7 +616,7 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		AggregateBeaconCommitteeSelectionsFunc: func(_ context.Context, selections []*eth2exp.BeaconCommitteeSelection) ([]*eth2exp.BeaconCommitteeSelection, error) {
 			return selections, nil
 		},
-		SubmitAggregateAttestationsFunc: func(context.Context, []*eth2p0.SignedAggregateAndProof) error {
+		SubmitAggregateAttestationsFunc: func(context.Context, *eth2api.SubmitAggregateAttestationsOpts) error {
 			return nil
 		},
 		SlotsPerEpochFunc: func(ctx context.Context) (uint64, error) {
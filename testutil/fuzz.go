@@ -10,6 +10,7 @@ import (
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	fuzz "github.com/google/gofuzz"
 	"github.com/prysmaticlabs/go-bitfield"
@@ -30,6 +31,7 @@ func NewEth2Fuzzer(t *testing.T, seed int64) *fuzz.Fuzzer {
 		eth2spec.DataVersionBellatrix,
 		eth2spec.DataVersionCapella,
 		eth2spec.DataVersionDeneb,
+		eth2spec.DataVersionElectra,
 	}
 
 	allVersions := []eth2spec.DataVersion{
@@ -38,6 +40,7 @@ func NewEth2Fuzzer(t *testing.T, seed int64) *fuzz.Fuzzer {
 		eth2spec.DataVersionBellatrix,
 		eth2spec.DataVersionCapella,
 		eth2spec.DataVersionDeneb,
+		eth2spec.DataVersionElectra,
 	}
 
 	if seed == 0 {
@@ -97,6 +100,41 @@ func NewEth2Fuzzer(t *testing.T, seed int64) *fuzz.Fuzzer {
 				}
 				e.AggregationBits = bits
 			},
+			// electra.AttesterSlashings has max
+			func(e *[]*electra.AttesterSlashing, c fuzz.Continue) {
+				c.FuzzNoCustom(e)
+				if len(*e) > 1 {
+					*e = (*e)[:1]
+				}
+			},
+			// electra.Attestations has max
+			func(e *[]*electra.Attestation, c fuzz.Continue) {
+				c.FuzzNoCustom(e)
+				if len(*e) > 8 {
+					*e = (*e)[:8]
+				}
+			},
+			// electra.Attestation.CommitteeBits must be 8 bytes
+			func(e *bitfield.Bitvector64, c fuzz.Continue) {
+				bits := bitfield.NewBitvector64()
+				bits.SetBitAt(uint64(c.Intn(64)), true)
+				*e = bits
+			},
+			// electra.DepositRequest.WithdrawalCredentials must be 32 bytes
+			func(e *electra.DepositRequest, c fuzz.Continue) {
+				c.FuzzNoCustom(e)
+
+				var creds [32]byte
+				_, _ = c.Read(creds[:])
+				e.WithdrawalCredentials = creds[:]
+			},
+			// electra.ConsolidationRequests has max
+			func(e *[]*electra.ConsolidationRequest, c fuzz.Continue) {
+				c.FuzzNoCustom(e)
+				if len(*e) > 2 {
+					*e = (*e)[:2]
+				}
+			},
 			// []deneb.KzgCommitment has max.
 			func(e *[]deneb.KZGCommitment, c fuzz.Continue) {
 				c.FuzzNoCustom(e)
@@ -140,6 +178,48 @@ func NewEth2Fuzzer(t *testing.T, seed int64) *fuzz.Fuzzer {
 						e.DenebBlinded.Message.Body.BlobKZGCommitments = e.DenebBlinded.Message.Body.BlobKZGCommitments[:maxBlobCommitments]
 					}
 				}
+
+				if e.Version == eth2spec.DataVersionElectra {
+					if e.Electra != nil {
+						if len(e.Electra.KZGProofs) > maxKZGProofs {
+							e.Electra.KZGProofs = e.Electra.KZGProofs[:maxKZGProofs]
+						}
+
+						if len(e.Electra.Blobs) > maxBlobs {
+							e.Electra.Blobs = e.Electra.Blobs[:maxBlobs]
+						}
+					}
+
+					if e.ElectraBlinded != nil && len(e.ElectraBlinded.Message.Body.BlobKZGCommitments) > maxBlobCommitments {
+						e.ElectraBlinded.Message.Body.BlobKZGCommitments = e.ElectraBlinded.Message.Body.BlobKZGCommitments[:maxBlobCommitments]
+					}
+				}
+			},
+			// Populate one of the versions of these versioned attestation types.
+			func(e *core.VersionedAttestation, c fuzz.Continue) {
+				e.VersionedAttestation = fuzzVersionedAttestation(c, allVersions)
+
+				var vIdx eth2p0.ValidatorIndex
+				c.Fuzz(&vIdx)
+				e.ValidatorIndex = &vIdx
+			},
+			func(e *core.VersionedAggregatedAttestation, c fuzz.Continue) {
+				e.VersionedAttestation = fuzzVersionedAttestation(c, allVersions)
+			},
+			func(e *core.VersionedSignedAggregateAndProof, c fuzz.Continue) {
+				e.Version = allVersions[(c.Intn(len(allVersions)))]
+				if e.Version == eth2spec.DataVersionElectra {
+					e.Electra = new(electra.SignedAggregateAndProof)
+					c.Fuzz(e.Electra)
+
+					return
+				}
+
+				agg := new(eth2p0.SignedAggregateAndProof)
+				c.Fuzz(agg)
+				versioned, err := eth2util.NewPhase0VersionedSignedAggregateAndProof(e.Version, agg)
+				require.NoError(t, err)
+				e.VersionedSignedAggregateAndProof = *versioned
 			},
 			func(e *core.VersionedProposal, c fuzz.Continue) {
 				e.Version = allVersions[(c.Intn(len(allVersions)))]
@@ -159,6 +239,38 @@ func NewEth2Fuzzer(t *testing.T, seed int64) *fuzz.Fuzzer {
 				if e.Version == eth2spec.DataVersionDeneb && len(e.Deneb.Blobs) > maxBlobs {
 					e.Deneb.Blobs = e.Deneb.Blobs[:maxBlobs]
 				}
+				if e.Version == eth2spec.DataVersionElectra && len(e.Electra.KZGProofs) > maxKZGProofs {
+					e.Electra.KZGProofs = e.Electra.KZGProofs[:maxKZGProofs]
+				}
+				if e.Version == eth2spec.DataVersionElectra && len(e.Electra.Blobs) > maxBlobs {
+					e.Electra.Blobs = e.Electra.Blobs[:maxBlobs]
+				}
 			},
 		)
 }
+
+// fuzzVersionedAttestation returns a fuzzed versioned attestation of a random version.
+func fuzzVersionedAttestation(c fuzz.Continue, versions []eth2spec.DataVersion) eth2spec.VersionedAttestation {
+	resp := eth2spec.VersionedAttestation{Version: versions[c.Intn(len(versions))]}
+
+	att := new(eth2p0.Attestation)
+	c.Fuzz(att)
+
+	switch resp.Version {
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0 = att
+	case eth2spec.DataVersionAltair:
+		resp.Altair = att
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix = att
+	case eth2spec.DataVersionCapella:
+		resp.Capella = att
+	case eth2spec.DataVersionDeneb:
+		resp.Deneb = att
+	default:
+		resp.Electra = new(electra.Attestation)
+		c.Fuzz(resp.Electra)
+	}
+
+	return resp
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path"
@@ -33,6 +34,7 @@ import (
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
+	"github.com/obolnetwork/charon/testutil/remotesignermock"
 )
 
 // vcType enumerates the different types of VCs.
@@ -57,6 +59,7 @@ func TestSimnetDuties(t *testing.T) {
 		tekuRegistration   bool
 		pregenRegistration bool
 		exit               bool
+		remoteSigner       bool
 		vcType             vcType
 	}{
 		{
@@ -65,6 +68,13 @@ func TestSimnetDuties(t *testing.T) {
 			duties:        []core.DutyType{core.DutyPrepareAggregator, core.DutyAttester, core.DutyAggregator},
 			vcType:        vcVmock,
 		},
+		{
+			name:          "attester with mock VCs with remote signer",
+			scheduledType: core.DutyAttester,
+			duties:        []core.DutyType{core.DutyPrepareAggregator, core.DutyAttester, core.DutyAggregator},
+			vcType:        vcVmock,
+			remoteSigner:  true,
+		},
 		{
 			name:          "attester with teku",
 			scheduledType: core.DutyAttester,
@@ -137,6 +147,7 @@ func TestSimnetDuties(t *testing.T) {
 			args.TekuRegistration = test.tekuRegistration
 			args.BuilderAPI = test.builderAPI
 			args.VoluntaryExit = test.exit
+			args.RemoteSigner = test.remoteSigner
 
 			if test.vcType == vcTeku {
 				for i := range args.N {
@@ -184,6 +195,7 @@ type simnetArgs struct {
 	TekuRegistration   bool
 	SyntheticProposals bool
 	VoluntaryExit      bool
+	RemoteSigner       bool
 }
 
 // newSimnetArgs defines the default simnet test args.
@@ -339,6 +351,16 @@ func testSimnet(t *testing.T, args simnetArgs, expect *simnetExpect) {
 			SyntheticBlockProposals: args.SyntheticProposals,
 		}
 
+		if args.RemoteSigner {
+			handler, _, err := remotesignermock.MockServer(args.SimnetKeys[i])
+			require.NoError(t, err)
+			signerSrv := httptest.NewServer(handler)
+			t.Cleanup(signerSrv.Close)
+
+			conf.SimnetRemoteSignerAddr = signerSrv.URL
+			conf.TestConfig.SimnetKeys = nil
+		}
+
 		eg.Go(func() error {
 			defer cancel()
 			return app.Run(ctx, conf)
@@ -73,6 +73,9 @@ type testServer struct {
 	// store the partial exits by the validator pubkey
 	partialExits map[string][]exitBlob
 
+	// store the partial signatures by the validator pubkey
+	partialSigs map[string][]obolapi.PartialSignature
+
 	// store the lock file by its lock hash
 	lockFiles map[string]cluster.Lock
 
@@ -320,6 +323,7 @@ func MockServer(dropOnePsig bool, beacon eth2wrap.Client) (http.Handler, func(lo
 	ts := testServer{
 		lock:         sync.Mutex{},
 		partialExits: map[string][]exitBlob{},
+		partialSigs:  map[string][]obolapi.PartialSignature{},
 		lockFiles:    map[string]cluster.Lock{},
 		dropOnePsig:  dropOnePsig,
 		beacon:       beacon,
@@ -333,6 +337,12 @@ func MockServer(dropOnePsig bool, beacon eth2wrap.Client) (http.Handler, func(lo
 
 	router.HandleFunc(partialExitTmpl, ts.HandlePartialExit).Methods(http.MethodPost)
 
+	router.HandleFunc(partialSigBaseTmpl, ts.HandlePartialSignatures).Methods(http.MethodPost)
+
+	sigs := router.PathPrefix(partialSigBaseTmpl).Subrouter()
+	sigs.Use(authMiddleware)
+	sigs.HandleFunc(partialSigsEndTmpl, ts.HandleGetPartialSignatures).Methods(http.MethodGet)
+
 	return router, ts.addLockFiles
 }
 
@@ -0,0 +1,162 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package obolapimock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	partialSigBaseTmpl = "/exp/partial_signatures/" + lockHashPath
+	partialSigsEndTmpl = "/" + shareIndexPath + "/" + valPubkeyPath
+)
+
+// HandlePartialSignatures stores partial signatures after verifying them against the operator's public shares.
+func (ts *testServer) HandlePartialSignatures(writer http.ResponseWriter, request *http.Request) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	var data obolapi.PartialSignaturesRequest
+	if err := json.NewDecoder(request.Body).Decode(&data); err != nil {
+		writeErr(writer, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	lock, ok := ts.lockFiles[mux.Vars(request)[cleanTmpl(lockHashPath)]]
+	if !ok {
+		writeErr(writer, http.StatusNotFound, "lock not found")
+		return
+	}
+
+	if data.ShareIdx == 0 || data.ShareIdx > uint64(len(lock.Operators)) {
+		writeErr(writer, http.StatusBadRequest, "invalid share index")
+		return
+	}
+
+	hash, err := obolapi.PartialSignaturesHash(data.ShareIdx, data.PartialSignatures)
+	if err != nil {
+		writeErr(writer, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	identitySig, err := from0x(data.Signature, 65)
+	if err != nil {
+		writeErr(writer, http.StatusBadRequest, "invalid signature")
+		return
+	}
+
+	if err := verifyIdentitySignature(lock.Operators[data.ShareIdx-1], identitySig, hash); err != nil {
+		writeErr(writer, http.StatusBadRequest, "cannot verify signature: "+err.Error())
+		return
+	}
+
+	for _, psig := range data.PartialSignatures {
+		var pubshare []byte
+		for _, val := range lock.Validators {
+			if strings.EqualFold(psig.ValidatorPubkey, val.PublicKeyHex()) {
+				pubshare = val.PubShares[data.ShareIdx-1]
+				break
+			}
+		}
+
+		if pubshare == nil {
+			writeErr(writer, http.StatusBadRequest, fmt.Sprintf("could not find validator %s in lock file", psig.ValidatorPubkey))
+			return
+		} else if psig.ShareIdx != data.ShareIdx {
+			writeErr(writer, http.StatusBadRequest, "mismatching share index")
+			return
+		}
+
+		root, err := from0x(psig.SigningRoot, 32)
+		if err != nil {
+			writeErr(writer, http.StatusBadRequest, "invalid signing root")
+			return
+		}
+
+		sig, err := from0x(psig.Signature, 96)
+		if err != nil {
+			writeErr(writer, http.StatusBadRequest, "invalid partial signature")
+			return
+		}
+
+		if err := tbls.Verify(tbls.PublicKey(pubshare), root, tbls.Signature(sig)); err != nil {
+			writeErr(writer, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		key := strings.ToLower(psig.ValidatorPubkey)
+		ts.partialSigs[key] = append(ts.partialSigs[key], psig)
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+}
+
+// HandleGetPartialSignatures returns all stored partial signatures of a validator ordered by share index.
+func (ts *testServer) HandleGetPartialSignatures(writer http.ResponseWriter, request *http.Request) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	authToken, ok := request.Context().Value(tokenContextKey).([]byte)
+	if !ok {
+		log.Error(request.Context(), "received context without token, that's impossible!", nil)
+		return
+	}
+
+	vars := mux.Vars(request)
+	lockHash := vars[cleanTmpl(lockHashPath)]
+	valPubkey := vars[cleanTmpl(valPubkeyPath)]
+
+	shareIdx, err := strconv.ParseUint(vars[cleanTmpl(shareIndexPath)], 10, 64)
+	if err != nil {
+		writeErr(writer, http.StatusBadRequest, "malformed share index")
+		return
+	}
+
+	lockHashBytes, err := from0x(lockHash, 32)
+	if err != nil {
+		writeErr(writer, http.StatusBadRequest, "invalid lock hash")
+		return
+	}
+
+	lock, ok := ts.lockFiles[lockHash]
+	if !ok {
+		writeErr(writer, http.StatusNotFound, "lock not found")
+		return
+	} else if shareIdx == 0 || shareIdx > uint64(len(lock.Operators)) {
+		writeErr(writer, http.StatusBadRequest, "invalid share index")
+		return
+	}
+
+	authHash := obolapi.PartialSignaturesAuthHash(lockHashBytes, valPubkey, shareIdx)
+	if err := verifyIdentitySignature(lock.Operators[shareIdx-1], authToken, authHash); err != nil {
+		writeErr(writer, http.StatusBadRequest, "cannot verify signature: "+err.Error())
+		return
+	}
+
+	psigs, ok := ts.partialSigs[strings.ToLower(valPubkey)]
+	if !ok {
+		writeErr(writer, http.StatusNotFound, "validator not found")
+		return
+	}
+
+	sort.SliceStable(psigs, func(i, j int) bool {
+		return psigs[i].ShareIdx < psigs[j].ShareIdx
+	})
+
+	if err := json.NewEncoder(writer).Encode(psigs); err != nil {
+		writeErr(writer, http.StatusInternalServerError, errors.Wrap(err, "cannot marshal partial signatures").Error())
+		return
+	}
+}
@@ -19,11 +19,13 @@ import (
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2electra "github.com/attestantio/go-eth2-client/api/v1/electra"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/holiman/uint256"
@@ -133,6 +135,42 @@ func RandomAggregateAttestation() *eth2p0.Attestation {
 	}
 }
 
+// RandomElectraAttestation returns a random electra attestation with a single committee bit set.
+func RandomElectraAttestation() *electra.Attestation {
+	data := RandomAttestationData()
+	commIdx := uint64(data.Index) % 64
+	data.Index = 0 // Electra attestation data committee index is always zero.
+
+	commBits := bitfield.NewBitvector64()
+	commBits.SetBitAt(commIdx, true)
+
+	return &electra.Attestation{
+		AggregationBits: RandomBitList(1),
+		Data:            data,
+		Signature:       RandomEth2Signature(),
+		CommitteeBits:   commBits,
+	}
+}
+
+// RandomDenebVersionedAttestation returns a random versioned deneb attestation.
+func RandomDenebVersionedAttestation() *eth2spec.VersionedAttestation {
+	return &eth2spec.VersionedAttestation{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   RandomAttestation(),
+	}
+}
+
+// RandomElectraVersionedAttestation returns a random versioned electra attestation including the validator index.
+func RandomElectraVersionedAttestation() *eth2spec.VersionedAttestation {
+	vIdx := RandomVIdx()
+
+	return &eth2spec.VersionedAttestation{
+		Version:        eth2spec.DataVersionElectra,
+		ValidatorIndex: &vIdx,
+		Electra:        RandomElectraAttestation(),
+	}
+}
+
 func RandomAttestationData() *eth2p0.AttestationData {
 	return RandomAttestationDataSeed(NewSeedRand())
 }
@@ -585,6 +623,135 @@ func RandomDenebBlindedBeaconBlockBody() *eth2deneb.BlindedBeaconBlockBody {
 	}
 }
 
+// RandomElectraCoreVersionedSignedProposal returns a random core signed proposal containing an electra beacon block.
+func RandomElectraCoreVersionedSignedProposal() core.VersionedSignedProposal {
+	return core.VersionedSignedProposal{
+		VersionedSignedProposal: *RandomElectraVersionedSignedProposal(),
+	}
+}
+
+// RandomElectraVersionedSignedProposal returns a random versioned signed proposal containing an electra beacon block.
+func RandomElectraVersionedSignedProposal() *eth2api.VersionedSignedProposal {
+	return &eth2api.VersionedSignedProposal{
+		Version: eth2spec.DataVersionElectra,
+		Electra: &eth2electra.SignedBlockContents{
+			SignedBlock: &electra.SignedBeaconBlock{
+				Message:   RandomElectraBeaconBlock(),
+				Signature: RandomEth2Signature(),
+			},
+			KZGProofs: []deneb.KZGProof{},
+			Blobs:     []deneb.Blob{},
+		},
+	}
+}
+
+// RandomElectraVersionedProposal returns a random versioned proposal containing an electra beacon block.
+func RandomElectraVersionedProposal() *eth2api.VersionedProposal {
+	return &eth2api.VersionedProposal{
+		Version: eth2spec.DataVersionElectra,
+		Electra: &eth2electra.BlockContents{
+			Block:     RandomElectraBeaconBlock(),
+			KZGProofs: []deneb.KZGProof{},
+			Blobs:     []deneb.Blob{},
+		},
+	}
+}
+
+func RandomElectraVersionedBlindedProposal() core.VersionedProposal {
+	return core.VersionedProposal{
+		VersionedProposal: eth2api.VersionedProposal{
+			Version:        eth2spec.DataVersionElectra,
+			Blinded:        true,
+			ElectraBlinded: RandomElectraBlindedBeaconBlock(),
+		},
+	}
+}
+
+func RandomElectraVersionedSignedBlindedProposal() core.VersionedSignedProposal {
+	return core.VersionedSignedProposal{
+		VersionedSignedProposal: eth2api.VersionedSignedProposal{
+			Blinded: true,
+			Version: eth2spec.DataVersionElectra,
+			ElectraBlinded: &eth2electra.SignedBlindedBeaconBlock{
+				Message:   RandomElectraBlindedBeaconBlock(),
+				Signature: RandomEth2Signature(),
+			},
+		},
+	}
+}
+
+func RandomElectraBeaconBlock() *electra.BeaconBlock {
+	return &electra.BeaconBlock{
+		Slot:          RandomSlot(),
+		ProposerIndex: RandomVIdx(),
+		ParentRoot:    RandomRoot(),
+		StateRoot:     RandomRoot(),
+		Body:          RandomElectraBeaconBlockBody(),
+	}
+}
+
+func RandomElectraBlindedBeaconBlock() *eth2electra.BlindedBeaconBlock {
+	return &eth2electra.BlindedBeaconBlock{
+		Slot:          RandomSlot(),
+		ProposerIndex: RandomVIdx(),
+		ParentRoot:    RandomRoot(),
+		StateRoot:     RandomRoot(),
+		Body:          RandomElectraBlindedBeaconBlockBody(),
+	}
+}
+
+func RandomElectraBeaconBlockBody() *electra.BeaconBlockBody {
+	return &electra.BeaconBlockBody{
+		RANDAOReveal: RandomEth2Signature(),
+		ETH1Data: &eth2p0.ETH1Data{
+			DepositRoot:  RandomRoot(),
+			DepositCount: 0,
+			BlockHash:    RandomBytes32(),
+		},
+		Graffiti:              RandomArray32(),
+		ProposerSlashings:     []*eth2p0.ProposerSlashing{},
+		AttesterSlashings:     []*electra.AttesterSlashing{},
+		Attestations:          []*electra.Attestation{RandomElectraAttestation(), RandomElectraAttestation()},
+		Deposits:              []*eth2p0.Deposit{},
+		VoluntaryExits:        []*eth2p0.SignedVoluntaryExit{},
+		SyncAggregate:         RandomSyncAggregate(),
+		ExecutionPayload:      RandomDenebExecutionPayload(),
+		BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+		BlobKZGCommitments:    []deneb.KZGCommitment{},
+		ExecutionRequests:     RandomElectraExecutionRequests(),
+	}
+}
+
+func RandomElectraBlindedBeaconBlockBody() *eth2electra.BlindedBeaconBlockBody {
+	return &eth2electra.BlindedBeaconBlockBody{
+		RANDAOReveal: RandomEth2Signature(),
+		ETH1Data: &eth2p0.ETH1Data{
+			DepositRoot:  RandomRoot(),
+			DepositCount: 0,
+			BlockHash:    RandomBytes32(),
+		},
+		Graffiti:               RandomArray32(),
+		ProposerSlashings:      []*eth2p0.ProposerSlashing{},
+		AttesterSlashings:      []*electra.AttesterSlashing{},
+		Attestations:           []*electra.Attestation{RandomElectraAttestation(), RandomElectraAttestation()},
+		Deposits:               []*eth2p0.Deposit{},
+		VoluntaryExits:         []*eth2p0.SignedVoluntaryExit{},
+		SyncAggregate:          RandomSyncAggregate(),
+		ExecutionPayloadHeader: RandomDenebExecutionPayloadHeader(),
+		BLSToExecutionChanges:  []*capella.SignedBLSToExecutionChange{},
+		BlobKZGCommitments:     []deneb.KZGCommitment{},
+		ExecutionRequests:      RandomElectraExecutionRequests(),
+	}
+}
+
+func RandomElectraExecutionRequests() *electra.ExecutionRequests {
+	return &electra.ExecutionRequests{
+		Deposits:       []*electra.DepositRequest{},
+		Withdrawals:    []*electra.WithdrawalRequest{},
+		Consolidations: []*electra.ConsolidationRequest{},
+	}
+}
+
 func RandomValidatorRegistration(t *testing.T) *eth2v1.ValidatorRegistration {
 	t.Helper()
 
@@ -660,6 +827,32 @@ func RandomAggregateAndProof() *eth2p0.AggregateAndProof {
 	}
 }
 
+// RandomDenebVersionedSignedAggregateAndProof returns a random versioned deneb signed aggregate and proof.
+func RandomDenebVersionedSignedAggregateAndProof() *eth2spec.VersionedSignedAggregateAndProof {
+	return &eth2spec.VersionedSignedAggregateAndProof{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   RandomSignedAggregateAndProof(),
+	}
+}
+
+// RandomElectraVersionedSignedAggregateAndProof returns a random versioned electra signed aggregate and proof.
+func RandomElectraVersionedSignedAggregateAndProof() *eth2spec.VersionedSignedAggregateAndProof {
+	agg := RandomElectraAttestation()
+	agg.AggregationBits = RandomBitList(64)
+
+	return &eth2spec.VersionedSignedAggregateAndProof{
+		Version: eth2spec.DataVersionElectra,
+		Electra: &electra.SignedAggregateAndProof{
+			Message: &electra.AggregateAndProof{
+				AggregatorIndex: RandomVIdx(),
+				Aggregate:       agg,
+				SelectionProof:  RandomEth2Signature(),
+			},
+			Signature: RandomEth2Signature(),
+		},
+	}
+}
+
 func RandomSignedSyncContributionAndProof() *altair.SignedContributionAndProof {
 	return &altair.SignedContributionAndProof{
 		Message:   RandomSyncContributionAndProof(),
@@ -0,0 +1,176 @@
+// Copyright © 2022-2024 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package remotesignermock provides a local stand-in of a remote Web3Signer-style signer
+// that signs bare signing roots and supports importing keystores via the keymanager API.
+package remotesignermock
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// MockServer returns a remote signer handler signing with the provided secrets and
+// a function returning the public shares of all secrets held by the signer.
+func MockServer(secrets ...tbls.PrivateKey) (http.Handler, func() []tbls.PublicKey, error) {
+	s := &server{secrets: make(map[tbls.PublicKey]tbls.PrivateKey)}
+	for _, secret := range secrets {
+		if err := s.add(secret); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/upcheck", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/eth2/publicKeys", s.handlePublicKeys).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/eth2/sign/{identifier}", s.handleSign).Methods(http.MethodPost)
+	r.HandleFunc("/eth/v1/keystores", s.handleImport).Methods(http.MethodPost)
+
+	return r, s.pubshares, nil
+}
+
+type server struct {
+	mu      sync.Mutex
+	secrets map[tbls.PublicKey]tbls.PrivateKey
+}
+
+func (s *server) add(secret tbls.PrivateKey) error {
+	pubshare, err := tbls.SecretToPublicKey(secret)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.secrets[pubshare] = secret
+
+	return nil
+}
+
+func (s *server) pubshares() []tbls.PublicKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resp []tbls.PublicKey
+	for pubshare := range s.secrets {
+		resp = append(resp, pubshare)
+	}
+
+	return resp
+}
+
+func (s *server) handlePublicKeys(w http.ResponseWriter, _ *http.Request) {
+	var resp []string
+	for _, pubshare := range s.pubshares() {
+		resp = append(resp, fmt.Sprintf("%#x", pubshare[:]))
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *server) handleSign(w http.ResponseWriter, r *http.Request) {
+	b, err := hex.DecodeString(strings.TrimPrefix(mux.Vars(r)["identifier"], "0x"))
+	if err != nil || len(b) != len(tbls.PublicKey{}) {
+		http.Error(w, "invalid identifier", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	secret, ok := s.secrets[tbls.PublicKey(b)]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown public key", http.StatusNotFound)
+		return
+	}
+
+	var req struct {
+		SigningRoot string `json:"signingRoot"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	root, err := hex.DecodeString(strings.TrimPrefix(req.SigningRoot, "0x"))
+	if err != nil || len(root) != 32 {
+		http.Error(w, "invalid signing root", http.StatusBadRequest)
+		return
+	}
+
+	sig, err := tbls.Sign(secret, root)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		writeJSON(w, map[string]string{"signature": fmt.Sprintf("%#x", sig[:])})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(fmt.Sprintf("%#x", sig[:])))
+}
+
+// handleImport imports keystores via the keymanager API.
+func (s *server) handleImport(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Keystores []string `json:"keystores"`
+		Passwords []string `json:"passwords"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Keystores) != len(req.Passwords) {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	type status struct {
+		Status string `json:"status"`
+	}
+
+	var statuses []status
+	for i, ks := range req.Keystores {
+		var store keystore.Keystore
+		if err := json.Unmarshal([]byte(ks), &store); err != nil {
+			http.Error(w, "invalid keystore", http.StatusBadRequest)
+			return
+		}
+
+		secret, err := keystore.Decrypt(store, req.Passwords[i])
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "decrypt keystore").Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := s.add(secret); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		statuses = append(statuses, status{Status: "imported"})
+	}
+
+	writeJSON(w, map[string]any{"data": statuses})
+}
+
+func writeJSON(w http.ResponseWriter, resp any) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -8,6 +8,8 @@ import (
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/prysmaticlabs/go-bitfield"
 
@@ -24,7 +26,7 @@ import (
 type (
 	attDuties     []*eth2v1.AttesterDuty
 	attSelections []*eth2exp.BeaconCommitteeSelection
-	attDatas      []*eth2p0.AttestationData
+	attDatas      map[eth2p0.CommitteeIndex]*eth2p0.AttestationData
 )
 
 // NewSlotAttester returns a new SlotAttester.
@@ -286,23 +288,26 @@ func prepareAggregators(ctx context.Context, eth2Cl eth2wrap.Client, signFunc Si
 	return selections, nil
 }
 
-// attest does attestations for the provided attesters and returns the attestation attDatas.
+// attest does attestations for the provided attesters and returns the attestation attDatas by committee index.
 func attest(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot eth2p0.Slot, duties attDuties,
 ) (attDatas, error) {
 	if len(duties) == 0 {
 		return nil, nil
 	}
 
+	version, err := eth2util.DataVersionAtSlot(ctx, eth2Cl, slot)
+	if err != nil {
+		return nil, err
+	}
+
 	// Group attDuties by committee.
 	dutyByComm := make(map[eth2p0.CommitteeIndex][]*eth2v1.AttesterDuty)
 	for _, duty := range duties {
 		dutyByComm[duty.CommitteeIndex] = append(dutyByComm[duty.CommitteeIndex], duty)
 	}
 
-	var (
-		atts  []*eth2p0.Attestation
-		datas attDatas
-	)
+	var atts []*eth2spec.VersionedAttestation
+	datas := make(attDatas)
 	for commIdx, duties := range dutyByComm {
 		opts := &eth2api.AttestationDataOpts{
 			Slot:           slot,
@@ -313,7 +318,7 @@ func attest(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot
 			return nil, err
 		}
 		data := eth2Resp.Data
-		datas = append(datas, data)
+		datas[commIdx] = data
 
 		root, err := data.HashTreeRoot()
 		if err != nil {
@@ -333,15 +338,16 @@ func attest(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot
 			aggBits := bitfield.NewBitlist(duty.CommitteeLength)
 			aggBits.SetBitAt(duty.ValidatorCommitteeIndex, true)
 
-			atts = append(atts, &eth2p0.Attestation{
-				AggregationBits: aggBits,
-				Data:            data,
-				Signature:       sig,
-			})
+			att, err := newVersionedAttestation(version, duty, aggBits, data, sig)
+			if err != nil {
+				return nil, err
+			}
+
+			atts = append(atts, att)
 		}
 	}
 
-	err := eth2Cl.SubmitAttestations(ctx, atts)
+	err = eth2Cl.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: atts})
 	if err != nil {
 		return nil, err
 	}
@@ -349,6 +355,35 @@ func attest(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot
 	return datas, nil
 }
 
+// newVersionedAttestation returns a versioned attestation for the duty.
+// Electra attestations include the committee bits and the validator index.
+func newVersionedAttestation(version eth2spec.DataVersion, duty *eth2v1.AttesterDuty, aggBits bitfield.Bitlist,
+	data *eth2p0.AttestationData, sig eth2p0.BLSSignature,
+) (*eth2spec.VersionedAttestation, error) {
+	if version < eth2spec.DataVersionElectra {
+		return eth2util.NewPhase0VersionedAttestation(version, &eth2p0.Attestation{
+			AggregationBits: aggBits,
+			Data:            data,
+			Signature:       sig,
+		})
+	}
+
+	commBits := bitfield.NewBitvector64()
+	commBits.SetBitAt(uint64(duty.CommitteeIndex), true)
+	vIdx := duty.ValidatorIndex
+
+	return &eth2spec.VersionedAttestation{
+		Version:        eth2spec.DataVersionElectra,
+		ValidatorIndex: &vIdx,
+		Electra: &electra.Attestation{
+			AggregationBits: aggBits,
+			Data:            data,
+			Signature:       sig,
+			CommitteeBits:   commBits,
+		},
+	}, nil
+}
+
 // aggregate does attestation aggregation for the provided validators, attSelections and attestation attDatas and returns true.
 // It returns false if aggregation is not required.
 func aggregate(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot eth2p0.Slot,
@@ -369,8 +404,8 @@ func aggregate(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, s
 	}
 
 	var (
-		aggs       []*eth2p0.SignedAggregateAndProof
-		attsByComm = make(map[eth2p0.CommitteeIndex]*eth2p0.Attestation)
+		aggs       []*eth2spec.VersionedSignedAggregateAndProof
+		attsByComm = make(map[eth2p0.CommitteeIndex]*eth2spec.VersionedAttestation)
 	)
 	for _, selection := range selections {
 		commIdx, ok := committees[selection.ValidatorIndex]
@@ -388,13 +423,7 @@ func aggregate(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, s
 			attsByComm[commIdx] = att
 		}
 
-		proof := eth2p0.AggregateAndProof{
-			AggregatorIndex: selection.ValidatorIndex,
-			Aggregate:       att,
-			SelectionProof:  selection.SelectionProof,
-		}
-
-		proofRoot, err := proof.HashTreeRoot()
+		agg, proofRoot, err := newVersionedAggregateAndProof(att, selection)
 		if err != nil {
 			return false, err
 		}
@@ -414,44 +443,122 @@ func aggregate(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, s
 			return false, err
 		}
 
-		aggs = append(aggs, &eth2p0.SignedAggregateAndProof{
-			Message:   &proof,
-			Signature: proofSig,
-		})
+		switch agg.Version {
+		case eth2spec.DataVersionPhase0:
+			agg.Phase0.Signature = proofSig
+		case eth2spec.DataVersionAltair:
+			agg.Altair.Signature = proofSig
+		case eth2spec.DataVersionBellatrix:
+			agg.Bellatrix.Signature = proofSig
+		case eth2spec.DataVersionCapella:
+			agg.Capella.Signature = proofSig
+		case eth2spec.DataVersionDeneb:
+			agg.Deneb.Signature = proofSig
+		case eth2spec.DataVersionElectra:
+			agg.Electra.Signature = proofSig
+		default:
+			return false, errors.New("unknown aggregate version")
+		}
+
+		aggs = append(aggs, agg)
 	}
 
-	if err := eth2Cl.SubmitAggregateAttestations(ctx, aggs); err != nil {
+	err = eth2Cl.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{
+		SignedAggregateAndProofs: aggs,
+	})
+	if err != nil {
 		return false, err
 	}
 
 	return true, nil
 }
 
-// getAggregateAttestation returns an aggregated attestation for the provided committee.
-func getAggregateAttestation(ctx context.Context, eth2Cl eth2wrap.Client, datas attDatas,
-	commIdx eth2p0.CommitteeIndex,
-) (*eth2p0.Attestation, error) {
-	for _, data := range datas {
-		if data.Index != commIdx {
-			continue
+// newVersionedAggregateAndProof returns an unsigned versioned aggregate and proof and its root.
+func newVersionedAggregateAndProof(att *eth2spec.VersionedAttestation, selection *eth2exp.BeaconCommitteeSelection,
+) (*eth2spec.VersionedSignedAggregateAndProof, eth2p0.Root, error) {
+	if att.Version == eth2spec.DataVersionElectra {
+		if att.Electra == nil {
+			return nil, eth2p0.Root{}, errors.New("no electra aggregate attestation")
 		}
 
-		root, err := data.HashTreeRoot()
-		if err != nil {
-			return nil, errors.Wrap(err, "hash attestation data")
+		proof := &electra.AggregateAndProof{
+			AggregatorIndex: selection.ValidatorIndex,
+			Aggregate:       att.Electra,
+			SelectionProof:  selection.SelectionProof,
 		}
 
-		opts := &eth2api.AggregateAttestationOpts{
-			Slot:                data.Slot,
-			AttestationDataRoot: root,
-		}
-		eth2Resp, err := eth2Cl.AggregateAttestation(ctx, opts)
+		root, err := proof.HashTreeRoot()
 		if err != nil {
-			return nil, err
+			return nil, eth2p0.Root{}, errors.Wrap(err, "hash aggregate and proof")
 		}
 
-		return eth2Resp.Data, nil
+		return &eth2spec.VersionedSignedAggregateAndProof{
+			Version: eth2spec.DataVersionElectra,
+			Electra: &electra.SignedAggregateAndProof{Message: proof},
+		}, root, nil
+	}
+
+	var aggregate *eth2p0.Attestation
+	switch att.Version {
+	case eth2spec.DataVersionPhase0:
+		aggregate = att.Phase0
+	case eth2spec.DataVersionAltair:
+		aggregate = att.Altair
+	case eth2spec.DataVersionBellatrix:
+		aggregate = att.Bellatrix
+	case eth2spec.DataVersionCapella:
+		aggregate = att.Capella
+	case eth2spec.DataVersionDeneb:
+		aggregate = att.Deneb
+	default:
+		return nil, eth2p0.Root{}, errors.New("unknown aggregate attestation version")
+	}
+	if aggregate == nil {
+		return nil, eth2p0.Root{}, errors.New("no aggregate attestation")
+	}
+
+	proof := &eth2p0.AggregateAndProof{
+		AggregatorIndex: selection.ValidatorIndex,
+		Aggregate:       aggregate,
+		SelectionProof:  selection.SelectionProof,
+	}
+
+	root, err := proof.HashTreeRoot()
+	if err != nil {
+		return nil, eth2p0.Root{}, errors.Wrap(err, "hash aggregate and proof")
+	}
+
+	agg, err := eth2util.NewPhase0VersionedSignedAggregateAndProof(att.Version, &eth2p0.SignedAggregateAndProof{Message: proof})
+	if err != nil {
+		return nil, eth2p0.Root{}, err
+	}
+
+	return agg, root, nil
+}
+
+// getAggregateAttestation returns an aggregated attestation for the provided committee.
+func getAggregateAttestation(ctx context.Context, eth2Cl eth2wrap.Client, datas attDatas,
+	commIdx eth2p0.CommitteeIndex,
+) (*eth2spec.VersionedAttestation, error) {
+	data, ok := datas[commIdx]
+	if !ok {
+		return nil, errors.New("missing attestation data for committee index")
+	}
+
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "hash attestation data")
+	}
+
+	opts := &eth2api.AggregateAttestationOpts{
+		Slot:                data.Slot,
+		AttestationDataRoot: root,
+		CommitteeIndex:      commIdx,
+	}
+	eth2Resp, err := eth2Cl.AggregateAttestation(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, errors.New("missing attestation data for committee index")
+	return eth2Resp.Data, nil
 }
@@ -13,11 +13,13 @@ import (
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
 	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2electra "github.com/attestantio/go-eth2-client/api/v1/electra"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -145,6 +147,11 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 				Message:   block.DenebBlinded,
 				Signature: sig,
 			}
+		case eth2spec.DataVersionElectra:
+			signedBlock.Electra = &eth2electra.SignedBlindedBeaconBlock{
+				Message:   block.ElectraBlinded,
+				Signature: sig,
+			}
 		default:
 			return errors.New("invalid blinded block")
 		}
@@ -187,6 +194,15 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 			KZGProofs: block.Deneb.KZGProofs,
 			Blobs:     block.Deneb.Blobs,
 		}
+	case eth2spec.DataVersionElectra:
+		signedBlock.Electra = &eth2electra.SignedBlockContents{
+			SignedBlock: &electra.SignedBeaconBlock{
+				Message:   block.Electra.Block,
+				Signature: sig,
+			},
+			KZGProofs: block.Electra.KZGProofs,
+			Blobs:     block.Electra.Blobs,
+		}
 	default:
 		return errors.New("invalid block")
 	}
@@ -11,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
@@ -55,14 +57,14 @@ func TestAttest(t *testing.T) {
 			require.NoError(t, err)
 
 			// Callback to collect attestations
-			var atts []*eth2p0.Attestation
-			var aggs []*eth2p0.SignedAggregateAndProof
-			beaconMock.SubmitAttestationsFunc = func(_ context.Context, attestations []*eth2p0.Attestation) error {
-				atts = attestations
+			var atts []*eth2spec.VersionedAttestation
+			var aggs []*eth2spec.VersionedSignedAggregateAndProof
+			beaconMock.SubmitAttestationsFunc = func(_ context.Context, opts *eth2api.SubmitAttestationsOpts) error {
+				atts = opts.Attestations
 				return nil
 			}
-			beaconMock.SubmitAggregateAttestationsFunc = func(_ context.Context, aggAndProofs []*eth2p0.SignedAggregateAndProof) error {
-				aggs = aggAndProofs
+			beaconMock.SubmitAggregateAttestationsFunc = func(_ context.Context, opts *eth2api.SubmitAggregateAttestationsOpts) error {
+				aggs = opts.SignedAggregateAndProofs
 				return nil
 			}
 
@@ -92,11 +94,21 @@ func TestAttest(t *testing.T) {
 
 			// Sort the outputs to make it deterministic to compare with json.
 			sort.Slice(atts, func(i, j int) bool {
-				return atts[i].Data.Index < atts[j].Data.Index
+				idxI, err := atts[i].CommitteeIndex()
+				require.NoError(t, err)
+				idxJ, err := atts[j].CommitteeIndex()
+				require.NoError(t, err)
+
+				return idxI < idxJ
 			})
 
 			sort.Slice(aggs, func(i, j int) bool {
-				return aggs[i].Message.Aggregate.Data.Index < aggs[j].Message.Aggregate.Data.Index
+				idxI, err := aggs[i].AggregatorIndex()
+				require.NoError(t, err)
+				idxJ, err := aggs[j].AggregatorIndex()
+				require.NoError(t, err)
+
+				return idxI < idxJ
 			})
 
 			t.Run("attestations", func(t *testing.T) {
@@ -109,6 +121,64 @@ func TestAttest(t *testing.T) {
 	}
 }
 
+func TestAttestElectra(t *testing.T) {
+	ctx := context.Background()
+	clock := clockwork.NewFakeClockAt(time.Date(2022, 0o3, 20, 0o1, 0, 0, 0, time.UTC))
+
+	valSet := beaconmock.ValidatorSetA
+	beaconMock, err := beaconmock.New(
+		beaconmock.WithClock(clock),
+		beaconmock.WithValidatorSet(valSet),
+		beaconmock.WithDeterministicAttesterDuties(0),
+		beaconmock.WithElectraForkEpoch(0),
+	)
+	require.NoError(t, err)
+
+	var atts []*eth2spec.VersionedAttestation
+	var aggs []*eth2spec.VersionedSignedAggregateAndProof
+	beaconMock.SubmitAttestationsFunc = func(_ context.Context, opts *eth2api.SubmitAttestationsOpts) error {
+		atts = opts.Attestations
+		return nil
+	}
+	beaconMock.SubmitAggregateAttestationsFunc = func(_ context.Context, opts *eth2api.SubmitAggregateAttestationsOpts) error {
+		aggs = opts.SignedAggregateAndProofs
+		return nil
+	}
+
+	signFunc := func(key eth2p0.BLSPubKey, _ []byte) (eth2p0.BLSSignature, error) {
+		var sig eth2p0.BLSSignature
+		copy(sig[:], key[:])
+
+		return sig, nil
+	}
+
+	slotsPerEpoch, err := beaconMock.SlotsPerEpoch(ctx)
+	require.NoError(t, err)
+
+	attester := validatormock.NewSlotAttester(beaconMock, eth2p0.Slot(slotsPerEpoch), signFunc, valSet.PublicKeys())
+
+	require.NoError(t, attester.Prepare(ctx))
+	require.NoError(t, attester.Attest(ctx))
+	ok, err := attester.Aggregate(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.Len(t, atts, 3)
+	for _, att := range atts {
+		require.Equal(t, eth2spec.DataVersionElectra, att.Version)
+		require.NotNil(t, att.ValidatorIndex)
+		require.Zero(t, att.Electra.Data.Index)
+		require.Len(t, att.Electra.CommitteeBits.BitIndices(), 1)
+	}
+
+	require.Len(t, aggs, 3)
+	for _, agg := range aggs {
+		require.Equal(t, eth2spec.DataVersionElectra, agg.Version)
+		require.Zero(t, agg.Electra.Message.Aggregate.Data.Index)
+		require.Len(t, agg.Electra.Message.Aggregate.CommitteeBits.BitIndices(), 1)
+	}
+}
+
 func TestProposeBlock(t *testing.T) {
 	ctx := context.Background()
 